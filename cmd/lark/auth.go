@@ -19,6 +19,9 @@ func newAuthCmd(state *appState) *cobra.Command {
 	cmd.AddCommand(newAuthPlatformCmd(state))
 	cmd.AddCommand(newAuthUserCmd(state))
 	cmd.AddCommand(newAuthExplainCmd(state))
+	cmd.AddCommand(newAuthTokenCmd(state))
+	cmd.AddCommand(newAuthAppTicketCmd(state))
+	cmd.AddCommand(newAuthDoctorCmd(state))
 	return cmd
 }
 
@@ -31,14 +34,17 @@ func newAuthTenantCmd(state *appState) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			_, expiresAt := cachedTenantToken(state)
 			payload := map[string]any{
 				"tenant_access_token": token,
-				"expires_at":          state.Config.TenantAccessTokenExpiresAt,
+				"expires_at":          expiresAt,
 			}
-			return state.Printer.Print(
-				payload,
-				fmt.Sprintf("tenant_access_token: %s\nexpires_at: %d", token, state.Config.TenantAccessTokenExpiresAt),
-			)
+			text := fmt.Sprintf("tenant_access_token: %s\nexpires_at: %d", token, expiresAt)
+			if state.TenantKey != "" {
+				payload["tenant_key"] = state.TenantKey
+				text = fmt.Sprintf("tenant_key: %s\n%s", state.TenantKey, text)
+			}
+			return state.Printer.Print(payload, text)
 		},
 	}
 	return cmd
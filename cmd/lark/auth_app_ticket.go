@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	larkevent "github.com/larksuite/oapi-sdk-go/v3/event"
+	"github.com/spf13/cobra"
+
+	"lark/internal/config"
+	"lark/internal/output"
+)
+
+const appTicketEventType = "app_ticket"
+
+func newAuthAppTicketCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "app-ticket",
+		Short: "Manage the app_ticket of a marketplace app",
+		Long: `Marketplace (store/ISV) apps cannot mint tokens from the app secret alone.
+The platform pushes an app_ticket to the app's event callback about once an
+hour; the CLI exchanges the latest ticket for an app access token and then for
+a tenant access token per installing tenant (--tenant-key).`,
+	}
+	cmd.AddCommand(newAuthAppTicketSetCmd(state))
+	cmd.AddCommand(newAuthAppTicketResendCmd(state))
+	return cmd
+}
+
+func newAuthAppTicketSetCmd(state *appState) *cobra.Command {
+	var eventFile string
+	var encryptKey string
+
+	cmd := &cobra.Command{
+		Use:   "set [ticket]",
+		Short: "Store the latest app_ticket",
+		Long: `Store the app_ticket delivered to the app's event callback. Pass the ticket
+itself, or the callback body with --event-file (use - for stdin). Encrypted
+callback bodies are decrypted with --encrypt-key.`,
+		Example: `  lark auth app-ticket set tk_0123456789
+  lark auth app-ticket set --event-file callback.json --encrypt-key "$LARK_ENCRYPT_KEY"
+  my-webhook-relay | lark auth app-ticket set --event-file -`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if state.Config == nil {
+				return errors.New("config is required")
+			}
+			var ticket string
+			switch {
+			case len(args) == 1 && eventFile != "":
+				return flagUsage(cmd, "pass either a ticket or --event-file, not both")
+			case len(args) == 1:
+				ticket = strings.TrimSpace(args[0])
+			case eventFile != "":
+				data, err := readInputFile(eventFile)
+				if err != nil {
+					return err
+				}
+				parsed, appID, err := parseAppTicketEvent(data, encryptKey)
+				if err != nil {
+					return err
+				}
+				if appID != "" && state.Config.AppID != "" && appID != state.Config.AppID {
+					return fmt.Errorf("app_ticket event is for app %s, but config has app_id %s", appID, state.Config.AppID)
+				}
+				ticket = parsed
+			default:
+				return flagUsage(cmd, "ticket or --event-file is required")
+			}
+			if ticket == "" {
+				return errors.New("app ticket is empty")
+			}
+			if !state.Config.IsMarketplace() {
+				fmt.Fprintln(errWriter(state), "warning: app_type is not marketplace; run `lark config set --app-type marketplace` to use the ticket")
+			}
+			state.Config.AppTicket = ticket
+			state.Config.AppTicketUpdatedAt = time.Now().Unix()
+			// A new ticket does not invalidate the cached app access token, but
+			// clearing it forces the next exchange to use the fresh ticket.
+			state.Config.AppAccessToken = ""
+			state.Config.AppAccessTokenExpiresAt = 0
+			if err := state.saveConfig(); err != nil {
+				return err
+			}
+			payload := map[string]any{
+				"app_ticket_updated_at": state.Config.AppTicketUpdatedAt,
+			}
+			return state.Printer.Print(payload, output.Notice(output.NoticeSuccess, "App ticket saved", nil))
+		},
+	}
+
+	cmd.Flags().StringVar(&eventFile, "event-file", "", "app_ticket callback body (JSON; use - for stdin)")
+	cmd.Flags().StringVar(&encryptKey, "encrypt-key", "", "event encrypt key for encrypted callback bodies")
+	return cmd
+}
+
+func newAuthAppTicketResendCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resend",
+		Short: "Ask the platform to push a new app_ticket to the event callback",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sdk, err := requireSDK(state)
+			if err != nil {
+				return err
+			}
+			if err := sdk.ResendAppTicket(cmd.Context()); err != nil {
+				return err
+			}
+			payload := map[string]any{"resent": true}
+			text := output.Notice(output.NoticeSuccess, "App ticket requested", []string{
+				"The ticket is delivered to the app's event callback; store it with `lark auth app-ticket set`.",
+			})
+			return state.Printer.Print(payload, text)
+		},
+	}
+	return cmd
+}
+
+// parseAppTicketEvent extracts the ticket and app ID from an app_ticket
+// callback body. Both the v1 ("event.type") and v2 ("header.event_type")
+// envelopes are accepted, optionally wrapped in {"encrypt": ...}.
+func parseAppTicketEvent(data []byte, encryptKey string) (string, string, error) {
+	var envelope struct {
+		Encrypt string `json:"encrypt"`
+		Header  struct {
+			EventType string `json:"event_type"`
+			AppID     string `json:"app_id"`
+		} `json:"header"`
+		Event struct {
+			Type      string `json:"type"`
+			AppID     string `json:"app_id"`
+			AppTicket string `json:"app_ticket"`
+		} `json:"event"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return "", "", fmt.Errorf("parse app_ticket event: %w", err)
+	}
+	if envelope.Encrypt != "" {
+		if strings.TrimSpace(encryptKey) == "" {
+			return "", "", errors.New("app_ticket event is encrypted; pass --encrypt-key")
+		}
+		plain, err := larkevent.EventDecrypt(envelope.Encrypt, encryptKey)
+		if err != nil {
+			return "", "", fmt.Errorf("decrypt app_ticket event: %w", err)
+		}
+		return parseAppTicketEvent(plain, "")
+	}
+	eventType := envelope.Event.Type
+	if eventType == "" {
+		eventType = envelope.Header.EventType
+	}
+	if eventType != appTicketEventType {
+		return "", "", fmt.Errorf("expected an %s event, got %q", appTicketEventType, eventType)
+	}
+	ticket := strings.TrimSpace(envelope.Event.AppTicket)
+	if ticket == "" {
+		return "", "", errors.New("app_ticket event has no app_ticket")
+	}
+	appID := envelope.Event.AppID
+	if appID == "" {
+		appID = envelope.Header.AppID
+	}
+	return ticket, appID, nil
+}
+
+// cachedTenantToken returns the cached tenant token for the active tenant:
+// the top-level token for self-built apps, or the tenant_tokens entry for
+// --tenant-key on marketplace apps.
+func cachedTenantToken(state *appState) (string, int64) {
+	if state == nil || state.Config == nil {
+		return "", 0
+	}
+	cfg := state.Config
+	if !cfg.IsMarketplace() {
+		return cfg.TenantAccessToken, cfg.TenantAccessTokenExpiresAt
+	}
+	if cached := cfg.TenantTokens[state.TenantKey]; cached != nil {
+		return cached.AccessToken, cached.ExpiresAt
+	}
+	return "", 0
+}
+
+func ensureMarketplaceTenantToken(ctx context.Context, state *appState) (string, error) {
+	tenantKey := state.TenantKey
+	if tenantKey == "" {
+		return "", errors.New("marketplace apps act for one tenant at a time: pass --tenant-key or set LARK_TENANT_KEY")
+	}
+	now := time.Now()
+	if token, expiresAt := cachedTenantToken(state); tokenUnexpired(token, expiresAt, now) {
+		return token, nil
+	}
+	if state.Verbose {
+		fmt.Fprintf(errWriter(state), "refreshing tenant access token for tenant %s\n", tenantKey)
+	}
+	sdk, err := requireSDK(state)
+	if err != nil {
+		return "", err
+	}
+	appToken, err := ensureMarketplaceAppToken(ctx, state)
+	if err != nil {
+		return "", err
+	}
+	token, expiresIn, err := sdk.MarketplaceTenantAccessToken(ctx, appToken, tenantKey)
+	if err != nil {
+		return "", err
+	}
+	if state.Config.TenantTokens == nil {
+		state.Config.TenantTokens = map[string]*config.TenantToken{}
+	}
+	state.Config.TenantTokens[tenantKey] = &config.TenantToken{
+		AccessToken: token,
+		ExpiresAt:   time.Now().Add(time.Duration(expiresIn) * time.Second).Unix(),
+	}
+	if err := state.saveConfig(); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func ensureMarketplaceAppToken(ctx context.Context, state *appState) (string, error) {
+	cfg := state.Config
+	if tokenUnexpired(cfg.AppAccessToken, cfg.AppAccessTokenExpiresAt, time.Now()) {
+		return cfg.AppAccessToken, nil
+	}
+	if cfg.AppTicket == "" {
+		return "", errors.New("no app_ticket stored: run `lark auth app-ticket resend`, then save the ticket from your event callback with `lark auth app-ticket set`")
+	}
+	sdk, err := requireSDK(state)
+	if err != nil {
+		return "", err
+	}
+	token, expiresIn, err := sdk.MarketplaceAppAccessToken(ctx, cfg.AppTicket)
+	if err != nil {
+		return "", fmt.Errorf("%w (the stored app_ticket may be stale; run `lark auth app-ticket resend` and store the new one)", err)
+	}
+	cfg.AppAccessToken = token
+	cfg.AppAccessTokenExpiresAt = time.Now().Add(time.Duration(expiresIn) * time.Second).Unix()
+	return token, nil
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"lark/internal/config"
+)
+
+func TestParseAppTicketEvent(t *testing.T) {
+	v1 := `{"ts":"1","uuid":"u","token":"v","type":"event_callback","event":{"app_id":"cli_a","app_ticket":"tk_v1","type":"app_ticket"}}`
+	ticket, appID, err := parseAppTicketEvent([]byte(v1), "")
+	if err != nil || ticket != "tk_v1" || appID != "cli_a" {
+		t.Fatalf("v1: got %q %q %v", ticket, appID, err)
+	}
+
+	v2 := `{"schema":"2.0","header":{"event_type":"app_ticket","app_id":"cli_b"},"event":{"app_ticket":"tk_v2"}}`
+	ticket, appID, err = parseAppTicketEvent([]byte(v2), "")
+	if err != nil || ticket != "tk_v2" || appID != "cli_b" {
+		t.Fatalf("v2: got %q %q %v", ticket, appID, err)
+	}
+
+	encrypted, _ := json.Marshal(map[string]string{"encrypt": encryptEventForTest(t, v1, "secret-key")})
+	if _, _, err := parseAppTicketEvent(encrypted, ""); err == nil || !strings.Contains(err.Error(), "--encrypt-key") {
+		t.Fatalf("expected encrypt key error, got %v", err)
+	}
+	ticket, _, err = parseAppTicketEvent(encrypted, "secret-key")
+	if err != nil || ticket != "tk_v1" {
+		t.Fatalf("encrypted: got %q %v", ticket, err)
+	}
+
+	other := `{"event":{"type":"message","app_id":"cli_a"}}`
+	if _, _, err := parseAppTicketEvent([]byte(other), ""); err == nil || !strings.Contains(err.Error(), "expected an app_ticket event") {
+		t.Fatalf("expected event type error, got %v", err)
+	}
+}
+
+func TestEnsureTenantTokenMarketplacePerTenant(t *testing.T) {
+	var appTokenCalls, tenantTokenCalls int
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/open-apis/auth/v3/app_access_token":
+			appTokenCalls++
+			if payload["app_ticket"] != "tk_1" {
+				t.Fatalf("unexpected app ticket: %v", payload)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "app_access_token": "a-1", "expire": 7200})
+		case "/open-apis/auth/v3/tenant_access_token":
+			tenantTokenCalls++
+			if payload["app_access_token"] != "a-1" {
+				t.Fatalf("unexpected app access token: %v", payload)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "tenant_access_token": "t-" + payload["tenant_key"], "expire": 7200})
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+	cfg := &config.Config{AppType: config.AppTypeMarketplace}
+	var buf bytes.Buffer
+	state := newTestState(t, handler, cfg, &buf)
+
+	if _, err := ensureTenantToken(context.Background(), state); err == nil || !strings.Contains(err.Error(), "--tenant-key") {
+		t.Fatalf("expected tenant key error, got %v", err)
+	}
+	state.TenantKey = "tk-alpha"
+	if _, err := ensureTenantToken(context.Background(), state); err == nil || !strings.Contains(err.Error(), "no app_ticket stored") {
+		t.Fatalf("expected missing ticket error, got %v", err)
+	}
+
+	cfg.AppTicket = "tk_1"
+	for _, tenant := range []string{"tk-alpha", "tk-beta", "tk-alpha"} {
+		state.TenantKey = tenant
+		token, err := ensureTenantToken(context.Background(), state)
+		if err != nil {
+			t.Fatalf("ensureTenantToken(%s) error: %v", tenant, err)
+		}
+		if token != "t-"+tenant {
+			t.Fatalf("unexpected token for %s: %q", tenant, token)
+		}
+	}
+	if appTokenCalls != 1 || tenantTokenCalls != 2 {
+		t.Fatalf("expected 1 app token and 2 tenant token calls, got %d and %d", appTokenCalls, tenantTokenCalls)
+	}
+	if cfg.TenantAccessToken != "" || len(cfg.TenantTokens) != 2 {
+		t.Fatalf("expected per-tenant cache only, got %q %#v", cfg.TenantAccessToken, cfg.TenantTokens)
+	}
+	saved, err := config.Load(state.ConfigPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if saved.TenantTokens["tk-beta"] == nil || saved.TenantTokens["tk-beta"].AccessToken != "t-tk-beta" {
+		t.Fatalf("expected tenant token persisted, got %#v", saved.TenantTokens)
+	}
+}
+
+func TestEnsureTenantTokenRejectsTenantKeyForSelfBuilt(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request: %s", r.URL.Path)
+	})
+	cfg := &config.Config{
+		TenantAccessToken:          "t-cached",
+		TenantAccessTokenExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}
+	var buf bytes.Buffer
+	state := newTestState(t, handler, cfg, &buf)
+	state.TenantKey = "tk-alpha"
+	if _, err := ensureTenantToken(context.Background(), state); err == nil || !strings.Contains(err.Error(), "only applies to marketplace apps") {
+		t.Fatalf("expected self-built tenant key error, got %v", err)
+	}
+}
+
+func TestAuthAppTicketSetFromEventFile(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request: %s", r.URL.Path)
+	})
+	cfg := &config.Config{
+		AppType:                 config.AppTypeMarketplace,
+		AppAccessToken:          "a-old",
+		AppAccessTokenExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}
+	var buf bytes.Buffer
+	state := newTestState(t, handler, cfg, &buf)
+	path := filepath.Join(t.TempDir(), "event.json")
+	if err := os.WriteFile(path, []byte(`{"event":{"app_id":"other","app_ticket":"tk_2","type":"app_ticket"}}`), 0o600); err != nil {
+		t.Fatalf("write event: %v", err)
+	}
+
+	cmd := newAuthCmd(state)
+	cmd.SetArgs([]string{"app-ticket", "set", "--event-file", path})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "is for app other") {
+		t.Fatalf("expected app mismatch error, got %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"event":{"app_id":"app","app_ticket":"tk_2","type":"app_ticket"}}`), 0o600); err != nil {
+		t.Fatalf("write event: %v", err)
+	}
+	cmd = newAuthCmd(state)
+	cmd.SetArgs([]string{"app-ticket", "set", "--event-file", path})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("app-ticket set error: %v", err)
+	}
+	saved, err := config.Load(state.ConfigPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if saved.AppTicket != "tk_2" || saved.AppTicketUpdatedAt == 0 || saved.AppAccessToken != "" {
+		t.Fatalf("unexpected saved ticket state: %#v", saved)
+	}
+}
+
+func encryptEventForTest(t *testing.T, plain, key string) string {
+	t.Helper()
+	sum := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		t.Fatalf("cipher: %v", err)
+	}
+	// EventDecrypt trims everything outside the outer braces, so space
+	// padding is enough.
+	data := []byte(plain)
+	if pad := aes.BlockSize - len(data)%aes.BlockSize; pad != aes.BlockSize {
+		data = append(data, bytes.Repeat([]byte(" "), pad)...)
+	}
+	iv := bytes.Repeat([]byte{1}, aes.BlockSize)
+	out := make([]byte, len(data))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(out, data)
+	return base64.StdEncoding.EncodeToString(append(iv, out...))
+}
@@ -0,0 +1,507 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zalando/go-keyring"
+
+	"lark/internal/authregistry"
+	"lark/internal/credfile"
+	"lark/internal/larksdk"
+	"lark/internal/output"
+)
+
+type doctorStatus string
+
+const (
+	doctorPass doctorStatus = "pass"
+	doctorWarn doctorStatus = "warn"
+	doctorFail doctorStatus = "fail"
+	doctorSkip doctorStatus = "skip"
+)
+
+// doctorClockSkewLimit matches the 60s margin used when deciding whether a
+// cached token is still valid.
+var doctorClockSkewLimit = time.Minute
+
+type doctorCheck struct {
+	Name          string              `json:"name"`
+	Account       string              `json:"account,omitempty"`
+	Status        doctorStatus        `json:"status"`
+	Detail        string              `json:"detail,omitempty"`
+	Remediation   string              `json:"remediation,omitempty"`
+	MissingScopes map[string][]string `json:"missing_scopes,omitempty"`
+	// OtherServices are user services the account did not request at login;
+	// their scopes are not expected to be granted.
+	OtherServices []string `json:"other_services,omitempty"`
+}
+
+type authDoctorReport struct {
+	OK       bool          `json:"ok"`
+	Failures int           `json:"failures"`
+	Warnings int           `json:"warnings"`
+	Checks   []doctorCheck `json:"checks"`
+}
+
+func newAuthDoctorCmd(state *appState) *cobra.Command {
+	var offline bool
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose credentials, tokens, and scopes",
+		Long: `Run a pass/fail checklist over the auth setup: config resolution, base URL,
+keyring backend, app credentials, clock skew, the tenant token, and every user
+account's token and granted scopes (compared with the required scopes of the
+services the account requested at login). Failing checks include a suggested fix.
+
+Network checks may refresh expired tokens, as a normal command would. Use
+--offline to inspect local state only. Exits non-zero when a check fails.`,
+		Example: `  lark auth doctor
+  lark auth doctor --json
+  lark --profile work --tenant-key 2ed263bf32cf1651 auth doctor`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if state.Config == nil {
+				return errors.New("config is required")
+			}
+			report := runAuthDoctor(cmd.Context(), state, offline)
+			if err := state.Printer.Print(report, formatAuthDoctorReport(report)); err != nil {
+				return err
+			}
+			if report.Failures > 0 {
+				return fmt.Errorf("auth doctor: %d check(s) failed", report.Failures)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&offline, "offline", false, "skip checks that call the API (tokens, clock skew)")
+	return cmd
+}
+
+func runAuthDoctor(ctx context.Context, state *appState, offline bool) authDoctorReport {
+	checks := []doctorCheck{
+		doctorConfigCheck(state),
+		doctorBaseURLCheck(state),
+		doctorKeyringCheck(state),
+	}
+	appCreds := doctorAppCredentialsCheck(state)
+	checks = append(checks, appCreds)
+	checks = append(checks, doctorClockSkewCheck(ctx, state, offline))
+	checks = append(checks, doctorTenantTokenCheck(ctx, state, offline, appCreds.Status == doctorFail))
+	checks = append(checks, doctorUserChecks(ctx, state, offline)...)
+
+	report := authDoctorReport{Checks: checks}
+	for _, check := range checks {
+		switch check.Status {
+		case doctorFail:
+			report.Failures++
+		case doctorWarn:
+			report.Warnings++
+		}
+	}
+	report.OK = report.Failures == 0
+	return report
+}
+
+func doctorConfigCheck(state *appState) doctorCheck {
+	check := doctorCheck{Name: "config", Status: doctorPass}
+	check.Detail = fmt.Sprintf("profile %s, %s", state.Profile, state.ConfigPath)
+	if state.credentials != nil {
+		check.Detail += fmt.Sprintf("; credentials from %s (config credentials ignored)", credfile.EnvPath)
+		return check
+	}
+	if _, err := os.Stat(state.ConfigPath); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			check.Status = doctorFail
+			check.Detail += fmt.Sprintf(" (%v)", err)
+			return check
+		}
+		check.Status = doctorWarn
+		check.Detail += " (not found; using environment only)"
+		check.Remediation = "save app credentials with `lark auth login`"
+	}
+	return check
+}
+
+func doctorBaseURLCheck(state *appState) doctorCheck {
+	check := doctorCheck{Name: "base_url", Status: doctorPass}
+	baseURL := state.Config.BaseURL
+	if baseURL == "" {
+		baseURL = "https://open.feishu.cn"
+	}
+	check.Detail = fmt.Sprintf("%s (platform %s)", baseURL, platformFromBaseURL(baseURL))
+	if state.BaseURL != "" || state.Platform != "" {
+		check.Detail += "; overridden by --base-url/--platform"
+	}
+	parsed, err := url.Parse(baseURL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		check.Status = doctorFail
+		check.Remediation = "select a host with `lark auth platform set feishu|lark`"
+	}
+	return check
+}
+
+func doctorKeyringCheck(state *appState) doctorCheck {
+	check := doctorCheck{Name: "keyring", Status: doctorPass}
+	backend := userTokenBackend(state.Config)
+	switch backend {
+	case "file", "keychain":
+	default:
+		check.Status = doctorFail
+		check.Detail = fmt.Sprintf("unsupported keyring_backend %q", backend)
+		check.Remediation = "set keyring_backend to file, keychain, or auto (or LARK_KEYRING_BACKEND)"
+		return check
+	}
+	if backend == "file" && !state.Config.AppSecretInKeyring {
+		check.Detail = "file backend; keychain not used"
+		return check
+	}
+	// A lookup of a key that never exists distinguishes "keychain reachable"
+	// from "keychain unavailable" without touching stored secrets.
+	_, err := keyring.Get(keyringServiceName, "doctor-probe")
+	switch {
+	case err == nil || errors.Is(err, keyring.ErrNotFound):
+		check.Detail = fmt.Sprintf("%s backend; keychain available", backend)
+	case errors.Is(err, keyring.ErrUnsupportedPlatform):
+		check.Status = doctorFail
+		check.Detail = "keychain is not supported on this platform"
+		check.Remediation = "use keyring_backend=file and store the app secret with `lark config set --app-secret <secret> --store-secret-in-config`"
+	default:
+		check.Status = doctorFail
+		check.Detail = fmt.Sprintf("keychain unavailable: %v", err)
+		check.Remediation = "unlock the OS keychain, or use keyring_backend=file"
+	}
+	return check
+}
+
+func doctorAppCredentialsCheck(state *appState) doctorCheck {
+	check := doctorCheck{Name: "app_credentials", Status: doctorPass}
+	cfg := state.Config
+	if state.credentials != nil {
+		check.Status = doctorSkip
+		check.Detail = fmt.Sprintf("app secret not needed with %s", credfile.EnvPath)
+		return check
+	}
+	if strings.TrimSpace(cfg.AppID) == "" {
+		check.Status = doctorFail
+		check.Detail = "app_id is not set"
+		check.Remediation = larksdk.RemediationHint(larksdk.ErrUnavailable)
+		return check
+	}
+	appType := configAppType(cfg)
+	if strings.TrimSpace(cfg.AppSecret) == "" {
+		check.Status = doctorFail
+		check.Remediation = "lark config set --app-secret <secret>"
+		check.Detail = fmt.Sprintf("app_id %s (%s); app secret missing", cfg.AppID, appType)
+		if cfg.AppSecretInKeyring {
+			check.Detail += " from keychain"
+			check.Remediation += " --store-secret-in-keyring"
+		}
+		return check
+	}
+	source := "config or environment"
+	if cfg.AppSecretInKeyring {
+		source = "keychain"
+	}
+	check.Detail = fmt.Sprintf("app_id %s (%s); app secret from %s", cfg.AppID, appType, source)
+	if cfg.IsMarketplace() && cfg.AppTicket == "" {
+		check.Status = doctorWarn
+		check.Detail += "; no app_ticket stored"
+		check.Remediation = "run `lark auth app-ticket resend`, then store the ticket with `lark auth app-ticket set`"
+	}
+	return check
+}
+
+func doctorClockSkewCheck(ctx context.Context, state *appState, offline bool) doctorCheck {
+	check := doctorCheck{Name: "clock_skew", Status: doctorSkip}
+	if offline {
+		check.Detail = "skipped (--offline)"
+		return check
+	}
+	if state.SDK == nil {
+		check.Detail = "skipped (SDK unavailable)"
+		return check
+	}
+	serverTime, err := state.SDK.ServerTime(ctx)
+	if err != nil {
+		check.Status = doctorWarn
+		check.Detail = fmt.Sprintf("could not read server time: %v", err)
+		check.Remediation = larksdk.RemediationHint(err)
+		return check
+	}
+	skew := time.Since(serverTime).Round(time.Second)
+	direction := "ahead of"
+	if skew < 0 {
+		direction = "behind"
+		skew = -skew
+	}
+	check.Status = doctorPass
+	check.Detail = fmt.Sprintf("local clock %s %s the server", skew, direction)
+	if skew > doctorClockSkewLimit {
+		check.Status = doctorFail
+		check.Remediation = "sync the system clock (enable NTP); token expiry checks depend on it"
+	}
+	return check
+}
+
+func doctorTenantTokenCheck(ctx context.Context, state *appState, offline, appCredsFailed bool) doctorCheck {
+	check := doctorCheck{Name: "tenant_token", Status: doctorSkip}
+	if bundle := state.credentials; bundle != nil {
+		if bundle.TokenType != credfile.TokenTenant {
+			check.Detail = fmt.Sprintf("skipped (%s holds a %s token)", credfile.EnvPath, bundle.TokenType)
+			return check
+		}
+		return doctorBundleCheck(check, bundle)
+	}
+	switch {
+	case offline:
+		check.Detail = "skipped (--offline)"
+		return check
+	case appCredsFailed:
+		check.Detail = "skipped (app credentials missing)"
+		return check
+	case state.Config.IsMarketplace() && state.TenantKey == "":
+		check.Detail = "skipped (marketplace app; pass --tenant-key to check a tenant)"
+		return check
+	}
+	if _, err := ensureTenantToken(ctx, state); err != nil {
+		check.Status = doctorFail
+		check.Detail = err.Error()
+		check.Remediation = larksdk.RemediationHint(err)
+		return check
+	}
+	_, expiresAt := cachedTenantToken(state)
+	check.Status = doctorPass
+	check.Detail = fmt.Sprintf("valid until %s", formatUnixTime(expiresAt))
+	if state.TenantKey != "" {
+		check.Detail = fmt.Sprintf("tenant %s; %s", state.TenantKey, check.Detail)
+	}
+	return check
+}
+
+func doctorBundleCheck(check doctorCheck, bundle *credfile.Bundle) doctorCheck {
+	if bundle.Expired(time.Now()) {
+		check.Status = doctorFail
+		check.Detail = fmt.Sprintf("%s expired at %s", credfile.EnvPath, formatUnixTime(bundle.ExpiresAt))
+		check.Remediation = "mint a new one with `lark auth token mint`"
+		return check
+	}
+	check.Status = doctorPass
+	check.Detail = fmt.Sprintf("from %s; valid until %s; scopes: %s", credfile.EnvPath, formatUnixTime(bundle.ExpiresAt), strings.Join(bundle.Scopes, " "))
+	return check
+}
+
+func doctorUserChecks(ctx context.Context, state *appState, offline bool) []doctorCheck {
+	if bundle := state.credentials; bundle != nil {
+		check := doctorCheck{Name: "user_token", Account: bundle.Account, Status: doctorSkip}
+		if bundle.TokenType != credfile.TokenUser {
+			check.Detail = fmt.Sprintf("skipped (%s holds a %s token)", credfile.EnvPath, bundle.TokenType)
+			return []doctorCheck{check}
+		}
+		return []doctorCheck{doctorBundleCheck(check, bundle)}
+	}
+	accounts := listUserAccountNames(state.Config)
+	if len(accounts) == 0 {
+		return []doctorCheck{{
+			Name:        "user_token",
+			Status:      doctorSkip,
+			Detail:      "no user accounts (only needed for user-token commands)",
+			Remediation: userOAuthReloginCommand,
+		}}
+	}
+	checks := make([]doctorCheck, 0, 2*len(accounts))
+	for _, account := range accounts {
+		token := doctorUserTokenCheck(ctx, state, account, offline)
+		checks = append(checks, token)
+		if token.Status != doctorSkip {
+			checks = append(checks, doctorUserScopesCheck(state, account))
+		}
+	}
+	return checks
+}
+
+func doctorUserTokenCheck(ctx context.Context, state *appState, account string, offline bool) doctorCheck {
+	check := doctorCheck{Name: "user_token", Account: account, Status: doctorPass}
+	relogin := fmt.Sprintf("lark auth user login --account %s --force-consent", account)
+	stored, ok, err := loadUserToken(state, account)
+	if err != nil {
+		check.Status = doctorFail
+		check.Detail = err.Error()
+		check.Remediation = larksdk.RemediationHint(err)
+		return check
+	}
+	acct, _ := loadUserAccount(state.Config, account)
+	refreshToken := stored.RefreshToken
+	if refreshToken == "" {
+		refreshToken = acct.RefreshTokenValue()
+	}
+	now := time.Now()
+	valid := ok && cachedUserTokenValid(stored, now)
+
+	switch {
+	case refreshToken == "" && stored.AccessToken == "":
+		check.Status = doctorSkip
+		check.Detail = "not logged in"
+		check.Remediation = relogin
+		return check
+	case refreshToken == "" && valid:
+		check.Status = doctorWarn
+		check.Detail = fmt.Sprintf("valid until %s, but no refresh token", formatUnixTime(stored.ExpiresAt))
+		check.Remediation = relogin
+		return check
+	case refreshToken == "":
+		check.Status = doctorFail
+		check.Detail = "access token expired and no refresh token"
+		check.Remediation = relogin
+		return check
+	case valid:
+		check.Detail = fmt.Sprintf("valid until %s", formatUnixTime(stored.ExpiresAt))
+	case offline:
+		check.Status = doctorWarn
+		check.Detail = "access token expired; refreshes on next use"
+	default:
+		refreshed, err := refreshUserToken(ctx, state, account, false)
+		if err != nil {
+			check.Status = doctorFail
+			check.Detail = fmt.Sprintf("refresh failed: %v", err)
+			check.Remediation = relogin
+			return check
+		}
+		check.Detail = fmt.Sprintf("refreshed; valid until %s", formatUnixTime(refreshed.ExpiresAt))
+	}
+	if expiresAt := userRefreshTokenExpiresAt(state, account); userRefreshTokenExpiring(expiresAt, now) {
+		check.Status = doctorWarn
+		check.Detail += fmt.Sprintf("; refresh token expires at %s", formatUnixTime(expiresAt))
+		check.Remediation = relogin
+	}
+	return check
+}
+
+// doctorUserScopesCheck compares the account's granted scopes with the
+// RequiredUserScopes of the services it requested at login. Accounts that
+// predate recorded services are compared with every user-capable service.
+func doctorUserScopesCheck(state *appState, account string) doctorCheck {
+	check := doctorCheck{Name: "user_scopes", Account: account, Status: doctorPass}
+	acct, _ := loadUserAccount(state.Config, account)
+	grantedRaw := acct.UserAccessTokenScope
+	if strings.TrimSpace(grantedRaw) == "" && acct.UserRefreshTokenPayload != nil {
+		grantedRaw = acct.UserRefreshTokenPayload.Scopes
+	}
+	granted := normalizeScopes(parseScopeList(grantedRaw))
+	if len(granted) == 0 {
+		check.Status = doctorWarn
+		check.Detail = "granted scopes unknown"
+		check.Remediation = fmt.Sprintf("lark auth user login --account %s --force-consent", account)
+		return check
+	}
+
+	var requested map[string]bool
+	if acct.UserRefreshTokenPayload != nil && len(acct.UserRefreshTokenPayload.Services) > 0 {
+		requested = map[string]bool{}
+		for _, name := range authregistry.ExpandUserOAuthServiceAliases(acct.UserRefreshTokenPayload.Services) {
+			requested[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(authregistry.Registry))
+	for name := range authregistry.Registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var covered, lacking []string
+	missing := map[string][]string{}
+	for _, name := range names {
+		def := authregistry.Registry[name]
+		if len(def.RequiredUserScopes) == 0 || !serviceAcceptsUserToken(def) {
+			continue
+		}
+		if requested != nil && !requested[name] {
+			check.OtherServices = append(check.OtherServices, name)
+			continue
+		}
+		if gaps := missingScopes(def.RequiredUserScopes, granted); len(gaps) > 0 {
+			missing[name] = gaps
+			lacking = append(lacking, name)
+			continue
+		}
+		covered = append(covered, name)
+	}
+	if requested != nil {
+		check.Detail = fmt.Sprintf("%d of %d requested services covered", len(covered), len(covered)+len(lacking))
+	} else {
+		check.Detail = fmt.Sprintf("%d of %d services covered (requested services not recorded)", len(covered), len(covered)+len(lacking))
+	}
+	if len(lacking) == 0 {
+		return check
+	}
+	check.Status = doctorWarn
+	check.Detail += fmt.Sprintf("; missing scopes for: %s", strings.Join(lacking, ", "))
+	check.MissingScopes = missing
+	// Request the same services again so re-consent does not drop the
+	// already granted ones.
+	all := append(append([]string{}, covered...), lacking...)
+	sort.Strings(all)
+	check.Remediation = fmt.Sprintf("lark auth user login --account %s --services %q --force-consent", account, strings.Join(all, ","))
+	return check
+}
+
+func serviceAcceptsUserToken(def authregistry.ServiceDef) bool {
+	for _, tt := range def.TokenTypes {
+		if tt == authregistry.TokenUser {
+			return true
+		}
+	}
+	return false
+}
+
+func formatAuthDoctorReport(report authDoctorReport) string {
+	blocks := make([]string, 0, len(report.Checks)+1)
+	for _, check := range report.Checks {
+		kind := output.NoticeSuccess
+		switch check.Status {
+		case doctorWarn:
+			kind = output.NoticeWarning
+		case doctorFail:
+			kind = output.NoticeError
+		case doctorSkip:
+			kind = output.NoticeInfo
+		}
+		title := check.Name
+		if check.Account != "" {
+			title = fmt.Sprintf("%s (%s)", check.Name, check.Account)
+		}
+		lines := []string{check.Detail}
+		if len(check.MissingScopes) > 0 {
+			services := make([]string, 0, len(check.MissingScopes))
+			for service := range check.MissingScopes {
+				services = append(services, service)
+			}
+			sort.Strings(services)
+			for _, service := range services {
+				lines = append(lines, fmt.Sprintf("%s: %s", service, strings.Join(check.MissingScopes[service], " ")))
+			}
+		}
+		if len(check.OtherServices) > 0 {
+			lines = append(lines, "not requested: "+strings.Join(check.OtherServices, ", "))
+		}
+		if check.Remediation != "" {
+			lines = append(lines, "fix: "+check.Remediation)
+		}
+		blocks = append(blocks, output.Notice(kind, title, lines))
+	}
+	summary := output.Notice(output.NoticeSuccess, "all checks passed", nil)
+	if report.Failures > 0 || report.Warnings > 0 {
+		summary = fmt.Sprintf("%d failed, %d warning(s)", report.Failures, report.Warnings)
+	}
+	blocks = append(blocks, summary)
+	return output.JoinBlocks(blocks...)
+}
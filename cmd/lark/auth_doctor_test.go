@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"lark/internal/config"
+)
+
+func doctorTestHandler(t *testing.T, serverNow time.Time) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", serverNow.UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodHead:
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/open-apis/auth/v3/tenant_access_token/internal":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "tenant_access_token": "t-1", "expire": 7200})
+		case r.URL.Path == "/open-apis/authen/v2/oauth/token":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 20064, "error": "invalid_grant", "error_description": "refresh token revoked"})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func runDoctorForTest(t *testing.T, handler http.Handler, cfg *config.Config, args ...string) (authDoctorReport, error) {
+	t.Helper()
+	var buf bytes.Buffer
+	state := newTestState(t, handler, cfg, &buf)
+	state.Profile = "default"
+	state.JSON = true
+	state.Printer.JSON = true
+
+	cmd := newAuthCmd(state)
+	cmd.SetArgs(append([]string{"doctor"}, args...))
+	err := cmd.Execute()
+	var report authDoctorReport
+	if decodeErr := json.Unmarshal(buf.Bytes(), &report); decodeErr != nil {
+		t.Fatalf("decode output %q: %v", buf.String(), decodeErr)
+	}
+	return report, err
+}
+
+func findDoctorCheck(t *testing.T, report authDoctorReport, name, account string) doctorCheck {
+	t.Helper()
+	for _, check := range report.Checks {
+		if check.Name == name && check.Account == account {
+			return check
+		}
+	}
+	t.Fatalf("check %s(%s) not found in %#v", name, account, report.Checks)
+	return doctorCheck{}
+}
+
+func TestAuthDoctorHealthySetup(t *testing.T) {
+	cfg := &config.Config{}
+	withUserAccount(cfg, "alice", "u1", "r1", time.Now().Add(time.Hour).Unix(), "offline_access im:chat:read wiki:wiki")
+
+	report, err := runDoctorForTest(t, doctorTestHandler(t, time.Now()), cfg)
+	if err != nil {
+		t.Fatalf("doctor error: %v", err)
+	}
+	if !report.OK || report.Failures != 0 {
+		t.Fatalf("expected no failures, got %#v", report)
+	}
+	for _, name := range []string{"app_credentials", "clock_skew", "tenant_token"} {
+		if check := findDoctorCheck(t, report, name, ""); check.Status != doctorPass {
+			t.Fatalf("%s: expected pass, got %#v", name, check)
+		}
+	}
+	if check := findDoctorCheck(t, report, "user_token", "alice"); check.Status != doctorPass {
+		t.Fatalf("user_token: expected pass, got %#v", check)
+	}
+	scopes := findDoctorCheck(t, report, "user_scopes", "alice")
+	if scopes.Status != doctorWarn || len(scopes.MissingScopes["mail"]) == 0 {
+		t.Fatalf("expected missing mail scopes, got %#v", scopes)
+	}
+	if _, ok := scopes.MissingScopes["im"]; ok {
+		t.Fatalf("im scopes are granted, got %#v", scopes.MissingScopes)
+	}
+	if !strings.Contains(scopes.Remediation, "--account alice --services") || !strings.Contains(scopes.Remediation, "im,") {
+		t.Fatalf("expected relogin with all services, got %q", scopes.Remediation)
+	}
+}
+
+func TestAuthDoctorComparesRequestedServices(t *testing.T) {
+	cfg := &config.Config{}
+	withUserAccount(cfg, "dana", "u1", "r1", time.Now().Add(time.Hour).Unix(), "offline_access im:chat:read")
+	cfg.UserAccounts["dana"].UserRefreshTokenPayload = &config.UserRefreshTokenPayload{Services: []string{"im"}}
+
+	report, err := runDoctorForTest(t, doctorTestHandler(t, time.Now()), cfg, "--offline")
+	if err != nil {
+		t.Fatalf("doctor error: %v", err)
+	}
+	scopes := findDoctorCheck(t, report, "user_scopes", "dana")
+	if scopes.Status != doctorPass || scopes.Detail != "1 of 1 requested services covered" || len(scopes.MissingScopes) != 0 {
+		t.Fatalf("expected requested services covered, got %#v", scopes)
+	}
+	if !strings.Contains(strings.Join(scopes.OtherServices, ","), "mail") {
+		t.Fatalf("expected unrequested services listed, got %#v", scopes.OtherServices)
+	}
+
+	cfg.UserAccounts["dana"].UserRefreshTokenPayload.Services = []string{"im", "mail"}
+	report, _ = runDoctorForTest(t, doctorTestHandler(t, time.Now()), cfg, "--offline")
+	scopes = findDoctorCheck(t, report, "user_scopes", "dana")
+	if scopes.Status != doctorWarn || len(scopes.MissingScopes["mail"]) == 0 || !strings.Contains(scopes.Remediation, `--services "im,mail"`) {
+		t.Fatalf("expected missing mail scopes, got %#v", scopes)
+	}
+}
+
+func TestAuthDoctorReportsFailures(t *testing.T) {
+	cfg := &config.Config{}
+	withUserAccount(cfg, "bob", "stale", "revoked", time.Now().Add(-time.Hour).Unix(), "im:chat:read")
+
+	report, err := runDoctorForTest(t, doctorTestHandler(t, time.Now().Add(-10*time.Minute)), cfg)
+	if err == nil || !strings.Contains(err.Error(), "2 check(s) failed") {
+		t.Fatalf("expected failure error, got %v", err)
+	}
+	skew := findDoctorCheck(t, report, "clock_skew", "")
+	if skew.Status != doctorFail || !strings.Contains(skew.Detail, "ahead of") || skew.Remediation == "" {
+		t.Fatalf("expected clock skew failure, got %#v", skew)
+	}
+	token := findDoctorCheck(t, report, "user_token", "bob")
+	if token.Status != doctorFail || !strings.Contains(token.Remediation, "--account bob") {
+		t.Fatalf("expected user token failure, got %#v", token)
+	}
+}
+
+func TestAuthDoctorOfflineMakesNoRequests(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+	})
+	cfg := &config.Config{}
+	withUserAccount(cfg, "carol", "stale", "r1", time.Now().Add(-time.Hour).Unix(), "")
+
+	report, err := runDoctorForTest(t, handler, cfg, "--offline")
+	if err != nil {
+		t.Fatalf("doctor error: %v", err)
+	}
+	for _, name := range []string{"clock_skew", "tenant_token"} {
+		if check := findDoctorCheck(t, report, name, ""); check.Status != doctorSkip {
+			t.Fatalf("%s: expected skip, got %#v", name, check)
+		}
+	}
+	if check := findDoctorCheck(t, report, "user_token", "carol"); check.Status != doctorWarn {
+		t.Fatalf("expected expired token warning, got %#v", check)
+	}
+	if check := findDoctorCheck(t, report, "user_scopes", "carol"); check.Status != doctorWarn || check.Detail != "granted scopes unknown" {
+		t.Fatalf("expected unknown scopes warning, got %#v", check)
+	}
+}
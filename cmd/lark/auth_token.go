@@ -0,0 +1,244 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/authregistry"
+	"lark/internal/config"
+	"lark/internal/credfile"
+	"lark/internal/output"
+)
+
+func newAuthTokenCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Mint scoped credentials for other machines",
+	}
+	cmd.AddCommand(newAuthTokenMintCmd(state))
+	return cmd
+}
+
+func newAuthTokenMintCmd(state *appState) *cobra.Command {
+	var scopes string
+	var ttl time.Duration
+	var out string
+
+	cmd := &cobra.Command{
+		Use:   "mint",
+		Short: "Write a short-lived, scope-restricted credentials file",
+		Long: `Write a credentials file holding a fresh tenant or user access token plus
+the declared scopes and an expiry. Point LARK_CREDENTIALS_FILE at it on another
+machine (e.g. a CI runner) to run commands without the app secret or a refresh
+token.
+
+Commands run with a credentials file are refused unless every scope they
+require (see ` + "`lark auth explain <command>`" + `) is declared with --scopes and
+the token type matches. Commands without declared requirements are refused.
+
+The file stops working at min(now + --ttl, access token expiry). Lark access
+tokens last about two hours, so --ttl cannot extend a token's lifetime; the
+token itself stays valid server-side until it expires.`,
+		Example: `  lark auth token mint --scopes im:chat:read --ttl 1h --out token.json
+  lark auth token mint --token-type user --scopes "drive:drive:readonly search:docs:read" --out ci.json
+  LARK_CREDENTIALS_FILE=token.json lark chats list`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scopeList := normalizeScopes(parseScopeList(scopes))
+			if len(scopeList) == 0 {
+				return flagUsage(cmd, "--scopes is required")
+			}
+			if ttl <= 0 {
+				return flagUsage(cmd, "--ttl must be positive")
+			}
+			if strings.TrimSpace(out) == "" {
+				return flagUsage(cmd, "--out is required (use - for stdout)")
+			}
+			if err := requireCredentials(state); err != nil {
+				return err
+			}
+			kind, err := parseTokenType(state.TokenType)
+			if err != nil {
+				return err
+			}
+			if kind == tokenTypeAuto {
+				kind = normalizeDefaultTokenType(state.Config.DefaultTokenType)
+			}
+
+			now := time.Now()
+			bundle := &credfile.Bundle{
+				Version:   credfile.Version,
+				AppID:     state.Config.AppID,
+				BaseURL:   state.Config.BaseURL,
+				TokenType: string(kind),
+				Scopes:    scopeList,
+				IssuedAt:  now.Unix(),
+			}
+			var tokenExpiresAt int64
+			switch kind {
+			case tokenTypeTenant:
+				token, err := ensureTenantToken(cmd.Context(), state)
+				if err != nil {
+					return err
+				}
+				bundle.AccessToken = token
+				_, tokenExpiresAt = cachedTenantToken(state)
+			case tokenTypeUser:
+				account := resolveUserAccountName(state)
+				token, err := refreshUserToken(cmd.Context(), state, account, true)
+				if err != nil {
+					return err
+				}
+				granted := token.Scope
+				if acct, ok := loadUserAccount(state.Config, account); ok && granted == "" {
+					granted = acct.UserAccessTokenScope
+				}
+				if grantedList := normalizeScopes(parseScopeList(granted)); len(grantedList) > 0 {
+					if missing := missingScopes(scopeList, grantedList); len(missing) > 0 {
+						return fmt.Errorf("account %q has not granted: %s", account, strings.Join(missing, ", "))
+					}
+				}
+				bundle.AccessToken = token.AccessToken
+				bundle.Account = account
+				tokenExpiresAt = token.ExpiresAt
+			default:
+				return fmt.Errorf("invalid token type: %s", kind)
+			}
+			bundle.ExpiresAt = now.Add(ttl).Unix()
+			if tokenExpiresAt > 0 && tokenExpiresAt < bundle.ExpiresAt {
+				bundle.ExpiresAt = tokenExpiresAt
+				fmt.Fprintf(errWriter(state), "warning: access token expires at %s, before the requested --ttl\n", time.Unix(tokenExpiresAt, 0).UTC().Format(time.RFC3339))
+			}
+
+			if out == "-" {
+				data, err := credfile.Marshal(bundle)
+				if err != nil {
+					return err
+				}
+				_, err = state.Printer.Writer.Write(data)
+				return err
+			}
+			if err := credfile.Save(out, bundle); err != nil {
+				return err
+			}
+			payload := map[string]any{
+				"path":       out,
+				"token_type": bundle.TokenType,
+				"scopes":     bundle.Scopes,
+				"expires_at": bundle.ExpiresAt,
+			}
+			text := output.Notice(output.NoticeSuccess, "Credentials file written", []string{
+				fmt.Sprintf("Path: %s", out),
+				fmt.Sprintf("Token type: %s", bundle.TokenType),
+				fmt.Sprintf("Scopes: %s", strings.Join(bundle.Scopes, " ")),
+				fmt.Sprintf("Expires: %s", time.Unix(bundle.ExpiresAt, 0).UTC().Format(time.RFC3339)),
+				fmt.Sprintf("Use with: %s=%s lark <command>", credfile.EnvPath, out),
+			})
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&scopes, "scopes", "", "scopes the credentials may be used for (space/comma-separated)")
+	cmd.Flags().DurationVar(&ttl, "ttl", time.Hour, "how long the credentials file stays usable (capped at the access token expiry)")
+	cmd.Flags().StringVar(&out, "out", "", "output path (use - for stdout)")
+	return cmd
+}
+
+// applyCredentialsFile switches state to the bundle named by
+// LARK_CREDENTIALS_FILE, if set. App secrets and cached tokens from config are
+// dropped so only the bundle's token can be used.
+func applyCredentialsFile(state *appState, cfg *config.Config) error {
+	path := strings.TrimSpace(os.Getenv(credfile.EnvPath))
+	if path == "" {
+		return nil
+	}
+	bundle, err := credfile.Load(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", credfile.EnvPath, err)
+	}
+	if err := bundle.Validate(time.Now()); err != nil {
+		return fmt.Errorf("%s: %w; mint a new one with `lark auth token mint`", credfile.EnvPath, err)
+	}
+	cfg.AppID = bundle.AppID
+	cfg.AppSecret = ""
+	cfg.AppSecretInKeyring = false
+	cfg.TenantAccessToken = ""
+	cfg.TenantAccessTokenExpiresAt = 0
+	cfg.AppTicket = ""
+	cfg.AppAccessToken = ""
+	cfg.AppAccessTokenExpiresAt = 0
+	cfg.TenantTokens = nil
+	cfg.UserAccounts = nil
+	if bundle.BaseURL != "" {
+		cfg.BaseURL = bundle.BaseURL
+	}
+	state.credentials = bundle
+	return nil
+}
+
+// credentialsExemptCommands call no scoped APIs and are allowed under a
+// credentials file.
+var credentialsExemptCommands = map[string]struct{}{
+	"help":         {},
+	"version":      {},
+	"completion":   {},
+	"auth explain": {},
+	"auth doctor":  {},
+}
+
+// enforceCredentialsScopes refuses the current command unless the credentials
+// file covers its token type and every scope it requires.
+func enforceCredentialsScopes(state *appState) error {
+	bundle := state.credentials
+	if bundle == nil {
+		return nil
+	}
+	command := strings.TrimSpace(state.Command)
+	if _, ok := credentialsExemptCommands[command]; ok || command == "" || strings.HasPrefix(command, "__complete") {
+		return nil
+	}
+	services, tokenTypes, _, _, ok, err := authregistry.RequirementsForCommand(command)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%q declares no scope requirements, so it cannot run with scoped credentials from %s", command, credfile.EnvPath)
+	}
+	allowed := false
+	for _, tt := range tokenTypes {
+		if string(tt) == bundle.TokenType {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("%q does not accept the %s token in %s", command, bundle.TokenType, credfile.EnvPath)
+	}
+	required, undeclared, err := authregistry.RequiredScopesForTokenReport(services, authregistry.TokenType(bundle.TokenType))
+	if err != nil {
+		return err
+	}
+	if len(undeclared) > 0 {
+		return fmt.Errorf("%q uses services without declared scopes (%s), so it cannot run with scoped credentials from %s", command, strings.Join(undeclared, ", "), credfile.EnvPath)
+	}
+	if missing := bundle.MissingScopes(required); len(missing) > 0 {
+		return fmt.Errorf("%q requires scopes not declared in %s: %s", command, credfile.EnvPath, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func credentialsAccessToken(state *appState, want tokenType) (string, error) {
+	bundle := state.credentials
+	if bundle.TokenType != string(want) {
+		return "", fmt.Errorf("%s holds a %s token; this command needs a %s token", credfile.EnvPath, bundle.TokenType, want)
+	}
+	if bundle.Expired(time.Now()) {
+		return "", errors.New("credentials from " + credfile.EnvPath + " expired; mint a new one with `lark auth token mint`")
+	}
+	return bundle.AccessToken, nil
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"lark/internal/config"
+	"lark/internal/credfile"
+)
+
+func TestAuthTokenMintTenantCapsTTL(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request: %s", r.URL.Path)
+	})
+	cfg := &config.Config{
+		TenantAccessToken:          "t-cached",
+		TenantAccessTokenExpiresAt: time.Now().Add(30 * time.Minute).Unix(),
+	}
+	var buf bytes.Buffer
+	state := newTestState(t, handler, cfg, &buf)
+	out := filepath.Join(t.TempDir(), "token.json")
+
+	cmd := newAuthCmd(state)
+	cmd.SetArgs([]string{"token", "mint", "--scopes", "im:chat:read,im:message:send", "--ttl", "1h", "--out", out})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("mint error: %v", err)
+	}
+	bundle, err := credfile.Load(out)
+	if err != nil {
+		t.Fatalf("load bundle: %v", err)
+	}
+	if bundle.TokenType != "tenant" || bundle.AccessToken != "t-cached" || bundle.AppID != "app" {
+		t.Fatalf("unexpected bundle: %#v", bundle)
+	}
+	if strings.Join(bundle.Scopes, " ") != "im:chat:read im:message:send" {
+		t.Fatalf("unexpected scopes: %v", bundle.Scopes)
+	}
+	if bundle.ExpiresAt != cfg.TenantAccessTokenExpiresAt {
+		t.Fatalf("expected expiry capped at token expiry, got %d", bundle.ExpiresAt)
+	}
+	if !strings.Contains(buf.String(), "before the requested --ttl") {
+		t.Fatalf("expected ttl warning, got %q", buf.String())
+	}
+}
+
+func TestAuthTokenMintUserRejectsUngrantedScopes(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "access_token": "u2", "refresh_token": "r2", "expires_in": 7200})
+	})
+	cfg := &config.Config{}
+	withUserAccount(cfg, defaultUserAccountName, "u1", "r1", time.Now().Add(time.Hour).Unix(), "offline_access drive:drive:readonly")
+	var buf bytes.Buffer
+	state := newTestState(t, handler, cfg, &buf)
+	state.TokenType = "user"
+
+	cmd := newAuthCmd(state)
+	cmd.SetArgs([]string{"token", "mint", "--scopes", "drive:drive:readonly mail:user_mailbox.message:send", "--out", filepath.Join(t.TempDir(), "t.json")})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "has not granted: mail:user_mailbox.message:send") {
+		t.Fatalf("expected ungranted scope error, got %v", err)
+	}
+}
+
+func TestCredentialsFileRunsScopedCommandsOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer t-bundle" {
+			t.Fatalf("expected bundle token, got %q", r.Header.Get("Authorization"))
+		}
+		if r.URL.Path != "/open-apis/im/v1/chats" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{
+			"items": []map[string]any{{"chat_id": "oc_1", "name": "Launch"}},
+		}})
+	}))
+	defer server.Close()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("LARK_APP_ID", "")
+	t.Setenv("LARK_APP_SECRET", "")
+	path := filepath.Join(t.TempDir(), "token.json")
+	if err := credfile.Save(path, &credfile.Bundle{
+		Version:     credfile.Version,
+		AppID:       "cli_app",
+		BaseURL:     server.URL,
+		TokenType:   credfile.TokenTenant,
+		AccessToken: "t-bundle",
+		Scopes:      []string{"im:chat:read"},
+		IssuedAt:    time.Now().Unix(),
+		ExpiresAt:   time.Now().Add(time.Hour).Unix(),
+	}); err != nil {
+		t.Fatalf("save bundle: %v", err)
+	}
+	t.Setenv(credfile.EnvPath, path)
+
+	run := func(args ...string) (string, error) {
+		var buf bytes.Buffer
+		cmd := newRootCmd()
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	out, err := run("chats", "list")
+	if err != nil {
+		t.Fatalf("chats list error: %v", err)
+	}
+	if !strings.Contains(out, "oc_1") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	for args, want := range map[string]string{
+		"mail send --to a@example.com --subject hi --text x":           "does not accept the tenant token",
+		"drive search report":                                          "requires scopes not declared",
+		"minutes info obcn":                                            "declares no scope requirements",
+		"auth user refresh":                                            "declares no scope requirements",
+		"mail public-mailboxes members add pm@example.com --user ou_1": "uses services without declared scopes (mail-admin)",
+		"rooms list":                                                   "uses services without declared scopes (vc-room)",
+		"base table list app_1":                                        "uses services without declared scopes (base)",
+	} {
+		_, err := run(strings.Fields(args)...)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("%s: expected %q, got %v", args, want, err)
+		}
+	}
+}
+
+func TestEnforceCredentialsScopesMissingScopes(t *testing.T) {
+	state := &appState{
+		Command: "wiki spaces list",
+		credentials: &credfile.Bundle{
+			Version: credfile.Version, TokenType: credfile.TokenUser, AccessToken: "u",
+			Scopes: []string{"wiki:wiki:readonly"}, ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		},
+	}
+	err := enforceCredentialsScopes(state)
+	if err == nil || !strings.Contains(err.Error(), "requires scopes not declared") || !strings.Contains(err.Error(), "wiki:wiki") {
+		t.Fatalf("expected missing scope error, got %v", err)
+	}
+	state.credentials.Scopes = []string{"wiki:wiki"}
+	if err := enforceCredentialsScopes(state); err != nil {
+		t.Fatalf("expected wiki command to be allowed, got %v", err)
+	}
+	state.Command = "auth explain"
+	state.credentials.Scopes = []string{"unrelated"}
+	if err := enforceCredentialsScopes(state); err != nil {
+		t.Fatalf("expected exempt command, got %v", err)
+	}
+}
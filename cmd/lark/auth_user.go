@@ -38,6 +38,7 @@ type userOAuthToken struct {
 	AccessToken      string `json:"access_token"`
 	RefreshToken     string `json:"refresh_token"`
 	ExpiresIn        int64  `json:"expires_in"`
+	RefreshExpiresIn int64  `json:"refresh_token_expires_in"`
 	TokenType        string `json:"token_type"`
 	Scope            string `json:"scope"`
 	Error            string `json:"error"`
@@ -59,6 +60,7 @@ func newAuthUserCmd(state *appState) *cobra.Command {
 	cmd.AddCommand(newAuthUserScopesCmd(state))
 	cmd.AddCommand(newAuthUserServicesCmd(state))
 	cmd.AddCommand(newAuthUserAccountsCmd(state))
+	cmd.AddCommand(newAuthUserRefreshCmd(state))
 	return cmd
 }
 
@@ -70,6 +72,8 @@ func newAuthUserLoginCmd(state *appState) *cobra.Command {
 	var forceConsent bool
 	var incremental bool
 	var timeout time.Duration
+	var device bool
+	var manual bool
 
 	cmd := &cobra.Command{
 		Use:   "login",
@@ -121,6 +125,9 @@ func newAuthUserLoginCmd(state *appState) *cobra.Command {
 				}
 			}
 
+			if device && manual {
+				return errors.New("--device cannot be combined with --manual")
+			}
 			if scopeSet {
 				if servicesSet || readonlySet || driveScopeSet {
 					return errors.New("--scopes cannot be combined with --services, --readonly, or --drive-scope")
@@ -147,45 +154,15 @@ func newAuthUserLoginCmd(state *appState) *cobra.Command {
 			requestedScopes := requestedUserOAuthScopes(scopeList, prevScope, incremental)
 			scopeValue := strings.Join(requestedScopes, " ")
 
-			authState, err := newOAuthState()
-			if err != nil {
-				return err
+			var tokens userOAuthToken
+			switch {
+			case device:
+				tokens, err = loginUserWithDeviceCode(cmd.Context(), state, scopeValue)
+			case manual:
+				tokens, err = loginUserWithPastedRedirect(cmd, state, scopeValue, forceConsent, incremental)
+			default:
+				tokens, err = loginUserWithCallback(cmd.Context(), state, scopeValue, forceConsent, incremental, timeout)
 			}
-			authorizeURL, err := buildUserAuthorizeURL(state.Config.BaseURL, state.Config.AppID, userOAuthRedirectURL, authState, scopeValue, userOAuthPrompt(forceConsent), incremental)
-			if err != nil {
-				return err
-			}
-			server := &http.Server{Addr: userOAuthListenAddr}
-			resultCh := make(chan oauthCallbackResult, 1)
-			server.Handler = oauthCallbackHandler(authState, resultCh, server)
-			listener, err := net.Listen("tcp", userOAuthListenAddr)
-			if err != nil {
-				return fmt.Errorf("listen on %s: %w", userOAuthListenAddr, err)
-			}
-			defer func() {
-				_ = server.Shutdown(cmd.Context())
-			}()
-			go func() {
-				_ = server.Serve(listener)
-			}()
-
-			if err := openBrowser(authorizeURL); err != nil {
-				fmt.Fprintf(errWriter(state), "Open this URL in your browser:\n%s\n", authorizeURL)
-			}
-
-			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
-			defer cancel()
-			var result oauthCallbackResult
-			select {
-			case result = <-resultCh:
-				if result.err != nil {
-					return result.err
-				}
-			case <-ctx.Done():
-				return fmt.Errorf("timed out waiting for OAuth callback")
-			}
-
-			tokens, err := exchangeUserAccessToken(ctx, nil, state.Config.BaseURL, state.Config.AppID, state.Config.AppSecret, result.code, userOAuthRedirectURL)
 			if err != nil {
 				return err
 			}
@@ -212,6 +189,9 @@ func newAuthUserLoginCmd(state *appState) *cobra.Command {
 			refreshPayload := &config.UserRefreshTokenPayload{
 				CreatedAt: now.Unix(),
 			}
+			if tokens.RefreshExpiresIn > 0 {
+				refreshPayload.ExpiresAt = now.Add(time.Duration(tokens.RefreshExpiresIn) * time.Second).Unix()
+			}
 			if userTokenBackend(state.Config) == "file" {
 				refreshPayload.RefreshToken = tokens.RefreshToken
 			}
@@ -255,10 +235,79 @@ func newAuthUserLoginCmd(state *appState) *cobra.Command {
 	cmd.Flags().BoolVar(&forceConsent, "force-consent", false, "force the consent screen during OAuth")
 	cmd.Flags().BoolVar(&incremental, "incremental", true, "use incremental OAuth (include granted scopes; set --incremental=false to request full scopes)")
 	cmd.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "timeout waiting for OAuth callback")
+	cmd.Flags().BoolVar(&device, "device", false, "use the device code flow (print a verification URL and code; no local callback or browser needed)")
+	cmd.Flags().BoolVar(&manual, "manual", false, "print the authorize URL and read the pasted redirect URL from stdin instead of running a local callback")
 
 	return cmd
 }
 
+func loginUserWithCallback(ctx context.Context, state *appState, scopeValue string, forceConsent, incremental bool, timeout time.Duration) (userOAuthToken, error) {
+	authState, err := newOAuthState()
+	if err != nil {
+		return userOAuthToken{}, err
+	}
+	authorizeURL, err := buildUserAuthorizeURL(state.Config.BaseURL, state.Config.AppID, userOAuthRedirectURL, authState, scopeValue, userOAuthPrompt(forceConsent), incremental)
+	if err != nil {
+		return userOAuthToken{}, err
+	}
+	server := &http.Server{Addr: userOAuthListenAddr}
+	resultCh := make(chan oauthCallbackResult, 1)
+	server.Handler = oauthCallbackHandler(authState, resultCh, server)
+	listener, err := net.Listen("tcp", userOAuthListenAddr)
+	if err != nil {
+		return userOAuthToken{}, fmt.Errorf("listen on %s: %w (use --device or --manual on remote machines)", userOAuthListenAddr, err)
+	}
+	defer func() {
+		_ = server.Shutdown(ctx)
+	}()
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	if err := openBrowser(authorizeURL); err != nil {
+		fmt.Fprintf(errWriter(state), "Open this URL in your browser:\n%s\n", authorizeURL)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var result oauthCallbackResult
+	select {
+	case result = <-resultCh:
+		if result.err != nil {
+			return userOAuthToken{}, result.err
+		}
+	case <-waitCtx.Done():
+		return userOAuthToken{}, fmt.Errorf("timed out waiting for OAuth callback")
+	}
+	return exchangeUserAccessToken(waitCtx, nil, state.Config.BaseURL, state.Config.AppID, state.Config.AppSecret, result.code, userOAuthRedirectURL)
+}
+
+func loginUserWithDeviceCode(ctx context.Context, state *appState, scopeValue string) (userOAuthToken, error) {
+	auth, err := requestUserDeviceAuthorization(ctx, nil, state.Config.BaseURL, state.Config.AppID, state.Config.AppSecret, scopeValue)
+	if err != nil {
+		return userOAuthToken{}, err
+	}
+	fmt.Fprint(errWriter(state), userDeviceLoginPrompt(auth))
+	return pollUserDeviceToken(ctx, nil, state.Config.BaseURL, state.Config.AppID, state.Config.AppSecret, auth)
+}
+
+func loginUserWithPastedRedirect(cmd *cobra.Command, state *appState, scopeValue string, forceConsent, incremental bool) (userOAuthToken, error) {
+	authState, err := newOAuthState()
+	if err != nil {
+		return userOAuthToken{}, err
+	}
+	authorizeURL, err := buildUserAuthorizeURL(state.Config.BaseURL, state.Config.AppID, userOAuthRedirectURL, authState, scopeValue, userOAuthPrompt(forceConsent), incremental)
+	if err != nil {
+		return userOAuthToken{}, err
+	}
+	fmt.Fprint(errWriter(state), userManualLoginPrompt(authorizeURL))
+	code, err := readManualRedirect(cmd.InOrStdin(), authState)
+	if err != nil {
+		return userOAuthToken{}, err
+	}
+	return exchangeUserAccessToken(cmd.Context(), nil, state.Config.BaseURL, state.Config.AppID, state.Config.AppSecret, code, userOAuthRedirectURL)
+}
+
 func newOAuthState() (string, error) {
 	buf := make([]byte, 16)
 	if _, err := rand.Read(buf); err != nil {
@@ -434,27 +483,10 @@ func oauthCallbackHandler(state string, resultCh chan<- oauthCallbackResult, ser
 	var once sync.Once
 	mux.HandleFunc(userOAuthCallbackPath, func(w http.ResponseWriter, r *http.Request) {
 		result := oauthCallbackResult{}
-		query := r.URL.Query()
-		if state != "" && query.Get("state") != state {
-			result.err = errors.New("oauth state mismatch")
-			writeOAuthError(w, "OAuth state mismatch")
-			finalizeOAuthResult(server, &once, resultCh, result)
-			return
-		}
-		if errValue := query.Get("error"); errValue != "" {
-			message := errValue
-			if desc := query.Get("error_description"); desc != "" {
-				message = fmt.Sprintf("%s: %s", errValue, desc)
-			}
-			result.err = fmt.Errorf("oauth error: %s", message)
-			writeOAuthError(w, message)
-			finalizeOAuthResult(server, &once, resultCh, result)
-			return
-		}
-		code := query.Get("code")
-		if code == "" {
-			result.err = errors.New("oauth callback missing code")
-			writeOAuthError(w, "Missing authorization code")
+		code, err := parseOAuthCallbackQuery(r.URL.Query(), state)
+		if err != nil {
+			result.err = err
+			writeOAuthError(w, err.Error())
 			finalizeOAuthResult(server, &once, resultCh, result)
 			return
 		}
@@ -465,6 +497,24 @@ func oauthCallbackHandler(state string, resultCh chan<- oauthCallbackResult, ser
 	return mux
 }
 
+func parseOAuthCallbackQuery(query url.Values, state string) (string, error) {
+	if state != "" && query.Get("state") != state {
+		return "", errors.New("oauth state mismatch")
+	}
+	if errValue := query.Get("error"); errValue != "" {
+		message := errValue
+		if desc := query.Get("error_description"); desc != "" {
+			message = fmt.Sprintf("%s: %s", errValue, desc)
+		}
+		return "", fmt.Errorf("oauth error: %s", message)
+	}
+	code := query.Get("code")
+	if code == "" {
+		return "", errors.New("oauth callback missing code")
+	}
+	return code, nil
+}
+
 func finalizeOAuthResult(server *http.Server, once *sync.Once, resultCh chan<- oauthCallbackResult, result oauthCallbackResult) {
 	once.Do(func() {
 		select {
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const userDeviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+var (
+	userDeviceAuthPollInterval = 5 * time.Second
+	userDeviceAuthSlowDownStep = 5 * time.Second
+)
+
+type userDeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int64  `json:"expires_in"`
+	Interval                int64  `json:"interval"`
+	Error                   string `json:"error"`
+	ErrorDescription        string `json:"error_description"`
+}
+
+func buildUserDeviceAuthorizationURL(baseURL string) (string, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	base.Path = "/open-apis/authen/v2/oauth/device_authorization"
+	return base.String(), nil
+}
+
+// requestUserDeviceAuthorization starts an RFC 8628 device authorization and
+// returns the device code plus the user-facing verification URL and code.
+func requestUserDeviceAuthorization(ctx context.Context, httpClient *http.Client, baseURL, appID, appSecret, scope string) (userDeviceAuthorization, error) {
+	endpoint, err := buildUserDeviceAuthorizationURL(baseURL)
+	if err != nil {
+		return userDeviceAuthorization{}, err
+	}
+	payload := map[string]string{
+		"client_id":     appID,
+		"client_secret": appSecret,
+	}
+	if scope != "" {
+		payload["scope"] = scope
+	}
+	status, data, err := postOAuthJSON(ctx, httpClient, endpoint, payload)
+	if err != nil {
+		return userDeviceAuthorization{}, err
+	}
+	var parsed userDeviceAuthorization
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		if status < 200 || status > 299 {
+			return userDeviceAuthorization{}, fmt.Errorf("device authorization failed (HTTP %d): %s; use --manual instead", status, strings.TrimSpace(string(data)))
+		}
+		return userDeviceAuthorization{}, err
+	}
+	if parsed.Error != "" {
+		return userDeviceAuthorization{}, fmt.Errorf("device authorization failed: %s; use --manual instead", oauthErrorMessage(userOAuthToken{Error: parsed.Error, ErrorDescription: parsed.ErrorDescription}))
+	}
+	if status < 200 || status > 299 {
+		return userDeviceAuthorization{}, fmt.Errorf("device authorization failed (HTTP %d): %s; use --manual instead", status, strings.TrimSpace(string(data)))
+	}
+	if parsed.DeviceCode == "" || parsed.UserCode == "" || parsed.VerificationURI == "" {
+		return userDeviceAuthorization{}, errors.New("device authorization failed: response missing device_code, user_code, or verification_uri; use --manual instead")
+	}
+	return parsed, nil
+}
+
+// pollUserDeviceToken polls the token endpoint until the user approves or
+// denies the device authorization, or the device code expires.
+func pollUserDeviceToken(ctx context.Context, httpClient *http.Client, baseURL, appID, appSecret string, auth userDeviceAuthorization) (userOAuthToken, error) {
+	endpoint, err := buildUserTokenURL(baseURL)
+	if err != nil {
+		return userOAuthToken{}, err
+	}
+	interval := userDeviceAuthPollInterval
+	if auth.Interval > 0 {
+		interval = time.Duration(auth.Interval) * time.Second
+	}
+	if auth.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(auth.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+	payload := map[string]string{
+		"grant_type":    userDeviceCodeGrantType,
+		"client_id":     appID,
+		"client_secret": appSecret,
+		"device_code":   auth.DeviceCode,
+	}
+	for {
+		if interval > 0 {
+			select {
+			case <-ctx.Done():
+				return userOAuthToken{}, errors.New("timed out waiting for device authorization")
+			case <-time.After(interval):
+			}
+		}
+		status, data, err := postOAuthJSON(ctx, httpClient, endpoint, payload)
+		if err != nil {
+			if ctx.Err() != nil {
+				return userOAuthToken{}, errors.New("timed out waiting for device authorization")
+			}
+			return userOAuthToken{}, err
+		}
+		var parsed userOAuthToken
+		if err := json.Unmarshal(data, &parsed); err != nil {
+			return userOAuthToken{}, fmt.Errorf("token exchange failed (HTTP %d): %s", status, strings.TrimSpace(string(data)))
+		}
+		switch parsed.Error {
+		case "":
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += userDeviceAuthSlowDownStep
+			continue
+		case "access_denied":
+			return userOAuthToken{}, errors.New("device authorization was denied")
+		case "expired_token":
+			return userOAuthToken{}, errors.New("device code expired; re-run `lark auth user login --device`")
+		default:
+			return userOAuthToken{}, fmt.Errorf("token exchange failed: %s", oauthErrorMessage(parsed))
+		}
+		if status < 200 || status > 299 {
+			return userOAuthToken{}, fmt.Errorf("token exchange failed: %s", strings.TrimSpace(string(data)))
+		}
+		if parsed.AccessToken == "" {
+			return userOAuthToken{}, errors.New("token exchange failed: missing access_token")
+		}
+		return parsed, nil
+	}
+}
+
+func postOAuthJSON(ctx context.Context, httpClient *http.Client, endpoint string, payload map[string]string) (int, []byte, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, data, nil
+}
+
+func userDeviceLoginPrompt(auth userDeviceAuthorization) string {
+	lines := []string{
+		fmt.Sprintf("Open %s on any device and enter code: %s", auth.VerificationURI, auth.UserCode),
+	}
+	if auth.VerificationURIComplete != "" {
+		lines = append(lines, fmt.Sprintf("Or open: %s", auth.VerificationURIComplete))
+	}
+	lines = append(lines, "Waiting for approval...")
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func userManualLoginPrompt(authorizeURL string) string {
+	return fmt.Sprintf("Open this URL in a browser on any machine:\n%s\n\n"+
+		"After approving, the browser is redirected to %s (the page may fail to load).\n"+
+		"Copy the full URL from the address bar and paste it here:\n", authorizeURL, userOAuthRedirectURL)
+}
+
+// readManualRedirect reads the pasted redirect URL and returns its
+// authorization code after validating the OAuth state.
+func readManualRedirect(r io.Reader, state string) (string, error) {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return parseManualRedirect(line, state)
+}
+
+func parseManualRedirect(raw, state string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", errors.New("no redirect URL provided")
+	}
+	rawQuery := raw
+	if strings.Contains(raw, "://") || strings.HasPrefix(raw, "/") {
+		parsed, err := url.Parse(raw)
+		if err != nil {
+			return "", fmt.Errorf("invalid redirect URL: %w", err)
+		}
+		rawQuery = parsed.RawQuery
+	} else {
+		rawQuery = strings.TrimPrefix(rawQuery, "?")
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", fmt.Errorf("invalid redirect URL: %w", err)
+	}
+	if query.Get("code") == "" && query.Get("error") == "" {
+		return "", errors.New("redirect URL missing code; paste the full URL from the browser address bar")
+	}
+	return parseOAuthCallbackQuery(query, state)
+}
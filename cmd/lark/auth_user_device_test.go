@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"lark/internal/testutil"
+)
+
+func TestUserDeviceCodeFlow(t *testing.T) {
+	prevInterval, prevStep := userDeviceAuthPollInterval, userDeviceAuthSlowDownStep
+	userDeviceAuthPollInterval, userDeviceAuthSlowDownStep = 0, 0
+	t.Cleanup(func() {
+		userDeviceAuthPollInterval, userDeviceAuthSlowDownStep = prevInterval, prevStep
+	})
+
+	polls := 0
+	httpClient, baseURL := testutil.NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("read body: %v", err)
+		}
+		var payload map[string]string
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Fatalf("unmarshal body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/open-apis/authen/v2/oauth/device_authorization":
+			if payload["client_id"] != "app-id" || payload["scope"] != "offline_access" {
+				t.Fatalf("unexpected device payload: %#v", payload)
+			}
+			_, _ = io.WriteString(w, `{"device_code":"dev-code","user_code":"ABCD-EFGH","verification_uri":"https://example.com/device","expires_in":600}`)
+		case "/open-apis/authen/v2/oauth/token":
+			if payload["grant_type"] != userDeviceCodeGrantType || payload["device_code"] != "dev-code" {
+				t.Fatalf("unexpected token payload: %#v", payload)
+			}
+			polls++
+			switch polls {
+			case 1:
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = io.WriteString(w, `{"error":"authorization_pending"}`)
+			case 2:
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = io.WriteString(w, `{"error":"slow_down"}`)
+			default:
+				_, _ = io.WriteString(w, `{"access_token":"user-token","refresh_token":"refresh-token","expires_in":3600,"scope":"offline_access"}`)
+			}
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+
+	ctx := context.Background()
+	auth, err := requestUserDeviceAuthorization(ctx, httpClient, baseURL, "app-id", "app-secret", "offline_access")
+	if err != nil {
+		t.Fatalf("device authorization: %v", err)
+	}
+	prompt := userDeviceLoginPrompt(auth)
+	if !strings.Contains(prompt, "https://example.com/device") || !strings.Contains(prompt, "ABCD-EFGH") {
+		t.Fatalf("unexpected prompt: %q", prompt)
+	}
+	token, err := pollUserDeviceToken(ctx, httpClient, baseURL, "app-id", "app-secret", auth)
+	if err != nil {
+		t.Fatalf("poll device token: %v", err)
+	}
+	if token.AccessToken != "user-token" || token.RefreshToken != "refresh-token" || polls != 3 {
+		t.Fatalf("unexpected token %#v after %d polls", token, polls)
+	}
+}
+
+func TestUserDeviceCodeFlowDenied(t *testing.T) {
+	prevInterval := userDeviceAuthPollInterval
+	userDeviceAuthPollInterval = 0
+	t.Cleanup(func() { userDeviceAuthPollInterval = prevInterval })
+
+	httpClient, baseURL := testutil.NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `{"error":"access_denied"}`)
+	}))
+	_, err := pollUserDeviceToken(context.Background(), httpClient, baseURL, "app-id", "app-secret", userDeviceAuthorization{DeviceCode: "dev-code"})
+	if err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Fatalf("expected denied error, got %v", err)
+	}
+}
+
+func TestUserDeviceAuthorizationUnsupported(t *testing.T) {
+	httpClient, baseURL := testutil.NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, "404 page not found")
+	}))
+	_, err := requestUserDeviceAuthorization(context.Background(), httpClient, baseURL, "app-id", "app-secret", "")
+	if err == nil || !strings.Contains(err.Error(), "use --manual") {
+		t.Fatalf("expected --manual hint, got %v", err)
+	}
+}
+
+func TestParseManualRedirect(t *testing.T) {
+	code, err := readManualRedirect(strings.NewReader(userOAuthRedirectURL+"?code=auth-code&state=s1\n"), "s1")
+	if err != nil || code != "auth-code" {
+		t.Fatalf("unexpected result: %q, %v", code, err)
+	}
+	if code, err := parseManualRedirect("?code=c2&state=s1", "s1"); err != nil || code != "c2" {
+		t.Fatalf("unexpected query-only result: %q, %v", code, err)
+	}
+	if _, err := parseManualRedirect(userOAuthRedirectURL+"?code=c&state=other", "s1"); err == nil || !strings.Contains(err.Error(), "state mismatch") {
+		t.Fatalf("expected state mismatch, got %v", err)
+	}
+	if _, err := parseManualRedirect(userOAuthRedirectURL+"?error=access_denied&state=s1", "s1"); err == nil || !strings.Contains(err.Error(), "access_denied") {
+		t.Fatalf("expected oauth error, got %v", err)
+	}
+	if _, err := parseManualRedirect("  ", "s1"); err == nil {
+		t.Fatalf("expected error for empty input")
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultRefreshTokenWarnDays = 3
+	refreshTokenWarnDaysEnv     = "LARK_REFRESH_TOKEN_WARN_DAYS"
+)
+
+type authUserRefreshResult struct {
+	Account               string `json:"account"`
+	Refreshed             bool   `json:"refreshed"`
+	AccessTokenExpiresAt  int64  `json:"access_token_expires_at,omitempty"`
+	RefreshTokenExpiresAt int64  `json:"refresh_token_expires_at,omitempty"`
+	RefreshTokenExpiring  bool   `json:"refresh_token_expiring,omitempty"`
+	Error                 string `json:"error,omitempty"`
+}
+
+func newAuthUserRefreshCmd(state *appState) *cobra.Command {
+	var allAccounts bool
+
+	cmd := &cobra.Command{
+		Use:   "refresh",
+		Short: "Refresh stored user access tokens now",
+		Long: `Refresh stored user access tokens now instead of on first use.
+
+Run it from cron to keep refresh tokens rotating on machines that are idle for
+long periods. Refreshes are serialized with other lark processes using the same
+config, so parallel jobs do not invalidate each other's refresh tokens.
+
+Commands warn on stderr when an account's refresh token expires within ` + strconv.Itoa(defaultRefreshTokenWarnDays) + ` days;
+set ` + refreshTokenWarnDaysEnv + ` to change the window (0 disables the warning).`,
+		Example: `  lark auth user refresh
+  lark auth user refresh --all-accounts --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireCredentials(state); err != nil {
+				return err
+			}
+			accounts := []string{resolveUserAccountName(state)}
+			if allAccounts {
+				accounts = refreshableUserAccounts(state)
+			}
+			now := time.Now()
+			results := make([]authUserRefreshResult, 0, len(accounts))
+			rows := make([][]string, 0, len(accounts))
+			failed := 0
+			for _, account := range accounts {
+				result := authUserRefreshResult{Account: account}
+				token, err := refreshUserToken(cmd.Context(), state, account, true)
+				if err != nil {
+					failed++
+					result.Error = err.Error()
+				} else {
+					result.Refreshed = true
+					result.AccessTokenExpiresAt = token.ExpiresAt
+					result.RefreshTokenExpiresAt = userRefreshTokenExpiresAt(state, account)
+					result.RefreshTokenExpiring = userRefreshTokenExpiring(result.RefreshTokenExpiresAt, now)
+				}
+				results = append(results, result)
+				rows = append(rows, authUserRefreshRow(result))
+			}
+			payload := map[string]any{
+				"config_path": state.ConfigPath,
+				"accounts":    results,
+			}
+			text := tableTextFromRows([]string{"account", "status", "expires_at", "refresh_expires_at"}, rows, "no accounts with refresh tokens found")
+			if err := state.Printer.Print(payload, text); err != nil {
+				return err
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d account(s) failed to refresh", failed, len(accounts))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&allAccounts, "all-accounts", false, "refresh every stored account that has a refresh token")
+	return cmd
+}
+
+func authUserRefreshRow(result authUserRefreshResult) []string {
+	status := "refreshed"
+	if result.Error != "" {
+		status = "error: " + result.Error
+	} else if result.RefreshTokenExpiring {
+		status = "refreshed (refresh token expiring soon)"
+	}
+	return []string{result.Account, status, formatUnixTime(result.AccessTokenExpiresAt), formatUnixTime(result.RefreshTokenExpiresAt)}
+}
+
+func formatUnixTime(value int64) string {
+	if value == 0 {
+		return "-"
+	}
+	return time.Unix(value, 0).UTC().Format(time.RFC3339)
+}
+
+func refreshableUserAccounts(state *appState) []string {
+	var accounts []string
+	for _, name := range listUserAccountNames(state.Config) {
+		stored, _, err := loadUserToken(state, name)
+		if err != nil {
+			continue
+		}
+		acct, _ := loadUserAccount(state.Config, name)
+		if stored.RefreshToken == "" && acct.RefreshTokenValue() == "" {
+			continue
+		}
+		accounts = append(accounts, name)
+	}
+	return accounts
+}
+
+func userRefreshTokenExpiresAt(state *appState, account string) int64 {
+	acct, ok := loadUserAccount(state.Config, account)
+	if !ok || acct.UserRefreshTokenPayload == nil {
+		return 0
+	}
+	return acct.UserRefreshTokenPayload.ExpiresAt
+}
+
+func userRefreshTokenWarnWindow() time.Duration {
+	days := defaultRefreshTokenWarnDays
+	if raw, ok := os.LookupEnv(refreshTokenWarnDaysEnv); ok {
+		if parsed, err := strconv.Atoi(strings.TrimSpace(raw)); err == nil && parsed >= 0 {
+			days = parsed
+		}
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+func userRefreshTokenExpiring(expiresAt int64, now time.Time) bool {
+	window := userRefreshTokenWarnWindow()
+	if expiresAt == 0 || window == 0 {
+		return false
+	}
+	return time.Unix(expiresAt, 0).Before(now.Add(window))
+}
+
+// warnUserRefreshTokenExpiry prints a one-time stderr warning when the
+// account's refresh token is close to expiring.
+func warnUserRefreshTokenExpiry(state *appState, account string, now time.Time) {
+	if state.refreshExpiryWarned {
+		return
+	}
+	expiresAt := userRefreshTokenExpiresAt(state, account)
+	if !userRefreshTokenExpiring(expiresAt, now) {
+		return
+	}
+	state.refreshExpiryWarned = true
+	remaining := "less than an hour"
+	if hours := int(time.Unix(expiresAt, 0).Sub(now).Hours()); hours >= 48 {
+		remaining = fmt.Sprintf("%d days", hours/24)
+	} else if hours >= 1 {
+		remaining = fmt.Sprintf("%d hours", hours)
+	}
+	fmt.Fprintf(errWriter(state), "warning: refresh token for account %q expires in %s; run `lark auth user refresh` regularly or `lark auth user login` to renew\n", account, remaining)
+}
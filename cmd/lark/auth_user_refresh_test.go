@@ -0,0 +1,266 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"lark/internal/config"
+)
+
+func TestEnsureUserTokenReusesTokenRotatedByOtherProcess(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected refresh: %s", r.URL.Path)
+	})
+	cfg := &config.Config{}
+	withUserAccount(cfg, defaultUserAccountName, "stale", "used-refresh", time.Now().Add(-time.Minute).Unix(), "")
+	var buf bytes.Buffer
+	state := newTestState(t, handler, cfg, &buf)
+
+	disk := &config.Config{AppID: "app", AppSecret: "secret"}
+	withUserAccount(disk, defaultUserAccountName, "fresh", "next-refresh", time.Now().Add(time.Hour).Unix(), "")
+	if err := config.Save(state.ConfigPath, disk); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	token, err := ensureUserToken(context.Background(), state)
+	if err != nil {
+		t.Fatalf("ensureUserToken error: %v", err)
+	}
+	if token != "fresh" {
+		t.Fatalf("expected token from disk, got %q", token)
+	}
+}
+
+func TestEnsureUserTokenSingleFlightRefresh(t *testing.T) {
+	var refreshes int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&refreshes, 1) > 1 || payload["refresh_token"] != "r1" {
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 20064, "error": "invalid_grant", "error_description": "refresh_token already used"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "access_token": "u2", "refresh_token": "r2", "expires_in": 7200})
+	})
+	cfg := &config.Config{}
+	withUserAccount(cfg, defaultUserAccountName, "u1", "r1", time.Now().Add(-time.Minute).Unix(), "")
+	var buf bytes.Buffer
+	state := newTestState(t, handler, cfg, &buf)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := ensureUserToken(context.Background(), state)
+			if err == nil && token != "u2" {
+				t.Errorf("unexpected token %q", token)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("ensureUserToken error: %v", err)
+		}
+	}
+	if refreshes != 1 {
+		t.Fatalf("expected a single refresh, got %d", refreshes)
+	}
+}
+
+func TestRefreshUserTokenKeepsRefreshTokenOnTransientError(t *testing.T) {
+	rejected := false
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if rejected {
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 20064, "error": "invalid_grant", "error_description": "refresh token revoked"})
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(w).Encode(map[string]any{"code": 20050, "error": "server_error", "error_description": "service unavailable"})
+	})
+	cfg := &config.Config{}
+	withUserAccount(cfg, defaultUserAccountName, "u1", "r1", time.Now().Add(-time.Minute).Unix(), "")
+	var buf bytes.Buffer
+	state := newTestState(t, handler, cfg, &buf)
+
+	_, err := refreshUserToken(context.Background(), state, defaultUserAccountName, true)
+	if err == nil || strings.Contains(err.Error(), "expired") {
+		t.Fatalf("expected the transient error as-is, got %v", err)
+	}
+	stored, ok, err := loadUserToken(state, defaultUserAccountName)
+	if err != nil || !ok || stored.RefreshToken != "r1" {
+		t.Fatalf("expected refresh token kept, got %#v ok=%v err=%v", stored, ok, err)
+	}
+
+	rejected = true
+	if _, err := refreshUserToken(context.Background(), state, defaultUserAccountName, true); err == nil || !strings.Contains(err.Error(), "revoked or expired") {
+		t.Fatalf("expected relogin error, got %v", err)
+	}
+	if stored, ok, _ := loadUserToken(state, defaultUserAccountName); ok && stored.RefreshToken != "" {
+		t.Fatalf("expected refresh token cleared, got %#v", stored)
+	}
+}
+
+func TestAcquireFileLock(t *testing.T) {
+	prevTimeout, prevRetry := userTokenLockTimeout, userTokenLockRetry
+	userTokenLockTimeout, userTokenLockRetry = 200*time.Millisecond, 5*time.Millisecond
+	t.Cleanup(func() { userTokenLockTimeout, userTokenLockRetry = prevTimeout, prevRetry })
+
+	path := userTokenLockPath(filepath.Join(t.TempDir(), "config.json"))
+	release, err := acquireFileLock(context.Background(), path)
+	if err != nil {
+		t.Fatalf("acquire lock: %v", err)
+	}
+	if _, err := acquireFileLock(context.Background(), path); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected lock timeout, got %v", err)
+	}
+	release()
+	release, err = acquireFileLock(context.Background(), path)
+	if err != nil {
+		t.Fatalf("reacquire lock: %v", err)
+	}
+	old := time.Now().Add(-2 * userTokenLockStaleAge)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+	if _, err := acquireFileLock(context.Background(), path); err != nil {
+		t.Fatalf("expected stale lock to be replaced, got %v", err)
+	}
+	release()
+}
+
+func TestReleaseFileLockKeepsLockOfOtherOwner(t *testing.T) {
+	path := userTokenLockPath(filepath.Join(t.TempDir(), "config.json"))
+	release, err := acquireFileLock(context.Background(), path)
+	if err != nil {
+		t.Fatalf("acquire lock: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("other-owner"), 0o600); err != nil {
+		t.Fatalf("overwrite lock: %v", err)
+	}
+	release()
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "other-owner" {
+		t.Fatalf("expected lock of other owner kept, got %q (%v)", data, err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Fatalf("expected only the lock file left, got %d entries", len(entries))
+	}
+}
+
+func TestAcquireFileLockStaleTakeoverIsExclusive(t *testing.T) {
+	path := userTokenLockPath(filepath.Join(t.TempDir(), "config.json"))
+	if err := os.WriteFile(path, []byte("crashed"), 0o600); err != nil {
+		t.Fatalf("write lock: %v", err)
+	}
+	old := time.Now().Add(-2 * userTokenLockStaleAge)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+
+	var holders, maxHolders int32
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := acquireFileLock(context.Background(), path)
+			if err != nil {
+				t.Errorf("acquire lock: %v", err)
+				return
+			}
+			n := atomic.AddInt32(&holders, 1)
+			for {
+				prev := atomic.LoadInt32(&maxHolders)
+				if n <= prev || atomic.CompareAndSwapInt32(&maxHolders, prev, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&holders, -1)
+			release()
+		}()
+	}
+	wg.Wait()
+	if maxHolders != 1 {
+		t.Fatalf("expected one holder at a time, got %d", maxHolders)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected lock released, got %v", err)
+	}
+}
+
+func TestAuthUserRefreshAllAccounts(t *testing.T) {
+	t.Setenv(refreshTokenWarnDaysEnv, "3")
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		w.Header().Set("Content-Type", "application/json")
+		switch payload["refresh_token"] {
+		case "r-alice":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "access_token": "a2", "refresh_token": "r-alice2", "expires_in": 7200, "refresh_token_expires_in": 86400})
+		case "r-bob":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "access_token": "b2", "refresh_token": "r-bob2", "expires_in": 7200, "refresh_token_expires_in": 30 * 86400})
+		default:
+			t.Fatalf("unexpected refresh token: %v", payload)
+		}
+	})
+	cfg := &config.Config{DefaultUserAccount: "alice"}
+	withUserAccount(cfg, "alice", "a1", "r-alice", time.Now().Add(time.Hour).Unix(), "")
+	withUserAccount(cfg, "bob", "b1", "r-bob", time.Now().Add(time.Hour).Unix(), "")
+	withUserAccount(cfg, "carol", "", "", 0, "")
+	for _, name := range []string{"alice", "bob"} {
+		cfg.UserAccounts[name].UserRefreshTokenPayload = &config.UserRefreshTokenPayload{CreatedAt: 1}
+	}
+	var buf bytes.Buffer
+	state := newTestState(t, handler, cfg, &buf)
+	state.JSON = true
+	state.Printer.JSON = true
+
+	cmd := newAuthUserCmd(state)
+	cmd.SetArgs([]string{"refresh", "--all-accounts"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("auth user refresh error: %v", err)
+	}
+	var payload struct {
+		Accounts []authUserRefreshResult `json:"accounts"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("decode output %q: %v", buf.String(), err)
+	}
+	if len(payload.Accounts) != 2 || payload.Accounts[0].Account != "alice" || payload.Accounts[1].Account != "bob" {
+		t.Fatalf("unexpected accounts: %#v", payload.Accounts)
+	}
+	if !payload.Accounts[0].RefreshTokenExpiring || payload.Accounts[1].RefreshTokenExpiring {
+		t.Fatalf("unexpected expiring flags: %#v", payload.Accounts)
+	}
+	if token, _, _ := loadUserToken(state, "bob"); token.AccessToken != "b2" || token.RefreshToken != "r-bob2" {
+		t.Fatalf("unexpected stored token: %#v", token)
+	}
+
+	buf.Reset()
+	state.JSON = false
+	state.Printer.JSON = false
+	if _, err := ensureUserToken(context.Background(), state); err != nil {
+		t.Fatalf("ensureUserToken error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `warning: refresh token for account "alice" expires in 23 hours`) {
+		t.Fatalf("expected expiry warning, got %q", buf.String())
+	}
+}
@@ -21,6 +21,7 @@ type authUserStatusPayload struct {
 	RefreshTokenScopes              string   `json:"refresh_token_scopes,omitempty"`
 	RefreshTokenCreatedAt           int64    `json:"refresh_token_created_at,omitempty"`
 	RefreshTokenCreatedAtRFC3339    string   `json:"refresh_token_created_at_rfc3339,omitempty"`
+	RefreshTokenExpiresAt           int64    `json:"refresh_token_expires_at,omitempty"`
 	Remediation                     string   `json:"remediation,omitempty"`
 }
 
@@ -65,6 +66,7 @@ func newAuthUserStatusCmd(state *appState) *cobra.Command {
 				if payload.RefreshTokenCreatedAt != 0 {
 					payload.RefreshTokenCreatedAtRFC3339 = time.Unix(payload.RefreshTokenCreatedAt, 0).UTC().Format(time.RFC3339)
 				}
+				payload.RefreshTokenExpiresAt = acct.UserRefreshTokenPayload.ExpiresAt
 			}
 			if !payload.RefreshTokenPresent {
 				payload.Remediation = userOAuthReloginCommand
@@ -92,6 +94,9 @@ func newAuthUserStatusCmd(state *appState) *cobra.Command {
 			if payload.RefreshTokenCreatedAtRFC3339 != "" {
 				text += fmt.Sprintf("\nrefresh_token_created_at_rfc3339: %s", payload.RefreshTokenCreatedAtRFC3339)
 			}
+			if payload.RefreshTokenExpiresAt != 0 {
+				text += fmt.Sprintf("\nrefresh_token_expires_at: %d", payload.RefreshTokenExpiresAt)
+			}
 			if payload.RefreshTokenScopes != "" {
 				text += fmt.Sprintf("\nrefresh_token_scopes: %s", payload.RefreshTokenScopes)
 			}
@@ -20,9 +20,16 @@ func newCalendarCmd(state *appState) *cobra.Command {
 		Short:   "Manage calendars (events)",
 		Long: `Calendars contain events scheduled for users.
 
-- calendar_id identifies a calendar (default: primary).
+- calendar_id identifies a calendar (default: primary); event commands also
+  accept --calendar <name> and resolve it to an id.
 - Events have event_id plus start/end times.
-- list/search operate on time ranges; create/update manage event details.
+- list/search operate on time ranges; --expand turns recurring events into
+  occurrences, and update/delete --instance target a single occurrence.
+- create/update manage event details.
+- freebusy/find-slot look up busy time and propose shared free slots.
+- calendars/acl manage shared calendars, subscriptions and access.
+- attendees manages event attendees; rsvp replies to an invitation.
+- export/import convert events to and from ICS (RFC 5545) files.
 
 Canonical command name: calendars (alias: calendar).`,
 	}
@@ -33,6 +40,14 @@ Canonical command name: calendars (alias: calendar).`,
 	cmd.AddCommand(newCalendarGetCmd(state))
 	cmd.AddCommand(newCalendarUpdateCmd(state))
 	cmd.AddCommand(newCalendarDeleteCmd(state))
+	cmd.AddCommand(newCalendarFreeBusyCmd(state))
+	cmd.AddCommand(newCalendarFindSlotCmd(state))
+	cmd.AddCommand(newCalendarExportCmd(state))
+	cmd.AddCommand(newCalendarImportCmd(state))
+	cmd.AddCommand(newCalendarCalendarsCmd(state))
+	cmd.AddCommand(newCalendarACLCmd(state))
+	cmd.AddCommand(newCalendarAttendeesCmd(state))
+	cmd.AddCommand(newCalendarRsvpCmd(state))
 	return cmd
 }
 
@@ -40,7 +55,9 @@ func newCalendarListCmd(state *appState) *cobra.Command {
 	var start string
 	var end string
 	var calendarID string
+	var calendarName string
 	var limit int
+	var expand bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -50,6 +67,9 @@ func newCalendarListCmd(state *appState) *cobra.Command {
 			if limit <= 0 {
 				return flagUsage(cmd, "limit must be greater than 0")
 			}
+			if expand && (start == "" || end == "") {
+				return flagUsage(cmd, "--expand requires --start and --end")
+			}
 			var startTime time.Time
 			var endTime time.Time
 			if start != "" || end != "" {
@@ -77,7 +97,7 @@ func newCalendarListCmd(state *appState) *cobra.Command {
 			if _, err := requireSDK(state); err != nil {
 				return err
 			}
-			resolvedCalendarID, err := resolveCalendarID(cmd.Context(), state, token, tokenType, calendarID)
+			resolvedCalendarID, err := resolveEventCalendarID(cmd.Context(), state, token, tokenType, calendarID, calendarName)
 			if err != nil {
 				return err
 			}
@@ -118,6 +138,15 @@ func newCalendarListCmd(state *appState) *cobra.Command {
 			if len(events) > limit {
 				events = events[:limit]
 			}
+			if expand {
+				events, err = expandCalendarEvents(cmd.Context(), state, token, tokenType, resolvedCalendarID, events, startTime, endTime)
+				if err != nil {
+					return err
+				}
+				if len(events) > limit {
+					events = events[:limit]
+				}
+			}
 			payload := map[string]any{
 				"calendar_id": resolvedCalendarID,
 				"events":      events,
@@ -134,7 +163,10 @@ func newCalendarListCmd(state *appState) *cobra.Command {
 	cmd.Flags().StringVar(&start, "start", "", "start time (RFC3339)")
 	cmd.Flags().StringVar(&end, "end", "", "end time (RFC3339)")
 	cmd.Flags().StringVar(&calendarID, "calendar-id", "", "calendar ID (default: primary)")
+	cmd.Flags().StringVar(&calendarName, "calendar", "", "calendar name or ID (alternative to --calendar-id)")
+	cmd.MarkFlagsMutuallyExclusive("calendar-id", "calendar")
 	cmd.Flags().IntVar(&limit, "limit", 50, "max number of events to return")
+	cmd.Flags().BoolVar(&expand, "expand", false, "expand recurring events into instances (requires --start/--end)")
 
 	return cmd
 }
@@ -143,6 +175,7 @@ func newCalendarCreateCmd(state *appState) *cobra.Command {
 	var start string
 	var end string
 	var calendarID string
+	var calendarName string
 	var summary string
 	var description string
 	var attendees []string
@@ -178,6 +211,8 @@ func newCalendarCreateCmd(state *appState) *cobra.Command {
 	var checkInEndType string
 	var checkInEndDuration int
 	var checkInNotifyAttendees bool
+	var rooms []string
+	var roomFilter meetingRoomFilter
 
 	cmd := &cobra.Command{
 		Use:   "create",
@@ -203,6 +238,19 @@ func newCalendarCreateCmd(state *appState) *cobra.Command {
 			if !endTime.After(startTime) {
 				return flagUsage(cmd, "end time must be after start time")
 			}
+			roomRefs := normalizeAttendeeValues(rooms)
+			autoRoom := false
+			for _, room := range roomRefs {
+				if strings.EqualFold(room, "auto") {
+					autoRoom = true
+				}
+			}
+			if !autoRoom && (roomFilter.MinCapacity != 0 || roomFilter.Building != "" || roomFilter.Floor != "" || len(roomFilter.Equipment) > 0) {
+				return flagUsage(cmd, "--capacity, --building, --floor, and --equipment require --room auto")
+			}
+			if roomFilter.MinCapacity < 0 {
+				return flagUsage(cmd, "capacity must be greater than or equal to 0")
+			}
 			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
 			if err != nil {
 				return err
@@ -210,10 +258,28 @@ func newCalendarCreateCmd(state *appState) *cobra.Command {
 			if _, err := requireSDK(state); err != nil {
 				return err
 			}
-			resolvedCalendarID, err := resolveCalendarID(cmd.Context(), state, token, tokenType, calendarID)
+			resolvedCalendarID, err := resolveEventCalendarID(cmd.Context(), state, token, tokenType, calendarID, calendarName)
 			if err != nil {
 				return err
 			}
+			var bookedRoom *meetingRoomInfo
+			roomIDs := make([]string, 0, len(roomRefs))
+			for _, room := range roomRefs {
+				if strings.EqualFold(room, "auto") {
+					if bookedRoom != nil {
+						continue
+					}
+					found, err := findAvailableMeetingRoom(cmd.Context(), state, startTime, endTime, roomFilter)
+					if err != nil {
+						return err
+					}
+					bookedRoom = &found
+					room = found.RoomID
+				}
+				if !containsString(roomIDs, room) {
+					roomIDs = append(roomIDs, room)
+				}
+			}
 			schemas, err := parseCalendarSchemas(schemaEntries)
 			if err != nil {
 				return err
@@ -252,18 +318,41 @@ func newCalendarCreateCmd(state *appState) *cobra.Command {
 			}
 			req.StartTime = startTime.Unix()
 			req.EndTime = endTime.Unix()
+			attendeeRecords := make([]larksdk.CalendarEventAttendee, 0, len(attendees))
+			for _, attendee := range attendees {
+				attendee = strings.TrimSpace(attendee)
+				if attendee == "" {
+					continue
+				}
+				openIDs, err := resolveUserRefs(cmd.Context(), state, []string{attendee}, "open_id")
+				if err != nil && !strings.HasPrefix(attendee, "@") {
+					denied := isLookupDenied(err)
+					if denied {
+						fmt.Fprintf(errWriter(state), "warning: cannot look up %s (%v); inviting by email\n", attendee, err)
+					}
+					if denied || errors.Is(err, errIdentityNotFound) {
+						// Emails outside the tenant, or that cannot be looked
+						// up, are invited as external guests.
+						attendeeRecords = append(attendeeRecords, larksdk.CalendarEventAttendee{
+							Type:            "third_party",
+							ThirdPartyEmail: attendee,
+						})
+						continue
+					}
+				}
+				if err != nil {
+					return err
+				}
+				attendeeRecords = append(attendeeRecords, larksdk.CalendarEventAttendee{Type: "user", UserID: openIDs[0]})
+			}
 			event, err := state.SDK.CreateCalendarEvent(cmd.Context(), token, larksdk.AccessTokenType(tokenType), req)
 			if err != nil {
 				return err
 			}
-			attendeeRecords := make([]larksdk.CalendarEventAttendee, 0, len(attendees))
-			for _, email := range attendees {
-				if email == "" {
-					continue
-				}
+			for _, roomID := range roomIDs {
 				attendeeRecords = append(attendeeRecords, larksdk.CalendarEventAttendee{
-					Type:            "third_party",
-					ThirdPartyEmail: email,
+					Type:   "resource",
+					RoomID: roomID,
 				})
 			}
 			if len(attendeeRecords) > 0 {
@@ -280,6 +369,12 @@ func newCalendarCreateCmd(state *appState) *cobra.Command {
 				"event":       event,
 				"attendees":   attendees,
 			}
+			if len(roomIDs) > 0 {
+				payload["rooms"] = roomIDs
+			}
+			if bookedRoom != nil {
+				payload["auto_room"] = bookedRoom
+			}
 			startText := formatEventTime(event.StartTime)
 			if startText == "" && !startTime.IsZero() {
 				startText = startTime.Format(time.RFC3339)
@@ -288,20 +383,27 @@ func newCalendarCreateCmd(state *appState) *cobra.Command {
 			if endText == "" && !endTime.IsZero() {
 				endText = endTime.Format(time.RFC3339)
 			}
-			text := tableTextRow(
-				[]string{"event_id", "start_time", "end_time", "summary", "status"},
-				[]string{event.EventID, startText, endText, event.Summary, event.Status},
-			)
+			headers := []string{"event_id", "start_time", "end_time", "summary", "status"}
+			values := []string{event.EventID, startText, endText, event.Summary, event.Status}
+			if len(roomIDs) > 0 {
+				headers = append(headers, "rooms")
+				values = append(values, strings.Join(roomIDs, ","))
+			}
+			text := tableTextRow(headers, values)
 			return state.Printer.Print(payload, text)
 		},
 	}
 
 	cmd.Flags().StringVar(&calendarID, "calendar-id", "", "calendar ID (default: primary)")
+	cmd.Flags().StringVar(&calendarName, "calendar", "", "calendar name or ID (alternative to --calendar-id)")
+	cmd.MarkFlagsMutuallyExclusive("calendar-id", "calendar")
 	cmd.Flags().StringVar(&start, "start", "", "start time (RFC3339)")
 	cmd.Flags().StringVar(&end, "end", "", "end time (RFC3339)")
 	cmd.Flags().StringVar(&summary, "summary", "", "event summary")
 	cmd.Flags().StringVar(&description, "description", "", "event description")
-	cmd.Flags().StringArrayVar(&attendees, "attendee", nil, "attendee email (repeatable)")
+	cmd.Flags().StringArrayVar(&attendees, "attendee", nil, "attendee email, open_id, or @name; unknown emails are invited as external guests (repeatable)")
+	cmd.Flags().StringArrayVar(&rooms, "room", nil, "meeting room id, or auto to pick a free room (repeatable)")
+	registerMeetingRoomFilterFlags(cmd, &roomFilter)
 	cmd.Flags().StringVar(&userIDType, "user-id-type", "", "user id type (open_id|union_id|user_id)")
 	cmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "idempotency key for event creation")
 	cmd.Flags().BoolVar(&needNotification, "need-notification", true, "notify attendees about event creation")
@@ -343,10 +445,12 @@ func newCalendarSearchCmd(state *appState) *cobra.Command {
 	var start string
 	var end string
 	var calendarID string
+	var calendarName string
 	var limit int
 	var userIDs []string
 	var roomIDs []string
 	var chatIDs []string
+	var expand bool
 
 	cmd := &cobra.Command{
 		Use:   "search <query>",
@@ -365,6 +469,9 @@ func newCalendarSearchCmd(state *appState) *cobra.Command {
 			if limit <= 0 {
 				return flagUsage(cmd, "limit must be greater than 0")
 			}
+			if expand && (start == "" || end == "") {
+				return flagUsage(cmd, "--expand requires --start and --end")
+			}
 			var startTime time.Time
 			var endTime time.Time
 			if start != "" || end != "" {
@@ -393,7 +500,7 @@ func newCalendarSearchCmd(state *appState) *cobra.Command {
 			if _, err := requireSDK(state); err != nil {
 				return err
 			}
-			resolvedCalendarID, err := resolveCalendarID(cmd.Context(), state, token, tokenType, calendarID)
+			resolvedCalendarID, err := resolveEventCalendarID(cmd.Context(), state, token, tokenType, calendarID, calendarName)
 			if err != nil {
 				return err
 			}
@@ -440,6 +547,15 @@ func newCalendarSearchCmd(state *appState) *cobra.Command {
 			if len(events) > limit {
 				events = events[:limit]
 			}
+			if expand {
+				events, err = expandCalendarEvents(cmd.Context(), state, token, tokenType, resolvedCalendarID, events, startTime, endTime)
+				if err != nil {
+					return err
+				}
+				if len(events) > limit {
+					events = events[:limit]
+				}
+			}
 			payload := map[string]any{
 				"calendar_id": resolvedCalendarID,
 				"events":      events,
@@ -457,7 +573,10 @@ func newCalendarSearchCmd(state *appState) *cobra.Command {
 	cmd.Flags().StringVar(&start, "start", "", "start time (RFC3339)")
 	cmd.Flags().StringVar(&end, "end", "", "end time (RFC3339)")
 	cmd.Flags().StringVar(&calendarID, "calendar-id", "", "calendar ID (default: primary)")
+	cmd.Flags().StringVar(&calendarName, "calendar", "", "calendar name or ID (alternative to --calendar-id)")
+	cmd.MarkFlagsMutuallyExclusive("calendar-id", "calendar")
 	cmd.Flags().IntVar(&limit, "limit", 20, "max number of events to return")
+	cmd.Flags().BoolVar(&expand, "expand", false, "expand recurring events into instances (requires --start/--end)")
 	cmd.Flags().StringArrayVar(&userIDs, "user-id", nil, "filter by attendee user id (repeatable)")
 	cmd.Flags().StringArrayVar(&roomIDs, "room-id", nil, "filter by room id (repeatable)")
 	cmd.Flags().StringArrayVar(&chatIDs, "chat-id", nil, "filter by chat id (repeatable)")
@@ -467,6 +586,7 @@ func newCalendarSearchCmd(state *appState) *cobra.Command {
 
 func newCalendarGetCmd(state *appState) *cobra.Command {
 	var calendarID string
+	var calendarName string
 	var eventID string
 	var needMeetingSettings bool
 	var needAttendee bool
@@ -494,7 +614,7 @@ func newCalendarGetCmd(state *appState) *cobra.Command {
 			if _, err := requireSDK(state); err != nil {
 				return err
 			}
-			resolvedCalendarID, err := resolveCalendarID(cmd.Context(), state, token, tokenType, calendarID)
+			resolvedCalendarID, err := resolveEventCalendarID(cmd.Context(), state, token, tokenType, calendarID, calendarName)
 			if err != nil {
 				return err
 			}
@@ -552,6 +672,8 @@ func newCalendarGetCmd(state *appState) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&calendarID, "calendar-id", "", "calendar ID (default: primary)")
+	cmd.Flags().StringVar(&calendarName, "calendar", "", "calendar name or ID (alternative to --calendar-id)")
+	cmd.MarkFlagsMutuallyExclusive("calendar-id", "calendar")
 	cmd.Flags().BoolVar(&needAttendee, "need-attendee", true, "include attendee info (requires permission)")
 	cmd.Flags().BoolVar(&needMeetingSettings, "need-meeting-settings", true, "include meeting settings for VC events")
 	cmd.Flags().IntVar(&maxAttendeeNum, "max-attendee-num", 100, "max number of attendees to return (only when --need-attendee)")
@@ -562,6 +684,7 @@ func newCalendarGetCmd(state *appState) *cobra.Command {
 
 func newCalendarUpdateCmd(state *appState) *cobra.Command {
 	var calendarID string
+	var calendarName string
 	var eventID string
 	var summary string
 	var description string
@@ -599,6 +722,7 @@ func newCalendarUpdateCmd(state *appState) *cobra.Command {
 	var checkInEndType string
 	var checkInEndDuration int
 	var checkInNotifyAttendees bool
+	var instance string
 
 	cmd := &cobra.Command{
 		Use:   "update <event-id>",
@@ -662,6 +786,13 @@ func newCalendarUpdateCmd(state *appState) *cobra.Command {
 				startTime = parsedStart
 				endTime = parsedEnd
 			}
+			if instance != "" {
+				originalTime, err := parseCalendarTimeArg(instance)
+				if err != nil {
+					return flagUsage(cmd, fmt.Sprintf("invalid instance time: %v", err))
+				}
+				eventID = calendarInstanceEventID(eventID, originalTime)
+			}
 
 			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
 			if err != nil {
@@ -670,7 +801,7 @@ func newCalendarUpdateCmd(state *appState) *cobra.Command {
 			if _, err := requireSDK(state); err != nil {
 				return err
 			}
-			resolvedCalendarID, err := resolveCalendarID(cmd.Context(), state, token, tokenType, calendarID)
+			resolvedCalendarID, err := resolveEventCalendarID(cmd.Context(), state, token, tokenType, calendarID, calendarName)
 			if err != nil {
 				return err
 			}
@@ -733,6 +864,8 @@ func newCalendarUpdateCmd(state *appState) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&calendarID, "calendar-id", "", "calendar ID (default: primary)")
+	cmd.Flags().StringVar(&calendarName, "calendar", "", "calendar name or ID (alternative to --calendar-id)")
+	cmd.MarkFlagsMutuallyExclusive("calendar-id", "calendar")
 	cmd.Flags().StringVar(&summary, "summary", "", "event summary")
 	cmd.Flags().StringVar(&description, "description", "", "event description")
 	cmd.Flags().StringVar(&start, "start", "", "start time (RFC3339)")
@@ -769,14 +902,17 @@ func newCalendarUpdateCmd(state *appState) *cobra.Command {
 	cmd.Flags().StringVar(&checkInEndType, "check-in-end-type", "", "check-in end time type (before_event_start|after_event_start|after_event_end)")
 	cmd.Flags().IntVar(&checkInEndDuration, "check-in-end-duration", 0, "check-in end offset minutes (0,5,15,30,60)")
 	cmd.Flags().BoolVar(&checkInNotifyAttendees, "check-in-notify-attendees", false, "notify attendees when check-in starts")
+	cmd.Flags().StringVar(&instance, "instance", "", "original start time of a single occurrence to update (recurring events)")
 
 	return cmd
 }
 
 func newCalendarDeleteCmd(state *appState) *cobra.Command {
 	var calendarID string
+	var calendarName string
 	var eventID string
 	var notify bool
+	var instance string
 
 	cmd := &cobra.Command{
 		Use:   "delete <event-id>",
@@ -792,6 +928,13 @@ func newCalendarDeleteCmd(state *appState) *cobra.Command {
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if instance != "" {
+				originalTime, err := parseCalendarTimeArg(instance)
+				if err != nil {
+					return flagUsage(cmd, fmt.Sprintf("invalid instance time: %v", err))
+				}
+				eventID = calendarInstanceEventID(eventID, originalTime)
+			}
 			if err := confirmDestructive(cmd, state, fmt.Sprintf("delete calendar event %s", eventID)); err != nil {
 				return err
 			}
@@ -802,7 +945,7 @@ func newCalendarDeleteCmd(state *appState) *cobra.Command {
 			if _, err := requireSDK(state); err != nil {
 				return err
 			}
-			resolvedCalendarID, err := resolveCalendarID(cmd.Context(), state, token, tokenType, calendarID)
+			resolvedCalendarID, err := resolveEventCalendarID(cmd.Context(), state, token, tokenType, calendarID, calendarName)
 			if err != nil {
 				return err
 			}
@@ -828,7 +971,10 @@ func newCalendarDeleteCmd(state *appState) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&calendarID, "calendar-id", "", "calendar ID (default: primary)")
+	cmd.Flags().StringVar(&calendarName, "calendar", "", "calendar name or ID (alternative to --calendar-id)")
+	cmd.MarkFlagsMutuallyExclusive("calendar-id", "calendar")
 	cmd.Flags().BoolVar(&notify, "notify", true, "notify attendees about deletion")
+	cmd.Flags().StringVar(&instance, "instance", "", "original start time of a single occurrence to delete (recurring events)")
 
 	return cmd
 }
@@ -1092,6 +1238,7 @@ func calendarEventDetailRows(event larksdk.CalendarEvent) [][]string {
 	add("schemas", formatSchemas(event.Schemas))
 	add("attendees.count", strconv.Itoa(len(event.Attendees)))
 	add("attendees", formatAttendeesSummary(event.Attendees))
+	add("attendees.rsvp", formatAttendeeRsvpCounts(event.Attendees))
 	add("has_more_attendee", formatBoolPtr(event.HasMoreAttendee))
 	add("attachments.count", strconv.Itoa(len(event.Attachments)))
 	add("attachments", formatAttachments(event.Attachments))
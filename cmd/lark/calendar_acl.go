@@ -0,0 +1,189 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+var calendarACLRoleValues = []string{"free_busy_reader", "reader", "writer", "owner"}
+
+func newCalendarACLCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "acl",
+		Short: "Manage who can access a calendar",
+		Long: `Manage calendar access control entries (ACLs).
+
+- <calendar> accepts a calendar_id, "primary", or a calendar name.
+- Roles: free_busy_reader, reader, writer, owner.`,
+	}
+	cmd.AddCommand(newCalendarACLAddCmd(state))
+	cmd.AddCommand(newCalendarACLListCmd(state))
+	cmd.AddCommand(newCalendarACLRemoveCmd(state))
+	return cmd
+}
+
+func newCalendarACLAddCmd(state *appState) *cobra.Command {
+	var ref string
+	var users []string
+	var role string
+
+	cmd := &cobra.Command{
+		Use:   "add <calendar>",
+		Short: "Grant users access to a calendar",
+		Args:  calendarRefArgs(&ref),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(users) == 0 {
+				return flagUsage(cmd, "at least one --user is required")
+			}
+			if err := validateOneOf(cmd, "role", role, calendarACLRoleValues); err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			calendarID, err := resolveCalendarRef(cmd.Context(), state, token, tokenType, ref)
+			if err != nil {
+				return err
+			}
+			userIDs, err := resolveEditorOpenIDs(cmd.Context(), state, users)
+			if err != nil {
+				return err
+			}
+			acls := make([]larksdk.CalendarACL, 0, len(userIDs))
+			for _, userID := range userIDs {
+				acl, err := state.SDK.CreateCalendarACL(cmd.Context(), token, larksdk.AccessTokenType(tokenType), calendarID, "open_id", larksdk.CalendarACL{
+					Role:  role,
+					Scope: larksdk.CalendarACLScope{Type: "user", UserID: userID},
+				})
+				if err != nil {
+					return err
+				}
+				if acl.Scope.UserID == "" {
+					acl.Scope.UserID = userID
+				}
+				acls = append(acls, acl)
+			}
+			payload := map[string]any{"calendar_id": calendarID, "acls": acls}
+			text := tableTextFromRows([]string{"acl_id", "role", "user_id"}, calendarACLRows(acls), "no acls created")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&users, "user", nil, "user email or open_id (repeatable)")
+	cmd.Flags().StringVar(&role, "role", "reader", "access role (free_busy_reader|reader|writer|owner)")
+	registerEnumCompletion(cmd, "role", calendarACLRoleValues)
+	return cmd
+}
+
+func newCalendarACLListCmd(state *appState) *cobra.Command {
+	var ref string
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "list <calendar>",
+		Short: "List calendar access entries",
+		Args:  calendarRefArgs(&ref),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if limit <= 0 {
+				return flagUsage(cmd, "limit must be greater than 0")
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			calendarID, err := resolveCalendarRef(cmd.Context(), state, token, tokenType, ref)
+			if err != nil {
+				return err
+			}
+			acls := make([]larksdk.CalendarACL, 0)
+			pageToken := ""
+			for {
+				result, err := state.SDK.ListCalendarACLs(cmd.Context(), token, larksdk.AccessTokenType(tokenType), calendarID, "open_id", 100, pageToken)
+				if err != nil {
+					return err
+				}
+				acls = append(acls, result.Items...)
+				if len(acls) >= limit || !result.HasMore || result.PageToken == "" {
+					break
+				}
+				pageToken = result.PageToken
+			}
+			if len(acls) > limit {
+				acls = acls[:limit]
+			}
+			payload := map[string]any{"calendar_id": calendarID, "acls": acls}
+			text := tableTextFromRows([]string{"acl_id", "role", "user_id"}, calendarACLRows(acls), "no acls found")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 200, "max number of entries to return")
+	return cmd
+}
+
+func newCalendarACLRemoveCmd(state *appState) *cobra.Command {
+	var ref string
+	var aclID string
+
+	cmd := &cobra.Command{
+		Use:     "remove <calendar> <acl-id>",
+		Aliases: []string{"delete"},
+		Short:   "Remove a calendar access entry",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(2)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			ref = strings.TrimSpace(args[0])
+			if ref == "" {
+				return argsUsageError(cmd, errors.New("calendar is required"))
+			}
+			aclID = strings.TrimSpace(args[1])
+			if aclID == "" {
+				return argsUsageError(cmd, errors.New("acl-id is required"))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := confirmDestructive(cmd, state, fmt.Sprintf("remove acl %s from calendar %s", aclID, ref)); err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			calendarID, err := resolveCalendarRef(cmd.Context(), state, token, tokenType, ref)
+			if err != nil {
+				return err
+			}
+			if err := state.SDK.DeleteCalendarACL(cmd.Context(), token, larksdk.AccessTokenType(tokenType), calendarID, aclID); err != nil {
+				return err
+			}
+			payload := map[string]any{"calendar_id": calendarID, "acl_id": aclID, "deleted": true}
+			return state.Printer.Print(payload, tableTextRow([]string{"acl_id", "deleted"}, []string{aclID, "true"}))
+		},
+	}
+	return cmd
+}
+
+func calendarACLRows(acls []larksdk.CalendarACL) [][]string {
+	rows := make([][]string, 0, len(acls))
+	for _, acl := range acls {
+		rows = append(rows, []string{acl.ACLID, acl.Role, infoValue(acl.Scope.UserID)})
+	}
+	return rows
+}
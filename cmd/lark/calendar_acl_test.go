@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCalendarACLAddResolvesEmails(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/open-apis/contact/v3/users/batch_get_id":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"user_list": []map[string]any{{"user_id": "ou_sre", "email": "sre@example.com"}},
+				},
+			})
+		case "/open-apis/calendar/v4/calendars/oncall@group.calendar.feishu.cn/acls":
+			if r.Method != http.MethodPost {
+				t.Fatalf("unexpected method: %s", r.Method)
+			}
+			if got := r.URL.Query().Get("user_id_type"); got != "open_id" {
+				t.Fatalf("unexpected user_id_type: %q", got)
+			}
+			var payload struct {
+				Role  string `json:"role"`
+				Scope struct {
+					Type   string `json:"type"`
+					UserID string `json:"user_id"`
+				} `json:"scope"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			if payload.Role != "writer" || payload.Scope.Type != "user" || payload.Scope.UserID != "ou_sre" {
+				t.Fatalf("unexpected payload: %+v", payload)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{"acl_id": "acl_1", "role": "writer", "scope": map[string]any{"type": "user", "user_id": "ou_sre"}},
+			})
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+
+	cmd := newCalendarCmd(state)
+	cmd.SetArgs([]string{"acl", "add", "oncall@group.calendar.feishu.cn", "--user", "sre@example.com", "--role", "writer"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("acl add error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "acl_1") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestCalendarACLAddRejectsUnknownRole(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request: %s", r.URL.Path)
+	})
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+
+	cmd := newCalendarCmd(state)
+	cmd.SetArgs([]string{"acl", "add", "cal@x", "--user", "ou_1", "--role", "admin"})
+	if err := cmd.Execute(); err == nil {
+		t.Fatalf("expected role validation error")
+	}
+}
@@ -0,0 +1,399 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+var calendarRsvpValues = []string{"accept", "decline", "tentative"}
+
+func newCalendarAttendeesCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attendees",
+		Short: "Manage calendar event attendees",
+		Long: `Manage the attendees of a calendar event.
+
+- Users accept an email or open_id; chats take a chat_id; rooms take a room_id.
+- --email adds external (third-party) attendees by email address.
+- remove also accepts --attendee-id values from attendees list.`,
+	}
+	cmd.AddCommand(newCalendarAttendeesListCmd(state))
+	cmd.AddCommand(newCalendarAttendeesAddCmd(state))
+	cmd.AddCommand(newCalendarAttendeesRemoveCmd(state))
+	return cmd
+}
+
+func newCalendarAttendeesListCmd(state *appState) *cobra.Command {
+	var calendarID string
+	var calendarName string
+	var eventID string
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "list <event-id>",
+		Short: "List event attendees with RSVP status",
+		Args:  calendarEventIDArgs(&eventID),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if limit <= 0 {
+				return flagUsage(cmd, "limit must be greater than 0")
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			resolvedCalendarID, err := resolveEventCalendarID(cmd.Context(), state, token, tokenType, calendarID, calendarName)
+			if err != nil {
+				return err
+			}
+			attendees := make([]larksdk.CalendarEventAttendee, 0)
+			pageToken := ""
+			for {
+				result, err := state.SDK.ListCalendarEventAttendees(cmd.Context(), token, larksdk.AccessTokenType(tokenType), larksdk.ListCalendarEventAttendeesRequest{
+					CalendarID: resolvedCalendarID,
+					EventID:    eventID,
+					UserIDType: "open_id",
+					PageSize:   100,
+					PageToken:  pageToken,
+				})
+				if err != nil {
+					return err
+				}
+				attendees = append(attendees, result.Items...)
+				if len(attendees) >= limit || !result.HasMore || result.PageToken == "" {
+					break
+				}
+				pageToken = result.PageToken
+			}
+			if len(attendees) > limit {
+				attendees = attendees[:limit]
+			}
+			payload := map[string]any{"calendar_id": resolvedCalendarID, "event_id": eventID, "attendees": attendees}
+			text := tableTextFromRows([]string{"attendee_id", "type", "id", "name", "rsvp"}, calendarAttendeeRows(attendees), "no attendees found")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&calendarID, "calendar-id", "", "calendar ID (default: primary)")
+	cmd.Flags().StringVar(&calendarName, "calendar", "", "calendar name or ID (alternative to --calendar-id)")
+	cmd.MarkFlagsMutuallyExclusive("calendar-id", "calendar")
+	cmd.Flags().IntVar(&limit, "limit", 500, "max number of attendees to return")
+	return cmd
+}
+
+func newCalendarAttendeesAddCmd(state *appState) *cobra.Command {
+	var calendarID string
+	var calendarName string
+	var eventID string
+	var users []string
+	var chats []string
+	var rooms []string
+	var emails []string
+	var optional bool
+	var notify bool
+
+	cmd := &cobra.Command{
+		Use:   "add <event-id>",
+		Short: "Add attendees to an event",
+		Args:  calendarEventIDArgs(&eventID),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(users)+len(chats)+len(rooms)+len(emails) == 0 {
+				return flagUsage(cmd, "at least one of --user, --chat, --room, or --email is required")
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			resolvedCalendarID, err := resolveEventCalendarID(cmd.Context(), state, token, tokenType, calendarID, calendarName)
+			if err != nil {
+				return err
+			}
+			userIDs, err := resolveEditorOpenIDs(cmd.Context(), state, users)
+			if err != nil {
+				return err
+			}
+			var optionalPtr *bool
+			if optional {
+				optionalPtr = &optional
+			}
+			attendees := make([]larksdk.CalendarEventAttendee, 0, len(userIDs)+len(chats)+len(rooms)+len(emails))
+			for _, userID := range userIDs {
+				attendees = append(attendees, larksdk.CalendarEventAttendee{Type: "user", UserID: userID, IsOptional: optionalPtr})
+			}
+			for _, chatID := range normalizeAttendeeValues(chats) {
+				attendees = append(attendees, larksdk.CalendarEventAttendee{Type: "chat", ChatID: chatID, IsOptional: optionalPtr})
+			}
+			for _, roomID := range normalizeAttendeeValues(rooms) {
+				attendees = append(attendees, larksdk.CalendarEventAttendee{Type: "resource", RoomID: roomID})
+			}
+			for _, email := range normalizeAttendeeValues(emails) {
+				attendees = append(attendees, larksdk.CalendarEventAttendee{Type: "third_party", ThirdPartyEmail: email, IsOptional: optionalPtr})
+			}
+			if err := state.SDK.CreateCalendarEventAttendees(cmd.Context(), token, larksdk.AccessTokenType(tokenType), larksdk.CreateCalendarEventAttendeesRequest{
+				CalendarID:       resolvedCalendarID,
+				EventID:          eventID,
+				UserIDType:       "open_id",
+				Attendees:        attendees,
+				NeedNotification: flagBoolPtr(cmd, "notify", notify),
+			}); err != nil {
+				return err
+			}
+			payload := map[string]any{"calendar_id": resolvedCalendarID, "event_id": eventID, "attendees": attendees}
+			text := tableTextFromRows([]string{"attendee_id", "type", "id", "name", "rsvp"}, calendarAttendeeRows(attendees), "no attendees added")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&calendarID, "calendar-id", "", "calendar ID (default: primary)")
+	cmd.Flags().StringVar(&calendarName, "calendar", "", "calendar name or ID (alternative to --calendar-id)")
+	cmd.MarkFlagsMutuallyExclusive("calendar-id", "calendar")
+	cmd.Flags().StringArrayVar(&users, "user", nil, "user email or open_id (repeatable)")
+	cmd.Flags().StringArrayVar(&chats, "chat", nil, "chat ID (repeatable)")
+	cmd.Flags().StringArrayVar(&rooms, "room", nil, "meeting room ID (repeatable)")
+	cmd.Flags().StringArrayVar(&emails, "email", nil, "external attendee email (repeatable)")
+	cmd.Flags().BoolVar(&optional, "optional", false, "mark added attendees as optional")
+	cmd.Flags().BoolVar(&notify, "notify", true, "notify added attendees")
+	return cmd
+}
+
+func newCalendarAttendeesRemoveCmd(state *appState) *cobra.Command {
+	var calendarID string
+	var calendarName string
+	var eventID string
+	var attendeeIDs []string
+	var users []string
+	var chats []string
+	var rooms []string
+	var emails []string
+	var notify bool
+
+	cmd := &cobra.Command{
+		Use:     "remove <event-id>",
+		Aliases: []string{"delete"},
+		Short:   "Remove attendees from an event",
+		Args:    calendarEventIDArgs(&eventID),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(attendeeIDs)+len(users)+len(chats)+len(rooms)+len(emails) == 0 {
+				return flagUsage(cmd, "at least one of --attendee-id, --user, --chat, --room, or --email is required")
+			}
+			if err := confirmDestructive(cmd, state, fmt.Sprintf("remove attendees from event %s", eventID)); err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			resolvedCalendarID, err := resolveEventCalendarID(cmd.Context(), state, token, tokenType, calendarID, calendarName)
+			if err != nil {
+				return err
+			}
+			userIDs, err := resolveEditorOpenIDs(cmd.Context(), state, users)
+			if err != nil {
+				return err
+			}
+			deleteIDs := make([]larksdk.CalendarEventAttendeeDeleteID, 0, len(userIDs)+len(chats)+len(rooms)+len(emails))
+			for _, userID := range userIDs {
+				deleteIDs = append(deleteIDs, larksdk.CalendarEventAttendeeDeleteID{Type: "user", UserID: userID})
+			}
+			for _, chatID := range normalizeAttendeeValues(chats) {
+				deleteIDs = append(deleteIDs, larksdk.CalendarEventAttendeeDeleteID{Type: "chat", ChatID: chatID})
+			}
+			for _, roomID := range normalizeAttendeeValues(rooms) {
+				deleteIDs = append(deleteIDs, larksdk.CalendarEventAttendeeDeleteID{Type: "resource", RoomID: roomID})
+			}
+			for _, email := range normalizeAttendeeValues(emails) {
+				deleteIDs = append(deleteIDs, larksdk.CalendarEventAttendeeDeleteID{Type: "third_party", ThirdPartyEmail: email})
+			}
+			ids := normalizeAttendeeValues(attendeeIDs)
+			if err := state.SDK.DeleteCalendarEventAttendees(cmd.Context(), token, larksdk.AccessTokenType(tokenType), larksdk.DeleteCalendarEventAttendeesRequest{
+				CalendarID:       resolvedCalendarID,
+				EventID:          eventID,
+				UserIDType:       "open_id",
+				AttendeeIDs:      ids,
+				DeleteIDs:        deleteIDs,
+				NeedNotification: flagBoolPtr(cmd, "notify", notify),
+			}); err != nil {
+				return err
+			}
+			payload := map[string]any{
+				"calendar_id":  resolvedCalendarID,
+				"event_id":     eventID,
+				"attendee_ids": ids,
+				"delete_ids":   deleteIDs,
+				"deleted":      true,
+			}
+			removed := len(ids) + len(deleteIDs)
+			return state.Printer.Print(payload, tableTextRow([]string{"event_id", "removed"}, []string{eventID, fmt.Sprintf("%d", removed)}))
+		},
+	}
+
+	cmd.Flags().StringVar(&calendarID, "calendar-id", "", "calendar ID (default: primary)")
+	cmd.Flags().StringVar(&calendarName, "calendar", "", "calendar name or ID (alternative to --calendar-id)")
+	cmd.MarkFlagsMutuallyExclusive("calendar-id", "calendar")
+	cmd.Flags().StringArrayVar(&attendeeIDs, "attendee-id", nil, "attendee ID from attendees list (repeatable)")
+	cmd.Flags().StringArrayVar(&users, "user", nil, "user email or open_id (repeatable)")
+	cmd.Flags().StringArrayVar(&chats, "chat", nil, "chat ID (repeatable)")
+	cmd.Flags().StringArrayVar(&rooms, "room", nil, "meeting room ID (repeatable)")
+	cmd.Flags().StringArrayVar(&emails, "email", nil, "external attendee email (repeatable)")
+	cmd.Flags().BoolVar(&notify, "notify", true, "notify removed attendees")
+	return cmd
+}
+
+func newCalendarRsvpCmd(state *appState) *cobra.Command {
+	var calendarID string
+	var calendarName string
+	var eventID string
+	var status string
+
+	cmd := &cobra.Command{
+		Use:   "rsvp <event-id> <accept|decline|tentative>",
+		Short: "Reply to an event invitation",
+		Long: `Reply to an event invitation as the signed-in user.
+
+Requires a user access token (lark auth user login).`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(2)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			eventID = strings.TrimSpace(args[0])
+			if eventID == "" {
+				return argsUsageError(cmd, errors.New("event-id is required"))
+			}
+			status = strings.ToLower(strings.TrimSpace(args[1]))
+			if status == "" {
+				return argsUsageError(cmd, errors.New("rsvp status is required"))
+			}
+			for _, value := range calendarRsvpValues {
+				if status == value {
+					return nil
+				}
+			}
+			return argsUsageError(cmd, fmt.Errorf("rsvp status must be one of %s", strings.Join(calendarRsvpValues, "|")))
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 1 {
+				return calendarRsvpValues, cobra.ShellCompDirectiveNoFileComp
+			}
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			resolvedCalendarID, err := resolveEventCalendarID(cmd.Context(), state, token, tokenType, calendarID, calendarName)
+			if err != nil {
+				return err
+			}
+			if err := state.SDK.ReplyCalendarEvent(cmd.Context(), token, resolvedCalendarID, eventID, status); err != nil {
+				return err
+			}
+			payload := map[string]any{"calendar_id": resolvedCalendarID, "event_id": eventID, "rsvp_status": status}
+			return state.Printer.Print(payload, tableTextRow([]string{"event_id", "rsvp_status"}, []string{eventID, status}))
+		},
+	}
+
+	cmd.Flags().StringVar(&calendarID, "calendar-id", "", "calendar ID (default: primary)")
+	cmd.Flags().StringVar(&calendarName, "calendar", "", "calendar name or ID (alternative to --calendar-id)")
+	cmd.MarkFlagsMutuallyExclusive("calendar-id", "calendar")
+	return cmd
+}
+
+func calendarEventIDArgs(eventID *string) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return argsUsageError(cmd, err)
+		}
+		*eventID = strings.TrimSpace(args[0])
+		if *eventID == "" {
+			return argsUsageError(cmd, errors.New("event-id is required"))
+		}
+		return nil
+	}
+}
+
+func calendarAttendeeRows(attendees []larksdk.CalendarEventAttendee) [][]string {
+	rows := make([][]string, 0, len(attendees))
+	for _, attendee := range attendees {
+		rows = append(rows, []string{
+			infoValue(attendee.AttendeeID),
+			infoValue(attendee.Type),
+			infoValue(calendarAttendeeRef(attendee)),
+			infoValue(attendee.DisplayName),
+			infoValue(attendee.RsvpStatus),
+		})
+	}
+	return rows
+}
+
+func calendarAttendeeRef(attendee larksdk.CalendarEventAttendee) string {
+	switch {
+	case attendee.UserID != "":
+		return attendee.UserID
+	case attendee.ChatID != "":
+		return attendee.ChatID
+	case attendee.RoomID != "":
+		return attendee.RoomID
+	default:
+		return attendee.ThirdPartyEmail
+	}
+}
+
+// formatAttendeeRsvpCounts tallies attendee RSVP statuses in a stable order.
+func formatAttendeeRsvpCounts(attendees []larksdk.CalendarEventAttendee) string {
+	if len(attendees) == 0 {
+		return ""
+	}
+	order := []string{"accept", "tentative", "decline", "needs_action"}
+	counts := map[string]int{}
+	for _, attendee := range attendees {
+		status := attendee.RsvpStatus
+		if status == "" {
+			status = "needs_action"
+		}
+		if _, ok := counts[status]; !ok && !containsString(order, status) {
+			order = append(order, status)
+		}
+		counts[status]++
+	}
+	parts := make([]string, 0, len(order))
+	for _, status := range order {
+		if counts[status] > 0 {
+			parts = append(parts, fmt.Sprintf("%s=%d", status, counts[status]))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func normalizeAttendeeValues(values []string) []string {
+	out := make([]string, 0, len(values))
+	seen := map[string]struct{}{}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		out = append(out, value)
+	}
+	return out
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"lark/internal/config"
+	"lark/internal/larksdk"
+	"lark/internal/output"
+	"lark/internal/testutil"
+)
+
+func TestCalendarAttendeesListCommand(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/open-apis/calendar/v4/calendars/cal_1/events/evt_1/attendees" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodGet {
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+		if got := r.URL.Query().Get("user_id_type"); got != "open_id" {
+			t.Fatalf("unexpected user_id_type: %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"code": 0,
+			"msg":  "ok",
+			"data": map[string]any{
+				"items": []map[string]any{
+					{"attendee_id": "user_1", "type": "user", "user_id": "ou_1", "display_name": "Ada", "rsvp_status": "accept"},
+					{"attendee_id": "room_1", "type": "resource", "room_id": "omm_1", "display_name": "Room 1", "rsvp_status": "accept"},
+					{"attendee_id": "third_1", "type": "third_party", "third_party_email": "guest@example.com", "rsvp_status": "needs_action"},
+				},
+				"has_more": false,
+			},
+		})
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newCalendarCmd(state)
+	cmd.SetArgs([]string{"attendees", "list", "evt_1", "--calendar-id", "cal_1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("attendees list error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"ou_1", "omm_1", "guest@example.com", "needs_action"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output: %q", want, out)
+		}
+	}
+}
+
+func TestCalendarAttendeesAddCommand(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/open-apis/contact/v3/users/batch_get_id":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"user_list": []map[string]any{{"user_id": "ou_ada", "email": "ada@example.com"}},
+				},
+			})
+		case "/open-apis/calendar/v4/calendars/cal_1/events/evt_1/attendees":
+			if r.Method != http.MethodPost {
+				t.Fatalf("unexpected method: %s", r.Method)
+			}
+			if got := r.URL.Query().Get("user_id_type"); got != "open_id" {
+				t.Fatalf("unexpected user_id_type: %q", got)
+			}
+			var payload struct {
+				Attendees        []larksdk.CalendarEventAttendee `json:"attendees"`
+				NeedNotification *bool                           `json:"need_notification"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			if len(payload.Attendees) != 4 {
+				t.Fatalf("unexpected attendees: %+v", payload.Attendees)
+			}
+			if payload.Attendees[0].Type != "user" || payload.Attendees[0].UserID != "ou_ada" {
+				t.Fatalf("unexpected user attendee: %+v", payload.Attendees[0])
+			}
+			if payload.Attendees[1].Type != "chat" || payload.Attendees[1].ChatID != "oc_1" {
+				t.Fatalf("unexpected chat attendee: %+v", payload.Attendees[1])
+			}
+			if payload.Attendees[2].Type != "resource" || payload.Attendees[2].RoomID != "omm_1" {
+				t.Fatalf("unexpected room attendee: %+v", payload.Attendees[2])
+			}
+			if payload.Attendees[3].Type != "third_party" || payload.Attendees[3].ThirdPartyEmail != "guest@example.com" {
+				t.Fatalf("unexpected email attendee: %+v", payload.Attendees[3])
+			}
+			if payload.NeedNotification == nil || *payload.NeedNotification {
+				t.Fatalf("expected need_notification=false, got %v", payload.NeedNotification)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{}})
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newCalendarCmd(state)
+	cmd.SetArgs([]string{
+		"attendees", "add", "evt_1",
+		"--calendar-id", "cal_1",
+		"--user", "ada@example.com",
+		"--chat", "oc_1",
+		"--room", "omm_1",
+		"--email", "guest@example.com",
+		"--notify=false",
+	})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("attendees add error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "ou_ada") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestCalendarAttendeesRemoveCommand(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/open-apis/calendar/v4/calendars/cal_1/events/evt_1/attendees/batch_delete" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		var payload struct {
+			AttendeeIDs []string                                `json:"attendee_ids"`
+			DeleteIDs   []larksdk.CalendarEventAttendeeDeleteID `json:"delete_ids"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		if len(payload.AttendeeIDs) != 1 || payload.AttendeeIDs[0] != "user_1" {
+			t.Fatalf("unexpected attendee_ids: %v", payload.AttendeeIDs)
+		}
+		if len(payload.DeleteIDs) != 1 || payload.DeleteIDs[0].Type != "third_party" || payload.DeleteIDs[0].ThirdPartyEmail != "guest@example.com" {
+			t.Fatalf("unexpected delete_ids: %+v", payload.DeleteIDs)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{}})
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	state.Force = true
+	cmd := newCalendarCmd(state)
+	cmd.SetArgs([]string{"attendees", "remove", "evt_1", "--calendar-id", "cal_1", "--attendee-id", "user_1", "--email", "guest@example.com"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("attendees remove error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "2") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestCalendarRsvpCommandUsesUserToken(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer user-token" {
+			t.Fatalf("unexpected authorization: %s", r.Header.Get("Authorization"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/open-apis/calendar/v4/calendars/primary":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"calendars": []map[string]any{{"calendar": map[string]any{"calendar_id": "cal_1"}}},
+				},
+			})
+		case "/open-apis/calendar/v4/calendars/cal_1/events/evt_1/reply":
+			if r.Method != http.MethodPost {
+				t.Fatalf("unexpected method: %s", r.Method)
+			}
+			var payload map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			if payload["rsvp_status"] != "tentative" {
+				t.Fatalf("unexpected payload: %+v", payload)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{}})
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+	httpClient, baseURL := testutil.NewTestClient(handler)
+
+	var buf bytes.Buffer
+	state := &appState{
+		TokenType: "user",
+		Config: &config.Config{
+			AppID:     "app",
+			AppSecret: "secret",
+			BaseURL:   baseURL,
+		},
+		Printer: output.Printer{Writer: &buf},
+	}
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "", time.Now().Add(2*time.Hour).Unix(), "")
+	sdkClient, err := larksdk.New(state.Config, larksdk.WithHTTPClient(httpClient))
+	if err != nil {
+		t.Fatalf("sdk client error: %v", err)
+	}
+	state.SDK = sdkClient
+
+	cmd := newCalendarCmd(state)
+	cmd.SetArgs([]string{"rsvp", "evt_1", "Tentative"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("rsvp error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "tentative") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestCalendarRsvpRejectsUnknownStatus(t *testing.T) {
+	var buf bytes.Buffer
+	state := newTestState(t, http.NotFoundHandler(), nil, &buf)
+	cmd := newCalendarCmd(state)
+	cmd.SetArgs([]string{"rsvp", "evt_1", "maybe"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	if err := cmd.Execute(); err == nil {
+		t.Fatalf("expected error for unknown rsvp status")
+	}
+}
+
+func TestFormatAttendeeRsvpCounts(t *testing.T) {
+	got := formatAttendeeRsvpCounts([]larksdk.CalendarEventAttendee{
+		{RsvpStatus: "accept"},
+		{RsvpStatus: "decline"},
+		{RsvpStatus: "accept"},
+		{},
+	})
+	if got != "accept=2 decline=1 needs_action=1" {
+		t.Fatalf("unexpected counts: %q", got)
+	}
+}
@@ -0,0 +1,436 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+var calendarPermissionValues = []string{"private", "show_only_free_busy", "public"}
+
+func newCalendarCalendarsCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "calendars",
+		Short: "Manage calendars (list, shared calendars, subscriptions)",
+		Long: `Manage the calendars themselves rather than their events.
+
+- <calendar> arguments accept a calendar_id, "primary", or a calendar name.
+- Names match summary or summary_alias of your calendars (case-insensitive),
+  then fall back to searching shared calendars in the tenant.`,
+	}
+	cmd.AddCommand(newCalendarCalendarsListCmd(state))
+	cmd.AddCommand(newCalendarCalendarsGetCmd(state))
+	cmd.AddCommand(newCalendarCalendarsCreateCmd(state))
+	cmd.AddCommand(newCalendarCalendarsUpdateCmd(state))
+	cmd.AddCommand(newCalendarCalendarsDeleteCmd(state))
+	cmd.AddCommand(newCalendarCalendarsSubscribeCmd(state))
+	cmd.AddCommand(newCalendarCalendarsUnsubscribeCmd(state))
+	return cmd
+}
+
+func newCalendarCalendarsListCmd(state *appState) *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List your calendars (owned and subscribed)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if limit <= 0 {
+				return flagUsage(cmd, "limit must be greater than 0")
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			calendars, err := listAllCalendars(cmd.Context(), state, token, tokenType, limit)
+			if err != nil {
+				return err
+			}
+			payload := map[string]any{"calendars": calendars}
+			text := tableTextFromRows([]string{"calendar_id", "summary", "type", "role", "permissions"}, calendarRows(calendars), "no calendars found")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 500, "max number of calendars to return")
+	return cmd
+}
+
+func newCalendarCalendarsGetCmd(state *appState) *cobra.Command {
+	var ref string
+
+	cmd := &cobra.Command{
+		Use:   "get <calendar>",
+		Short: "Show calendar details",
+		Args:  calendarRefArgs(&ref),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			calendarID, err := resolveCalendarRef(cmd.Context(), state, token, tokenType, ref)
+			if err != nil {
+				return err
+			}
+			calendar, err := state.SDK.GetCalendar(cmd.Context(), token, larksdk.AccessTokenType(tokenType), calendarID)
+			if err != nil {
+				return err
+			}
+			return state.Printer.Print(map[string]any{"calendar": calendar}, formatCalendarInfo(calendar))
+		},
+	}
+	return cmd
+}
+
+func newCalendarCalendarsCreateCmd(state *appState) *cobra.Command {
+	var summary string
+	var description string
+	var permissions string
+	var color int
+	var summaryAlias string
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a shared calendar",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.TrimSpace(summary) == "" {
+				return flagUsage(cmd, "summary is required")
+			}
+			if err := validateOneOf(cmd, "permissions", permissions, calendarPermissionValues); err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			req := buildCalendarRequest(cmd, description, permissions, color, summaryAlias)
+			req.Summary = &summary
+			calendar, err := state.SDK.CreateCalendar(cmd.Context(), token, larksdk.AccessTokenType(tokenType), req)
+			if err != nil {
+				return err
+			}
+			payload := map[string]any{"calendar": calendar}
+			text := tableTextRow([]string{"calendar_id", "summary", "permissions"}, []string{calendar.CalendarID, calendar.Summary, infoValue(calendar.Permissions)})
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&summary, "summary", "", "calendar title")
+	cmd.Flags().StringVar(&description, "description", "", "calendar description")
+	cmd.Flags().StringVar(&permissions, "permissions", "", "visibility (private|show_only_free_busy|public)")
+	cmd.Flags().IntVar(&color, "color", 0, "calendar color (RGB int32)")
+	cmd.Flags().StringVar(&summaryAlias, "summary-alias", "", "calendar alias shown to you")
+	registerEnumCompletion(cmd, "permissions", calendarPermissionValues)
+	return cmd
+}
+
+func newCalendarCalendarsUpdateCmd(state *appState) *cobra.Command {
+	var ref string
+	var summary string
+	var description string
+	var permissions string
+	var color int
+	var summaryAlias string
+
+	cmd := &cobra.Command{
+		Use:   "update <calendar>",
+		Short: "Update calendar title, description, color or visibility",
+		Args:  calendarRefArgs(&ref),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOneOf(cmd, "permissions", permissions, calendarPermissionValues); err != nil {
+				return err
+			}
+			req := buildCalendarRequest(cmd, description, permissions, color, summaryAlias)
+			if cmd.Flags().Changed("summary") {
+				req.Summary = &summary
+			}
+			if req.Summary == nil && req.Description == nil && req.Permissions == "" && req.Color == nil && req.SummaryAlias == nil {
+				return flagUsage(cmd, "at least one field must be updated")
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			calendarID, err := resolveCalendarRef(cmd.Context(), state, token, tokenType, ref)
+			if err != nil {
+				return err
+			}
+			calendar, err := state.SDK.UpdateCalendar(cmd.Context(), token, larksdk.AccessTokenType(tokenType), calendarID, req)
+			if err != nil {
+				return err
+			}
+			if calendar.CalendarID == "" {
+				calendar.CalendarID = calendarID
+			}
+			payload := map[string]any{"calendar": calendar}
+			text := tableTextRow([]string{"calendar_id", "summary", "permissions"}, []string{calendar.CalendarID, infoValue(calendar.Summary), infoValue(calendar.Permissions)})
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&summary, "summary", "", "calendar title")
+	cmd.Flags().StringVar(&description, "description", "", "calendar description")
+	cmd.Flags().StringVar(&permissions, "permissions", "", "visibility (private|show_only_free_busy|public)")
+	cmd.Flags().IntVar(&color, "color", 0, "calendar color (RGB int32)")
+	cmd.Flags().StringVar(&summaryAlias, "summary-alias", "", "calendar alias shown to you")
+	registerEnumCompletion(cmd, "permissions", calendarPermissionValues)
+	return cmd
+}
+
+func newCalendarCalendarsDeleteCmd(state *appState) *cobra.Command {
+	var ref string
+
+	cmd := &cobra.Command{
+		Use:   "delete <calendar>",
+		Short: "Delete a shared calendar",
+		Args:  calendarRefArgs(&ref),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := confirmDestructive(cmd, state, fmt.Sprintf("delete calendar %s", ref)); err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			calendarID, err := resolveCalendarRef(cmd.Context(), state, token, tokenType, ref)
+			if err != nil {
+				return err
+			}
+			if err := state.SDK.DeleteCalendar(cmd.Context(), token, larksdk.AccessTokenType(tokenType), calendarID); err != nil {
+				return err
+			}
+			payload := map[string]any{"calendar_id": calendarID, "deleted": true}
+			return state.Printer.Print(payload, tableTextRow([]string{"calendar_id", "deleted"}, []string{calendarID, "true"}))
+		},
+	}
+	return cmd
+}
+
+func newCalendarCalendarsSubscribeCmd(state *appState) *cobra.Command {
+	var ref string
+
+	cmd := &cobra.Command{
+		Use:   "subscribe <calendar>",
+		Short: "Subscribe to a shared calendar",
+		Args:  calendarRefArgs(&ref),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			calendarID, err := resolveCalendarRef(cmd.Context(), state, token, tokenType, ref)
+			if err != nil {
+				return err
+			}
+			calendar, err := state.SDK.SubscribeCalendar(cmd.Context(), token, larksdk.AccessTokenType(tokenType), calendarID)
+			if err != nil {
+				return err
+			}
+			payload := map[string]any{"calendar": calendar, "subscribed": true}
+			return state.Printer.Print(payload, tableTextRow([]string{"calendar_id", "summary", "subscribed"}, []string{calendar.CalendarID, infoValue(calendar.Summary), "true"}))
+		},
+	}
+	return cmd
+}
+
+func newCalendarCalendarsUnsubscribeCmd(state *appState) *cobra.Command {
+	var ref string
+
+	cmd := &cobra.Command{
+		Use:   "unsubscribe <calendar>",
+		Short: "Unsubscribe from a shared calendar",
+		Args:  calendarRefArgs(&ref),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			calendarID, err := resolveCalendarRef(cmd.Context(), state, token, tokenType, ref)
+			if err != nil {
+				return err
+			}
+			if err := state.SDK.UnsubscribeCalendar(cmd.Context(), token, larksdk.AccessTokenType(tokenType), calendarID); err != nil {
+				return err
+			}
+			payload := map[string]any{"calendar_id": calendarID, "subscribed": false}
+			return state.Printer.Print(payload, tableTextRow([]string{"calendar_id", "subscribed"}, []string{calendarID, "false"}))
+		},
+	}
+	return cmd
+}
+
+func calendarRefArgs(ref *string) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return argsUsageError(cmd, err)
+		}
+		*ref = strings.TrimSpace(args[0])
+		if *ref == "" {
+			return argsUsageError(cmd, errors.New("calendar is required"))
+		}
+		return nil
+	}
+}
+
+func buildCalendarRequest(cmd *cobra.Command, description, permissions string, color int, summaryAlias string) larksdk.CalendarRequest {
+	req := larksdk.CalendarRequest{
+		Permissions: permissions,
+		Color:       flagIntPtr(cmd, "color", color),
+	}
+	if cmd.Flags().Changed("description") {
+		req.Description = &description
+	}
+	if cmd.Flags().Changed("summary-alias") {
+		req.SummaryAlias = &summaryAlias
+	}
+	return req
+}
+
+func listAllCalendars(ctx context.Context, state *appState, token string, accessType tokenType, limit int) ([]larksdk.Calendar, error) {
+	calendars := make([]larksdk.Calendar, 0)
+	pageToken := ""
+	for {
+		result, err := state.SDK.ListCalendars(ctx, token, larksdk.AccessTokenType(accessType), larksdk.ListCalendarsRequest{
+			PageSize:  500,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, calendar := range result.Items {
+			if calendar.IsDeleted {
+				continue
+			}
+			calendars = append(calendars, calendar)
+		}
+		if (limit > 0 && len(calendars) >= limit) || !result.HasMore || result.PageToken == "" {
+			break
+		}
+		pageToken = result.PageToken
+	}
+	if limit > 0 && len(calendars) > limit {
+		calendars = calendars[:limit]
+	}
+	return calendars, nil
+}
+
+// resolveEventCalendarID picks the calendar for event commands: --calendar
+// (name or id) when set, otherwise --calendar-id or the primary calendar.
+func resolveEventCalendarID(ctx context.Context, state *appState, token string, accessType tokenType, calendarID, calendarName string) (string, error) {
+	if strings.TrimSpace(calendarName) != "" {
+		return resolveCalendarRef(ctx, state, token, accessType, calendarName)
+	}
+	return resolveCalendarID(ctx, state, token, accessType, calendarID)
+}
+
+// resolveCalendarRef maps a calendar reference to a calendar_id. Values that
+// look like ids (contain "@") pass through; "primary" resolves to the primary
+// calendar; anything else is matched by name against the caller's calendars
+// and then against shared calendars in the tenant.
+func resolveCalendarRef(ctx context.Context, state *appState, token string, accessType tokenType, ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.EqualFold(ref, "primary") {
+		return resolveCalendarID(ctx, state, token, accessType, "")
+	}
+	if strings.Contains(ref, "@") {
+		return ref, nil
+	}
+	if state.SDK == nil {
+		return "", errors.New("sdk client is required")
+	}
+	calendars, err := listAllCalendars(ctx, state, token, accessType, 0)
+	if err != nil {
+		return "", err
+	}
+	matches := matchCalendarsByName(calendars, ref)
+	if len(matches) == 0 {
+		found, _, err := state.SDK.SearchCalendars(ctx, token, larksdk.AccessTokenType(accessType), ref, 50, "")
+		if err != nil {
+			return "", err
+		}
+		matches = matchCalendarsByName(found, ref)
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no calendar named %q; use `lark calendars calendars list` or pass a calendar_id", ref)
+	case 1:
+		return matches[0].CalendarID, nil
+	default:
+		ids := make([]string, 0, len(matches))
+		for _, calendar := range matches {
+			ids = append(ids, calendar.CalendarID)
+		}
+		return "", fmt.Errorf("calendar name %q is ambiguous; matches: %s", ref, strings.Join(ids, ", "))
+	}
+}
+
+func matchCalendarsByName(calendars []larksdk.Calendar, name string) []larksdk.Calendar {
+	matches := make([]larksdk.Calendar, 0)
+	seen := map[string]bool{}
+	for _, calendar := range calendars {
+		if calendar.CalendarID == "" || seen[calendar.CalendarID] {
+			continue
+		}
+		if strings.EqualFold(calendar.Summary, name) || (calendar.SummaryAlias != "" && strings.EqualFold(calendar.SummaryAlias, name)) {
+			seen[calendar.CalendarID] = true
+			matches = append(matches, calendar)
+		}
+	}
+	return matches
+}
+
+func calendarRows(calendars []larksdk.Calendar) [][]string {
+	rows := make([][]string, 0, len(calendars))
+	for _, calendar := range calendars {
+		summary := calendar.Summary
+		if calendar.SummaryAlias != "" {
+			summary = calendar.SummaryAlias
+		}
+		rows = append(rows, []string{calendar.CalendarID, infoValue(summary), infoValue(calendar.Type), infoValue(calendar.Role), infoValue(calendar.Permissions)})
+	}
+	return rows
+}
+
+func formatCalendarInfo(calendar larksdk.Calendar) string {
+	rows := [][]string{
+		{"calendar_id", calendar.CalendarID},
+		{"summary", infoValue(calendar.Summary)},
+		{"summary_alias", infoValue(calendar.SummaryAlias)},
+		{"description", infoValue(calendar.Description)},
+		{"type", infoValue(calendar.Type)},
+		{"role", infoValue(calendar.Role)},
+		{"permissions", infoValue(calendar.Permissions)},
+		{"color", infoValueIntZeroDash(calendar.Color)},
+		{"third_party", strconv.FormatBool(calendar.IsThirdParty)},
+	}
+	return formatInfoTable(rows, "no calendar found")
+}
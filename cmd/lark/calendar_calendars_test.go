@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"lark/internal/larksdk"
+)
+
+func writeCalendarList(w http.ResponseWriter) {
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"code": 0,
+		"msg":  "ok",
+		"data": map[string]any{
+			"calendar_list": []map[string]any{
+				{"calendar_id": "primary@group.calendar.feishu.cn", "summary": "Me", "type": "primary", "role": "owner"},
+				{"calendar_id": "oncall@group.calendar.feishu.cn", "summary": "On-call", "type": "shared", "role": "writer", "permissions": "public"},
+				{"calendar_id": "old@group.calendar.feishu.cn", "summary": "Release", "type": "shared", "is_deleted": true},
+			},
+			"has_more": false,
+		},
+	})
+}
+
+func TestCalendarCalendarsListSkipsDeleted(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/open-apis/calendar/v4/calendars" || r.Method != http.MethodGet {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		writeCalendarList(w)
+	})
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+
+	cmd := newCalendarCmd(state)
+	cmd.SetArgs([]string{"calendars", "list"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("calendars list error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "oncall@group.calendar.feishu.cn") || strings.Contains(out, "old@group.calendar.feishu.cn") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestCalendarListResolvesCalendarName(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/open-apis/calendar/v4/calendars":
+			writeCalendarList(w)
+		case "/open-apis/calendar/v4/calendars/oncall@group.calendar.feishu.cn/events":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"items":    []map[string]any{{"event_id": "evt_1", "summary": "Primary shift", "start_time": map[string]any{"timestamp": "1700000000"}, "end_time": map[string]any{"timestamp": "1700003600"}}},
+					"has_more": false,
+				},
+			})
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+
+	cmd := newCalendarCmd(state)
+	cmd.SetArgs([]string{"list", "--calendar", "on-call"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("list error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "evt_1") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestCalendarCalendarsSubscribeSearchesSharedCalendars(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/open-apis/calendar/v4/calendars":
+			writeCalendarList(w)
+		case "/open-apis/calendar/v4/calendars/search":
+			var payload map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			if payload["query"] != "Release train" {
+				t.Fatalf("unexpected query: %+v", payload)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"items": []map[string]any{
+						{"calendar_id": "train@group.calendar.feishu.cn", "summary": "Release train"},
+						{"calendar_id": "train2@group.calendar.feishu.cn", "summary": "Release train (archive)"},
+					},
+				},
+			})
+		case "/open-apis/calendar/v4/calendars/train@group.calendar.feishu.cn/subscribe":
+			if r.Method != http.MethodPost {
+				t.Fatalf("unexpected method: %s", r.Method)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{"calendar": map[string]any{"calendar_id": "train@group.calendar.feishu.cn", "summary": "Release train"}},
+			})
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+
+	cmd := newCalendarCmd(state)
+	cmd.SetArgs([]string{"calendars", "subscribe", "Release train"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("subscribe error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "train@group.calendar.feishu.cn") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestMatchCalendarsByNameUsesAlias(t *testing.T) {
+	calendars := []larksdk.Calendar{
+		{CalendarID: "a@x", Summary: "Team"},
+		{CalendarID: "b@x", Summary: "Ops", SummaryAlias: "team"},
+		{CalendarID: "c@x", Summary: "Other"},
+	}
+	matches := matchCalendarsByName(calendars, "TEAM")
+	if len(matches) != 2 {
+		t.Fatalf("unexpected matches: %+v", matches)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+func newCalendarExportCmd(state *appState) *cobra.Command {
+	var start string
+	var end string
+	var calendarID string
+	var calendarName string
+	var outPath string
+	var withAttendees bool
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export events to an ICS file",
+		Long: `Export events in a time range as RFC 5545 VEVENTs.
+
+Each VEVENT carries UID (the event_id), DTSTART/DTEND, SUMMARY, DESCRIPTION,
+LOCATION, RRULE, STATUS, the VC meeting URL, attendees and reminders (VALARM).
+Cancelled events are skipped.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if limit <= 0 {
+				return flagUsage(cmd, "limit must be greater than 0")
+			}
+			if start == "" || end == "" {
+				return flagUsage(cmd, "start and end times are required")
+			}
+			startTime, endTime, err := parseCalendarRange(cmd, start, end)
+			if err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			resolvedCalendarID, err := resolveEventCalendarID(cmd.Context(), state, token, tokenType, calendarID, calendarName)
+			if err != nil {
+				return err
+			}
+			events, err := listCalendarEventsInRange(cmd.Context(), state, token, tokenType, resolvedCalendarID, startTime, endTime, limit)
+			if err != nil {
+				return err
+			}
+			exported := make([]larksdk.CalendarEvent, 0, len(events))
+			for _, event := range events {
+				if strings.EqualFold(event.Status, "cancelled") {
+					continue
+				}
+				if withAttendees {
+					needAttendee := true
+					detailed, err := state.SDK.GetCalendarEvent(cmd.Context(), token, larksdk.AccessTokenType(tokenType), larksdk.GetCalendarEventRequest{
+						CalendarID:   resolvedCalendarID,
+						EventID:      event.EventID,
+						NeedAttendee: &needAttendee,
+					})
+					if err != nil {
+						return fmt.Errorf("get event %s: %w", event.EventID, err)
+					}
+					event.Attendees = detailed.Attendees
+					if event.VChat == nil {
+						event.VChat = detailed.VChat
+					}
+				}
+				exported = append(exported, event)
+			}
+			content := encodeICSCalendar(exported, time.Now())
+
+			if strings.TrimSpace(outPath) == "-" {
+				_, err := io.WriteString(cmd.OutOrStdout(), content)
+				return err
+			}
+			if err := os.WriteFile(outPath, []byte(content), 0o644); err != nil {
+				return err
+			}
+			payload := map[string]any{
+				"calendar_id": resolvedCalendarID,
+				"output_path": outPath,
+				"events":      len(exported),
+			}
+			text := tableTextRow(
+				[]string{"calendar_id", "output_path", "events"},
+				[]string{resolvedCalendarID, outPath, strconv.Itoa(len(exported))},
+			)
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&start, "start", "", "range start (RFC3339, unix seconds, or relative)")
+	cmd.Flags().StringVar(&end, "end", "", "range end (RFC3339, unix seconds, or relative)")
+	cmd.Flags().StringVar(&calendarID, "calendar-id", "", "calendar ID (default: primary)")
+	cmd.Flags().StringVar(&calendarName, "calendar", "", "calendar name or ID (alternative to --calendar-id)")
+	cmd.MarkFlagsMutuallyExclusive("calendar-id", "calendar")
+	cmd.Flags().StringVar(&outPath, "out", "", "output .ics path (or - for stdout)")
+	cmd.Flags().BoolVar(&withAttendees, "attendees", true, "fetch and export attendees (one request per event)")
+	cmd.Flags().IntVar(&limit, "limit", 1000, "max number of events to export")
+	_ = cmd.MarkFlagRequired("out")
+	return cmd
+}
+
+func listCalendarEventsInRange(ctx context.Context, state *appState, token string, accessType tokenType, calendarID string, start, end time.Time, limit int) ([]larksdk.CalendarEvent, error) {
+	events := make([]larksdk.CalendarEvent, 0)
+	pageToken := ""
+	for {
+		result, err := state.SDK.ListCalendarEvents(ctx, token, larksdk.AccessTokenType(accessType), larksdk.ListCalendarEventsRequest{
+			CalendarID: calendarID,
+			StartTime:  strconv.FormatInt(start.Unix(), 10),
+			EndTime:    strconv.FormatInt(end.Unix(), 10),
+			PageSize:   1000,
+			PageToken:  pageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, result.Items...)
+		if len(events) >= limit || !result.HasMore || result.PageToken == "" {
+			break
+		}
+		pageToken = result.PageToken
+	}
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func calendarExportHandler(t *testing.T, detailCalls *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/open-apis/calendar/v4/calendars/cal@x/events":
+			if r.URL.Query().Get("start_time") != "1773104400" || r.URL.Query().Get("end_time") != "1773190800" {
+				t.Fatalf("unexpected range: %s", r.URL.RawQuery)
+			}
+			items := []map[string]any{
+				{"event_id": "evt_1", "summary": "Standup", "status": "confirmed", "recurrence": "FREQ=DAILY;COUNT=5", "start_time": map[string]any{"timestamp": "1773104400"}, "end_time": map[string]any{"timestamp": "1773106200"}},
+				{"event_id": "evt_gone", "summary": "Dropped", "status": "cancelled", "start_time": map[string]any{"timestamp": "1773108000"}, "end_time": map[string]any{"timestamp": "1773111600"}},
+			}
+			next := "page_2"
+			hasMore := true
+			if r.URL.Query().Get("page_token") == "page_2" {
+				items = []map[string]any{
+					{"event_id": "evt_2", "summary": "Offsite", "status": "confirmed", "start_time": map[string]any{"date": "2026-03-10"}, "end_time": map[string]any{"date": "2026-03-10"}},
+				}
+				next = ""
+				hasMore = false
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"items": items, "has_more": hasMore, "page_token": next}})
+		case "/open-apis/calendar/v4/calendars/cal@x/events/evt_1", "/open-apis/calendar/v4/calendars/cal@x/events/evt_2":
+			*detailCalls++
+			if r.URL.Query().Get("need_attendee") != "true" {
+				t.Fatalf("expected attendees requested: %s", r.URL.RawQuery)
+			}
+			eventID := strings.TrimPrefix(r.URL.Path, "/open-apis/calendar/v4/calendars/cal@x/events/")
+			event := map[string]any{"event_id": eventID}
+			if eventID == "evt_1" {
+				event["attendees"] = []map[string]any{{"type": "third_party", "third_party_email": "guest@example.com"}}
+				event["vchat"] = map[string]any{"vc_type": "vc", "meeting_url": "https://vc.feishu.cn/j/123"}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"event": event}})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func TestCalendarExportWritesICS(t *testing.T) {
+	detailCalls := 0
+	var buf bytes.Buffer
+	state := newTestState(t, calendarExportHandler(t, &detailCalls), nil, &buf)
+	outPath := filepath.Join(t.TempDir(), "events.ics")
+
+	cmd := newCalendarCmd(state)
+	cmd.SetArgs([]string{"export", "--calendar-id", "cal@x", "--start", "2026-03-10T01:00:00Z", "--end", "2026-03-11T01:00:00Z", "--out", outPath})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("calendar export error: %v", err)
+	}
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read ics: %v", err)
+	}
+	ics := string(data)
+	for _, want := range []string{
+		"UID:evt_1\r\n",
+		"DTSTART:20260310T010000Z\r\n",
+		"RRULE:FREQ=DAILY;COUNT=5\r\n",
+		"URL:https://vc.feishu.cn/j/123\r\n",
+		"mailto:guest@example.com\r\n",
+		"UID:evt_2\r\n",
+		"DTSTART;VALUE=DATE:20260310\r\nDTEND;VALUE=DATE:20260311\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Fatalf("missing %q in ics:\n%s", want, ics)
+		}
+	}
+	if strings.Contains(ics, "evt_gone") {
+		t.Fatalf("cancelled events must be skipped:\n%s", ics)
+	}
+	if detailCalls != 2 {
+		t.Fatalf("expected one attendee lookup per exported event, got %d", detailCalls)
+	}
+	if !strings.Contains(buf.String(), "cal@x\t"+outPath+"\t2") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestCalendarExportStdoutWithoutAttendees(t *testing.T) {
+	detailCalls := 0
+	var buf bytes.Buffer
+	state := newTestState(t, calendarExportHandler(t, &detailCalls), nil, &buf)
+
+	cmd := newCalendarCmd(state)
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"export", "--calendar-id", "cal@x", "--start", "2026-03-10T01:00:00Z", "--end", "2026-03-11T01:00:00Z", "--out", "-", "--attendees=false", "--limit", "1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("calendar export error: %v", err)
+	}
+	ics := buf.String()
+	if !strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n") || !strings.Contains(ics, "UID:evt_1\r\n") {
+		t.Fatalf("unexpected ics:\n%s", ics)
+	}
+	if strings.Contains(ics, "evt_2") || strings.Contains(ics, "ATTENDEE") {
+		t.Fatalf("expected the first event only, without attendees:\n%s", ics)
+	}
+	if detailCalls != 0 {
+		t.Fatalf("--attendees=false must not fetch event details, got %d calls", detailCalls)
+	}
+}
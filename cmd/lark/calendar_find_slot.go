@@ -0,0 +1,386 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+const defaultFindSlotWithin = "mon-fri 09:00-18:00"
+
+var slotWeekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// slotWindow is a recurring daily window such as "mon-fri 10:00-18:00".
+// StartMinute/EndMinute are minutes after local midnight.
+type slotWindow struct {
+	Days        [7]bool
+	StartMinute int
+	EndMinute   int
+}
+
+func newCalendarFindSlotCmd(state *appState) *cobra.Command {
+	var attendees []string
+	var rooms []string
+	var durationRaw string
+	var within []string
+	var tz string
+	var start string
+	var end string
+	var count int
+	var stepRaw string
+	var book bool
+	var pick int
+	var calendarID string
+	var calendarName string
+	var summary string
+	var description string
+
+	cmd := &cobra.Command{
+		Use:   "find-slot",
+		Short: "Find free meeting slots shared by attendees",
+		Long: `Find the earliest free slots shared by all attendees (and rooms).
+
+Busy intervals come from the freebusy API and are intersected with the
+--within windows, evaluated in --tz. Windows use "<days> HH:MM-HH:MM" where
+days is a range (mon-fri), a list (mon,wed,fri), or "daily"; repeat --within
+to combine windows. The search range defaults to the next 7 days.
+
+Use --book with --summary to create an event in the chosen slot (--pick, 1-based)
+and invite the attendees.
+
+Example:
+  lark calendars find-slot --attendee a@example.com --attendee b@example.com \
+    --duration 30m --within "mon-fri 10:00-18:00" --tz Asia/Shanghai`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(attendees) == 0 {
+				return flagUsage(cmd, "at least one --attendee is required")
+			}
+			if count <= 0 {
+				return flagUsage(cmd, "count must be greater than 0")
+			}
+			duration, err := time.ParseDuration(strings.TrimSpace(durationRaw))
+			if err != nil || duration <= 0 {
+				return flagUsage(cmd, fmt.Sprintf("invalid duration %q", durationRaw))
+			}
+			step, err := time.ParseDuration(strings.TrimSpace(stepRaw))
+			if err != nil || step <= 0 {
+				return flagUsage(cmd, fmt.Sprintf("invalid step %q", stepRaw))
+			}
+			loc := time.Local
+			if strings.TrimSpace(tz) != "" {
+				loc, err = time.LoadLocation(strings.TrimSpace(tz))
+				if err != nil {
+					return flagUsage(cmd, fmt.Sprintf("invalid time zone %q", tz))
+				}
+			}
+			windows := make([]slotWindow, 0, len(within))
+			for _, raw := range within {
+				window, err := parseSlotWindow(raw)
+				if err != nil {
+					return flagUsage(cmd, err.Error())
+				}
+				windows = append(windows, window)
+			}
+			if len(windows) == 0 {
+				return flagUsage(cmd, "at least one --within window is required")
+			}
+			if book {
+				if strings.TrimSpace(summary) == "" {
+					return flagUsage(cmd, "--summary is required with --book")
+				}
+				if pick <= 0 || pick > count {
+					return flagUsage(cmd, fmt.Sprintf("pick must be between 1 and %d", count))
+				}
+			}
+			rangeStart := time.Now().UTC()
+			if start != "" {
+				rangeStart, err = parseCalendarTimeArg(start)
+				if err != nil {
+					return flagUsage(cmd, fmt.Sprintf("invalid start time: %v", err))
+				}
+			}
+			rangeEnd := rangeStart.Add(7 * 24 * time.Hour)
+			if end != "" {
+				rangeEnd, err = parseCalendarTimeArg(end)
+				if err != nil {
+					return flagUsage(cmd, fmt.Sprintf("invalid end time: %v", err))
+				}
+			}
+			if !rangeEnd.After(rangeStart) {
+				return flagUsage(cmd, "end time must be after start time")
+			}
+
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			owners, err := resolveCalendarBusyOwners(cmd.Context(), state, attendees, rooms)
+			if err != nil {
+				return err
+			}
+			if err := fetchCalendarBusy(cmd.Context(), state, token, tokenType, owners, rangeStart, rangeEnd, nil); err != nil {
+				return err
+			}
+			slots := findFreeSlots(rangeStart.In(loc), rangeEnd.In(loc), windows, mergeBusyIntervals(owners), duration, step, count)
+
+			payload := map[string]any{
+				"time_zone": loc.String(),
+				"duration":  duration.String(),
+				"owners":    owners,
+				"slots":     formatSlotIntervals(slots, loc),
+			}
+			if !book {
+				rows := make([][]string, 0, len(slots))
+				for i, slot := range slots {
+					rows = append(rows, []string{strconv.Itoa(i + 1), slot.Start.In(loc).Format(time.RFC3339), slot.End.In(loc).Format(time.RFC3339)})
+				}
+				text := tableTextFromRows([]string{"#", "start_time", "end_time"}, rows, "no free slots found")
+				return state.Printer.Print(payload, text)
+			}
+
+			if len(slots) < pick {
+				return fmt.Errorf("no free slot #%d found (found %d)", pick, len(slots))
+			}
+			slot := slots[pick-1]
+			resolvedCalendarID, err := resolveEventCalendarID(cmd.Context(), state, token, tokenType, calendarID, calendarName)
+			if err != nil {
+				return err
+			}
+			event, err := state.SDK.CreateCalendarEvent(cmd.Context(), token, larksdk.AccessTokenType(tokenType), larksdk.CreateCalendarEventRequest{
+				CalendarID:  resolvedCalendarID,
+				Summary:     summary,
+				Description: description,
+				StartTime:   slot.Start.Unix(),
+				EndTime:     slot.End.Unix(),
+			})
+			if err != nil {
+				return err
+			}
+			attendeeRecords := make([]larksdk.CalendarEventAttendee, 0, len(owners))
+			for _, owner := range owners {
+				if owner.RoomID != "" {
+					attendeeRecords = append(attendeeRecords, larksdk.CalendarEventAttendee{Type: "resource", RoomID: owner.RoomID})
+					continue
+				}
+				attendeeRecords = append(attendeeRecords, larksdk.CalendarEventAttendee{Type: "user", UserID: owner.UserID})
+			}
+			if err := state.SDK.CreateCalendarEventAttendees(cmd.Context(), token, larksdk.AccessTokenType(tokenType), larksdk.CreateCalendarEventAttendeesRequest{
+				CalendarID: resolvedCalendarID,
+				EventID:    event.EventID,
+				UserIDType: "open_id",
+				Attendees:  attendeeRecords,
+			}); err != nil {
+				return err
+			}
+			payload["calendar_id"] = resolvedCalendarID
+			payload["event"] = event
+			text := tableTextRow(
+				[]string{"event_id", "start_time", "end_time", "summary"},
+				[]string{event.EventID, slot.Start.In(loc).Format(time.RFC3339), slot.End.In(loc).Format(time.RFC3339), summary},
+			)
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&attendees, "attendee", nil, "attendee email or open_id (repeatable)")
+	cmd.Flags().StringArrayVar(&rooms, "room", nil, "meeting room id that must also be free (repeatable)")
+	cmd.Flags().StringVar(&durationRaw, "duration", "30m", "meeting duration (e.g. 30m, 1h)")
+	cmd.Flags().StringArrayVar(&within, "within", []string{defaultFindSlotWithin}, "allowed window, e.g. \"mon-fri 10:00-18:00\" (repeatable)")
+	cmd.Flags().StringVar(&tz, "tz", "", "IANA time zone for --within and output (default: local)")
+	cmd.Flags().StringVar(&start, "start", "", "search range start (default: now)")
+	cmd.Flags().StringVar(&end, "end", "", "search range end (default: start + 7d)")
+	cmd.Flags().IntVar(&count, "count", 3, "number of slots to propose")
+	cmd.Flags().StringVar(&stepRaw, "step", "15m", "slot start granularity")
+	cmd.Flags().BoolVar(&book, "book", false, "create an event in the chosen slot")
+	cmd.Flags().IntVar(&pick, "pick", 1, "slot number to book with --book (1-based)")
+	cmd.Flags().StringVar(&calendarID, "calendar-id", "", "calendar ID for --book (default: primary)")
+	cmd.Flags().StringVar(&calendarName, "calendar", "", "calendar name or ID for --book")
+	cmd.MarkFlagsMutuallyExclusive("calendar-id", "calendar")
+	cmd.Flags().StringVar(&summary, "summary", "", "event summary for --book")
+	cmd.Flags().StringVar(&description, "description", "", "event description for --book")
+	return cmd
+}
+
+// parseSlotWindow parses "<days> HH:MM-HH:MM" or "HH:MM-HH:MM" (every day).
+func parseSlotWindow(raw string) (slotWindow, error) {
+	fields := strings.Fields(strings.ToLower(strings.TrimSpace(raw)))
+	var window slotWindow
+	var daysSpec string
+	var hoursSpec string
+	switch len(fields) {
+	case 1:
+		daysSpec = "daily"
+		hoursSpec = fields[0]
+	case 2:
+		daysSpec = fields[0]
+		hoursSpec = fields[1]
+	default:
+		return slotWindow{}, fmt.Errorf("invalid window %q (expected \"mon-fri 10:00-18:00\")", raw)
+	}
+
+	if daysSpec == "daily" || daysSpec == "*" {
+		for i := range window.Days {
+			window.Days[i] = true
+		}
+	} else {
+		for _, part := range strings.Split(daysSpec, ",") {
+			from, to, isRange := strings.Cut(part, "-")
+			fromDay, ok := slotWeekdays[from]
+			if !ok {
+				return slotWindow{}, fmt.Errorf("invalid weekday %q in window %q", from, raw)
+			}
+			if !isRange {
+				window.Days[fromDay] = true
+				continue
+			}
+			toDay, ok := slotWeekdays[to]
+			if !ok {
+				return slotWindow{}, fmt.Errorf("invalid weekday %q in window %q", to, raw)
+			}
+			for day := fromDay; ; day = (day + 1) % 7 {
+				window.Days[day] = true
+				if day == toDay {
+					break
+				}
+			}
+		}
+	}
+
+	startRaw, endRaw, ok := strings.Cut(hoursSpec, "-")
+	if !ok {
+		return slotWindow{}, fmt.Errorf("invalid hours %q in window %q", hoursSpec, raw)
+	}
+	startMinute, err := parseClockMinutes(startRaw)
+	if err != nil {
+		return slotWindow{}, fmt.Errorf("invalid window %q: %w", raw, err)
+	}
+	endMinute, err := parseClockMinutes(endRaw)
+	if err != nil {
+		return slotWindow{}, fmt.Errorf("invalid window %q: %w", raw, err)
+	}
+	if endMinute <= startMinute {
+		return slotWindow{}, fmt.Errorf("invalid window %q: end must be after start", raw)
+	}
+	window.StartMinute = startMinute
+	window.EndMinute = endMinute
+	return window, nil
+}
+
+func parseClockMinutes(raw string) (int, error) {
+	hourRaw, minuteRaw, ok := strings.Cut(strings.TrimSpace(raw), ":")
+	if !ok {
+		return 0, fmt.Errorf("invalid clock time %q (expected HH:MM)", raw)
+	}
+	hour, err := strconv.Atoi(hourRaw)
+	if err != nil || hour < 0 || hour > 24 {
+		return 0, fmt.Errorf("invalid clock time %q (expected HH:MM)", raw)
+	}
+	minute, err := strconv.Atoi(minuteRaw)
+	if err != nil || minute < 0 || minute > 59 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("invalid clock time %q (expected HH:MM)", raw)
+	}
+	return hour*60 + minute, nil
+}
+
+// findFreeSlots returns up to limit non-overlapping slots of the given duration
+// that fall inside the windows and outside every busy interval. Slot starts are
+// aligned to step relative to local midnight of start's location.
+func findFreeSlots(start, end time.Time, windows []slotWindow, busy []timeInterval, duration, step time.Duration, limit int) []timeInterval {
+	loc := start.Location()
+	allowed := make([]timeInterval, 0)
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	for ; day.Before(end); day = day.AddDate(0, 0, 1) {
+		for _, window := range windows {
+			if !window.Days[day.Weekday()] {
+				continue
+			}
+			// Wall-clock construction keeps windows on local hours across
+			// DST changes, where days are 23 or 25 hours long.
+			windowStart := time.Date(day.Year(), day.Month(), day.Day(), 0, window.StartMinute, 0, 0, loc)
+			windowEnd := time.Date(day.Year(), day.Month(), day.Day(), 0, window.EndMinute, 0, 0, loc)
+			if windowStart.Before(start) {
+				windowStart = start
+			}
+			if windowEnd.After(end) {
+				windowEnd = end
+			}
+			if windowEnd.After(windowStart) {
+				allowed = append(allowed, timeInterval{Start: windowStart, End: windowEnd})
+			}
+		}
+	}
+
+	slots := make([]timeInterval, 0, limit)
+	for _, free := range subtractTimeIntervals(mergeTimeIntervals(allowed), busy) {
+		slotStart := alignToStep(free.Start, step)
+		for !slotStart.Add(duration).After(free.End) {
+			slots = append(slots, timeInterval{Start: slotStart, End: slotStart.Add(duration)})
+			if len(slots) == limit {
+				return slots
+			}
+			slotStart = alignToStep(slotStart.Add(duration), step)
+		}
+	}
+	return slots
+}
+
+// subtractTimeIntervals removes busy (sorted, merged) from each allowed interval.
+func subtractTimeIntervals(allowed, busy []timeInterval) []timeInterval {
+	out := make([]timeInterval, 0, len(allowed))
+	for _, interval := range allowed {
+		cursor := interval.Start
+		for _, block := range busy {
+			if !block.End.After(cursor) {
+				continue
+			}
+			if !block.Start.Before(interval.End) {
+				break
+			}
+			if block.Start.After(cursor) {
+				out = append(out, timeInterval{Start: cursor, End: block.Start})
+			}
+			cursor = block.End
+		}
+		if interval.End.After(cursor) {
+			out = append(out, timeInterval{Start: cursor, End: interval.End})
+		}
+	}
+	return out
+}
+
+func alignToStep(t time.Time, step time.Duration) time.Time {
+	hour, minute, second := t.Clock()
+	sinceMidnight := time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute + time.Duration(second)*time.Second + time.Duration(t.Nanosecond())
+	if rem := sinceMidnight % step; rem > 0 {
+		return t.Add(step - rem)
+	}
+	return t
+}
+
+func formatSlotIntervals(slots []timeInterval, loc *time.Location) []map[string]string {
+	out := make([]map[string]string, 0, len(slots))
+	for _, slot := range slots {
+		out = append(out, map[string]string{
+			"start_time": slot.Start.In(loc).Format(time.RFC3339),
+			"end_time":   slot.End.In(loc).Format(time.RFC3339),
+		})
+	}
+	return out
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"lark/internal/config"
+	"lark/internal/larksdk"
+	"lark/internal/output"
+	"lark/internal/testutil"
+)
+
+func TestParseSlotWindow(t *testing.T) {
+	window, err := parseSlotWindow("mon-fri 10:00-18:00")
+	if err != nil {
+		t.Fatalf("parse window: %v", err)
+	}
+	if window.Days[time.Sunday] || window.Days[time.Saturday] || !window.Days[time.Monday] || !window.Days[time.Friday] {
+		t.Fatalf("unexpected days: %+v", window.Days)
+	}
+	if window.StartMinute != 600 || window.EndMinute != 1080 {
+		t.Fatalf("unexpected minutes: %d-%d", window.StartMinute, window.EndMinute)
+	}
+	window, err = parseSlotWindow("fri-mon 09:00-12:00")
+	if err != nil {
+		t.Fatalf("parse wrapping window: %v", err)
+	}
+	if !window.Days[time.Saturday] || !window.Days[time.Sunday] || window.Days[time.Wednesday] {
+		t.Fatalf("unexpected wrapped days: %+v", window.Days)
+	}
+	for _, raw := range []string{"mon-fri", "xyz 10:00-11:00", "mon 18:00-10:00", "mon 10-11"} {
+		if _, err := parseSlotWindow(raw); err == nil {
+			t.Fatalf("expected error for %q", raw)
+		}
+	}
+}
+
+func TestFindFreeSlots(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+	window, err := parseSlotWindow("mon-fri 10:00-12:00")
+	if err != nil {
+		t.Fatalf("parse window: %v", err)
+	}
+	// 2026-03-06 is a Friday; the search spans into the weekend and Monday.
+	start := time.Date(2026, 3, 6, 10, 5, 0, 0, loc)
+	end := time.Date(2026, 3, 10, 0, 0, 0, 0, loc)
+	busy := []timeInterval{
+		{Start: time.Date(2026, 3, 6, 10, 30, 0, 0, loc), End: time.Date(2026, 3, 6, 11, 40, 0, 0, loc)},
+	}
+	slots := findFreeSlots(start, end, []slotWindow{window}, busy, 30*time.Minute, 15*time.Minute, 3)
+	if len(slots) != 3 {
+		t.Fatalf("unexpected slots: %+v", slots)
+	}
+	want := []time.Time{
+		time.Date(2026, 3, 9, 10, 0, 0, 0, loc),
+		time.Date(2026, 3, 9, 10, 30, 0, 0, loc),
+		time.Date(2026, 3, 9, 11, 0, 0, 0, loc),
+	}
+	for i, slot := range slots {
+		if !slot.Start.Equal(want[i]) || slot.End.Sub(slot.Start) != 30*time.Minute {
+			t.Fatalf("slot %d = %v-%v, want start %v", i, slot.Start, slot.End, want[i])
+		}
+	}
+}
+
+func TestFindFreeSlotsAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	window, err := parseSlotWindow("09:00-10:00")
+	if err != nil {
+		t.Fatalf("parse window: %v", err)
+	}
+	// Clocks move forward on 2026-03-08 and back on 2026-11-01.
+	for _, day := range []time.Time{
+		time.Date(2026, 3, 8, 0, 0, 0, 0, loc),
+		time.Date(2026, 11, 1, 0, 0, 0, 0, loc),
+	} {
+		slots := findFreeSlots(day, day.AddDate(0, 0, 1), []slotWindow{window}, nil, 30*time.Minute, 30*time.Minute, 5)
+		if len(slots) != 2 {
+			t.Fatalf("%s: unexpected slots: %+v", day.Format("2006-01-02"), slots)
+		}
+		for i, want := range []string{"09:00", "09:30"} {
+			if got := slots[i].Start.In(loc).Format("15:04"); got != want {
+				t.Fatalf("%s: slot %d starts at %s, want %s", day.Format("2006-01-02"), i, got, want)
+			}
+		}
+	}
+}
+
+func TestCalendarFindSlotBooksPick(t *testing.T) {
+	var attendeePayload struct {
+		Attendees []larksdk.CalendarEventAttendee `json:"attendees"`
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/open-apis/calendar/v4/freebusy/list":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"freebusy_list": []map[string]any{
+						{"start_time": "2026-03-02T10:00:00+08:00", "end_time": "2026-03-02T11:00:00+08:00"},
+					},
+				},
+			})
+		case "/open-apis/calendar/v4/calendars/cal_1/events":
+			var payload struct {
+				Summary   string            `json:"summary"`
+				StartTime map[string]string `json:"start_time"`
+				EndTime   map[string]string `json:"end_time"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			wantStart := time.Date(2026, 3, 2, 11, 30, 0, 0, time.FixedZone("", 8*3600)).Unix()
+			if payload.Summary != "Sync" || payload.StartTime["timestamp"] != strconv.FormatInt(wantStart, 10) {
+				t.Fatalf("unexpected event payload: %+v", payload)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{"event": map[string]any{"event_id": "evt_1", "summary": "Sync"}},
+			})
+		case "/open-apis/calendar/v4/calendars/cal_1/events/evt_1/attendees":
+			if got := r.URL.Query().Get("user_id_type"); got != "open_id" {
+				t.Fatalf("unexpected user_id_type: %q", got)
+			}
+			if err := json.NewDecoder(r.Body).Decode(&attendeePayload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{}})
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+	httpClient, baseURL := testutil.NewTestClient(handler)
+
+	var buf bytes.Buffer
+	state := &appState{
+		Config: &config.Config{
+			AppID:                      "app",
+			AppSecret:                  "secret",
+			BaseURL:                    baseURL,
+			TenantAccessToken:          "token",
+			TenantAccessTokenExpiresAt: time.Now().Add(2 * time.Hour).Unix(),
+		},
+		Printer: output.Printer{Writer: &buf},
+	}
+	sdkClient, err := larksdk.New(state.Config, larksdk.WithHTTPClient(httpClient))
+	if err != nil {
+		t.Fatalf("sdk client error: %v", err)
+	}
+	state.SDK = sdkClient
+
+	cmd := newCalendarCmd(state)
+	cmd.SetArgs([]string{
+		"find-slot",
+		"--attendee", "ou_a",
+		"--start", "2026-03-02T09:45:00+08:00",
+		"--end", "2026-03-02T18:00:00+08:00",
+		"--within", "mon-fri 10:00-12:00",
+		"--tz", "Asia/Shanghai",
+		"--duration", "30m",
+		"--book", "--pick", "2",
+		"--summary", "Sync",
+		"--calendar-id", "cal_1",
+	})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("find-slot error: %v", err)
+	}
+	if len(attendeePayload.Attendees) != 1 || attendeePayload.Attendees[0].Type != "user" || attendeePayload.Attendees[0].UserID != "ou_a" {
+		t.Fatalf("unexpected attendees: %+v", attendeePayload.Attendees)
+	}
+	if !strings.Contains(buf.String(), "evt_1") || !strings.Contains(buf.String(), "2026-03-02T11:30:00+08:00") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
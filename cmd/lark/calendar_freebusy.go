@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+// calendarBusyOwner is a user or room whose busy intervals were queried.
+type calendarBusyOwner struct {
+	Input  string                     `json:"input"`
+	UserID string                     `json:"user_id,omitempty"`
+	RoomID string                     `json:"room_id,omitempty"`
+	Busy   []larksdk.CalendarFreeBusy `json:"busy"`
+}
+
+func newCalendarFreeBusyCmd(state *appState) *cobra.Command {
+	var users []string
+	var rooms []string
+	var start string
+	var end string
+	var includeExternal bool
+
+	cmd := &cobra.Command{
+		Use:   "freebusy",
+		Short: "Show busy intervals for users or rooms",
+		Long: `Show busy intervals for users or rooms in a time range.
+
+- --user accepts an email or open_id (repeatable); emails are resolved via the contact API.
+- --room accepts a meeting room id (repeatable).
+- Times accept RFC3339, unix seconds, or relative offsets (e.g. +2h).
+
+Example:
+  lark calendars freebusy --user a@example.com --user b@example.com \
+    --start 2026-03-02T09:00:00+08:00 --end 2026-03-02T18:00:00+08:00`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(users) == 0 && len(rooms) == 0 {
+				return flagUsage(cmd, "at least one --user or --room is required")
+			}
+			if start == "" || end == "" {
+				return flagUsage(cmd, "start and end times are required")
+			}
+			startTime, endTime, err := parseCalendarRange(cmd, start, end)
+			if err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			owners, err := resolveCalendarBusyOwners(cmd.Context(), state, users, rooms)
+			if err != nil {
+				return err
+			}
+			if err := fetchCalendarBusy(cmd.Context(), state, token, tokenType, owners, startTime, endTime, flagBoolPtr(cmd, "include-external", includeExternal)); err != nil {
+				return err
+			}
+			payload := map[string]any{
+				"time_min": startTime.Format(time.RFC3339),
+				"time_max": endTime.Format(time.RFC3339),
+				"owners":   owners,
+			}
+			rows := make([][]string, 0)
+			for _, owner := range owners {
+				if len(owner.Busy) == 0 {
+					rows = append(rows, []string{owner.Input, "-", "-"})
+					continue
+				}
+				for _, busy := range owner.Busy {
+					rows = append(rows, []string{owner.Input, busy.StartTime, busy.EndTime})
+				}
+			}
+			text := tableTextFromRows([]string{"owner", "busy_start", "busy_end"}, rows, "no owners")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&users, "user", nil, "user email or open_id (repeatable)")
+	cmd.Flags().StringArrayVar(&rooms, "room", nil, "meeting room id (repeatable)")
+	cmd.Flags().StringVar(&start, "start", "", "range start (RFC3339, unix seconds, or relative)")
+	cmd.Flags().StringVar(&end, "end", "", "range end (RFC3339, unix seconds, or relative)")
+	cmd.Flags().BoolVar(&includeExternal, "include-external", true, "include events from bound external calendars")
+	return cmd
+}
+
+func parseCalendarRange(cmd *cobra.Command, start, end string) (time.Time, time.Time, error) {
+	startTime, err := parseCalendarTimeArg(start)
+	if err != nil {
+		return time.Time{}, time.Time{}, flagUsage(cmd, fmt.Sprintf("invalid start time: %v", err))
+	}
+	endTime, err := parseCalendarTimeArg(end)
+	if err != nil {
+		return time.Time{}, time.Time{}, flagUsage(cmd, fmt.Sprintf("invalid end time: %v", err))
+	}
+	if !endTime.After(startTime) {
+		return time.Time{}, time.Time{}, flagUsage(cmd, "end time must be after start time")
+	}
+	return startTime, endTime, nil
+}
+
+// resolveCalendarBusyOwners keeps the caller's ordering while resolving user
+// emails to open_ids in a single batch.
+func resolveCalendarBusyOwners(ctx context.Context, state *appState, users, rooms []string) ([]calendarBusyOwner, error) {
+	owners := make([]calendarBusyOwner, 0, len(users)+len(rooms))
+	emails := make([]string, 0, len(users))
+	for _, value := range users {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if strings.Contains(value, "@") {
+			emails = append(emails, value)
+		}
+		owners = append(owners, calendarBusyOwner{Input: value, UserID: value})
+	}
+	if len(emails) > 0 {
+		byEmail, err := resolveEmailOpenIDs(ctx, state, emails)
+		if err != nil {
+			return nil, err
+		}
+		for i := range owners {
+			if id, ok := byEmail[strings.ToLower(owners[i].Input)]; ok {
+				owners[i].UserID = id
+			}
+		}
+	}
+	for _, value := range rooms {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		owners = append(owners, calendarBusyOwner{Input: value, RoomID: value})
+	}
+	if len(owners) == 0 {
+		return nil, errors.New("at least one user or room is required")
+	}
+	return owners, nil
+}
+
+func fetchCalendarBusy(ctx context.Context, state *appState, token string, accessType tokenType, owners []calendarBusyOwner, start, end time.Time, includeExternal *bool) error {
+	for i := range owners {
+		req := larksdk.ListCalendarFreeBusyRequest{
+			TimeMin:                 start.Format(time.RFC3339),
+			TimeMax:                 end.Format(time.RFC3339),
+			UserID:                  owners[i].UserID,
+			RoomID:                  owners[i].RoomID,
+			IncludeExternalCalendar: includeExternal,
+		}
+		if req.UserID != "" {
+			req.UserIDType = "open_id"
+		}
+		busy, err := state.SDK.ListCalendarFreeBusy(ctx, token, larksdk.AccessTokenType(accessType), req)
+		if err != nil {
+			return fmt.Errorf("freebusy for %s: %w", owners[i].Input, err)
+		}
+		if busy == nil {
+			busy = []larksdk.CalendarFreeBusy{}
+		}
+		owners[i].Busy = busy
+	}
+	return nil
+}
+
+type timeInterval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// mergeBusyIntervals flattens the busy lists of all owners into sorted,
+// non-overlapping intervals. Entries with unparsable bounds are skipped.
+func mergeBusyIntervals(owners []calendarBusyOwner) []timeInterval {
+	intervals := make([]timeInterval, 0)
+	for _, owner := range owners {
+		for _, busy := range owner.Busy {
+			start, err := time.Parse(time.RFC3339, busy.StartTime)
+			if err != nil {
+				continue
+			}
+			end, err := time.Parse(time.RFC3339, busy.EndTime)
+			if err != nil || !end.After(start) {
+				continue
+			}
+			intervals = append(intervals, timeInterval{Start: start, End: end})
+		}
+	}
+	return mergeTimeIntervals(intervals)
+}
+
+// mergeTimeIntervals sorts intervals and coalesces overlapping or touching ones.
+func mergeTimeIntervals(intervals []timeInterval) []timeInterval {
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].Start.Before(intervals[j].Start)
+	})
+	merged := make([]timeInterval, 0, len(intervals))
+	for _, interval := range intervals {
+		if n := len(merged); n > 0 && !interval.Start.After(merged[n-1].End) {
+			if interval.End.After(merged[n-1].End) {
+				merged[n-1].End = interval.End
+			}
+			continue
+		}
+		merged = append(merged, interval)
+	}
+	return merged
+}
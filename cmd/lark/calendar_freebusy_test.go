@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"lark/internal/config"
+	"lark/internal/larksdk"
+	"lark/internal/output"
+	"lark/internal/testutil"
+)
+
+func TestCalendarFreeBusyResolvesEmails(t *testing.T) {
+	seen := []string{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/open-apis/contact/v3/users/batch_get_id":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"user_list": []map[string]any{{"user_id": "ou_a", "email": "a@example.com"}},
+				},
+			})
+		case "/open-apis/calendar/v4/freebusy/list":
+			if r.Method != http.MethodPost {
+				t.Fatalf("unexpected method: %s", r.Method)
+			}
+			if got := r.URL.Query().Get("user_id_type"); got != "open_id" {
+				t.Fatalf("unexpected user_id_type: %q", got)
+			}
+			var payload map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			if payload["time_min"] != "2026-03-02T01:00:00Z" || payload["time_max"] != "2026-03-02T10:00:00Z" {
+				t.Fatalf("unexpected range: %+v", payload)
+			}
+			userID, _ := payload["user_id"].(string)
+			seen = append(seen, userID)
+			busy := []map[string]any{}
+			if userID == "ou_a" {
+				busy = append(busy, map[string]any{"start_time": "2026-03-02T02:00:00Z", "end_time": "2026-03-02T03:00:00Z"})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{"freebusy_list": busy},
+			})
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+	httpClient, baseURL := testutil.NewTestClient(handler)
+
+	var buf bytes.Buffer
+	state := &appState{
+		Config: &config.Config{
+			AppID:                      "app",
+			AppSecret:                  "secret",
+			BaseURL:                    baseURL,
+			TenantAccessToken:          "token",
+			TenantAccessTokenExpiresAt: time.Now().Add(2 * time.Hour).Unix(),
+		},
+		Printer: output.Printer{Writer: &buf},
+	}
+	sdkClient, err := larksdk.New(state.Config, larksdk.WithHTTPClient(httpClient))
+	if err != nil {
+		t.Fatalf("sdk client error: %v", err)
+	}
+	state.SDK = sdkClient
+
+	cmd := newCalendarCmd(state)
+	cmd.SetArgs([]string{
+		"freebusy",
+		"--user", "a@example.com",
+		"--user", "ou_b",
+		"--start", "2026-03-02T09:00:00+08:00",
+		"--end", "2026-03-02T18:00:00+08:00",
+	})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("freebusy error: %v", err)
+	}
+	if len(seen) != 2 || seen[0] != "ou_a" || seen[1] != "ou_b" {
+		t.Fatalf("unexpected users queried: %v", seen)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "a@example.com") || !strings.Contains(out, "2026-03-02T02:00:00Z") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestMergeBusyIntervals(t *testing.T) {
+	owners := []calendarBusyOwner{
+		{Busy: []larksdk.CalendarFreeBusy{
+			{StartTime: "2026-03-02T10:00:00Z", EndTime: "2026-03-02T11:00:00Z"},
+			{StartTime: "2026-03-02T13:00:00Z", EndTime: "2026-03-02T14:00:00Z"},
+		}},
+		{Busy: []larksdk.CalendarFreeBusy{
+			{StartTime: "2026-03-02T10:30:00Z", EndTime: "2026-03-02T12:00:00Z"},
+			{StartTime: "bad", EndTime: "2026-03-02T12:00:00Z"},
+		}},
+	}
+	merged := mergeBusyIntervals(owners)
+	if len(merged) != 2 {
+		t.Fatalf("unexpected merged intervals: %+v", merged)
+	}
+	if merged[0].Start.Hour() != 10 || merged[0].End.Hour() != 12 {
+		t.Fatalf("unexpected first interval: %+v", merged[0])
+	}
+}
@@ -0,0 +1,558 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"lark/internal/larksdk"
+)
+
+const (
+	icsProdID         = "-//lark-cli//calendars export//EN"
+	icsDateLayout     = "20060102"
+	icsDateTimeLayout = "20060102T150405"
+	icsLineLimit      = 75
+)
+
+// icsEvent is the subset of a VEVENT that maps onto a Lark calendar event.
+// All-day events keep StartDate/EndDate (EndDate exclusive, as in RFC 5545);
+// timed events keep Start/End.
+type icsEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Status      string
+	AllDay      bool
+	Start       time.Time
+	End         time.Time
+	StartDate   string
+	EndDate     string
+	RRule       string
+	// RecurrenceID is set on VEVENTs that override one occurrence of a
+	// recurring event; they share the UID of the series.
+	RecurrenceID string
+	Reminders    []int
+	Attendees    []icsAttendee
+}
+
+type icsAttendee struct {
+	Email    string
+	Name     string
+	UserType string
+	PartStat string
+}
+
+// encodeICSCalendar renders events as an RFC 5545 VCALENDAR.
+func encodeICSCalendar(events []larksdk.CalendarEvent, now time.Time) string {
+	var b strings.Builder
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:"+icsProdID)
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	stamp := now.UTC().Format(icsDateTimeLayout) + "Z"
+	for _, event := range events {
+		writeICSLine(&b, "BEGIN:VEVENT")
+		writeICSLine(&b, "UID:"+escapeICSText(event.EventID))
+		writeICSLine(&b, "DTSTAMP:"+stamp)
+		if line := icsTimeProperty("DTSTART", event.StartTime, false); line != "" {
+			writeICSLine(&b, line)
+		}
+		if line := icsTimeProperty("DTEND", event.EndTime, true); line != "" {
+			writeICSLine(&b, line)
+		}
+		if event.Summary != "" {
+			writeICSLine(&b, "SUMMARY:"+escapeICSText(event.Summary))
+		}
+		if event.Description != "" {
+			writeICSLine(&b, "DESCRIPTION:"+escapeICSText(event.Description))
+		}
+		if location := formatICSLocation(event.Location); location != "" {
+			writeICSLine(&b, "LOCATION:"+escapeICSText(location))
+		}
+		if rule := strings.TrimSpace(event.Recurrence); rule != "" {
+			writeICSLine(&b, "RRULE:"+strings.TrimPrefix(rule, "RRULE:"))
+		}
+		if status := icsStatus(event.Status); status != "" {
+			writeICSLine(&b, "STATUS:"+status)
+		}
+		if event.VChat != nil && event.VChat.MeetingURL != "" {
+			writeICSLine(&b, "URL:"+event.VChat.MeetingURL)
+			writeICSLine(&b, "X-LARK-VC-URL:"+event.VChat.MeetingURL)
+		}
+		if organizer := event.EventOrganizer; organizer != nil && organizer.UserID != "" {
+			line := "ORGANIZER"
+			if organizer.DisplayName != "" {
+				line += ";CN=" + quoteICSParam(organizer.DisplayName)
+			}
+			writeICSLine(&b, line+":urn:lark:user:"+organizer.UserID)
+		}
+		for _, attendee := range event.Attendees {
+			if line := icsAttendeeLine(attendee); line != "" {
+				writeICSLine(&b, line)
+			}
+		}
+		for _, reminder := range event.Reminders {
+			writeICSLine(&b, "BEGIN:VALARM")
+			writeICSLine(&b, "ACTION:DISPLAY")
+			writeICSLine(&b, "DESCRIPTION:Reminder")
+			writeICSLine(&b, "TRIGGER:"+formatICSTrigger(reminder.Minutes))
+			writeICSLine(&b, "END:VALARM")
+		}
+		writeICSLine(&b, "END:VEVENT")
+	}
+	writeICSLine(&b, "END:VCALENDAR")
+	return b.String()
+}
+
+// icsTimeProperty renders DTSTART/DTEND. Lark all-day end dates are inclusive,
+// so DTEND is shifted one day forward to the exclusive RFC 5545 form.
+func icsTimeProperty(name string, eventTime larksdk.CalendarEventTime, isEnd bool) string {
+	if eventTime.Timestamp != "" {
+		seconds, err := strconv.ParseInt(eventTime.Timestamp, 10, 64)
+		if err != nil {
+			return ""
+		}
+		return name + ":" + time.Unix(seconds, 0).UTC().Format(icsDateTimeLayout) + "Z"
+	}
+	if eventTime.Date != "" {
+		date, err := time.Parse("2006-01-02", eventTime.Date)
+		if err != nil {
+			return ""
+		}
+		if isEnd {
+			date = date.AddDate(0, 0, 1)
+		}
+		return name + ";VALUE=DATE:" + date.Format(icsDateLayout)
+	}
+	return ""
+}
+
+func icsStatus(status string) string {
+	switch strings.ToLower(status) {
+	case "confirmed":
+		return "CONFIRMED"
+	case "tentative":
+		return "TENTATIVE"
+	case "cancelled":
+		return "CANCELLED"
+	default:
+		return ""
+	}
+}
+
+func icsAttendeeLine(attendee larksdk.CalendarEventAttendee) string {
+	params := []string{}
+	value := ""
+	switch attendee.Type {
+	case "third_party":
+		if attendee.ThirdPartyEmail == "" {
+			return ""
+		}
+		params = append(params, "CUTYPE=INDIVIDUAL")
+		value = "mailto:" + attendee.ThirdPartyEmail
+	case "resource":
+		params = append(params, "CUTYPE=ROOM")
+		value = "urn:lark:room:" + attendee.RoomID
+	case "chat":
+		params = append(params, "CUTYPE=GROUP")
+		value = "urn:lark:chat:" + attendee.ChatID
+	default:
+		if attendee.UserID == "" {
+			return ""
+		}
+		params = append(params, "CUTYPE=INDIVIDUAL")
+		value = "urn:lark:user:" + attendee.UserID
+	}
+	if attendee.DisplayName != "" {
+		params = append(params, "CN="+quoteICSParam(attendee.DisplayName))
+	}
+	if partStat := icsPartStat(attendee.RsvpStatus); partStat != "" {
+		params = append(params, "PARTSTAT="+partStat)
+	}
+	if attendee.IsOptional != nil && *attendee.IsOptional {
+		params = append(params, "ROLE=OPT-PARTICIPANT")
+	}
+	return "ATTENDEE;" + strings.Join(params, ";") + ":" + value
+}
+
+func icsPartStat(rsvp string) string {
+	switch rsvp {
+	case "accept":
+		return "ACCEPTED"
+	case "decline":
+		return "DECLINED"
+	case "tentative":
+		return "TENTATIVE"
+	case "needs_action":
+		return "NEEDS-ACTION"
+	default:
+		return ""
+	}
+}
+
+func formatICSLocation(location *larksdk.CalendarEventLocation) string {
+	if location == nil {
+		return ""
+	}
+	parts := []string{}
+	if location.Name != "" {
+		parts = append(parts, location.Name)
+	}
+	if location.Address != "" && location.Address != location.Name {
+		parts = append(parts, location.Address)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatICSTrigger converts a Lark reminder (minutes before start; negative
+// means after) into an RFC 5545 duration relative to DTSTART.
+func formatICSTrigger(minutes int) string {
+	if minutes < 0 {
+		return fmt.Sprintf("PT%dM", -minutes)
+	}
+	return fmt.Sprintf("-PT%dM", minutes)
+}
+
+func escapeICSText(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return replacer.Replace(value)
+}
+
+func unescapeICSText(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			b.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+	return b.String()
+}
+
+func quoteICSParam(value string) string {
+	value = strings.ReplaceAll(value, `"`, "'")
+	if strings.ContainsAny(value, ";:,") {
+		return `"` + value + `"`
+	}
+	return value
+}
+
+// writeICSLine folds content lines at 75 octets without splitting UTF-8 runes.
+// Continuation lines start with a space, which counts toward their limit.
+func writeICSLine(b *strings.Builder, line string) {
+	limit := icsLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = icsLineLimit - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+type icsProperty struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// parseICSEvents reads the VEVENTs of an ICS document. Floating times and
+// unknown TZIDs are interpreted in defaultLoc.
+func parseICSEvents(data []byte, defaultLoc *time.Location) ([]icsEvent, error) {
+	lines := unfoldICSLines(data)
+	events := make([]icsEvent, 0)
+	var current *icsEvent
+	inAlarm := false
+	for lineNo, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		prop, err := parseICSProperty(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo+1, err)
+		}
+		switch {
+		case prop.Name == "BEGIN" && strings.EqualFold(prop.Value, "VEVENT"):
+			current = &icsEvent{}
+			continue
+		case prop.Name == "END" && strings.EqualFold(prop.Value, "VEVENT"):
+			if current != nil {
+				if err := finishICSEvent(current); err != nil {
+					return nil, err
+				}
+				events = append(events, *current)
+			}
+			current = nil
+			continue
+		case prop.Name == "BEGIN" && strings.EqualFold(prop.Value, "VALARM"):
+			inAlarm = true
+			continue
+		case prop.Name == "END" && strings.EqualFold(prop.Value, "VALARM"):
+			inAlarm = false
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if inAlarm {
+			if prop.Name == "TRIGGER" && !strings.EqualFold(prop.Params["RELATED"], "END") && !strings.EqualFold(prop.Params["VALUE"], "DATE-TIME") {
+				offset, err := parseICSDuration(prop.Value)
+				if err != nil {
+					return nil, fmt.Errorf("event %q: invalid TRIGGER: %w", current.UID, err)
+				}
+				current.Reminders = append(current.Reminders, int(-offset/time.Minute))
+			}
+			continue
+		}
+		if err := applyICSProperty(current, prop, defaultLoc); err != nil {
+			return nil, fmt.Errorf("event %q: %w", current.UID, err)
+		}
+	}
+	return events, nil
+}
+
+func applyICSProperty(event *icsEvent, prop icsProperty, defaultLoc *time.Location) error {
+	switch prop.Name {
+	case "UID":
+		event.UID = strings.TrimSpace(prop.Value)
+	case "SUMMARY":
+		event.Summary = unescapeICSText(prop.Value)
+	case "DESCRIPTION":
+		event.Description = unescapeICSText(prop.Value)
+	case "LOCATION":
+		event.Location = unescapeICSText(prop.Value)
+	case "URL", "X-LARK-VC-URL":
+		event.URL = strings.TrimSpace(prop.Value)
+	case "STATUS":
+		event.Status = strings.ToUpper(strings.TrimSpace(prop.Value))
+	case "RRULE":
+		event.RRule = strings.TrimSpace(prop.Value)
+	case "RECURRENCE-ID":
+		event.RecurrenceID = strings.TrimSpace(prop.Value)
+	case "DTSTART", "DTEND":
+		value, date, allDay, err := parseICSTime(prop, defaultLoc)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", prop.Name, err)
+		}
+		if prop.Name == "DTSTART" {
+			event.AllDay = allDay
+			event.Start = value
+			event.StartDate = date
+		} else {
+			event.End = value
+			event.EndDate = date
+		}
+	case "DURATION":
+		duration, err := parseICSDuration(prop.Value)
+		if err != nil {
+			return fmt.Errorf("invalid DURATION: %w", err)
+		}
+		if event.AllDay {
+			start, _ := time.Parse(icsDateLayout, event.StartDate)
+			event.EndDate = start.Add(duration).Format(icsDateLayout)
+		} else {
+			event.End = event.Start.Add(duration)
+		}
+	case "ATTENDEE":
+		email := ""
+		if strings.HasPrefix(strings.ToLower(prop.Value), "mailto:") {
+			email = strings.TrimSpace(prop.Value[len("mailto:"):])
+		}
+		event.Attendees = append(event.Attendees, icsAttendee{
+			Email:    email,
+			Name:     prop.Params["CN"],
+			UserType: strings.ToUpper(prop.Params["CUTYPE"]),
+			PartStat: strings.ToUpper(prop.Params["PARTSTAT"]),
+		})
+	}
+	return nil
+}
+
+func finishICSEvent(event *icsEvent) error {
+	if event.UID == "" {
+		return fmt.Errorf("event %q is missing UID", event.Summary)
+	}
+	if event.AllDay {
+		if event.StartDate == "" {
+			return fmt.Errorf("event %q is missing DTSTART", event.UID)
+		}
+		if event.EndDate == "" {
+			start, _ := time.Parse(icsDateLayout, event.StartDate)
+			event.EndDate = start.AddDate(0, 0, 1).Format(icsDateLayout)
+		}
+		return nil
+	}
+	if event.Start.IsZero() {
+		return fmt.Errorf("event %q is missing DTSTART", event.UID)
+	}
+	if event.End.IsZero() {
+		event.End = event.Start
+	}
+	return nil
+}
+
+func parseICSTime(prop icsProperty, defaultLoc *time.Location) (time.Time, string, bool, error) {
+	value := strings.TrimSpace(prop.Value)
+	if strings.EqualFold(prop.Params["VALUE"], "DATE") || len(value) == len(icsDateLayout) {
+		if _, err := time.Parse(icsDateLayout, value); err != nil {
+			return time.Time{}, "", false, err
+		}
+		return time.Time{}, value, true, nil
+	}
+	if strings.HasSuffix(value, "Z") {
+		parsed, err := time.Parse(icsDateTimeLayout, strings.TrimSuffix(value, "Z"))
+		return parsed, "", false, err
+	}
+	loc := defaultLoc
+	if tzid := strings.Trim(prop.Params["TZID"], `"`); tzid != "" {
+		if loaded, err := time.LoadLocation(tzid); err == nil {
+			loc = loaded
+		}
+	}
+	parsed, err := time.ParseInLocation(icsDateTimeLayout, value, loc)
+	return parsed, "", false, err
+}
+
+// parseICSDuration parses RFC 5545 durations such as "PT15M", "-P1D", "P1W".
+func parseICSDuration(raw string) (time.Duration, error) {
+	value := strings.ToUpper(strings.TrimSpace(raw))
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(value, "-"):
+		sign = -1
+		value = value[1:]
+	case strings.HasPrefix(value, "+"):
+		value = value[1:]
+	}
+	if !strings.HasPrefix(value, "P") || len(value) < 2 {
+		return 0, fmt.Errorf("invalid duration %q", raw)
+	}
+	value = value[1:]
+	var total time.Duration
+	inTime := false
+	number := ""
+	components := 0
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			number += string(r)
+			continue
+		case r == 'T':
+			inTime = true
+			continue
+		}
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", raw)
+		}
+		number = ""
+		components++
+		switch {
+		case r == 'W' && !inTime:
+			total += time.Duration(n) * 7 * 24 * time.Hour
+		case r == 'D' && !inTime:
+			total += time.Duration(n) * 24 * time.Hour
+		case r == 'H' && inTime:
+			total += time.Duration(n) * time.Hour
+		case r == 'M' && inTime:
+			total += time.Duration(n) * time.Minute
+		case r == 'S' && inTime:
+			total += time.Duration(n) * time.Second
+		default:
+			return 0, fmt.Errorf("invalid duration %q", raw)
+		}
+	}
+	if number != "" || components == 0 {
+		return 0, fmt.Errorf("invalid duration %q", raw)
+	}
+	return sign * total, nil
+}
+
+func unfoldICSLines(data []byte) []string {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	lines := make([]string, 0)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func parseICSProperty(line string) (icsProperty, error) {
+	inQuotes := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		}
+		if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return icsProperty{}, fmt.Errorf("invalid content line %q", line)
+	}
+	head := line[:colon]
+	prop := icsProperty{Params: map[string]string{}, Value: line[colon+1:]}
+	parts := splitICSParams(head)
+	prop.Name = strings.ToUpper(parts[0])
+	for _, part := range parts[1:] {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		prop.Params[strings.ToUpper(name)] = strings.Trim(value, `"`)
+	}
+	return prop, nil
+}
+
+func splitICSParams(head string) []string {
+	parts := []string{}
+	inQuotes := false
+	start := 0
+	for i, r := range head {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == ';' && !inQuotes:
+			parts = append(parts, head[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, head[start:])
+}
+
+// icsIdempotencyKey derives a stable UUID-shaped key from a namespace and UID
+// so re-importing the same file maps each UID to the same event.
+func icsIdempotencyKey(namespace, uid string) string {
+	sum := sha1.Sum([]byte(namespace + "\x00" + uid))
+	sum[6] = (sum[6] & 0x0f) | 0x50
+	sum[8] = (sum[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
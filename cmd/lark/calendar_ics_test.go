@@ -0,0 +1,185 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"lark/internal/larksdk"
+)
+
+func TestEncodeICSCalendar(t *testing.T) {
+	optional := true
+	events := []larksdk.CalendarEvent{
+		{
+			EventID:        "evt_1",
+			Summary:        "Planning; Q3, kickoff",
+			Description:    "line one\nline two",
+			StartTime:      larksdk.CalendarEventTime{Timestamp: "1767322800"},
+			EndTime:        larksdk.CalendarEventTime{Timestamp: "1767326400"},
+			Recurrence:     "FREQ=WEEKLY;BYDAY=MO",
+			Status:         "confirmed",
+			Location:       &larksdk.CalendarEventLocation{Name: "Room 1", Address: "Floor 3"},
+			VChat:          &larksdk.CalendarEventVChat{MeetingURL: "https://vc.example.com/j/1"},
+			Reminders:      []larksdk.CalendarEventReminder{{Minutes: 15}},
+			EventOrganizer: &larksdk.CalendarEventOrganizer{UserID: "ou_org", DisplayName: "Org"},
+			Attendees: []larksdk.CalendarEventAttendee{
+				{Type: "third_party", ThirdPartyEmail: "guest@example.com", RsvpStatus: "accept", IsOptional: &optional},
+				{Type: "user", UserID: "ou_1", DisplayName: "Ann", RsvpStatus: "tentative"},
+			},
+		},
+		{
+			EventID:        "evt_2",
+			Summary:        "Holiday",
+			StartTime:      larksdk.CalendarEventTime{Date: "2026-05-01"},
+			EndTime:        larksdk.CalendarEventTime{Date: "2026-05-01"},
+			EventOrganizer: &larksdk.CalendarEventOrganizer{DisplayName: "Shared calendar"},
+		},
+	}
+	out := encodeICSCalendar(events, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	for _, line := range strings.Split(out, "\r\n") {
+		if len(line) > icsLineLimit {
+			t.Fatalf("line not folded: %q", line)
+		}
+	}
+	out = strings.ReplaceAll(out, "\r\n ", "")
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:evt_1\r\n",
+		"DTSTART:20260102T030000Z\r\n",
+		"DTEND:20260102T040000Z\r\n",
+		`SUMMARY:Planning\; Q3\, kickoff`,
+		`DESCRIPTION:line one\nline two`,
+		"LOCATION:Room 1\\, Floor 3",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO",
+		"URL:https://vc.example.com/j/1",
+		"ATTENDEE;CUTYPE=INDIVIDUAL;PARTSTAT=ACCEPTED;ROLE=OPT-PARTICIPANT:mailto:guest@example.com",
+		"ATTENDEE;CUTYPE=INDIVIDUAL;CN=Ann;PARTSTAT=TENTATIVE:urn:lark:user:ou_1",
+		"ORGANIZER;CN=Org:urn:lark:user:ou_org",
+		"TRIGGER:-PT15M",
+		"DTSTART;VALUE=DATE:20260501",
+		"DTEND;VALUE=DATE:20260502",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "ORGANIZER") != 1 {
+		t.Fatalf("organizers without a user ID must be skipped:\n%s", out)
+	}
+}
+
+func TestWriteICSLineFoldsContinuationsAt75Octets(t *testing.T) {
+	var b strings.Builder
+	line := "DESCRIPTION:" + strings.Repeat("a", 150) + strings.Repeat("é", 60)
+	writeICSLine(&b, line)
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	if len(lines) < 3 {
+		t.Fatalf("expected several folded lines, got %q", lines)
+	}
+	for i, folded := range lines {
+		if len(folded) > icsLineLimit {
+			t.Fatalf("line %d is %d octets: %q", i, len(folded), folded)
+		}
+		if i > 0 && (!strings.HasPrefix(folded, " ") || !utf8.ValidString(folded)) {
+			t.Fatalf("bad continuation line %d: %q", i, folded)
+		}
+	}
+	if len(lines[1]) != icsLineLimit {
+		t.Fatalf("expected a full continuation line, got %d octets", len(lines[1]))
+	}
+	if got := strings.Join(unfoldICSLines([]byte(b.String())), ""); got != line {
+		t.Fatalf("unfold mismatch: %q", got)
+	}
+}
+
+func TestParseICSEvents(t *testing.T) {
+	data := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:conf-1@example.com",
+		"SUMMARY:Keynote\\, day 1",
+		"DESCRIPTION:Opening talk ",
+		" continues here",
+		"DTSTART;TZID=Asia/Shanghai:20260310T090000",
+		"DURATION:PT1H30M",
+		"RRULE:FREQ=DAILY;COUNT=3",
+		"LOCATION:Hall A",
+		"URL:https://meet.example.com/k",
+		`ATTENDEE;CN="Doe, Jane";PARTSTAT=ACCEPTED:mailto:jane@example.com`,
+		"BEGIN:VALARM",
+		"TRIGGER:-P1D",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:holiday-1",
+		"SUMMARY:Labour Day",
+		"DTSTART;VALUE=DATE:20260501",
+		"DTEND;VALUE=DATE:20260504",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	events, err := parseICSEvents([]byte(data), time.UTC)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+	first := events[0]
+	if first.Summary != "Keynote, day 1" || first.Description != "Opening talk continues here" {
+		t.Fatalf("unexpected text fields: %+v", first)
+	}
+	if first.Start.UTC().Format(time.RFC3339) != "2026-03-10T01:00:00Z" || first.End.Sub(first.Start) != 90*time.Minute {
+		t.Fatalf("unexpected times: %v - %v", first.Start, first.End)
+	}
+	if first.RRule != "FREQ=DAILY;COUNT=3" || first.URL != "https://meet.example.com/k" || first.Location != "Hall A" {
+		t.Fatalf("unexpected fields: %+v", first)
+	}
+	if len(first.Reminders) != 1 || first.Reminders[0] != 1440 {
+		t.Fatalf("unexpected reminders: %v", first.Reminders)
+	}
+	if len(first.Attendees) != 1 || first.Attendees[0].Email != "jane@example.com" || first.Attendees[0].Name != "Doe, Jane" {
+		t.Fatalf("unexpected attendees: %+v", first.Attendees)
+	}
+	start, end := icsEventTimes(events[1])
+	if start.Date != "2026-05-01" || end.Date != "2026-05-03" {
+		t.Fatalf("unexpected all-day range: %+v - %+v", start, end)
+	}
+}
+
+func TestParseICSDuration(t *testing.T) {
+	cases := map[string]time.Duration{
+		"PT15M":   15 * time.Minute,
+		"-PT1H":   -time.Hour,
+		"P1W":     7 * 24 * time.Hour,
+		"P1DT2H":  26 * time.Hour,
+		"+PT30S":  30 * time.Second,
+		"-P0DT0S": 0,
+	}
+	for raw, want := range cases {
+		got, err := parseICSDuration(raw)
+		if err != nil || got != want {
+			t.Fatalf("parseICSDuration(%q) = %v, %v; want %v", raw, got, err, want)
+		}
+	}
+	for _, raw := range []string{"15M", "PT", "P1H", "PT1D"} {
+		if _, err := parseICSDuration(raw); err == nil {
+			t.Fatalf("expected error for %q", raw)
+		}
+	}
+}
+
+func TestICSIdempotencyKeyIsStable(t *testing.T) {
+	a := icsIdempotencyKey("cal", "uid-1")
+	if a != icsIdempotencyKey("cal", "uid-1") {
+		t.Fatalf("key not stable")
+	}
+	if a == icsIdempotencyKey("cal", "uid-2") || a == icsIdempotencyKey("other", "uid-1") {
+		t.Fatalf("keys should differ by namespace and uid")
+	}
+	if len(a) != 36 || strings.Count(a, "-") != 4 {
+		t.Fatalf("unexpected key shape: %q", a)
+	}
+}
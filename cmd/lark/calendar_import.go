@@ -0,0 +1,299 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+func newCalendarImportCmd(state *appState) *cobra.Command {
+	var path string
+	var calendarID string
+	var calendarName string
+	var idempotencyKey string
+	var mapPath string
+	var tz string
+	var invite bool
+
+	cmd := &cobra.Command{
+		Use:   "import <file.ics>",
+		Short: "Import events from an ICS file",
+		Long: `Create or update events from an ICS file.
+
+Imports are idempotent per VEVENT UID:
+- each UID is created with an idempotency key derived from --idempotency-key
+  (default: the calendar id) and the UID, so re-running creates no duplicates;
+- with --map, the UID -> event_id mapping is saved to a JSON file and later
+  runs update the mapped events in place.
+
+Cancelled VEVENTs are skipped, as are VEVENTs with a RECURRENCE-ID: they
+override one occurrence of a recurring series and share its UID, so importing
+them would overwrite the series. Notifications are not sent. Use --invite to
+add ATTENDEE mailto addresses as external attendees of newly created events;
+addresses already on the event are not added again.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			path = strings.TrimSpace(args[0])
+			if path == "" {
+				return argsUsageError(cmd, errors.New("file is required"))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			loc := time.Local
+			if strings.TrimSpace(tz) != "" {
+				loaded, err := time.LoadLocation(strings.TrimSpace(tz))
+				if err != nil {
+					return flagUsage(cmd, fmt.Sprintf("invalid time zone %q", tz))
+				}
+				loc = loaded
+			}
+			data, err := readInputFile(path)
+			if err != nil {
+				return err
+			}
+			events, err := parseICSEvents(data, loc)
+			if err != nil {
+				return err
+			}
+			mapping := map[string]string{}
+			if mapPath != "" {
+				mapping, err = readICSImportMap(mapPath)
+				if err != nil {
+					return err
+				}
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			resolvedCalendarID, err := resolveEventCalendarID(cmd.Context(), state, token, tokenType, calendarID, calendarName)
+			if err != nil {
+				return err
+			}
+			namespace := strings.TrimSpace(idempotencyKey)
+			if namespace == "" {
+				namespace = resolvedCalendarID
+			}
+
+			notify := false
+			results := make([]map[string]string, 0, len(events))
+			rows := make([][]string, 0, len(events))
+			for _, item := range events {
+				action := ""
+				eventID := mapping[item.UID]
+				start, end := icsEventTimes(item)
+				switch {
+				case item.RecurrenceID != "":
+					action = "skipped"
+					eventID = ""
+					fmt.Fprintf(errWriter(state), "warning: skipped %s (RECURRENCE-ID %s): single-occurrence overrides are not imported\n", item.UID, item.RecurrenceID)
+				case item.Status == "CANCELLED":
+					action = "skipped"
+				case eventID != "":
+					_, err := state.SDK.UpdateCalendarEvent(cmd.Context(), token, larksdk.AccessTokenType(tokenType), larksdk.UpdateCalendarEventRequest{
+						CalendarID:       resolvedCalendarID,
+						EventID:          eventID,
+						Summary:          icsEventSummary(item),
+						Description:      item.Description,
+						Start:            start,
+						End:              end,
+						NeedNotification: &notify,
+						Location:         icsEventLocation(item),
+						Reminders:        buildCalendarReminders(item.Reminders),
+						Recurrence:       item.RRule,
+						VChat:            icsEventVChat(item),
+					})
+					if err != nil {
+						return fmt.Errorf("update %s (%s): %w", item.UID, eventID, err)
+					}
+					action = "updated"
+				default:
+					created, err := state.SDK.CreateCalendarEvent(cmd.Context(), token, larksdk.AccessTokenType(tokenType), larksdk.CreateCalendarEventRequest{
+						CalendarID:       resolvedCalendarID,
+						Summary:          icsEventSummary(item),
+						Description:      item.Description,
+						IdempotencyKey:   icsIdempotencyKey(namespace, item.UID),
+						Start:            start,
+						End:              end,
+						NeedNotification: &notify,
+						Location:         icsEventLocation(item),
+						Reminders:        buildCalendarReminders(item.Reminders),
+						Recurrence:       item.RRule,
+						VChat:            icsEventVChat(item),
+					})
+					if err != nil {
+						return fmt.Errorf("create %s: %w", item.UID, err)
+					}
+					eventID = created.EventID
+					action = "created"
+					mapping[item.UID] = eventID
+					// Save the mapping as we go so a later failure does not
+					// lose it; the next run then updates instead of creating.
+					if mapPath != "" {
+						if err := writeICSImportMap(mapPath, mapping); err != nil {
+							return err
+						}
+					}
+					if invite {
+						if err := inviteICSAttendees(cmd, state, token, tokenType, resolvedCalendarID, eventID, item.Attendees); err != nil {
+							return fmt.Errorf("invite attendees for %s: %w", item.UID, err)
+						}
+					}
+				}
+				result := map[string]string{"uid": item.UID, "event_id": eventID, "action": action, "summary": item.Summary}
+				label := item.UID
+				if item.RecurrenceID != "" {
+					result["recurrence_id"] = item.RecurrenceID
+					label = fmt.Sprintf("%s (%s)", item.UID, item.RecurrenceID)
+				}
+				results = append(results, result)
+				rows = append(rows, []string{label, infoValue(eventID), action, infoValue(item.Summary)})
+			}
+			payload := map[string]any{
+				"calendar_id": resolvedCalendarID,
+				"events":      results,
+			}
+			text := tableTextFromRows([]string{"uid", "event_id", "action", "summary"}, rows, "no events found")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&calendarID, "calendar-id", "", "calendar ID (default: primary)")
+	cmd.Flags().StringVar(&calendarName, "calendar", "", "calendar name or ID (alternative to --calendar-id)")
+	cmd.MarkFlagsMutuallyExclusive("calendar-id", "calendar")
+	cmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "namespace for per-UID idempotency keys (default: calendar id)")
+	cmd.Flags().StringVar(&mapPath, "map", "", "JSON file storing the UID -> event_id mapping (read and updated)")
+	cmd.Flags().StringVar(&tz, "tz", "", "IANA time zone for floating times (default: local)")
+	cmd.Flags().BoolVar(&invite, "invite", false, "invite ATTENDEE mailto addresses as external attendees")
+	return cmd
+}
+
+// icsEventTimes converts VEVENT times to Lark event times. All-day DTEND is
+// exclusive in RFC 5545 while Lark end dates are inclusive.
+func icsEventTimes(item icsEvent) (*larksdk.CalendarEventTime, *larksdk.CalendarEventTime) {
+	if item.AllDay {
+		start, _ := time.Parse(icsDateLayout, item.StartDate)
+		end, _ := time.Parse(icsDateLayout, item.EndDate)
+		end = end.AddDate(0, 0, -1)
+		if end.Before(start) {
+			end = start
+		}
+		return &larksdk.CalendarEventTime{Date: start.Format("2006-01-02")}, &larksdk.CalendarEventTime{Date: end.Format("2006-01-02")}
+	}
+	return &larksdk.CalendarEventTime{Timestamp: strconv.FormatInt(item.Start.Unix(), 10)},
+		&larksdk.CalendarEventTime{Timestamp: strconv.FormatInt(item.End.Unix(), 10)}
+}
+
+func icsEventSummary(item icsEvent) string {
+	if strings.TrimSpace(item.Summary) == "" {
+		return "(no title)"
+	}
+	return item.Summary
+}
+
+func icsEventLocation(item icsEvent) *larksdk.CalendarEventLocation {
+	if strings.TrimSpace(item.Location) == "" {
+		return nil
+	}
+	return &larksdk.CalendarEventLocation{Name: item.Location}
+}
+
+func icsEventVChat(item icsEvent) *larksdk.CalendarEventVChat {
+	if item.URL == "" {
+		return nil
+	}
+	return &larksdk.CalendarEventVChat{VCType: "third_party", MeetingURL: item.URL}
+}
+
+// inviteICSAttendees adds ATTENDEE addresses missing from the event. A create
+// deduplicated by its idempotency key returns the existing event, whose
+// attendees were already invited by the earlier run.
+func inviteICSAttendees(cmd *cobra.Command, state *appState, token string, accessType tokenType, calendarID, eventID string, attendees []icsAttendee) error {
+	records := make([]larksdk.CalendarEventAttendee, 0, len(attendees))
+	for _, attendee := range attendees {
+		if attendee.Email == "" || attendee.UserType == "ROOM" || attendee.UserType == "RESOURCE" {
+			continue
+		}
+		records = append(records, larksdk.CalendarEventAttendee{Type: "third_party", ThirdPartyEmail: attendee.Email})
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	existing := map[string]bool{}
+	pageToken := ""
+	for {
+		result, err := state.SDK.ListCalendarEventAttendees(cmd.Context(), token, larksdk.AccessTokenType(accessType), larksdk.ListCalendarEventAttendeesRequest{
+			CalendarID: calendarID,
+			EventID:    eventID,
+			PageSize:   100,
+			PageToken:  pageToken,
+		})
+		if err != nil {
+			return err
+		}
+		for _, attendee := range result.Items {
+			if attendee.ThirdPartyEmail != "" {
+				existing[strings.ToLower(attendee.ThirdPartyEmail)] = true
+			}
+		}
+		if !result.HasMore || result.PageToken == "" {
+			break
+		}
+		pageToken = result.PageToken
+	}
+	missing := records[:0]
+	for _, record := range records {
+		if !existing[strings.ToLower(record.ThirdPartyEmail)] {
+			missing = append(missing, record)
+		}
+	}
+	records = missing
+	if len(records) == 0 {
+		return nil
+	}
+	return state.SDK.CreateCalendarEventAttendees(cmd.Context(), token, larksdk.AccessTokenType(accessType), larksdk.CreateCalendarEventAttendeesRequest{
+		CalendarID: calendarID,
+		EventID:    eventID,
+		Attendees:  records,
+	})
+}
+
+func readICSImportMap(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	mapping := map[string]string{}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return mapping, nil
+	}
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("invalid map file %s: %w", path, err)
+	}
+	return mapping, nil
+}
+
+func writeICSImportMap(path string, mapping map[string]string) error {
+	data, err := json.MarshalIndent(mapping, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
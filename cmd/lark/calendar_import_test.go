@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCalendarImportCreatesThenUpdatesViaMap(t *testing.T) {
+	dir := t.TempDir()
+	icsPath := filepath.Join(dir, "events.ics")
+	mapPath := filepath.Join(dir, "map.json")
+	ics := "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:u1\r\nSUMMARY:Talk\r\nDTSTART:20260310T010000Z\r\nDTEND:20260310T020000Z\r\nEND:VEVENT\r\nBEGIN:VEVENT\r\nUID:u2\r\nSUMMARY:Gone\r\nSTATUS:CANCELLED\r\nDTSTART:20260311T010000Z\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+	if err := os.WriteFile(icsPath, []byte(ics), 0o644); err != nil {
+		t.Fatalf("write ics: %v", err)
+	}
+
+	creates := 0
+	updates := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/open-apis/calendar/v4/calendars/cal@x/events" && r.Method == http.MethodPost:
+			creates++
+			if got := r.URL.Query().Get("idempotency_key"); got != icsIdempotencyKey("conf", "u1") {
+				t.Fatalf("unexpected idempotency key: %q", got)
+			}
+			var payload map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			if payload["need_notification"] != false {
+				t.Fatalf("expected notifications disabled: %+v", payload)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{"event": map[string]any{"event_id": "evt_1", "summary": "Talk"}},
+			})
+		case r.URL.Path == "/open-apis/calendar/v4/calendars/cal@x/events/evt_1" && r.Method == http.MethodPatch:
+			updates++
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{"event": map[string]any{"event_id": "evt_1", "summary": "Talk"}},
+			})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+
+	args := []string{"import", icsPath, "--calendar-id", "cal@x", "--idempotency-key", "conf", "--map", mapPath}
+	cmd := newCalendarCmd(state)
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("first import error: %v", err)
+	}
+	if creates != 1 || updates != 0 {
+		t.Fatalf("unexpected calls after first import: creates=%d updates=%d", creates, updates)
+	}
+	if !strings.Contains(buf.String(), "created") || !strings.Contains(buf.String(), "skipped") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+	mapData, err := os.ReadFile(mapPath)
+	if err != nil || !strings.Contains(string(mapData), `"u1": "evt_1"`) {
+		t.Fatalf("unexpected map file: %q (%v)", mapData, err)
+	}
+
+	buf.Reset()
+	cmd = newCalendarCmd(state)
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("second import error: %v", err)
+	}
+	if creates != 1 || updates != 1 {
+		t.Fatalf("unexpected calls after second import: creates=%d updates=%d", creates, updates)
+	}
+	if !strings.Contains(buf.String(), "updated") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestCalendarImportSkipsRecurrenceExceptionsAndSavesMapOnError(t *testing.T) {
+	dir := t.TempDir()
+	icsPath := filepath.Join(dir, "events.ics")
+	mapPath := filepath.Join(dir, "map.json")
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:series",
+		"SUMMARY:Standup",
+		"DTSTART:20260310T010000Z",
+		"DTEND:20260310T011500Z",
+		"RRULE:FREQ=DAILY;COUNT=5",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:series",
+		"RECURRENCE-ID:20260312T010000Z",
+		"SUMMARY:Standup (moved)",
+		"DTSTART:20260312T030000Z",
+		"DTEND:20260312T031500Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:broken",
+		"SUMMARY:Fails",
+		"DTSTART:20260313T010000Z",
+		"DTEND:20260313T020000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n") + "\r\n"
+	if err := os.WriteFile(icsPath, []byte(ics), 0o644); err != nil {
+		t.Fatalf("write ics: %v", err)
+	}
+
+	var summaries []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/open-apis/calendar/v4/calendars/cal@x/events" || r.Method != http.MethodPost {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		summary, _ := payload["summary"].(string)
+		summaries = append(summaries, summary)
+		if summary == "Fails" {
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 190002, "msg": "invalid parameters"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"code": 0,
+			"msg":  "ok",
+			"data": map[string]any{"event": map[string]any{"event_id": "evt_series", "summary": summary}},
+		})
+	})
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+
+	cmd := newCalendarCmd(state)
+	cmd.SetArgs([]string{"import", icsPath, "--calendar-id", "cal@x", "--map", mapPath})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "create broken") {
+		t.Fatalf("expected create error, got %v", err)
+	}
+	if strings.Join(summaries, "|") != "Standup|Fails" {
+		t.Fatalf("exception should not be sent, got %q", summaries)
+	}
+	mapData, err := os.ReadFile(mapPath)
+	if err != nil || !strings.Contains(string(mapData), `"series": "evt_series"`) {
+		t.Fatalf("expected map saved before the failure, got %q (%v)", mapData, err)
+	}
+}
+
+func TestCalendarImportInviteSkipsExistingAttendees(t *testing.T) {
+	dir := t.TempDir()
+	icsPath := filepath.Join(dir, "events.ics")
+	ics := "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:u1\r\nSUMMARY:Talk\r\nDTSTART:20260310T010000Z\r\nDTEND:20260310T020000Z\r\nATTENDEE:mailto:old@example.com\r\nATTENDEE:mailto:new@example.com\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+	if err := os.WriteFile(icsPath, []byte(ics), 0o644); err != nil {
+		t.Fatalf("write ics: %v", err)
+	}
+
+	var invited []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/open-apis/calendar/v4/calendars/cal@x/events" && r.Method == http.MethodPost:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{"event": map[string]any{"event_id": "evt_1", "summary": "Talk"}},
+			})
+		case r.URL.Path == "/open-apis/calendar/v4/calendars/cal@x/events/evt_1/attendees" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{"items": []map[string]any{{"type": "third_party", "third_party_email": "Old@example.com"}}},
+			})
+		case r.URL.Path == "/open-apis/calendar/v4/calendars/cal@x/events/evt_1/attendees" && r.Method == http.MethodPost:
+			var payload struct {
+				Attendees []map[string]any `json:"attendees"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			for _, attendee := range payload.Attendees {
+				invited = append(invited, attendee["third_party_email"].(string))
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{}})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+
+	cmd := newCalendarCmd(state)
+	cmd.SetArgs([]string{"import", icsPath, "--calendar-id", "cal@x", "--invite"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("import error: %v", err)
+	}
+	if strings.Join(invited, ",") != "new@example.com" {
+		t.Fatalf("expected only the missing attendee invited, got %v", invited)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"lark/internal/larksdk"
+)
+
+// expandCalendarEvents replaces recurring masters with their occurrences in
+// [start, end). Exception events returned alongside a master are dropped since
+// the instances API already includes them.
+func expandCalendarEvents(ctx context.Context, state *appState, token string, accessType tokenType, calendarID string, events []larksdk.CalendarEvent, start, end time.Time) ([]larksdk.CalendarEvent, error) {
+	expanded := make([]larksdk.CalendarEvent, 0, len(events))
+	masters := map[string]struct{}{}
+	for _, event := range events {
+		if !isRecurringMaster(event) {
+			continue
+		}
+		masters[event.EventID] = struct{}{}
+		instances, err := listCalendarEventInstances(ctx, state, token, accessType, calendarID, event.EventID, start, end)
+		if err != nil {
+			return nil, err
+		}
+		for _, instance := range instances {
+			if instance.RecurringEventID == "" {
+				instance.RecurringEventID = event.EventID
+			}
+			if instance.Recurrence == "" {
+				instance.Recurrence = event.Recurrence
+			}
+			expanded = append(expanded, instance)
+		}
+	}
+	for _, event := range events {
+		if isRecurringMaster(event) {
+			continue
+		}
+		if _, ok := masters[event.RecurringEventID]; ok && event.RecurringEventID != "" {
+			continue
+		}
+		expanded = append(expanded, event)
+	}
+	sort.SliceStable(expanded, func(i, j int) bool {
+		return calendarEventStartUnix(expanded[i]) < calendarEventStartUnix(expanded[j])
+	})
+	return expanded, nil
+}
+
+func listCalendarEventInstances(ctx context.Context, state *appState, token string, accessType tokenType, calendarID, eventID string, start, end time.Time) ([]larksdk.CalendarEvent, error) {
+	instances := make([]larksdk.CalendarEvent, 0)
+	pageToken := ""
+	for {
+		result, err := state.SDK.ListCalendarEventInstances(ctx, token, larksdk.AccessTokenType(accessType), larksdk.ListCalendarEventInstancesRequest{
+			CalendarID: calendarID,
+			EventID:    eventID,
+			StartTime:  strconv.FormatInt(start.Unix(), 10),
+			EndTime:    strconv.FormatInt(end.Unix(), 10),
+			PageSize:   500,
+			PageToken:  pageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, result.Items...)
+		if !result.HasMore || result.PageToken == "" || result.PageToken == pageToken {
+			break
+		}
+		pageToken = result.PageToken
+	}
+	return instances, nil
+}
+
+func isRecurringMaster(event larksdk.CalendarEvent) bool {
+	return strings.TrimSpace(event.Recurrence) != "" && event.RecurringEventID == ""
+}
+
+func calendarEventStartUnix(event larksdk.CalendarEvent) int64 {
+	if event.StartTime.Timestamp != "" {
+		seconds, err := strconv.ParseInt(event.StartTime.Timestamp, 10, 64)
+		if err == nil {
+			return seconds
+		}
+	}
+	if event.StartTime.Date != "" {
+		parsed, err := time.Parse("2006-01-02", event.StartTime.Date)
+		if err == nil {
+			return parsed.Unix()
+		}
+	}
+	return 0
+}
+
+// calendarInstanceEventID derives the id of a single occurrence from its
+// series id and original start time: "<uid>_0" becomes "<uid>_<unix>".
+func calendarInstanceEventID(eventID string, originalTime time.Time) string {
+	base := eventID
+	if idx := strings.LastIndex(eventID, "_"); idx > 0 {
+		if _, err := strconv.ParseInt(eventID[idx+1:], 10, 64); err == nil {
+			base = eventID[:idx]
+		}
+	}
+	return base + "_" + strconv.FormatInt(originalTime.Unix(), 10)
+}
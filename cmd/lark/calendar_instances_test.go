@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCalendarListExpandsRecurringEvents(t *testing.T) {
+	startUnix := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC).Unix()
+	endUnix := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC).Unix()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/open-apis/calendar/v4/calendars/cal_1/events":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"items": []map[string]any{
+						{
+							"event_id":   "standup_0",
+							"summary":    "Standup",
+							"status":     "confirmed",
+							"recurrence": "FREQ=WEEKLY;BYDAY=TU,TH",
+							"start_time": map[string]any{"timestamp": "1767000000"},
+							"end_time":   map[string]any{"timestamp": "1767000900"},
+						},
+						{
+							"event_id":           "standup_1767686400",
+							"summary":            "Standup (moved)",
+							"status":             "confirmed",
+							"is_exception":       true,
+							"recurring_event_id": "standup_0",
+							"start_time":         map[string]any{"timestamp": "1767690000"},
+							"end_time":           map[string]any{"timestamp": "1767690900"},
+						},
+						{
+							"event_id":   "review_0",
+							"summary":    "Review",
+							"status":     "confirmed",
+							"start_time": map[string]any{"timestamp": "1767600000"},
+							"end_time":   map[string]any{"timestamp": "1767603600"},
+						},
+					},
+					"has_more": false,
+				},
+			})
+		case "/open-apis/calendar/v4/calendars/cal_1/events/standup_0/instances":
+			if got := r.URL.Query().Get("start_time"); got != "1767571200" {
+				t.Fatalf("unexpected start_time: %q (want %d)", got, startUnix)
+			}
+			if got := r.URL.Query().Get("end_time"); got != "1768176000" {
+				t.Fatalf("unexpected end_time: %q (want %d)", got, endUnix)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"items": []map[string]any{
+						{
+							"event_id":     "standup_1767686400",
+							"summary":      "Standup (moved)",
+							"status":       "confirmed",
+							"is_exception": true,
+							"start_time":   map[string]any{"timestamp": "1767690000"},
+							"end_time":     map[string]any{"timestamp": "1767690900"},
+						},
+						{
+							"event_id":   "standup_1767859200",
+							"summary":    "Standup",
+							"status":     "confirmed",
+							"start_time": map[string]any{"timestamp": "1767859200"},
+							"end_time":   map[string]any{"timestamp": "1767860100"},
+						},
+					},
+					"has_more": false,
+				},
+			})
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newCalendarCmd(state)
+	cmd.SetArgs([]string{"list", "--calendar-id", "cal_1", "--start", "2026-01-05T00:00:00Z", "--end", "2026-01-12T00:00:00Z", "--expand"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("list error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected header plus 3 events, got %q", buf.String())
+	}
+	if !strings.HasPrefix(lines[2], "review_0") || !strings.HasPrefix(lines[3], "standup_1767686400") || !strings.HasPrefix(lines[4], "standup_1767859200") {
+		t.Fatalf("unexpected order: %q", buf.String())
+	}
+	if strings.Contains(buf.String(), "standup_0") {
+		t.Fatalf("expected master to be replaced by instances: %q", buf.String())
+	}
+}
+
+func TestCalendarListExpandRequiresRange(t *testing.T) {
+	var buf bytes.Buffer
+	state := newTestState(t, http.NotFoundHandler(), nil, &buf)
+	cmd := newCalendarCmd(state)
+	cmd.SetArgs([]string{"list", "--expand"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	if err := cmd.Execute(); err == nil {
+		t.Fatalf("expected error without --start/--end")
+	}
+}
+
+func TestCalendarDeleteInstance(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/open-apis/calendar/v4/calendars/cal_1/events/standup_1767686400" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodDelete {
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{}})
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	state.Force = true
+	cmd := newCalendarCmd(state)
+	cmd.SetArgs([]string{"delete", "standup_0", "--calendar-id", "cal_1", "--instance", "2026-01-06T08:00:00Z"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("delete error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "standup_1767686400") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestCalendarInstanceEventID(t *testing.T) {
+	original := time.Date(2026, 1, 6, 8, 0, 0, 0, time.UTC)
+	cases := map[string]string{
+		"abc-123_0":          "abc-123_1767686400",
+		"abc-123_1767000000": "abc-123_1767686400",
+		"abc-123":            "abc-123_1767686400",
+	}
+	for input, want := range cases {
+		if got := calendarInstanceEventID(input, original); got != want {
+			t.Fatalf("calendarInstanceEventID(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -261,6 +261,22 @@ func TestCalendarCreateCommand(t *testing.T) {
 					},
 				},
 			})
+		case "/open-apis/contact/v3/users/batch_get_id":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"user_list": []map[string]any{{"user_id": "ou_ops", "email": "ops@example.com"}, {"email": "dev@example.com"}},
+				},
+			})
+		case "/open-apis/contact/v3/users/ou_ops":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{"user": map[string]any{"open_id": "ou_ops", "name": "Ops", "email": "ops@example.com"}},
+			})
 		case "/open-apis/calendar/v4/calendars/cal_1/events/evt_1/attendees":
 			w.Header().Set("Content-Type", "application/json")
 			var payload map[string]any
@@ -272,9 +288,13 @@ func TestCalendarCreateCommand(t *testing.T) {
 				t.Fatalf("unexpected attendees: %+v", attendees)
 			}
 			first := attendees[0].(map[string]any)
-			if first["third_party_email"] != "dev@example.com" {
+			if first["type"] != "third_party" || first["third_party_email"] != "dev@example.com" {
 				t.Fatalf("unexpected attendee: %+v", first)
 			}
+			second := attendees[1].(map[string]any)
+			if second["type"] != "user" || second["user_id"] != "ou_ops" {
+				t.Fatalf("expected internal email resolved to a user, got %+v", second)
+			}
 			_ = json.NewEncoder(w).Encode(map[string]any{
 				"code": 0,
 				"msg":  "ok",
@@ -322,6 +342,57 @@ func TestCalendarCreateCommand(t *testing.T) {
 	}
 }
 
+func TestCalendarCreateInvitesByEmailWhenLookupDenied(t *testing.T) {
+	var attendees []any
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/open-apis/contact/v3/users/batch_get_id":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 99991672, "msg": "Access denied. One of the following scopes is required: [contact:user.id:readonly]"})
+		case "/open-apis/calendar/v4/calendars/primary":
+			if r.Header.Get("Authorization") != "Bearer user-token" {
+				t.Fatalf("calendar create must honour --token-type user")
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{
+				"calendars": []map[string]any{{"calendar": map[string]any{"calendar_id": "cal_1"}}},
+			}})
+		case "/open-apis/calendar/v4/calendars/cal_1/events":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{
+				"event": map[string]any{"event_id": "evt_1", "summary": "Demo", "status": "confirmed"},
+			}})
+		case "/open-apis/calendar/v4/calendars/cal_1/events/evt_1/attendees":
+			var payload map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			attendees, _ = payload["attendees"].([]any)
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok"})
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	state.TokenType = "user"
+
+	cmd := newCalendarCmd(state)
+	cmd.SetArgs([]string{"create", "--start", "2026-01-02T03:04:05Z", "--end", "2026-01-02T04:04:05Z", "--summary", "Demo", "--attendee", "dev@example.com"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("calendar create error: %v", err)
+	}
+	if len(attendees) != 1 {
+		t.Fatalf("unexpected attendees: %#v", attendees)
+	}
+	guest := attendees[0].(map[string]any)
+	if guest["type"] != "third_party" || guest["third_party_email"] != "dev@example.com" {
+		t.Fatalf("expected an external guest, got %#v", guest)
+	}
+	if !strings.Contains(buf.String(), "warning: cannot look up dev@example.com") {
+		t.Fatalf("expected lookup warning, got %q", buf.String())
+	}
+}
+
 func TestCalendarSearchCommand(t *testing.T) {
 	start := "2026-01-02T03:04:05Z"
 	end := "2026-01-02T04:04:05Z"
@@ -45,6 +45,17 @@ func newChatsCreateCmd(state *appState) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			userIDs, err = resolveUserRefs(cmd.Context(), state, userIDs, userIDType)
+			if err != nil {
+				return err
+			}
+			if ownerID != "" {
+				owners, err := resolveUserRefs(cmd.Context(), state, []string{ownerID}, userIDType)
+				if err != nil {
+					return err
+				}
+				ownerID = owners[0]
+			}
 
 			var i18nNames *larksdk.I18nNames
 			if nameZh != "" || nameEn != "" || nameJa != "" {
@@ -96,8 +107,8 @@ func newChatsCreateCmd(state *appState) *cobra.Command {
 	cmd.Flags().StringVar(&name, "name", "", "chat name")
 	cmd.Flags().StringVar(&description, "description", "", "chat description")
 	cmd.Flags().StringVar(&avatar, "avatar", "", "avatar image key")
-	cmd.Flags().StringVar(&ownerID, "owner-id", "", "owner ID")
-	cmd.Flags().StringArrayVar(&userIDs, "user-id", nil, "user IDs to invite (repeatable)")
+	cmd.Flags().StringVar(&ownerID, "owner-id", "", "owner ID, email, or @name")
+	cmd.Flags().StringArrayVar(&userIDs, "user-id", nil, "user ID, email, or @name to invite (repeatable)")
 	cmd.Flags().StringArrayVar(&botIDs, "bot-id", nil, "bot app IDs to invite (repeatable)")
 	cmd.Flags().StringVar(&groupMessageType, "group-message-type", "", "message type (chat or thread)")
 	cmd.Flags().StringVar(&chatMode, "chat-mode", "", "chat mode (group)")
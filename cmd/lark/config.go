@@ -3,6 +3,7 @@ package main
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -46,6 +47,7 @@ func newConfigSetCmd(state *appState) *cobra.Command {
 	var defaultUserAccount string
 	var appID string
 	var appSecret string
+	var appType string
 	var storeSecretInKeyring bool
 	var storeSecretInConfig bool
 
@@ -64,6 +66,7 @@ func newConfigSetCmd(state *appState) *cobra.Command {
 			useDefaultUserAccount := cmd.Flags().Changed("default-user-account")
 			useAppID := cmd.Flags().Changed("app-id")
 			useAppSecret := cmd.Flags().Changed("app-secret")
+			useAppType := cmd.Flags().Changed("app-type")
 			useStoreSecretInKeyring := cmd.Flags().Changed("store-secret-in-keyring")
 			useStoreSecretInConfig := cmd.Flags().Changed("store-secret-in-config")
 
@@ -71,7 +74,7 @@ func newConfigSetCmd(state *appState) *cobra.Command {
 			usedMailboxGroup := useDefaultMailboxID
 			usedTokenTypeGroup := useDefaultTokenType
 			usedUserAccountGroup := useDefaultUserAccount
-			usedAppCredsGroup := useAppID || useAppSecret
+			usedAppCredsGroup := useAppID || useAppSecret || useAppType
 
 			groupsUsed := 0
 			if usedBaseURLGroup {
@@ -90,10 +93,10 @@ func newConfigSetCmd(state *appState) *cobra.Command {
 				groupsUsed++
 			}
 			if groupsUsed == 0 {
-				return errors.New("one of --base-url, --platform, --default-mailbox-id, --default-token-type, --default-user-account, --app-id, or --app-secret is required")
+				return errors.New("one of --base-url, --platform, --default-mailbox-id, --default-token-type, --default-user-account, --app-id, --app-secret, or --app-type is required")
 			}
 			if groupsUsed > 1 {
-				return errors.New("flags are mutually exclusive; choose one of: (--base-url|--platform), --default-mailbox-id, --default-token-type, --default-user-account, or (--app-id/--app-secret)")
+				return errors.New("flags are mutually exclusive; choose one of: (--base-url|--platform), --default-mailbox-id, --default-token-type, --default-user-account, or (--app-id/--app-secret/--app-type)")
 			}
 
 			if usedAppCredsGroup {
@@ -109,6 +112,20 @@ func newConfigSetCmd(state *appState) *cobra.Command {
 					state.Config.AppID = appID
 					payload["app_id"] = appID
 				}
+				if useAppType {
+					appType = strings.ToLower(strings.TrimSpace(appType))
+					if appType != config.AppTypeSelfBuilt && appType != config.AppTypeMarketplace {
+						return errors.New("app-type must be self_built or marketplace")
+					}
+					if state.Config.IsMarketplace() != (appType == config.AppTypeMarketplace) {
+						// Cached tenant tokens were minted for the other app type.
+						state.Config.TenantAccessToken = ""
+						state.Config.TenantAccessTokenExpiresAt = 0
+						state.Config.TenantTokens = nil
+					}
+					state.Config.AppType = appType
+					payload["app_type"] = appType
+				}
 				if useAppSecret {
 					appSecret = strings.TrimSpace(appSecret)
 					if appSecret == "" {
@@ -226,6 +243,7 @@ func newConfigSetCmd(state *appState) *cobra.Command {
 	cmd.Flags().StringVar(&defaultUserAccount, "default-user-account", "", "default user account label to persist")
 	cmd.Flags().StringVar(&appID, "app-id", "", "app ID to persist")
 	cmd.Flags().StringVar(&appSecret, "app-secret", "", "app secret to persist (stored in plain text unless stored in keychain)")
+	cmd.Flags().StringVar(&appType, "app-type", "", "app type (self_built|marketplace)")
 	cmd.Flags().BoolVar(&storeSecretInKeyring, "store-secret-in-keyring", false, "store app secret in keychain instead of config")
 	cmd.Flags().BoolVar(&storeSecretInConfig, "store-secret-in-config", false, "store app secret in config (disables keychain storage)")
 	cmd.MarkFlagsMutuallyExclusive("base-url", "platform", "default-mailbox-id", "default-token-type", "default-user-account")
@@ -404,6 +422,10 @@ func configKeys() []configKeyInfo {
 			Key:         "app-secret",
 			Description: "App secret to persist (config set)",
 		},
+		{
+			Key:         "app-type",
+			Description: "App type (self_built or marketplace) to persist (config set)",
+		},
 		{
 			Key:         "app-secret-in-keyring",
 			Description: "Store app secret in keychain (config set --store-secret-in-keyring)",
@@ -440,6 +462,7 @@ func formatConfigHuman(cfg *config.Config) string {
 	lines := []string{
 		fmt.Sprintf("app_id: %s", cfg.AppID),
 		fmt.Sprintf("app_secret_in_keyring: %t", cfg.AppSecretInKeyring),
+		fmt.Sprintf("app_type: %s", configAppType(cfg)),
 		fmt.Sprintf("base_url: %s", cfg.BaseURL),
 		fmt.Sprintf("default_mailbox_id: %s", cfg.DefaultMailboxID),
 		fmt.Sprintf("default_token_type: %s", cfg.DefaultTokenType),
@@ -449,5 +472,23 @@ func formatConfigHuman(cfg *config.Config) string {
 		fmt.Sprintf("user_scopes: %s", strings.Join(cfg.UserScopes, " ")),
 		fmt.Sprintf("tenant_access_token_expires_at: %d", cfg.TenantAccessTokenExpiresAt),
 	}
+	if cfg.IsMarketplace() {
+		tenants := make([]string, 0, len(cfg.TenantTokens))
+		for key := range cfg.TenantTokens {
+			tenants = append(tenants, key)
+		}
+		sort.Strings(tenants)
+		lines = append(lines,
+			fmt.Sprintf("app_ticket_updated_at: %d", cfg.AppTicketUpdatedAt),
+			fmt.Sprintf("tenant_keys: %s", strings.Join(tenants, " ")),
+		)
+	}
 	return strings.Join(lines, "\n")
 }
+
+func configAppType(cfg *config.Config) string {
+	if cfg.IsMarketplace() {
+		return config.AppTypeMarketplace
+	}
+	return config.AppTypeSelfBuilt
+}
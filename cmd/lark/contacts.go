@@ -11,17 +11,21 @@ func newContactsCmd(state *appState) *cobra.Command {
 		Long: `Contacts expose the organization directory.
 
 - Contact users are address-book entries for tenant users.
-- Use contacts user info to resolve basic profile data for directory lookups.`,
+- Use contacts user info to resolve basic profile data for directory lookups.
+- Departments form the org tree; users export dumps members with their department path and leader.`,
 	}
 	cmd.AddCommand(newContactsUserCmd(state))
+	cmd.AddCommand(newContactsDepartmentsCmd(state))
 	return cmd
 }
 
 func newContactsUserCmd(state *appState) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "user",
-		Short: "Manage contact user",
+		Use:     "user",
+		Aliases: []string{"users"},
+		Short:   "Manage contact user",
 	}
 	cmd.AddCommand(newUserInfoCmd(state))
+	cmd.AddCommand(newContactsUsersExportCmd(state))
 	return cmd
 }
@@ -0,0 +1,336 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/tree"
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+const maxDepartmentPageSize = 50
+
+func newContactsDepartmentsCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "departments",
+		Aliases: []string{"department", "dept"},
+		Short:   "Browse the department tree",
+		Long: `Departments form the organization tree.
+
+- The root department ID is 0.
+- IDs are open_department_id unless --department-id-type department_id is set.
+- list walks every descendant; children stops at the first level.`,
+		Example: `  lark contacts departments tree --depth 2
+  lark contacts departments children 0
+  lark contacts departments get od-xxx`,
+	}
+	cmd.AddCommand(newContactsDepartmentsListCmd(state))
+	cmd.AddCommand(newContactsDepartmentsGetCmd(state))
+	cmd.AddCommand(newContactsDepartmentsTreeCmd(state))
+	cmd.AddCommand(newContactsDepartmentsChildrenCmd(state))
+	return cmd
+}
+
+func departmentIDArgs(cmd *cobra.Command, args []string) error {
+	if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+		return argsUsageError(cmd, err)
+	}
+	if strings.TrimSpace(args[0]) == "" {
+		return argsUsageError(cmd, errors.New("department-id is required"))
+	}
+	return nil
+}
+
+func validateDepartmentIDType(cmd *cobra.Command, idType string) error {
+	switch idType {
+	case "", "open_department_id", "department_id":
+		return nil
+	}
+	return flagUsage(cmd, "department-id-type must be open_department_id or department_id")
+}
+
+func newContactsDepartmentsListCmd(state *appState) *cobra.Command {
+	var parentID string
+	var idType string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List every department under a parent",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateDepartmentIDType(cmd, idType); err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, err := tokenFor(cmd.Context(), state, tokenTypesTenantOrUser)
+			if err != nil {
+				return err
+			}
+			parentID = strings.TrimSpace(parentID)
+			departments, err := collectDepartments(cmd.Context(), state, token, parentID, idType, 0)
+			if err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			payload := map[string]any{"parent_department_id": parentID, "departments": departments}
+			return state.Printer.Print(payload, formatDepartmentTable(departments))
+		},
+	}
+
+	cmd.Flags().StringVar(&parentID, "parent-id", larksdk.RootDepartmentID, "parent department ID")
+	cmd.Flags().StringVar(&idType, "department-id-type", "", "department ID type (open_department_id or department_id)")
+	return cmd
+}
+
+func newContactsDepartmentsChildrenCmd(state *appState) *cobra.Command {
+	var idType string
+
+	cmd := &cobra.Command{
+		Use:   "children <department-id>",
+		Short: "List the direct child departments",
+		Args:  departmentIDArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateDepartmentIDType(cmd, idType); err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, err := tokenFor(cmd.Context(), state, tokenTypesTenantOrUser)
+			if err != nil {
+				return err
+			}
+			parentID := strings.TrimSpace(args[0])
+			departments, err := collectDepartments(cmd.Context(), state, token, parentID, idType, 1)
+			if err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			payload := map[string]any{"parent_department_id": parentID, "departments": departments}
+			return state.Printer.Print(payload, formatDepartmentTable(departments))
+		},
+	}
+
+	cmd.Flags().StringVar(&idType, "department-id-type", "", "department ID type (open_department_id or department_id)")
+	return cmd
+}
+
+func newContactsDepartmentsGetCmd(state *appState) *cobra.Command {
+	var idType string
+
+	cmd := &cobra.Command{
+		Use:   "get <department-id>",
+		Short: "Show a department",
+		Args:  departmentIDArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateDepartmentIDType(cmd, idType); err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, err := tokenFor(cmd.Context(), state, tokenTypesTenantOrUser)
+			if err != nil {
+				return err
+			}
+			department, err := state.SDK.GetDepartment(cmd.Context(), token, larksdk.GetDepartmentRequest{
+				DepartmentID:     strings.TrimSpace(args[0]),
+				DepartmentIDType: idType,
+			})
+			if err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			payload := map[string]any{"department": department}
+			return state.Printer.Print(payload, tableTextRow(departmentTableHeaders, departmentTableRow(department)))
+		},
+	}
+
+	cmd.Flags().StringVar(&idType, "department-id-type", "", "department ID type (open_department_id or department_id)")
+	return cmd
+}
+
+// departmentNode is the JSON shape of the tree command.
+type departmentNode struct {
+	larksdk.Department
+	Children []*departmentNode `json:"children,omitempty"`
+}
+
+func newContactsDepartmentsTreeCmd(state *appState) *cobra.Command {
+	var depth int
+	var idType string
+
+	cmd := &cobra.Command{
+		Use:   "tree [department-id]",
+		Short: "Render the department tree",
+		Example: `  lark contacts departments tree
+  lark contacts departments tree od-xxx --depth 1`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.MaximumNArgs(1)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if depth < 0 {
+				return flagUsage(cmd, "depth must be 0 or greater")
+			}
+			if err := validateDepartmentIDType(cmd, idType); err != nil {
+				return err
+			}
+			rootID := larksdk.RootDepartmentID
+			if len(args) > 0 && strings.TrimSpace(args[0]) != "" {
+				rootID = strings.TrimSpace(args[0])
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, err := tokenFor(cmd.Context(), state, tokenTypesTenantOrUser)
+			if err != nil {
+				return err
+			}
+			root := &departmentNode{Department: larksdk.Department{ID: rootID, Name: "(root)"}}
+			if rootID != larksdk.RootDepartmentID {
+				department, err := state.SDK.GetDepartment(cmd.Context(), token, larksdk.GetDepartmentRequest{DepartmentID: rootID, DepartmentIDType: idType})
+				if err != nil {
+					return withUserScopeHintForCommand(state, err)
+				}
+				root.Department = department
+			}
+			departments, err := collectDepartments(cmd.Context(), state, token, rootID, idType, depth)
+			if err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			buildDepartmentTree(root, rootID, departments, idType)
+
+			payload := map[string]any{"tree": root}
+			return state.Printer.Print(payload, renderDepartmentTree(root).String())
+		},
+	}
+
+	cmd.Flags().IntVar(&depth, "depth", 0, "levels below the root to show (0 for all)")
+	cmd.Flags().StringVar(&idType, "department-id-type", "", "department ID type (open_department_id or department_id)")
+	return cmd
+}
+
+// collectDepartments returns the descendants of rootID down to depth levels
+// (0 for all). Unlimited walks use a single recursive listing; bounded walks
+// fetch one level at a time.
+func collectDepartments(ctx context.Context, state *appState, token, rootID, idType string, depth int) ([]larksdk.Department, error) {
+	if rootID == "" {
+		rootID = larksdk.RootDepartmentID
+	}
+	if depth == 0 {
+		return listDepartmentChildren(ctx, state, token, rootID, idType, true)
+	}
+	departments := []larksdk.Department{}
+	level := []string{rootID}
+	for current := 1; current <= depth && len(level) > 0; current++ {
+		next := []string{}
+		for _, parentID := range level {
+			children, err := listDepartmentChildren(ctx, state, token, parentID, idType, false)
+			if err != nil {
+				return nil, err
+			}
+			for _, child := range children {
+				departments = append(departments, child)
+				next = append(next, departmentKey(child, idType))
+			}
+		}
+		level = next
+	}
+	return departments, nil
+}
+
+func listDepartmentChildren(ctx context.Context, state *appState, token, parentID, idType string, recursive bool) ([]larksdk.Department, error) {
+	departments := []larksdk.Department{}
+	pageToken := ""
+	for {
+		result, err := state.SDK.ListDepartmentChildren(ctx, token, larksdk.ListDepartmentChildrenRequest{
+			DepartmentID:     parentID,
+			FetchChild:       recursive,
+			PageSize:         maxDepartmentPageSize,
+			PageToken:        pageToken,
+			DepartmentIDType: idType,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("department %s: %w", parentID, err)
+		}
+		departments = append(departments, result.Items...)
+		if !result.HasMore || result.PageToken == "" {
+			break
+		}
+		pageToken = result.PageToken
+	}
+	return departments, nil
+}
+
+// departmentKey is the ID the API uses for parent references under idType.
+func departmentKey(department larksdk.Department, idType string) string {
+	if idType == "department_id" && department.DepartmentID != "" {
+		return department.DepartmentID
+	}
+	return department.ID
+}
+
+func buildDepartmentTree(root *departmentNode, rootID string, departments []larksdk.Department, idType string) {
+	nodes := map[string]*departmentNode{rootID: root}
+	for _, department := range departments {
+		nodes[departmentKey(department, idType)] = &departmentNode{Department: department}
+	}
+	for _, department := range departments {
+		parent, ok := nodes[department.ParentID]
+		if !ok {
+			parent = root
+		}
+		parent.Children = append(parent.Children, nodes[departmentKey(department, idType)])
+	}
+}
+
+func renderDepartmentTree(node *departmentNode) *tree.Tree {
+	t := tree.Root(departmentLabel(node.Department))
+	for _, child := range node.Children {
+		if len(child.Children) == 0 {
+			t.Child(departmentLabel(child.Department))
+			continue
+		}
+		t.Child(renderDepartmentTree(child))
+	}
+	return t
+}
+
+func departmentLabel(department larksdk.Department) string {
+	name := department.Name
+	if name == "" {
+		name = department.ID
+	}
+	if department.ID == "" || department.ID == larksdk.RootDepartmentID {
+		return name
+	}
+	return fmt.Sprintf("%s (%s, %d members)", name, department.ID, department.MemberCount)
+}
+
+var departmentTableHeaders = []string{"open_department_id", "department_id", "name", "parent_department_id", "members", "leader_user_id"}
+
+func departmentTableRow(department larksdk.Department) []string {
+	return []string{
+		department.ID,
+		department.DepartmentID,
+		department.Name,
+		department.ParentID,
+		strconv.Itoa(department.MemberCount),
+		department.LeaderUserID,
+	}
+}
+
+func formatDepartmentTable(departments []larksdk.Department) string {
+	rows := make([][]string, 0, len(departments))
+	for _, department := range departments {
+		rows = append(rows, departmentTableRow(department))
+	}
+	return tableTextFromRows(departmentTableHeaders, rows, "no departments found")
+}
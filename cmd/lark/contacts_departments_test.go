@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func contactsDirectoryHandler(t *testing.T) http.HandlerFunc {
+	departments := []map[string]any{
+		{"open_department_id": "od-eng", "name": "Engineering", "parent_department_id": "0", "member_count": 2, "leader_user_id": "ou_ann"},
+		{"open_department_id": "od-plat", "name": "Platform", "parent_department_id": "od-eng", "member_count": 1},
+		{"open_department_id": "od-sales", "name": "Sales", "parent_department_id": "0", "member_count": 1},
+	}
+	members := map[string][]map[string]any{
+		"0":        {},
+		"od-eng":   {{"open_id": "ou_ann", "name": "Ann", "email": "ann@example.com", "employee_no": "E001"}},
+		"od-plat":  {{"open_id": "ou_bo", "name": "Bo", "enterprise_email": "bo@example.com", "employee_no": "E002", "leader_user_id": "ou_ann"}},
+		"od-sales": {{"open_id": "ou_bo", "name": "Bo", "employee_no": "E002", "leader_user_id": "ou_ann"}, {"open_id": "ou_cy", "name": "Cy", "leader_user_id": "ou_ext"}, {"open_id": "ou_di", "name": "Di", "leader_user_id": "ou_gone"}},
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/open-apis/contact/v3/departments/") && strings.HasSuffix(r.URL.Path, "/children"):
+			parent := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/open-apis/contact/v3/departments/"), "/children")
+			parents := map[any]any{}
+			for _, department := range departments {
+				parents[department["open_department_id"]] = department["parent_department_id"]
+			}
+			items := []map[string]any{}
+			for _, department := range departments {
+				for id := department["parent_department_id"]; id != nil; id = parents[id] {
+					if id == parent {
+						items = append(items, department)
+						break
+					}
+					if r.URL.Query().Get("fetch_child") != "true" {
+						break
+					}
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"items": items, "has_more": false}})
+		case r.URL.Path == "/open-apis/contact/v3/departments/od-eng":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"department": departments[0]}})
+		case r.URL.Path == "/open-apis/contact/v3/users/ou_ext":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"user": map[string]any{"open_id": "ou_ext", "name": "Eve"}}})
+		case r.URL.Path == "/open-apis/contact/v3/users/ou_gone":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 41050, "msg": "no user authority"})
+		case r.URL.Path == "/open-apis/contact/v3/users/find_by_department":
+			if r.URL.Query().Get("user_id_type") != "open_id" {
+				t.Fatalf("unexpected user_id_type: %s", r.URL.RawQuery)
+			}
+			items, ok := members[r.URL.Query().Get("department_id")]
+			if !ok {
+				t.Fatalf("unexpected department: %s", r.URL.RawQuery)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"items": items, "has_more": false}})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.String())
+		}
+	}
+}
+
+func TestContactsDepartmentsTreeCommand(t *testing.T) {
+	var buf bytes.Buffer
+	state := newTestState(t, contactsDirectoryHandler(t), nil, &buf)
+	cmd := newContactsCmd(state)
+	cmd.SetArgs([]string{"departments", "tree"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("contacts departments tree error: %v", err)
+	}
+	want := "(root)\n├── Engineering (od-eng, 2 members)\n│   └── Platform (od-plat, 1 members)\n└── Sales (od-sales, 1 members)\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected tree:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	cmd = newContactsCmd(state)
+	cmd.SetArgs([]string{"departments", "tree", "od-eng", "--depth", "1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("contacts departments tree --depth error: %v", err)
+	}
+	if buf.String() != "Engineering (od-eng, 2 members)\n└── Platform (od-plat, 1 members)\n" {
+		t.Fatalf("unexpected subtree:\n%s", buf.String())
+	}
+}
+
+func TestContactsDepartmentsChildrenCommand(t *testing.T) {
+	var buf bytes.Buffer
+	state := newTestState(t, contactsDirectoryHandler(t), nil, &buf)
+	cmd := newContactsCmd(state)
+	cmd.SetArgs([]string{"departments", "children", "0"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("contacts departments children error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "od-eng\t\tEngineering\t0\t2\tou_ann") || !strings.Contains(out, "od-sales") || strings.Contains(out, "od-plat") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestContactsUsersExportRecursiveCSV(t *testing.T) {
+	var buf bytes.Buffer
+	state := newTestState(t, contactsDirectoryHandler(t), nil, &buf)
+	outPath := filepath.Join(t.TempDir(), "org.csv")
+	cmd := newContactsCmd(state)
+	cmd.SetArgs([]string{"users", "export", "--department-id", "0", "--recursive", "--format", "csv", "--out", outPath})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("contacts users export error: %v", err)
+	}
+	file, err := os.Open(outPath)
+	if err != nil {
+		t.Fatalf("open csv: %v", err)
+	}
+	defer file.Close()
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	want := [][]string{
+		{"name", "email", "employee_id", "open_id", "job_title", "department_path", "leader", "leader_open_id"},
+		{"Ann", "ann@example.com", "E001", "ou_ann", "", "Engineering", "", ""},
+		{"Bo", "bo@example.com", "E002", "ou_bo", "", "Engineering / Platform; Sales", "Ann", "ou_ann"},
+		{"Cy", "", "", "ou_cy", "", "Sales", "Eve", "ou_ext"},
+		{"Di", "", "", "ou_di", "", "Sales", "ou_gone", "ou_gone"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("unexpected csv: %#v", records)
+	}
+	for i := range want {
+		if strings.Join(records[i], ",") != strings.Join(want[i], ",") {
+			t.Fatalf("row %d: got %#v, want %#v", i, records[i], want[i])
+		}
+	}
+	if !strings.Contains(buf.String(), "exported 4 user(s) from 4 department(s) to "+outPath) {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestContactsUsersExportSubtreeJSON(t *testing.T) {
+	var buf bytes.Buffer
+	state := newTestState(t, contactsDirectoryHandler(t), nil, &buf)
+	cmd := newContactsCmd(state)
+	cmd.SetArgs([]string{"users", "export", "--department-id", "od-eng", "--recursive", "--format", "json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("contacts users export error: %v", err)
+	}
+	var export struct {
+		Department struct {
+			Name string `json:"name"`
+		} `json:"department"`
+		Recursive bool               `json:"recursive"`
+		Users     []contactExportRow `json:"users"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &export); err != nil {
+		t.Fatalf("decode export %q: %v", buf.String(), err)
+	}
+	if export.Department.Name != "Engineering" || !export.Recursive || len(export.Users) != 2 {
+		t.Fatalf("unexpected export: %#v", export)
+	}
+	bo := export.Users[1]
+	if bo.Name != "Bo" || strings.Join(bo.DepartmentPath, ";") != "Engineering / Platform" {
+		t.Fatalf("expected path below the export root, got %#v", bo)
+	}
+	if bo.Leader != "Ann" || bo.LeaderOpenID != "ou_ann" {
+		t.Fatalf("expected leader resolved from the export, got %#v", bo)
+	}
+}
@@ -43,7 +43,7 @@ func newDrivePermissionAddCmd(state *appState) *cobra.Command {
 Arguments:
   file-token: Drive file_token (use drive list/search to find it).
   member-type: collaborator identifier type (openid, userid, email, openchat, opendepartmentid).
-  member-id: identifier value for the chosen member-type.
+  member-id: identifier value for the chosen member-type; user member types also accept an email or @name.
 `,
 		Example: `  lark drive permissions add <file-token> openid ou_xxx --type docx --perm view --member-kind user`,
 		Args: func(cmd *cobra.Command, args []string) error {
@@ -94,6 +94,10 @@ Arguments:
 			if err != nil {
 				return err
 			}
+			memberID, err = resolveMemberRef(ctx, state, memberType, memberID)
+			if err != nil {
+				return err
+			}
 
 			needNotificationSet := cmd.Flags().Changed("need-notification")
 			req := larksdk.AddDrivePermissionMemberRequest{
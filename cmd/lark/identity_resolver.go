@@ -0,0 +1,468 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"lark/internal/idcache"
+	"lark/internal/larksdk"
+)
+
+const maxBatchGetUserIDs = 50
+
+var mobilePattern = regexp.MustCompile(`^\+?[0-9][0-9 -]{5,}$`)
+
+// errIdentityNotFound reports an email or mobile that matches no user.
+var errIdentityNotFound = errors.New("not found")
+
+// lookupDeniedCodes are API errors for lookups the caller may not perform:
+// missing app or user scopes, or a user or department outside the app's
+// visibility.
+var lookupDeniedCodes = map[int]bool{
+	40004:    true,
+	41050:    true,
+	99991672: true,
+	99991679: true,
+}
+
+// isLookupDenied reports whether err means the lookup was refused rather than
+// that the user does not exist.
+func isLookupDenied(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return lookupDeniedCodes[extractErrorCode(msg)] || shouldSuggestScopes(msg)
+}
+
+// detectIdentityField guesses which ID field a raw value is.
+func detectIdentityField(value string) string {
+	value = strings.TrimSpace(value)
+	switch {
+	case strings.HasPrefix(value, "ou_"):
+		return idcache.FieldOpenID
+	case strings.HasPrefix(value, "on_"):
+		return idcache.FieldUnionID
+	case strings.HasPrefix(value, "oc_"):
+		return idcache.FieldChatID
+	case strings.Contains(value, "@"):
+		return idcache.FieldEmail
+	case mobilePattern.MatchString(value):
+		return idcache.FieldMobile
+	}
+	return idcache.FieldUserID
+}
+
+// identityResult is the outcome of resolving one input value.
+type identityResult struct {
+	Input     string `json:"input"`
+	InputType string `json:"input_type"`
+	idcache.Identity
+	Cached bool   `json:"cached,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// identityResolver maps user and chat IDs between forms, backed by the
+// on-disk ID cache so repeated lookups skip the API.
+type identityResolver struct {
+	state     *appState
+	token     string
+	tokenType larksdk.AccessTokenType
+	cache     *idcache.Cache
+	ttl       time.Duration
+	refresh   bool
+	now       func() time.Time
+}
+
+// newIdentityResolver looks users up with token, a tenant or user token as
+// given by tokenType. Resolvers that only read the cache pass empty values.
+func newIdentityResolver(state *appState, token string, tokenType tokenType) *identityResolver {
+	appID := ""
+	tenantKey := ""
+	configPath := ""
+	if state != nil {
+		configPath = state.ConfigPath
+		if state.Config != nil {
+			appID = state.Config.AppID
+			if state.Config.IsMarketplace() {
+				tenantKey = state.TenantKey
+			}
+		}
+	}
+	cache, err := idcache.Load(configPath, appID, tenantKey)
+	if err != nil {
+		cache = &idcache.Cache{AppID: appID, TenantKey: tenantKey}
+	}
+	return &identityResolver{state: state, token: token, tokenType: larksdk.AccessTokenType(tokenType), cache: cache, ttl: idcache.DefaultTTL, now: time.Now}
+}
+
+// save persists new lookups; failures only cost a future API call.
+func (r *identityResolver) save() {
+	if r == nil || !r.cache.Dirty() || r.state == nil {
+		return
+	}
+	_ = idcache.Save(r.state.ConfigPath, r.cache)
+}
+
+func (r *identityResolver) cached(field, value string) (idcache.Identity, bool) {
+	if r.refresh {
+		return idcache.Identity{}, false
+	}
+	return r.cache.Lookup(field, value, r.ttl, r.now())
+}
+
+// cachedName returns a name from the cache without calling the API.
+func (r *identityResolver) cachedName(field, value string) string {
+	identity, ok := r.cached(field, value)
+	if !ok {
+		return ""
+	}
+	return identity.Name
+}
+
+// resolve looks up every value, batching email and mobile lookups.
+func (r *identityResolver) resolve(ctx context.Context, values []string) []identityResult {
+	results := make([]identityResult, len(values))
+	pendingEmails := map[string][]int{}
+	pendingMobiles := map[string][]int{}
+	for i, value := range values {
+		value = strings.TrimSpace(value)
+		field := detectIdentityField(value)
+		results[i] = identityResult{Input: value, InputType: field}
+		if identity, ok := r.cached(field, value); ok {
+			results[i].Identity = identity
+			results[i].Cached = true
+			continue
+		}
+		switch field {
+		case idcache.FieldEmail:
+			pendingEmails[strings.ToLower(value)] = append(pendingEmails[strings.ToLower(value)], i)
+		case idcache.FieldMobile:
+			pendingMobiles[value] = append(pendingMobiles[value], i)
+		case idcache.FieldChatID:
+			results[i].Identity, results[i].Error = r.lookupChat(ctx, value)
+		default:
+			results[i].Identity, results[i].Error = r.lookupUser(ctx, value, field)
+		}
+	}
+
+	r.resolveContacts(ctx, results, pendingEmails, idcache.FieldEmail)
+	r.resolveContacts(ctx, results, pendingMobiles, idcache.FieldMobile)
+	return results
+}
+
+// resolveContacts maps emails or mobiles to open_ids in batches, then loads
+// each user's profile.
+func (r *identityResolver) resolveContacts(ctx context.Context, results []identityResult, pending map[string][]int, field string) {
+	if len(pending) == 0 {
+		return
+	}
+	values := make([]string, 0, len(pending))
+	for value := range pending {
+		values = append(values, value)
+	}
+	for start := 0; start < len(values); start += maxBatchGetUserIDs {
+		end := min(start+maxBatchGetUserIDs, len(values))
+		req := larksdk.BatchGetUserIDRequest{UserIDType: "open_id"}
+		if field == idcache.FieldEmail {
+			req.Emails = values[start:end]
+		} else {
+			req.Mobiles = values[start:end]
+		}
+		users, err := r.batchGetUserIDs(ctx, req)
+		if err != nil {
+			for _, value := range values[start:end] {
+				for _, i := range pending[value] {
+					results[i].Error = err.Error()
+				}
+			}
+			continue
+		}
+		found := map[string]string{}
+		for _, user := range users {
+			if user.UserID == "" {
+				continue
+			}
+			if field == idcache.FieldEmail {
+				found[strings.ToLower(user.Email)] = user.UserID
+			} else {
+				found[user.Mobile] = user.UserID
+			}
+		}
+		for _, value := range values[start:end] {
+			openID, ok := found[value]
+			if !ok {
+				for _, i := range pending[value] {
+					results[i].Error = errIdentityNotFound.Error()
+				}
+				continue
+			}
+			identity, errText := r.lookupUser(ctx, openID, idcache.FieldOpenID)
+			if errText != "" {
+				// The profile lookup needs extra scopes; the mapping alone is
+				// still useful.
+				identity = idcache.Identity{Kind: "user", OpenID: openID, ResolvedAtUnix: r.now().Unix()}
+			}
+			if field == idcache.FieldEmail && identity.Email == "" {
+				identity.Email = value
+			}
+			if field == idcache.FieldMobile && identity.Mobile == "" {
+				identity.Mobile = value
+			}
+			r.cache.Put(identity)
+			for _, i := range pending[value] {
+				results[i].Identity = identity
+			}
+		}
+	}
+}
+
+// batchGetUserIDs maps emails or mobiles to user IDs. The endpoint only
+// accepts a tenant token, so one is fetched when lookups run as a user.
+func (r *identityResolver) batchGetUserIDs(ctx context.Context, req larksdk.BatchGetUserIDRequest) ([]larksdk.User, error) {
+	tenantToken := r.token
+	if r.tokenType != larksdk.AccessTokenTenant {
+		token, err := ensureTenantToken(ctx, r.state)
+		if err != nil {
+			return nil, err
+		}
+		tenantToken = token
+	}
+	return r.state.SDK.BatchGetUserIDs(ctx, tenantToken, req)
+}
+
+func (r *identityResolver) lookupUser(ctx context.Context, id, field string) (idcache.Identity, string) {
+	user, err := r.state.SDK.GetContactUser(ctx, r.token, r.tokenType, larksdk.GetContactUserRequest{UserID: id, UserIDType: field})
+	if err != nil {
+		return idcache.Identity{}, err.Error()
+	}
+	identity := idcache.Identity{
+		Kind:           "user",
+		OpenID:         user.OpenID,
+		UnionID:        user.UnionID,
+		UserID:         user.UserID,
+		Email:          user.Email,
+		Mobile:         user.Mobile,
+		Name:           user.Name,
+		ResolvedAtUnix: r.now().Unix(),
+	}
+	if identity.Email == "" {
+		identity.Email = user.EnterpriseEmail
+	}
+	switch field {
+	case idcache.FieldOpenID:
+		identity.OpenID = id
+	case idcache.FieldUnionID:
+		identity.UnionID = id
+	case idcache.FieldUserID:
+		identity.UserID = id
+	}
+	r.cache.Put(identity)
+	return identity, ""
+}
+
+func (r *identityResolver) lookupChat(ctx context.Context, chatID string) (idcache.Identity, string) {
+	chat, err := r.state.SDK.GetChatInfo(ctx, r.token, larksdk.GetChatRequest{ChatID: chatID})
+	if err != nil {
+		return idcache.Identity{}, err.Error()
+	}
+	identity := idcache.Identity{Kind: "chat", ChatID: chatID, Name: chat.Name, ResolvedAtUnix: r.now().Unix()}
+	r.cache.Put(identity)
+	return identity, ""
+}
+
+// userName returns the display name of a user, from the cache or the API.
+func (r *identityResolver) userName(ctx context.Context, id, field string) string {
+	if name := r.cachedName(field, id); name != "" {
+		return name
+	}
+	identity, errText := r.lookupUser(ctx, id, field)
+	if errText != "" {
+		return ""
+	}
+	return strings.TrimSpace(identity.Name)
+}
+
+func formatIdentityValue(result identityResult, field string) string {
+	if result.Error != "" {
+		return fmt.Sprintf("error: %s", result.Error)
+	}
+	return result.Value(field)
+}
+
+const maxUserRefMatches = 10
+
+// userRefNeedsLookup reports whether ref must be resolved to become an ID of
+// idType. Values that are not an email, @name, or an ID of another form are
+// passed through as-is.
+func userRefNeedsLookup(ref, idType string) bool {
+	switch {
+	case strings.HasPrefix(ref, "@"):
+		return true
+	case strings.Contains(ref, "@"):
+		return idType != idcache.FieldEmail
+	case strings.HasPrefix(ref, "ou_"):
+		return idType != idcache.FieldOpenID
+	case strings.HasPrefix(ref, "on_"):
+		return idType != idcache.FieldUnionID
+	}
+	return false
+}
+
+// resolveUserRefs converts user references to IDs of idType (open_id,
+// union_id, user_id or email). A reference is an ID, an email, or "@name",
+// which runs a user search and fails when several people match.
+func resolveUserRefs(ctx context.Context, state *appState, refs []string, idType string) ([]string, error) {
+	idType = strings.TrimSpace(idType)
+	if idType == "" {
+		idType = idcache.FieldOpenID
+	}
+	resolved := make([]string, 0, len(refs))
+	var resolver *identityResolver
+	for _, ref := range refs {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			continue
+		}
+		if !userRefNeedsLookup(ref, idType) {
+			resolved = append(resolved, ref)
+			continue
+		}
+		if resolver == nil {
+			if _, err := requireSDK(state); err != nil {
+				return nil, err
+			}
+			token, tokenType, err := resolveAccessToken(ctx, state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return nil, err
+			}
+			resolver = newIdentityResolver(state, token, tokenType)
+			defer resolver.save()
+		}
+		identity, err := resolver.resolveUserRef(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		value := identity.Value(idType)
+		if value == "" && identity.OpenID != "" {
+			// Search results and email lookups may lack some ID forms.
+			if full, errText := resolver.lookupUser(ctx, identity.OpenID, idcache.FieldOpenID); errText == "" {
+				value = full.Value(idType)
+			}
+		}
+		if value == "" {
+			return nil, fmt.Errorf("%s: no %s available for this user", ref, idType)
+		}
+		resolved = append(resolved, value)
+	}
+	return resolved, nil
+}
+
+// resolveUserRef resolves one email, @name, or ID to a user identity.
+func (r *identityResolver) resolveUserRef(ctx context.Context, ref string) (idcache.Identity, error) {
+	if strings.HasPrefix(ref, "@") {
+		return r.searchUser(ctx, strings.TrimPrefix(ref, "@"))
+	}
+	result := r.resolve(ctx, []string{ref})[0]
+	if result.Error == errIdentityNotFound.Error() {
+		return idcache.Identity{}, fmt.Errorf("%s: %w", ref, errIdentityNotFound)
+	}
+	if result.Error != "" {
+		return idcache.Identity{}, fmt.Errorf("%s: %s", ref, result.Error)
+	}
+	return result.Identity, nil
+}
+
+// searchUser finds the single user matching query. An exact (case-insensitive)
+// name match wins over partial matches.
+func (r *identityResolver) searchUser(ctx context.Context, query string) (idcache.Identity, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return idcache.Identity{}, errors.New("@ must be followed by a name")
+	}
+	// User search only accepts a user token, even when lookups run as the
+	// tenant.
+	userToken := r.token
+	if r.tokenType != larksdk.AccessTokenUser {
+		token, err := ensureUserToken(ctx, r.state)
+		if err != nil {
+			return idcache.Identity{}, fmt.Errorf("@%s: searching users by name needs a user login: %w", query, err)
+		}
+		userToken = token
+	}
+	result, err := r.state.SDK.SearchUsers(ctx, userToken, larksdk.SearchUsersRequest{Query: query, PageSize: maxUserRefMatches})
+	if err != nil {
+		return idcache.Identity{}, withUserScopeHintForCommand(r.state, fmt.Errorf("@%s: %w", query, err))
+	}
+	matches := result.Users
+	exact := []larksdk.User{}
+	for _, user := range matches {
+		if strings.EqualFold(strings.TrimSpace(user.Name), query) {
+			exact = append(exact, user)
+		}
+	}
+	if len(exact) > 0 {
+		matches = exact
+	}
+	switch len(matches) {
+	case 0:
+		return idcache.Identity{}, fmt.Errorf("@%s: no user matches", query)
+	case 1:
+		user := matches[0]
+		email := user.Email
+		if email == "" {
+			email = user.EnterpriseEmail
+		}
+		identity := idcache.Identity{
+			Kind:           "user",
+			OpenID:         user.OpenID,
+			UserID:         user.UserID,
+			Email:          email,
+			Name:           user.Name,
+			ResolvedAtUnix: r.now().Unix(),
+		}
+		r.cache.Put(identity)
+		return identity, nil
+	}
+	lines := make([]string, 0, len(matches))
+	for _, user := range matches {
+		lines = append(lines, formatUserSearchLine(user))
+	}
+	more := ""
+	if result.HasMore {
+		more = " (showing the first results)"
+	}
+	return idcache.Identity{}, fmt.Errorf("@%s matches %d users%s; use an email or ID instead:\n%s",
+		query, len(matches), more, tableText([]string{"user_id", "name", "email", "departments"}, lines, ""))
+}
+
+// driveMemberIDFields maps Drive and Wiki member types to the ID form the
+// member_id must have.
+var driveMemberIDFields = map[string]string{
+	"openid":  idcache.FieldOpenID,
+	"userid":  idcache.FieldUserID,
+	"unionid": idcache.FieldUnionID,
+	"email":   idcache.FieldEmail,
+}
+
+// resolveMemberRef converts a Drive/Wiki member ID given as an email, @name,
+// or another ID form into what memberType expects. Non-user member types pass
+// through unchanged.
+func resolveMemberRef(ctx context.Context, state *appState, memberType, memberID string) (string, error) {
+	field, ok := driveMemberIDFields[strings.ToLower(strings.TrimSpace(memberType))]
+	if !ok {
+		return memberID, nil
+	}
+	resolved, err := resolveUserRefs(ctx, state, []string{memberID}, field)
+	if err != nil {
+		return "", err
+	}
+	if len(resolved) == 0 {
+		return memberID, nil
+	}
+	return resolved[0], nil
+}
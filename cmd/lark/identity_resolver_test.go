@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func userRefHandler(t *testing.T, chatBody *map[string]any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/open-apis/search/v1/user":
+			if r.Header.Get("Authorization") != "Bearer user-token" {
+				t.Fatalf("user search must use the user token")
+			}
+			users := []map[string]any{}
+			switch strings.ToLower(r.URL.Query().Get("query")) {
+			case "ada":
+				users = []map[string]any{{"open_id": "ou_ada", "name": "Ada"}, {"open_id": "ou_adam", "name": "Adam"}}
+			case "al":
+				users = []map[string]any{{"open_id": "ou_al1", "name": "Alice", "email": "alice@example.com"}, {"open_id": "ou_al2", "name": "Alan"}}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"users": users}})
+		case "/open-apis/contact/v3/users/batch_get_id":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"user_list": []map[string]any{
+				{"user_id": "ou_bo", "email": "bo@example.com"},
+			}}})
+		case "/open-apis/contact/v3/users/ou_bo":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"user": map[string]any{
+				"open_id": "ou_bo", "user_id": "u_bo", "name": "Bo",
+			}}})
+		case "/open-apis/im/v1/chats":
+			if err := json.NewDecoder(r.Body).Decode(chatBody); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"chat_id": "oc_new", "name": "Launch"}})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func TestResolveUserRefs(t *testing.T) {
+	var buf bytes.Buffer
+	state := newTestState(t, userRefHandler(t, nil), nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	ctx := context.Background()
+
+	got, err := resolveUserRefs(ctx, state, []string{"ou_x", " u_plain ", "@Ada", "bo@example.com"}, "open_id")
+	if err != nil {
+		t.Fatalf("resolveUserRefs error: %v", err)
+	}
+	if strings.Join(got, ",") != "ou_x,u_plain,ou_ada,ou_bo" {
+		t.Fatalf("unexpected open_ids: %v", got)
+	}
+
+	got, err = resolveUserRefs(ctx, state, []string{"bo@example.com"}, "user_id")
+	if err != nil || len(got) != 1 || got[0] != "u_bo" {
+		t.Fatalf("unexpected user_id: %v, %v", got, err)
+	}
+
+	_, err = resolveUserRefs(ctx, state, []string{"@al"}, "open_id")
+	if err == nil || !strings.Contains(err.Error(), "@al matches 2 users") || !strings.Contains(err.Error(), "alice@example.com") || !strings.Contains(err.Error(), "ou_al2") {
+		t.Fatalf("expected disambiguation error, got %v", err)
+	}
+
+	_, err = resolveUserRefs(ctx, state, []string{"@nobody"}, "open_id")
+	if err == nil || !strings.Contains(err.Error(), "@nobody: no user matches") {
+		t.Fatalf("expected no-match error, got %v", err)
+	}
+}
+
+func TestResolveUserRefsFollowsTokenType(t *testing.T) {
+	for _, tc := range []struct {
+		tokenType string
+		auth      string
+	}{
+		{tokenType: "user", auth: "Bearer user-token"},
+		{tokenType: "tenant", auth: "Bearer token"},
+	} {
+		t.Run(tc.tokenType, func(t *testing.T) {
+			lookups := map[string]string{}
+			base := userRefHandler(t, nil)
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasPrefix(r.URL.Path, "/open-apis/contact/v3/users/") {
+					lookups[r.URL.Path] = r.Header.Get("Authorization")
+				}
+				base(w, r)
+			})
+			var buf bytes.Buffer
+			state := newTestState(t, handler, nil, &buf)
+			withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+			state.TokenType = tc.tokenType
+
+			got, err := resolveUserRefs(context.Background(), state, []string{"bo@example.com", "@Ada"}, "open_id")
+			if err != nil {
+				t.Fatalf("resolveUserRefs error: %v", err)
+			}
+			if strings.Join(got, ",") != "ou_bo,ou_ada" {
+				t.Fatalf("unexpected open_ids: %v", got)
+			}
+			// batch_get_id only accepts a tenant token; profiles follow the
+			// requested token type.
+			if lookups["/open-apis/contact/v3/users/batch_get_id"] != "Bearer token" || lookups["/open-apis/contact/v3/users/ou_bo"] != tc.auth {
+				t.Fatalf("unexpected lookup tokens: %v", lookups)
+			}
+		})
+	}
+}
+
+func TestChatsCreateResolvesUserRefs(t *testing.T) {
+	var body map[string]any
+	var buf bytes.Buffer
+	state := newTestState(t, userRefHandler(t, &body), nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	cmd := newChatsCmd(state)
+	cmd.SetArgs([]string{"create", "--name", "Launch", "--owner-id", "@ada", "--user-id", "bo@example.com", "--user-id", "ou_cy"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("chats create error: %v", err)
+	}
+	users, _ := body["user_id_list"].([]any)
+	if body["owner_id"] != "ou_ada" || len(users) != 2 || users[0] != "ou_bo" || users[1] != "ou_cy" {
+		t.Fatalf("unexpected payload: %#v", body)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/idcache"
+)
+
+var idsResolveTargets = []string{"open_id", "union_id", "user_id", "email", "mobile", "name"}
+
+func newIDsCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ids",
+		Short: "Convert between user and chat ID forms",
+		Long: `IDs identify the same user in several forms.
+
+- open_id (ou_...) is app-scoped, union_id (on_...) spans apps, user_id is tenant-scoped.
+- Emails and mobiles are resolved through the directory; chat_id (oc_...) resolves to a chat name.
+- Results are cached next to the config file (id_cache.json) and reused by other commands.`,
+		Example: `  lark ids resolve ada@example.com ou_xxx --to user_id
+  lark ids resolve oc_xxx --to name
+  lark ids cache clear`,
+	}
+	cmd.AddCommand(newIDsResolveCmd(state))
+	cmd.AddCommand(newIDsCacheCmd(state))
+	return cmd
+}
+
+func newIDsResolveCmd(state *appState) *cobra.Command {
+	var to string
+	var ttl time.Duration
+	var refresh bool
+
+	cmd := &cobra.Command{
+		Use:   "resolve <value...>",
+		Short: "Resolve user or chat IDs, emails and mobiles",
+		Long: `Resolve detects each value's type from its form:
+
+- ou_ open_id, on_ union_id, oc_ chat_id
+- anything with @ is an email, +86... or digits a mobile
+- everything else is a user_id`,
+		Example: `  lark ids resolve ada@example.com +15550100 --to open_id
+  lark ids resolve ou_xxx on_xxx --to email --json`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.MinimumNArgs(1)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			for _, arg := range args {
+				if strings.TrimSpace(arg) == "" {
+					return argsUsageError(cmd, errors.New("values must not be empty"))
+				}
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			to = strings.ToLower(strings.TrimSpace(to))
+			if !containsString(idsResolveTargets, to) {
+				return flagUsage(cmd, fmt.Sprintf("to must be one of %s", strings.Join(idsResolveTargets, ", ")))
+			}
+			if ttl < 0 {
+				return flagUsage(cmd, "ttl must not be negative")
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			resolver := newIdentityResolver(state, token, tokenType)
+			resolver.ttl = ttl
+			resolver.refresh = refresh
+			results := resolver.resolve(cmd.Context(), args)
+			resolver.save()
+
+			rows := make([][]string, 0, len(results))
+			failed := 0
+			for _, result := range results {
+				if result.Error != "" {
+					failed++
+				}
+				rows = append(rows, []string{result.Input, result.InputType, formatIdentityValue(result, to)})
+			}
+			payload := map[string]any{"to": to, "results": results}
+			if err := state.Printer.Print(payload, tableTextFromRows([]string{"input", "type", to}, rows, "no values")); err != nil {
+				return err
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d value(s) could not be resolved", failed, len(results))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&to, "to", "open_id", "target form (open_id, union_id, user_id, email, mobile, name)")
+	cmd.Flags().DurationVar(&ttl, "ttl", idcache.DefaultTTL, "reuse cached results younger than this (0 never expires)")
+	cmd.Flags().BoolVar(&refresh, "refresh", false, "ignore cached results and look every value up again")
+	registerEnumCompletion(cmd, "to", idsResolveTargets)
+	return cmd
+}
+
+func newIDsCacheCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the local ID cache",
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "clear",
+		Short: "Remove every cached ID",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resolver := newIdentityResolver(state, "", "")
+			count := len(resolver.cache.Entries)
+			resolver.cache.Clear()
+			if err := idcache.Save(state.ConfigPath, resolver.cache); err != nil {
+				return err
+			}
+			payload := map[string]any{"removed": count, "path": idcache.PathForConfig(state.ConfigPath, resolver.cache.TenantKey)}
+			return state.Printer.Print(payload, fmt.Sprintf("cleared %d cached key(s)", count))
+		},
+	})
+	return cmd
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"lark/internal/larksdk"
+)
+
+func TestIDsResolveUsesCache(t *testing.T) {
+	calls := map[string]int{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/open-apis/contact/v3/users/batch_get_id":
+			if r.URL.Query().Get("user_id_type") != "open_id" {
+				t.Fatalf("unexpected user_id_type: %s", r.URL.RawQuery)
+			}
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			if emails, _ := body["emails"].([]any); len(emails) != 2 {
+				t.Fatalf("expected one batch with both emails, got %#v", body)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"user_list": []map[string]any{
+				{"user_id": "ou_ada", "email": "ada@example.com"},
+				{"email": "ghost@example.com"},
+			}}})
+		case "/open-apis/contact/v3/users/ou_ada":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"user": map[string]any{
+				"open_id": "ou_ada", "user_id": "u_ada", "name": "Ada", "email": "ada@example.com",
+			}}})
+		case "/open-apis/im/v1/chats/oc_team":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"name": "Team"}})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	state.ConfigPath = filepath.Join(t.TempDir(), "config.json")
+	cmd := newIDsCmd(state)
+	cmd.SetArgs([]string{"resolve", "Ada@example.com", "ghost@example.com", "oc_team", "--to", "name"})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "1 of 3 value(s) could not be resolved") {
+		t.Fatalf("expected partial failure, got %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "Ada@example.com\temail\tAda") || !strings.Contains(out, "ghost@example.com\temail\terror: not found") || !strings.Contains(out, "oc_team\tchat_id\tTeam") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	buf.Reset()
+	cmd = newIDsCmd(state)
+	cmd.SetArgs([]string{"resolve", "u_ada", "ada@example.com", "--to", "open_id"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("ids resolve error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "u_ada\tuser_id\tou_ada") || !strings.Contains(buf.String(), "ada@example.com\temail\tou_ada") {
+		t.Fatalf("unexpected cached output: %q", buf.String())
+	}
+	if calls["/open-apis/contact/v3/users/batch_get_id"] != 1 || calls["/open-apis/contact/v3/users/ou_ada"] != 1 {
+		t.Fatalf("expected cached lookups, got calls %#v", calls)
+	}
+
+	tasks := []larksdk.Task{{Members: []larksdk.TaskMember{{ID: "ou_ada", Type: "user", Role: "assignee"}, {ID: "ou_other", Type: "user", Role: "assignee"}}}}
+	nameTaskMembers(state, tasks)
+	if got := taskAssignees(tasks[0].Members); got != "Ada,ou_other" {
+		t.Fatalf("unexpected assignees: %q", got)
+	}
+}
+
+func TestIDsResolveWithUserToken(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/open-apis/contact/v3/users/ou_ada" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer user-token" {
+			t.Fatalf("ids resolve must honour --token-type user")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"user": map[string]any{
+			"open_id": "ou_ada", "name": "Ada",
+		}}})
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	state.TokenType = "user"
+	cmd := newIDsCmd(state)
+	cmd.SetArgs([]string{"resolve", "ou_ada", "--to", "name"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("ids resolve error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "ou_ada\topen_id\tAda") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
@@ -23,7 +23,9 @@ func newMailCmd(state *appState) *cobra.Command {
 
 - A mailbox represents a user or public mailbox.
 - Folders (e.g., Inbox) contain messages; folder_id identifies the folder.
-- Messages are identified by message_id; send/list/info operate within a mailbox.`,
+- Messages are identified by message_id; send/list/info operate within a mailbox.
+- reply/reply-all/forward keep the conversation threaded; thread shows it.
+- mark-read/mark-unread/move/delete accept message ids or a --query-* filter.`,
 	}
 	annotateAuthServices(cmd, "mail")
 	cmd.AddCommand(newMailMailboxCmd(state))
@@ -37,6 +39,19 @@ func newMailCmd(state *appState) *cobra.Command {
 	cmd.AddCommand(newMailInfoCmd(state))
 	cmd.AddCommand(newMailGetCmd(state))
 	cmd.AddCommand(newMailSendCmd(state))
+	cmd.AddCommand(newMailAttachmentsCmd(state))
+	cmd.AddCommand(newMailReplyCmd(state))
+	cmd.AddCommand(newMailReplyAllCmd(state))
+	cmd.AddCommand(newMailForwardCmd(state))
+	cmd.AddCommand(newMailThreadCmd(state))
+	cmd.AddCommand(newMailDraftsCmd(state))
+	cmd.AddCommand(newMailMarkReadCmd(state))
+	cmd.AddCommand(newMailMarkUnreadCmd(state))
+	cmd.AddCommand(newMailMoveCmd(state))
+	cmd.AddCommand(newMailDeleteCmd(state))
+	cmd.AddCommand(newMailRulesCmd(state))
+	cmd.AddCommand(newMailAliasesCmd(state))
+	cmd.AddCommand(newMailBackupCmd(state))
 	return cmd
 }
 
@@ -121,11 +136,13 @@ func newMailMailboxSetCmd(state *appState) *cobra.Command {
 
 func newMailPublicMailboxesCmd(state *appState) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "public-mailboxes",
-		Short: "Discover public mailboxes",
+		Use:     "public-mailboxes",
+		Aliases: []string{"public-mailbox"},
+		Short:   "Discover public mailboxes and manage members",
 	}
 	annotateAuthServices(cmd, "mail-public")
 	cmd.AddCommand(newMailPublicMailboxesListCmd(state))
+	cmd.AddCommand(newMailPublicMailboxMembersCmd(state))
 	return cmd
 }
 
@@ -411,10 +428,17 @@ func newMailSendCmd(state *appState) *cobra.Command {
 	var userAccessToken string
 	var raw string
 	var rawFile string
+	var attach []string
+	var inline []string
 
 	cmd := &cobra.Command{
 		Use:   "send",
 		Short: "Send an email message",
+		Long: `Send an email message from a mailbox.
+
+- --attach adds a file attachment; repeat for more files.
+- --inline embeds an image for --html; reference it as <img src="cid:<file name>">.
+- Attachments are base64url-encoded; --raw/--raw-file must carry their own.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			token := strings.TrimSpace(userAccessToken)
 			if token == "" {
@@ -459,6 +483,9 @@ func newMailSendCmd(state *appState) *cobra.Command {
 				if subject != "" || len(to) > 0 || len(cc) > 0 || len(bcc) > 0 || bodyText != "" || bodyHTML != "" {
 					return errors.New("raw is mutually exclusive with subject/to/cc/bcc/text/html")
 				}
+				if len(attach) > 0 || len(inline) > 0 {
+					return errors.New("raw is mutually exclusive with attach/inline")
+				}
 			} else {
 				if subject == "" {
 					return errors.New("subject is required")
@@ -469,6 +496,13 @@ func newMailSendCmd(state *appState) *cobra.Command {
 				if bodyText == "" && bodyHTML == "" {
 					return errors.New("text or html is required")
 				}
+				if len(inline) > 0 && bodyHTML == "" {
+					return errors.New("inline requires html")
+				}
+			}
+			attachments, err := buildMailAttachments(attach, inline)
+			if err != nil {
+				return err
 			}
 
 			toInputs := buildMailAddressInputs(to)
@@ -483,6 +517,7 @@ func newMailSendCmd(state *appState) *cobra.Command {
 				BodyPlainText: bodyText,
 				BodyHTML:      bodyHTML,
 				Raw:           rawValue,
+				Attachments:   attachments,
 			}
 			messageID, err := state.SDK.SendMail(cmd.Context(), token, mailboxID, request)
 			if err != nil {
@@ -503,6 +538,8 @@ func newMailSendCmd(state *appState) *cobra.Command {
 	cmd.Flags().StringVar(&bodyHTML, "html", "", "HTML body")
 	cmd.Flags().StringVar(&raw, "raw", "", "raw EML content (base64url-encoded)")
 	cmd.Flags().StringVar(&rawFile, "raw-file", "", "path to .eml file (will be base64url-encoded; use - for stdin)")
+	cmd.Flags().StringArrayVar(&attach, "attach", nil, "file to attach (repeatable)")
+	cmd.Flags().StringArrayVar(&inline, "inline", nil, "image to embed in --html as cid:<file name> (repeatable)")
 	cmd.Flags().StringVar(&headFrom, "from-name", "", "display name for From header")
 	cmd.Flags().StringVar(&userAccessToken, "user-access-token", "", "user access token (OAuth)")
 	return cmd
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+// mailAliasTarget picks the mailbox whose aliases are managed. Alias APIs are
+// admin APIs (tenant token), so the user mailbox must be an address, not "me".
+type mailAliasTarget struct {
+	mailboxID       string
+	publicMailboxID string
+}
+
+func (t *mailAliasTarget) bindFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&t.mailboxID, "mailbox-id", "", "user mailbox address (defaults to config default_mailbox_id)")
+	cmd.Flags().StringVar(&t.publicMailboxID, "public-mailbox", "", "public mailbox ID or address (instead of --mailbox-id)")
+}
+
+func (t *mailAliasTarget) resolve(cmd *cobra.Command, state *appState) (string, bool, error) {
+	mailboxID := strings.TrimSpace(t.mailboxID)
+	publicMailboxID := strings.TrimSpace(t.publicMailboxID)
+	if mailboxID != "" && publicMailboxID != "" {
+		return "", false, flagUsage(cmd, "mailbox-id and public-mailbox are mutually exclusive")
+	}
+	if publicMailboxID != "" {
+		return publicMailboxID, true, nil
+	}
+	mailboxID = resolveMailboxID(state, mailboxID)
+	if mailboxID == "me" {
+		return "", false, flagUsage(cmd, "aliases need a mailbox address; pass --mailbox-id <address> or --public-mailbox <id>")
+	}
+	return mailboxID, false, nil
+}
+
+func newMailAliasesCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "aliases",
+		Short: "Manage mailbox aliases",
+		Long: `Aliases are extra addresses that deliver to a mailbox.
+
+- Target a user mailbox with --mailbox-id <address> or a public mailbox with --public-mailbox.
+- Alias management uses the tenant token (mail admin permissions).`,
+	}
+	annotateAuthServices(cmd, "mail-admin")
+	cmd.AddCommand(newMailAliasesListCmd(state))
+	cmd.AddCommand(newMailAliasesAddCmd(state))
+	cmd.AddCommand(newMailAliasesRemoveCmd(state))
+	return cmd
+}
+
+func newMailAliasesListCmd(state *appState) *cobra.Command {
+	var target mailAliasTarget
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List mailbox aliases",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mailboxID, public, err := target.resolve(cmd, state)
+			if err != nil {
+				return err
+			}
+			token, err := tokenFor(cmd.Context(), state, tokenTypesTenant)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			var aliases []larksdk.MailAlias
+			if public {
+				aliases, err = state.SDK.ListPublicMailboxAliases(cmd.Context(), token, mailboxID)
+			} else {
+				aliases, err = state.SDK.ListMailboxAliases(cmd.Context(), token, mailboxID)
+			}
+			if err != nil {
+				return err
+			}
+			payload := map[string]any{"mailbox_id": mailboxID, "aliases": aliases}
+			lines := make([]string, 0, len(aliases))
+			for _, alias := range aliases {
+				lines = append(lines, strings.Join([]string{alias.EmailAlias, alias.PrimaryEmail}, "\t"))
+			}
+			text := tableText([]string{"alias", "primary_email"}, lines, "no aliases found")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	target.bindFlags(cmd)
+	return cmd
+}
+
+func newMailAliasesAddCmd(state *appState) *cobra.Command {
+	var target mailAliasTarget
+
+	cmd := &cobra.Command{
+		Use:     "add <alias-address>",
+		Short:   "Add a mailbox alias",
+		Example: "  lark mail aliases add help@example.com --public-mailbox support@example.com",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			if strings.TrimSpace(args[0]) == "" {
+				return errors.New("alias-address is required")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			alias := strings.TrimSpace(args[0])
+			mailboxID, public, err := target.resolve(cmd, state)
+			if err != nil {
+				return err
+			}
+			token, err := tokenFor(cmd.Context(), state, tokenTypesTenant)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			var created larksdk.MailAlias
+			if public {
+				created, err = state.SDK.CreatePublicMailboxAlias(cmd.Context(), token, mailboxID, alias)
+			} else {
+				created, err = state.SDK.CreateMailboxAlias(cmd.Context(), token, mailboxID, alias)
+			}
+			if err != nil {
+				return err
+			}
+			payload := map[string]any{"mailbox_id": mailboxID, "alias": created}
+			return state.Printer.Print(payload, fmt.Sprintf("added alias %s to %s", created.EmailAlias, mailboxID))
+		},
+	}
+
+	target.bindFlags(cmd)
+	return cmd
+}
+
+func newMailAliasesRemoveCmd(state *appState) *cobra.Command {
+	var target mailAliasTarget
+
+	cmd := &cobra.Command{
+		Use:   "remove <alias-address>",
+		Short: "Remove a mailbox alias",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			if strings.TrimSpace(args[0]) == "" {
+				return errors.New("alias-address is required")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			alias := strings.TrimSpace(args[0])
+			mailboxID, public, err := target.resolve(cmd, state)
+			if err != nil {
+				return err
+			}
+			if err := confirmDestructive(cmd, state, fmt.Sprintf("remove alias %s from %s", alias, mailboxID)); err != nil {
+				return err
+			}
+			token, err := tokenFor(cmd.Context(), state, tokenTypesTenant)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			if public {
+				err = state.SDK.DeletePublicMailboxAlias(cmd.Context(), token, mailboxID, alias)
+			} else {
+				err = state.SDK.DeleteMailboxAlias(cmd.Context(), token, mailboxID, alias)
+			}
+			if err != nil {
+				return err
+			}
+			payload := map[string]any{"mailbox_id": mailboxID, "alias": alias, "removed": true}
+			return state.Printer.Print(payload, fmt.Sprintf("removed alias %s from %s", alias, mailboxID))
+		},
+	}
+
+	target.bindFlags(cmd)
+	return cmd
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMailAliasesAddPublicMailbox(t *testing.T) {
+	var body map[string]any
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/open-apis/mail/v1/public_mailboxes/support@example.com/aliases" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Fatalf("unexpected authorization: %s", r.Header.Get("Authorization"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{
+			"public_mailbox_alias": map[string]any{"email_alias": "help@example.com", "primary_email": "support@example.com"},
+		}})
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{"aliases", "add", "help@example.com", "--public-mailbox", "support@example.com"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("aliases add error: %v", err)
+	}
+	if body["email_alias"] != "help@example.com" {
+		t.Fatalf("unexpected payload: %#v", body)
+	}
+	if !strings.Contains(buf.String(), "added alias help@example.com to support@example.com") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestMailAliasesListRequiresMailboxAddress(t *testing.T) {
+	var buf bytes.Buffer
+	state := newTestState(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+	}), nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{"aliases", "list"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "mailbox address") {
+		t.Fatalf("expected mailbox address error, got %v", err)
+	}
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+func newMailAttachmentsCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "attachments",
+		Aliases: []string{"attachment"},
+		Short:   "List and download mail attachments",
+		Long: `Attachments belong to a received message.
+
+- list shows attachment_id, file name, and whether the attachment is an inline image.
+- download saves attachments into --out using their file names; --id picks specific ones.`,
+	}
+	cmd.AddCommand(newMailAttachmentsListCmd(state))
+	cmd.AddCommand(newMailAttachmentsDownloadCmd(state))
+	return cmd
+}
+
+func newMailAttachmentsListCmd(state *appState) *cobra.Command {
+	var mailboxID string
+
+	cmd := &cobra.Command{
+		Use:   "list <message-id>",
+		Short: "List attachments of a mail message",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			if strings.TrimSpace(args[0]) == "" {
+				return errors.New("message-id is required")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mailboxID = resolveMailboxID(state, mailboxID)
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			messageID := strings.TrimSpace(args[0])
+			token, err := tokenFor(cmd.Context(), state, tokenTypesUser)
+			if err != nil {
+				return err
+			}
+			message, err := state.SDK.GetMailMessage(cmd.Context(), token, mailboxID, messageID)
+			if err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			stripMailMessageContent(&message)
+			payload := map[string]any{"message_id": message.MessageID, "attachments": message.Attachments}
+			rows := make([][]string, 0, len(message.Attachments))
+			for _, attachment := range message.Attachments {
+				rows = append(rows, []string{attachment.ID, attachment.Filename, fmt.Sprintf("%t", attachment.IsInline), infoValue(attachment.CID)})
+			}
+			text := tableTextFromRows([]string{"attachment_id", "filename", "inline", "cid"}, rows, "no attachments found")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&mailboxID, "mailbox-id", "", "user mailbox ID (defaults to config default_mailbox_id or 'me')")
+	return cmd
+}
+
+func newMailAttachmentsDownloadCmd(state *appState) *cobra.Command {
+	var mailboxID string
+	var outDir string
+	var ids []string
+
+	cmd := &cobra.Command{
+		Use:   "download <message-id> --out <dir>",
+		Short: "Download attachments of a mail message",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			if strings.TrimSpace(args[0]) == "" {
+				return errors.New("message-id is required")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outDir = strings.TrimSpace(outDir)
+			if outDir == "" {
+				return flagUsage(cmd, "out is required")
+			}
+			mailboxID = resolveMailboxID(state, mailboxID)
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			messageID := strings.TrimSpace(args[0])
+			token, err := tokenFor(cmd.Context(), state, tokenTypesUser)
+			if err != nil {
+				return err
+			}
+			message, err := state.SDK.GetMailMessage(cmd.Context(), token, mailboxID, messageID)
+			if err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			attachments, err := selectMailAttachments(message.Attachments, ids)
+			if err != nil {
+				return err
+			}
+			if len(attachments) == 0 {
+				payload := map[string]any{"message_id": message.MessageID, "files": []any{}}
+				return state.Printer.Print(payload, "no attachments found")
+			}
+			attachmentIDs := make([]string, 0, len(attachments))
+			for _, attachment := range attachments {
+				attachmentIDs = append(attachmentIDs, attachment.ID)
+			}
+			urls, err := state.SDK.GetMailAttachmentDownloadURLs(cmd.Context(), token, mailboxID, message.MessageID, attachmentIDs)
+			if err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			urlByID := make(map[string]string, len(urls.Items))
+			for _, item := range urls.Items {
+				urlByID[item.AttachmentID] = item.DownloadURL
+			}
+			if err := os.MkdirAll(outDir, 0o755); err != nil {
+				return err
+			}
+
+			files := make([]map[string]any, 0, len(attachments))
+			rows := make([][]string, 0, len(attachments))
+			used := map[string]bool{}
+			for _, attachment := range attachments {
+				downloadURL := urlByID[attachment.ID]
+				if downloadURL == "" {
+					return fmt.Errorf("no download url returned for attachment %s", attachment.ID)
+				}
+				outPath := filepath.Join(outDir, uniqueMailAttachmentName(attachment, used))
+				written, err := downloadMailAttachment(cmd, state, downloadURL, outPath)
+				if err != nil {
+					return err
+				}
+				files = append(files, map[string]any{
+					"attachment_id": attachment.ID,
+					"filename":      attachment.Filename,
+					"output_path":   outPath,
+					"bytes_written": written,
+				})
+				rows = append(rows, []string{attachment.ID, outPath, fmt.Sprintf("%d", written)})
+			}
+			payload := map[string]any{"message_id": message.MessageID, "files": files}
+			text := tableTextFromRows([]string{"attachment_id", "output_path", "bytes_written"}, rows, "no attachments found")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&mailboxID, "mailbox-id", "", "user mailbox ID (defaults to config default_mailbox_id or 'me')")
+	cmd.Flags().StringVar(&outDir, "out", "", "output directory (created if missing)")
+	cmd.Flags().StringArrayVar(&ids, "id", nil, "attachment ID to download (repeatable; default: all)")
+	_ = cmd.MarkFlagRequired("out")
+	return cmd
+}
+
+func selectMailAttachments(attachments []larksdk.MailAttachment, ids []string) ([]larksdk.MailAttachment, error) {
+	if len(ids) == 0 {
+		return attachments, nil
+	}
+	selected := make([]larksdk.MailAttachment, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		found := false
+		for _, attachment := range attachments {
+			if attachment.ID == id {
+				selected = append(selected, attachment)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("attachment %s not found in message", id)
+		}
+	}
+	return selected, nil
+}
+
+// uniqueMailAttachmentName keeps two attachments with the same file name from
+// overwriting each other within one download. Names that would leave the
+// output directory fall back to the attachment ID.
+func uniqueMailAttachmentName(attachment larksdk.MailAttachment, used map[string]bool) string {
+	name := filepath.Base(strings.TrimSpace(attachment.Filename))
+	if name == "" || name == "." || name == ".." || name == string(filepath.Separator) {
+		name = sanitizeMailBackupName(attachment.ID)
+	}
+	candidate := name
+	ext := filepath.Ext(name)
+	for i := 2; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s (%d)%s", strings.TrimSuffix(name, ext), i, ext)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+func downloadMailAttachment(cmd *cobra.Command, state *appState, downloadURL, outPath string) (int64, error) {
+	reader, err := state.SDK.DownloadMailAttachment(cmd.Context(), downloadURL)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+	outFile, err := os.Create(outPath)
+	if err != nil {
+		return 0, err
+	}
+	defer outFile.Close()
+	return io.Copy(outFile, reader)
+}
+
+// buildMailAttachments reads files for mail send; inline files get a cid equal
+// to their file name so --html can reference them.
+func buildMailAttachments(attach, inline []string) ([]larksdk.MailAttachment, error) {
+	attachments := make([]larksdk.MailAttachment, 0, len(attach)+len(inline))
+	add := func(path string, isInline bool) error {
+		path = strings.TrimSpace(path)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read attachment: %w", err)
+		}
+		name := filepath.Base(path)
+		attachment := larksdk.MailAttachment{
+			Body:     base64.URLEncoding.EncodeToString(data),
+			Filename: name,
+		}
+		if isInline {
+			attachment.IsInline = true
+			attachment.CID = name
+		}
+		attachments = append(attachments, attachment)
+		return nil
+	}
+	for _, path := range attach {
+		if err := add(path, false); err != nil {
+			return nil, err
+		}
+	}
+	for _, path := range inline {
+		if err := add(path, true); err != nil {
+			return nil, err
+		}
+	}
+	if len(attachments) == 0 {
+		return nil, nil
+	}
+	return attachments, nil
+}
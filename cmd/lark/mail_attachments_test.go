@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"lark/internal/larksdk"
+)
+
+func TestMailSendCommandWithAttachments(t *testing.T) {
+	dir := t.TempDir()
+	reportPath := filepath.Join(dir, "report.pdf")
+	logoPath := filepath.Join(dir, "logo.png")
+	if err := os.WriteFile(reportPath, []byte("pdf-bytes"), 0o644); err != nil {
+		t.Fatalf("write report: %v", err)
+	}
+	if err := os.WriteFile(logoPath, []byte{0xff, 0xfe, 0xfd}, 0o644); err != nil {
+		t.Fatalf("write logo: %v", err)
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/open-apis/mail/v1/user_mailboxes/me/messages/send" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var payload struct {
+			Attachments []map[string]any `json:"attachments"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		if len(payload.Attachments) != 2 {
+			t.Fatalf("unexpected attachments: %#v", payload.Attachments)
+		}
+		report := payload.Attachments[0]
+		if report["filename"] != "report.pdf" || report["body"] != base64.URLEncoding.EncodeToString([]byte("pdf-bytes")) || report["is_inline"] != nil {
+			t.Fatalf("unexpected attachment: %#v", report)
+		}
+		logo := payload.Attachments[1]
+		if logo["filename"] != "logo.png" || logo["body"] != base64.URLEncoding.EncodeToString([]byte{0xff, 0xfe, 0xfd}) || logo["is_inline"] != true || logo["cid"] != "logo.png" {
+			t.Fatalf("unexpected inline attachment: %#v", logo)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"message_id": "msg_1"}})
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{
+		"send",
+		"--subject", "Report",
+		"--to", "a@example.com",
+		"--html", `<p>See attached</p><img src="cid:logo.png">`,
+		"--attach", reportPath,
+		"--inline", logoPath,
+	})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("mail send error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "message_id: msg_1") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestMailSendInlineRequiresHTML(t *testing.T) {
+	var buf bytes.Buffer
+	state := newTestState(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+	}), nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{"send", "--subject", "Hi", "--to", "a@example.com", "--text", "hi", "--inline", "logo.png"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "inline requires html") {
+		t.Fatalf("expected inline error, got %v", err)
+	}
+}
+
+func TestMailAttachmentsDownloadCommand(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/open-apis/mail/v1/user_mailboxes/me/messages/msg_1":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"message": map[string]any{
+						"message_id": "msg_1",
+						"attachments": []map[string]any{
+							{"id": "att_1", "filename": "invoice.pdf"},
+							{"id": "att_2", "filename": "invoice.pdf"},
+						},
+					},
+				},
+			})
+		case "/open-apis/mail/v1/user_mailboxes/me/messages/msg_1/attachments/download_url":
+			if got := r.URL.Query()["attachment_ids"]; len(got) != 2 {
+				t.Fatalf("unexpected attachment ids: %v", got)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"download_urls": []map[string]any{
+						{"attachment_id": "att_1", "download_url": "http://files.test/att_1"},
+						{"attachment_id": "att_2", "download_url": "http://files.test/att_2"},
+					},
+				},
+			})
+		case "/att_1", "/att_2":
+			if r.Header.Get("Authorization") != "" {
+				t.Fatalf("download url should not carry a token")
+			}
+			_, _ = w.Write([]byte("content" + strings.TrimPrefix(r.URL.Path, "/att")))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.String())
+		}
+	})
+
+	outDir := filepath.Join(t.TempDir(), "invoices")
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{"attachments", "download", "msg_1", "--out", outDir})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("attachments download error: %v", err)
+	}
+	first, err := os.ReadFile(filepath.Join(outDir, "invoice.pdf"))
+	if err != nil || string(first) != "content_1" {
+		t.Fatalf("unexpected first file: %q %v", first, err)
+	}
+	second, err := os.ReadFile(filepath.Join(outDir, "invoice (2).pdf"))
+	if err != nil || string(second) != "content_2" {
+		t.Fatalf("unexpected second file: %q %v", second, err)
+	}
+	if !strings.Contains(buf.String(), "att_2") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestUniqueMailAttachmentNameStaysInOutputDir(t *testing.T) {
+	used := map[string]bool{}
+	for _, tc := range []struct {
+		filename string
+		id       string
+		want     string
+	}{
+		{filename: "report.pdf", id: "att_1", want: "report.pdf"},
+		{filename: "../report.pdf", id: "att_2", want: "report (2).pdf"},
+		{filename: "..", id: "att_3", want: "att_3"},
+		{filename: "notes/../..", id: "att_4", want: "att_4"},
+		{filename: ".", id: "att_5", want: "att_5"},
+		{filename: " ", id: "../att_6", want: "_att_6"},
+	} {
+		got := uniqueMailAttachmentName(larksdk.MailAttachment{Filename: tc.filename, ID: tc.id}, used)
+		if got != tc.want {
+			t.Fatalf("uniqueMailAttachmentName(%q, %q) = %q, want %q", tc.filename, tc.id, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,399 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+const (
+	mailBackupStateFile = ".lark-mail-backup.json"
+	// mailBackupSaveEvery bounds how many messages a crash can re-download.
+	mailBackupSaveEvery = 100
+)
+
+// mailBackupState is the incremental index stored next to the archive; a
+// message id present here is never fetched again.
+type mailBackupState struct {
+	MailboxID string                            `json:"mailbox_id"`
+	Format    string                            `json:"format"`
+	UpdatedAt string                            `json:"updated_at,omitempty"`
+	Messages  map[string]mailBackupStateMessage `json:"messages"`
+}
+
+type mailBackupStateMessage struct {
+	FolderID string `json:"folder_id"`
+	Path     string `json:"path"`
+}
+
+type mailBackupFolderResult struct {
+	FolderID string `json:"folder_id"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Saved    int    `json:"saved"`
+	Skipped  int    `json:"skipped"`
+}
+
+func newMailBackupCmd(state *appState) *cobra.Command {
+	var mailboxID string
+	var outDir string
+	var format string
+	var folderIDs []string
+
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Back up a mailbox to a local Maildir or mbox archive",
+		Long: `Backup walks every folder and stores each message as an RFC 822 file.
+
+- --format maildir writes <folder>/cur/<file> per message; mbox appends to <folder>.mbox.
+- Nested folders become nested directories under --out.
+- The run is incremental: ` + mailBackupStateFile + ` in --out records saved message ids.
+- Messages without raw content are rebuilt from their headers and bodies.`,
+		Example: `  lark mail backup --out ~/lark-mail
+  lark mail backup --mailbox-id me --out ~/lark-mail --format mbox`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format = strings.ToLower(strings.TrimSpace(format))
+			if format != "maildir" && format != "mbox" {
+				return flagUsage(cmd, "format must be maildir or mbox")
+			}
+			outDir = strings.TrimSpace(outDir)
+			if outDir == "" {
+				return flagUsage(cmd, "out is required")
+			}
+			mailboxID = resolveMailboxID(state, mailboxID)
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			token, err := tokenFor(ctx, state, tokenTypesUser)
+			if err != nil {
+				return err
+			}
+
+			if err := os.MkdirAll(outDir, 0o700); err != nil {
+				return fmt.Errorf("create output dir: %w", err)
+			}
+			statePath := filepath.Join(outDir, mailBackupStateFile)
+			backup, err := loadMailBackupState(statePath, mailboxID, format)
+			if err != nil {
+				return err
+			}
+
+			folders, err := state.SDK.ListMailFolders(ctx, token, mailboxID)
+			if err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			paths := mailBackupFolderPaths(folders)
+			if len(folderIDs) > 0 {
+				selected := make([]larksdk.MailFolder, 0, len(folderIDs))
+				for _, folderID := range folderIDs {
+					resolved, err := resolveMailFolderAlias(ctx, state, token, mailboxID, folderID)
+					if err != nil {
+						return withUserScopeHintForCommand(state, err)
+					}
+					folder := larksdk.MailFolder{FolderID: resolved, Name: resolved}
+					for _, candidate := range folders {
+						if candidate.FolderID == resolved {
+							folder = candidate
+						}
+					}
+					if _, ok := paths[resolved]; !ok {
+						paths[resolved] = sanitizeMailBackupName(resolved)
+					}
+					selected = append(selected, folder)
+				}
+				folders = selected
+			}
+
+			results := make([]mailBackupFolderResult, 0, len(folders))
+			pending := 0
+			// Messages already written must be in the index even when the run
+			// fails part-way, or the next run fetches them again.
+			defer func() {
+				if pending == 0 {
+					return
+				}
+				if err := saveMailBackupState(statePath, backup); err != nil {
+					fmt.Fprintf(errWriter(state), "warning: failed to save backup state: %v\n", err)
+				}
+			}()
+			for _, folder := range folders {
+				result := mailBackupFolderResult{FolderID: folder.FolderID, Name: folder.Name, Path: paths[folder.FolderID]}
+				pageToken := ""
+				for {
+					page, err := state.SDK.ListMailMessages(ctx, token, larksdk.ListMailMessagesRequest{
+						MailboxID: mailboxID,
+						FolderID:  folder.FolderID,
+						PageSize:  maxMailPageSize,
+						PageToken: pageToken,
+					})
+					if err != nil {
+						return withUserScopeHintForCommand(state, err)
+					}
+					for _, item := range page.Items {
+						if item.MessageID == "" {
+							continue
+						}
+						if _, ok := backup.Messages[item.MessageID]; ok {
+							result.Skipped++
+							continue
+						}
+						message, err := state.SDK.GetMailMessage(ctx, token, mailboxID, item.MessageID)
+						if err != nil {
+							return withUserScopeHintForCommand(state, err)
+						}
+						if message.MessageID == "" {
+							message.MessageID = item.MessageID
+						}
+						eml, err := mailBackupEML(message)
+						if err != nil {
+							return fmt.Errorf("message %s: %w", item.MessageID, err)
+						}
+						relPath, err := writeMailBackupMessage(outDir, result.Path, format, message, eml)
+						if err != nil {
+							return fmt.Errorf("message %s: %w", item.MessageID, err)
+						}
+						backup.Messages[item.MessageID] = mailBackupStateMessage{FolderID: folder.FolderID, Path: relPath}
+						result.Saved++
+						pending++
+						if pending >= mailBackupSaveEvery {
+							if err := saveMailBackupState(statePath, backup); err != nil {
+								return err
+							}
+							pending = 0
+						}
+					}
+					if !page.HasMore || page.PageToken == "" {
+						break
+					}
+					pageToken = page.PageToken
+				}
+				if pending > 0 {
+					if err := saveMailBackupState(statePath, backup); err != nil {
+						return err
+					}
+					pending = 0
+				}
+				results = append(results, result)
+			}
+
+			saved, skipped := 0, 0
+			lines := make([]string, 0, len(results))
+			for _, result := range results {
+				saved += result.Saved
+				skipped += result.Skipped
+				lines = append(lines, strings.Join([]string{result.FolderID, result.Path, fmt.Sprintf("%d", result.Saved), fmt.Sprintf("%d", result.Skipped)}, "\t"))
+			}
+			payload := map[string]any{
+				"mailbox_id": mailboxID,
+				"format":     format,
+				"out":        outDir,
+				"folders":    results,
+				"saved":      saved,
+				"skipped":    skipped,
+			}
+			text := tableText([]string{"folder_id", "path", "saved", "skipped"}, lines, "no folders found")
+			text += fmt.Sprintf("\nsaved %d new message(s), %d already backed up", saved, skipped)
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&mailboxID, "mailbox-id", "", "user mailbox ID (defaults to config default_mailbox_id or 'me')")
+	cmd.Flags().StringVar(&outDir, "out", "", "archive directory")
+	cmd.Flags().StringVar(&format, "format", "maildir", "archive format: maildir or mbox")
+	cmd.Flags().StringArrayVar(&folderIDs, "folder-id", nil, "only back up this folder (repeatable; system aliases: INBOX/SENT/DRAFT/TRASH/SPAM/ARCHIVED)")
+	_ = cmd.MarkFlagRequired("out")
+	return cmd
+}
+
+func loadMailBackupState(path, mailboxID, format string) (*mailBackupState, error) {
+	backup := &mailBackupState{MailboxID: mailboxID, Format: format, Messages: map[string]mailBackupStateMessage{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return backup, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read backup state: %w", err)
+	}
+	if err := json.Unmarshal(data, backup); err != nil {
+		return nil, fmt.Errorf("parse backup state %s: %w", path, err)
+	}
+	if backup.Format != format {
+		return nil, fmt.Errorf("%s holds a %s backup; use --format %s or another --out", path, backup.Format, backup.Format)
+	}
+	if backup.MailboxID != mailboxID {
+		return nil, fmt.Errorf("%s holds a backup of mailbox %s; use another --out", path, backup.MailboxID)
+	}
+	if backup.Messages == nil {
+		backup.Messages = map[string]mailBackupStateMessage{}
+	}
+	return backup, nil
+}
+
+func saveMailBackupState(path string, backup *mailBackupState) error {
+	backup.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	data, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write backup state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("write backup state: %w", err)
+	}
+	return nil
+}
+
+// mailBackupFolderPaths maps folder ids to slash-separated relative paths
+// built from folder names, following parent_folder_id.
+func mailBackupFolderPaths(folders []larksdk.MailFolder) map[string]string {
+	byID := make(map[string]larksdk.MailFolder, len(folders))
+	for _, folder := range folders {
+		byID[folder.FolderID] = folder
+	}
+	paths := make(map[string]string, len(folders))
+	var resolve func(id string, depth int) string
+	resolve = func(id string, depth int) string {
+		if path, ok := paths[id]; ok {
+			return path
+		}
+		folder := byID[id]
+		name := folder.Name
+		if name == "" {
+			name = folder.FolderID
+		}
+		path := sanitizeMailBackupName(name)
+		if parent, ok := byID[folder.ParentFolderID]; ok && parent.FolderID != id && depth < len(folders) {
+			path = resolve(parent.FolderID, depth+1) + "/" + path
+		}
+		paths[id] = path
+		return path
+	}
+	for _, folder := range folders {
+		resolve(folder.FolderID, 0)
+	}
+	return paths
+}
+
+var mailBackupUnsafeName = regexp.MustCompile(`[^\p{L}\p{N} ._@+-]+`)
+
+func sanitizeMailBackupName(name string) string {
+	name = strings.TrimSpace(mailBackupUnsafeName.ReplaceAllString(name, "_"))
+	name = strings.TrimLeft(name, ".")
+	if name == "" {
+		return "_"
+	}
+	return name
+}
+
+// mailBackupEML returns the message's RFC 822 bytes, rebuilding them from the
+// parsed fields when the API did not return raw content.
+func mailBackupEML(message larksdk.MailMessage) ([]byte, error) {
+	if raw, err := decodeMailBase64(message.Raw); err == nil && len(raw) > 0 {
+		return raw, nil
+	}
+	compose := mailCompose{
+		Subject:     message.Subject,
+		Text:        decodeMailBody(message.BodyPlainText),
+		HTML:        decodeMailBody(message.BodyHTML),
+		Attachments: message.Attachments,
+	}
+	if message.From.MailAddress != "" {
+		compose.From = &mail.Address{Name: message.From.Name, Address: message.From.MailAddress}
+	}
+	for _, address := range message.To {
+		compose.To = append(compose.To, larksdk.MailAddressInput{MailAddress: address.MailAddress, Name: address.Name})
+	}
+	for _, address := range message.CC {
+		compose.CC = append(compose.CC, larksdk.MailAddressInput{MailAddress: address.MailAddress, Name: address.Name})
+	}
+	if date, ok := mailMessageTime(message); ok {
+		compose.Date = date
+	}
+	// Attachments without inline bodies cannot be embedded offline.
+	kept := compose.Attachments[:0:0]
+	for _, attachment := range compose.Attachments {
+		if attachment.Body != "" {
+			kept = append(kept, attachment)
+		}
+	}
+	compose.Attachments = kept
+	return buildMailEML(compose)
+}
+
+// writeMailBackupMessage stores one message and returns its path relative to
+// outDir.
+func writeMailBackupMessage(outDir, folderPath, format string, message larksdk.MailMessage, eml []byte) (string, error) {
+	date, ok := mailMessageTime(message)
+	if !ok {
+		date = time.Now()
+	}
+	if format == "mbox" {
+		relPath := folderPath + ".mbox"
+		path := filepath.Join(outDir, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return "", err
+		}
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return "", err
+		}
+		if _, err := file.Write(mboxEntry(message.From.MailAddress, date, eml)); err != nil {
+			_ = file.Close()
+			return "", err
+		}
+		return relPath, file.Close()
+	}
+
+	dir := filepath.Join(outDir, filepath.FromSlash(folderPath))
+	for _, sub := range []string{"cur", "new", "tmp"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o700); err != nil {
+			return "", err
+		}
+	}
+	name := fmt.Sprintf("%d.%s.lark:2,", date.Unix(), sanitizeMailBackupName(message.MessageID))
+	tmp := filepath.Join(dir, "tmp", name)
+	if err := os.WriteFile(tmp, eml, 0o600); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp, filepath.Join(dir, "cur", name)); err != nil {
+		return "", err
+	}
+	return folderPath + "/cur/" + name, nil
+}
+
+// mboxEntry renders one mboxrd entry: a From_ separator line, the message with
+// LF line endings and ">"-quoted From_ lines, and a trailing blank line.
+func mboxEntry(sender string, date time.Time, eml []byte) []byte {
+	if sender == "" {
+		sender = "MAILER-DAEMON"
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From %s %s\n", sender, date.UTC().Format(time.ANSIC))
+	scanner := bufio.NewScanner(bytes.NewReader(bytes.ReplaceAll(eml, []byte("\r\n"), []byte("\n"))))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(eml)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(strings.TrimLeft(line, ">"), "From ") {
+			buf.WriteByte('>')
+		}
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMailBackupMaildirIncremental(t *testing.T) {
+	raw := "From: a@example.com\r\nSubject: Hello\r\n\r\nbody\r\n"
+	fetched := map[string]int{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/folders":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"items": []map[string]any{
+				{"id": "fld_in", "name": "Inbox", "folder_type": "INBOX"},
+				{"id": "fld_proj", "name": "Projects/2024", "parent_folder_id": "fld_in"},
+			}}})
+		case r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/messages" && r.URL.Query().Get("folder_id") == "fld_in":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"items": []string{"m1"}, "has_more": false}})
+		case r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/messages" && r.URL.Query().Get("folder_id") == "fld_proj":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"items": []string{"m2"}, "has_more": false}})
+		case strings.HasPrefix(r.URL.Path, "/open-apis/mail/v1/user_mailboxes/me/messages/"):
+			id := strings.TrimPrefix(r.URL.Path, "/open-apis/mail/v1/user_mailboxes/me/messages/")
+			fetched[id]++
+			message := map[string]any{"message_id": id, "internal_date": "1700000000000"}
+			if id == "m1" {
+				message["raw"] = base64.URLEncoding.EncodeToString([]byte(raw))
+			} else {
+				message["subject"] = "Rebuilt"
+				message["head_from"] = map[string]any{"mail_address": "b@example.com"}
+				message["body_plain_text"] = base64.URLEncoding.EncodeToString([]byte("plain body"))
+			}
+			_ = json.NewEncoder(w).Encode(mailMessageResponse(message))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.String())
+		}
+	})
+
+	out := t.TempDir()
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{"backup", "--out", out})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("mail backup error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "saved 2 new message(s), 0 already backed up") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+
+	first, err := os.ReadFile(filepath.Join(out, "Inbox", "cur", "1700000000.m1.lark:2,"))
+	if err != nil {
+		t.Fatalf("read m1: %v", err)
+	}
+	if string(first) != raw {
+		t.Fatalf("unexpected m1 content: %q", first)
+	}
+	second, err := os.ReadFile(filepath.Join(out, "Inbox", "Projects_2024", "cur", "1700000000.m2.lark:2,"))
+	if err != nil {
+		t.Fatalf("read m2: %v", err)
+	}
+	if !strings.Contains(string(second), "Subject: Rebuilt") || !strings.Contains(string(second), "plain body") {
+		t.Fatalf("unexpected m2 content: %q", second)
+	}
+
+	buf.Reset()
+	state.Printer.JSON = true
+	cmd = newMailCmd(state)
+	cmd.SetArgs([]string{"backup", "--out", out})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("mail backup rerun error: %v", err)
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if payload["saved"] != float64(0) || payload["skipped"] != float64(2) {
+		t.Fatalf("unexpected rerun payload: %#v", payload)
+	}
+	if fetched["m1"] != 1 || fetched["m2"] != 1 {
+		t.Fatalf("messages fetched again: %#v", fetched)
+	}
+
+	cmd = newMailCmd(state)
+	cmd.SetArgs([]string{"backup", "--out", out, "--format", "mbox"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "maildir backup") {
+		t.Fatalf("expected format mismatch error, got %v", err)
+	}
+}
+
+func TestMailBackupSavesStateWhenFolderFails(t *testing.T) {
+	failing := true
+	fetched := map[string]int{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/folders":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"items": []map[string]any{
+				{"id": "fld_in", "name": "Inbox", "folder_type": "INBOX"},
+			}}})
+		case r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/messages":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"items": []string{"m1", "m2", "m3"}, "has_more": false}})
+		case strings.HasPrefix(r.URL.Path, "/open-apis/mail/v1/user_mailboxes/me/messages/"):
+			id := strings.TrimPrefix(r.URL.Path, "/open-apis/mail/v1/user_mailboxes/me/messages/")
+			fetched[id]++
+			if id == "m3" && failing {
+				_ = json.NewEncoder(w).Encode(map[string]any{"code": 1234, "msg": "internal error"})
+				return
+			}
+			raw := "Subject: " + id + "\r\n\r\nbody\r\n"
+			_ = json.NewEncoder(w).Encode(mailMessageResponse(map[string]any{
+				"message_id":    id,
+				"internal_date": "1700000000000",
+				"raw":           base64.URLEncoding.EncodeToString([]byte(raw)),
+			}))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.String())
+		}
+	})
+
+	out := t.TempDir()
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{"backup", "--out", out})
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("expected backup to fail on m3")
+	}
+	data, err := os.ReadFile(filepath.Join(out, mailBackupStateFile))
+	if err != nil {
+		t.Fatalf("expected state saved after failure: %v", err)
+	}
+	if !strings.Contains(string(data), `"m1"`) || !strings.Contains(string(data), `"m2"`) || strings.Contains(string(data), `"m3"`) {
+		t.Fatalf("unexpected state: %s", data)
+	}
+
+	failing = false
+	buf.Reset()
+	cmd = newMailCmd(state)
+	cmd.SetArgs([]string{"backup", "--out", out})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("mail backup rerun error: %v", err)
+	}
+	if fetched["m1"] != 1 || fetched["m2"] != 1 || fetched["m3"] != 2 {
+		t.Fatalf("unexpected fetches: %v", fetched)
+	}
+	if !strings.Contains(buf.String(), "saved 1 new message(s), 2 already backed up") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestMboxEntryQuotesFromLines(t *testing.T) {
+	date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	entry := mboxEntry("a@example.com", date, []byte("Subject: x\r\n\r\nFrom here\r\n>From there\r\nok\r\n"))
+	want := "From a@example.com Tue Jan  2 03:04:05 2024\nSubject: x\n\n>From here\n>>From there\nok\n\n"
+	if string(entry) != want {
+		t.Fatalf("unexpected mbox entry:\n%q\nwant\n%q", entry, want)
+	}
+}
@@ -0,0 +1,339 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"lark/internal/larksdk"
+)
+
+// mailCompose describes a message that is sent as raw EML, which is the only
+// way to set threading headers such as In-Reply-To through the send API.
+// BCC is never written into the EML, which every recipient receives; it is
+// passed to the send API as recipient fields instead.
+type mailCompose struct {
+	From        *mail.Address
+	To          []larksdk.MailAddressInput
+	CC          []larksdk.MailAddressInput
+	BCC         []larksdk.MailAddressInput
+	Subject     string
+	Text        string
+	HTML        string
+	InReplyTo   string
+	References  string
+	Attachments []larksdk.MailAttachment
+	Date        time.Time
+}
+
+type mailEntity struct {
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+func buildMailEML(msg mailCompose) ([]byte, error) {
+	body, err := buildMailBodyEntity(msg)
+	if err != nil {
+		return nil, err
+	}
+	date := msg.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	var buf bytes.Buffer
+	writeHeader := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&buf, "%s: %s\r\n", name, value)
+		}
+	}
+	if msg.From != nil {
+		writeHeader("From", msg.From.String())
+	}
+	writeHeader("To", formatMailAddressHeader(msg.To))
+	writeHeader("Cc", formatMailAddressHeader(msg.CC))
+	writeHeader("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	writeHeader("Date", date.Format(time.RFC1123Z))
+	writeHeader("In-Reply-To", msg.InReplyTo)
+	writeHeader("References", msg.References)
+	writeHeader("MIME-Version", "1.0")
+	writeMailEntity(&buf, body)
+	return buf.Bytes(), nil
+}
+
+// buildMailBodyEntity nests the body as mixed(related(alternative(text, html),
+// inline...), attachments...), dropping any level that has a single part.
+func buildMailBodyEntity(msg mailCompose) (mailEntity, error) {
+	parts := make([]mailEntity, 0, 2)
+	if msg.Text != "" || msg.HTML == "" {
+		parts = append(parts, mailTextEntity("text/plain", msg.Text))
+	}
+	if msg.HTML != "" {
+		parts = append(parts, mailTextEntity("text/html", msg.HTML))
+	}
+	body := parts[0]
+	if len(parts) > 1 {
+		body = mailMultipartEntity("alternative", parts)
+	}
+
+	inline := []mailEntity{body}
+	attached := []mailEntity{}
+	for _, attachment := range msg.Attachments {
+		entity, err := mailAttachmentEntity(attachment)
+		if err != nil {
+			return mailEntity{}, err
+		}
+		if attachment.IsInline {
+			inline = append(inline, entity)
+		} else {
+			attached = append(attached, entity)
+		}
+	}
+	if len(inline) > 1 {
+		body = mailMultipartEntity("related", inline)
+	}
+	if len(attached) > 0 {
+		body = mailMultipartEntity("mixed", append([]mailEntity{body}, attached...))
+	}
+	return body, nil
+}
+
+func mailTextEntity(contentType, content string) mailEntity {
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", contentType+"; charset=UTF-8")
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+	var buf bytes.Buffer
+	writer := quotedprintable.NewWriter(&buf)
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	_, _ = writer.Write([]byte(strings.ReplaceAll(content, "\n", "\r\n")))
+	_ = writer.Close()
+	return mailEntity{header: header, body: buf.Bytes()}
+}
+
+func mailAttachmentEntity(attachment larksdk.MailAttachment) (mailEntity, error) {
+	data, err := decodeMailBase64(attachment.Body)
+	if err != nil {
+		return mailEntity{}, fmt.Errorf("attachment %s: %w", attachment.Filename, err)
+	}
+	contentType := mime.TypeByExtension(filepath.Ext(attachment.Filename))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	disposition := "attachment"
+	header := textproto.MIMEHeader{}
+	if attachment.IsInline {
+		disposition = "inline"
+		if attachment.CID != "" {
+			header.Set("Content-ID", "<"+attachment.CID+">")
+		}
+	}
+	header.Set("Content-Type", mime.FormatMediaType(contentType, map[string]string{"name": attachment.Filename}))
+	header.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Filename}))
+	header.Set("Content-Transfer-Encoding", "base64")
+
+	encoded := base64.StdEncoding.EncodeToString(data)
+	var buf bytes.Buffer
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded)
+	return mailEntity{header: header, body: buf.Bytes()}, nil
+}
+
+func mailMultipartEntity(subtype string, parts []mailEntity) mailEntity {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	for _, part := range parts {
+		w, _ := writer.CreatePart(part.header)
+		_, _ = w.Write(part.body)
+	}
+	_ = writer.Close()
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", fmt.Sprintf("multipart/%s; boundary=%s", subtype, writer.Boundary()))
+	return mailEntity{header: header, body: buf.Bytes()}
+}
+
+func writeMailEntity(buf *bytes.Buffer, entity mailEntity) {
+	keys := make([]string, 0, len(entity.header))
+	for key := range entity.header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(buf, "%s: %s\r\n", key, entity.header.Get(key))
+	}
+	buf.WriteString("\r\n")
+	buf.Write(entity.body)
+}
+
+func formatMailAddressHeader(addresses []larksdk.MailAddressInput) string {
+	values := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		values = append(values, (&mail.Address{Name: address.Name, Address: address.MailAddress}).String())
+	}
+	return strings.Join(values, ", ")
+}
+
+// decodeMailBase64 accepts both base64url (the mail API's encoding) and the
+// standard alphabet, padded or not.
+func decodeMailBase64(value string) ([]byte, error) {
+	value = strings.TrimSpace(value)
+	for _, encoding := range []*base64.Encoding{base64.URLEncoding, base64.RawURLEncoding, base64.StdEncoding, base64.RawStdEncoding} {
+		if data, err := encoding.DecodeString(value); err == nil {
+			return data, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid base64 content")
+}
+
+// decodeMailBody returns a message body as text. The API returns bodies
+// base64url-encoded; values that do not decode to UTF-8 are kept as is.
+func decodeMailBody(value string) string {
+	if value == "" {
+		return ""
+	}
+	data, err := decodeMailBase64(value)
+	if err != nil || !utf8.Valid(data) {
+		return value
+	}
+	return string(data)
+}
+
+// mailMessageReferences builds the References header for a reply: the
+// original's References (read from its raw EML when available) followed by its
+// Message-ID.
+func mailMessageReferences(message larksdk.MailMessage) (string, string) {
+	messageID := strings.TrimSpace(message.SMTPMessageID)
+	if messageID != "" && !strings.HasPrefix(messageID, "<") {
+		messageID = "<" + messageID + ">"
+	}
+	references := ""
+	if raw, err := decodeMailBase64(message.Raw); err == nil && len(raw) > 0 {
+		if parsed, err := mail.ReadMessage(bytes.NewReader(raw)); err == nil {
+			references = strings.Join(strings.Fields(parsed.Header.Get("References")), " ")
+		}
+	}
+	if messageID != "" && !strings.Contains(references, messageID) {
+		references = strings.TrimSpace(references + " " + messageID)
+	}
+	return messageID, references
+}
+
+func mailMessageTime(message larksdk.MailMessage) (time.Time, bool) {
+	value := strings.TrimSpace(message.InternalDate)
+	if value == "" {
+		return time.Time{}, false
+	}
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.UnixMilli(ms), true
+	}
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, true
+	}
+	return time.Time{}, false
+}
+
+func formatMailMessageDate(message larksdk.MailMessage) string {
+	if parsed, ok := mailMessageTime(message); ok {
+		return parsed.Format("Mon, 2 Jan 2006 at 15:04")
+	}
+	return message.InternalDate
+}
+
+func formatMailAddressDisplay(address larksdk.MailAddress) string {
+	if address.Name == "" {
+		return address.MailAddress
+	}
+	if address.MailAddress == "" {
+		return address.Name
+	}
+	return fmt.Sprintf("%s <%s>", address.Name, address.MailAddress)
+}
+
+func mailOriginalText(message larksdk.MailMessage) string {
+	if text := decodeMailBody(message.BodyPlainText); text != "" {
+		return text
+	}
+	return message.Snippet
+}
+
+func mailOriginalHTML(message larksdk.MailMessage) string {
+	if body := decodeMailBody(message.BodyHTML); body != "" {
+		return body
+	}
+	return strings.ReplaceAll(html.EscapeString(mailOriginalText(message)), "\n", "<br>")
+}
+
+func quoteMailText(message larksdk.MailMessage) string {
+	lines := strings.Split(strings.TrimRight(mailOriginalText(message), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = "> " + line
+	}
+	header := fmt.Sprintf("On %s, %s wrote:", formatMailMessageDate(message), formatMailAddressDisplay(message.From))
+	return header + "\n" + strings.Join(lines, "\n")
+}
+
+func quoteMailHTML(message larksdk.MailMessage) string {
+	header := html.EscapeString(fmt.Sprintf("On %s, %s wrote:", formatMailMessageDate(message), formatMailAddressDisplay(message.From)))
+	return fmt.Sprintf(`<div>%s</div><blockquote style="margin:0 0 0 .8ex;border-left:1px solid #ccc;padding-left:1ex">%s</blockquote>`, header, mailOriginalHTML(message))
+}
+
+func forwardMailHeaderLines(message larksdk.MailMessage) []string {
+	to := make([]string, 0, len(message.To))
+	for _, address := range message.To {
+		to = append(to, formatMailAddressDisplay(address))
+	}
+	lines := []string{
+		"---------- Forwarded message ---------",
+		"From: " + formatMailAddressDisplay(message.From),
+		"Date: " + formatMailMessageDate(message),
+		"Subject: " + message.Subject,
+		"To: " + strings.Join(to, ", "),
+	}
+	if len(message.CC) > 0 {
+		cc := make([]string, 0, len(message.CC))
+		for _, address := range message.CC {
+			cc = append(cc, formatMailAddressDisplay(address))
+		}
+		lines = append(lines, "Cc: "+strings.Join(cc, ", "))
+	}
+	return lines
+}
+
+func forwardMailText(message larksdk.MailMessage) string {
+	return strings.Join(forwardMailHeaderLines(message), "\n") + "\n\n" + mailOriginalText(message)
+}
+
+func forwardMailHTML(message larksdk.MailMessage) string {
+	lines := forwardMailHeaderLines(message)
+	for i, line := range lines {
+		lines[i] = html.EscapeString(line)
+	}
+	return "<div>" + strings.Join(lines, "<br>") + "</div><br>" + mailOriginalHTML(message)
+}
+
+// mailSubjectWithPrefix adds prefix ("Re:"/"Fwd:") unless the subject already
+// starts with it.
+func mailSubjectWithPrefix(prefix, subject string) string {
+	subject = strings.TrimSpace(subject)
+	if strings.HasPrefix(strings.ToLower(subject), strings.ToLower(prefix)) {
+		return subject
+	}
+	if subject == "" {
+		return prefix
+	}
+	return prefix + " " + subject
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+func newMailDraftsCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drafts",
+		Short: "Manage mail drafts",
+		Long: `Drafts are unsent messages saved in a mailbox.
+
+- create saves a new draft; update replaces a draft's content entirely.
+- send delivers a saved draft; delete discards it.`,
+	}
+	annotateAuthServices(cmd, "mail", "mail-modify")
+	cmd.AddCommand(newMailDraftsListCmd(state))
+	cmd.AddCommand(newMailDraftsCreateCmd(state))
+	cmd.AddCommand(newMailDraftsUpdateCmd(state))
+	cmd.AddCommand(newMailDraftsSendCmd(state))
+	cmd.AddCommand(newMailDraftsDeleteCmd(state))
+	return cmd
+}
+
+func newMailDraftsListCmd(state *appState) *cobra.Command {
+	var mailboxID string
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List mail drafts",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if limit <= 0 {
+				return flagUsage(cmd, "limit must be greater than 0")
+			}
+			mailboxID = resolveMailboxID(state, mailboxID)
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			token, err := tokenFor(ctx, state, tokenTypesUser)
+			if err != nil {
+				return err
+			}
+			drafts := make([]larksdk.MailDraft, 0, limit)
+			pageToken := ""
+			for len(drafts) < limit {
+				pageSize := limit - len(drafts)
+				if pageSize > maxMailPageSize {
+					pageSize = maxMailPageSize
+				}
+				result, err := state.SDK.ListMailDrafts(ctx, token, larksdk.ListMailDraftsRequest{
+					MailboxID: mailboxID,
+					PageSize:  pageSize,
+					PageToken: pageToken,
+				})
+				if err != nil {
+					return withUserScopeHintForCommand(state, err)
+				}
+				drafts = append(drafts, result.Items...)
+				if !result.HasMore || result.PageToken == "" {
+					break
+				}
+				pageToken = result.PageToken
+			}
+			if len(drafts) > limit {
+				drafts = drafts[:limit]
+			}
+			payload := map[string]any{"drafts": drafts}
+			lines := make([]string, 0, len(drafts))
+			for _, draft := range drafts {
+				lines = append(lines, formatMailDraftLine(draft))
+			}
+			text := tableText([]string{"draft_id", "subject", "to", "updated_at"}, lines, "no drafts found")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&mailboxID, "mailbox-id", "", "user mailbox ID (defaults to config default_mailbox_id or 'me')")
+	cmd.Flags().IntVar(&limit, "limit", 20, "max number of drafts to return")
+	return cmd
+}
+
+// mailDraftOptions holds the content flags shared by drafts create and update.
+type mailDraftOptions struct {
+	mailboxID string
+	subject   string
+	to        []string
+	cc        []string
+	bcc       []string
+	bodyText  string
+	bodyHTML  string
+	headFrom  string
+	attach    []string
+	inline    []string
+}
+
+func (o *mailDraftOptions) bindFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.mailboxID, "mailbox-id", "", "user mailbox ID (defaults to config default_mailbox_id or 'me')")
+	cmd.Flags().StringVar(&o.subject, "subject", "", "message subject")
+	cmd.Flags().StringArrayVar(&o.to, "to", nil, "recipient email (repeatable)")
+	cmd.Flags().StringArrayVar(&o.cc, "cc", nil, "cc email (repeatable)")
+	cmd.Flags().StringArrayVar(&o.bcc, "bcc", nil, "bcc email (repeatable)")
+	cmd.Flags().StringVar(&o.bodyText, "text", "", "plain text body")
+	cmd.Flags().StringVar(&o.bodyHTML, "html", "", "HTML body")
+	cmd.Flags().StringArrayVar(&o.attach, "attach", nil, "file to attach (repeatable)")
+	cmd.Flags().StringArrayVar(&o.inline, "inline", nil, "image to embed in --html as cid:<file name> (repeatable)")
+	cmd.Flags().StringVar(&o.headFrom, "from-name", "", "display name for From header")
+}
+
+func (o *mailDraftOptions) request() (larksdk.SendMailRequest, error) {
+	if strings.TrimSpace(o.subject) == "" && len(o.to) == 0 && o.bodyText == "" && o.bodyHTML == "" && len(o.attach) == 0 {
+		return larksdk.SendMailRequest{}, errors.New("subject, to, text, html, or attach is required")
+	}
+	if len(o.inline) > 0 && o.bodyHTML == "" {
+		return larksdk.SendMailRequest{}, errors.New("inline requires html")
+	}
+	attachments, err := buildMailAttachments(o.attach, o.inline)
+	if err != nil {
+		return larksdk.SendMailRequest{}, err
+	}
+	return larksdk.SendMailRequest{
+		Subject:       o.subject,
+		To:            buildMailAddressInputs(o.to),
+		CC:            buildMailAddressInputs(o.cc),
+		BCC:           buildMailAddressInputs(o.bcc),
+		HeadFromName:  o.headFrom,
+		BodyPlainText: o.bodyText,
+		BodyHTML:      o.bodyHTML,
+		Attachments:   attachments,
+	}, nil
+}
+
+func newMailDraftsCreateCmd(state *appState) *cobra.Command {
+	var opts mailDraftOptions
+
+	cmd := &cobra.Command{
+		Use:     "create",
+		Short:   "Save a new mail draft",
+		Example: "  lark mail drafts create --subject \"Q3 plan\" --to alice@example.com --text \"Draft notes\"",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			request, err := opts.request()
+			if err != nil {
+				return err
+			}
+			opts.mailboxID = resolveMailboxID(state, opts.mailboxID)
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, err := tokenFor(cmd.Context(), state, tokenTypesUser)
+			if err != nil {
+				return err
+			}
+			draft, err := state.SDK.CreateMailDraft(cmd.Context(), token, opts.mailboxID, request)
+			if err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			payload := map[string]any{"draft": draft}
+			return state.Printer.Print(payload, fmt.Sprintf("draft_id: %s", draft.DraftID))
+		},
+	}
+	opts.bindFlags(cmd)
+	return cmd
+}
+
+func newMailDraftsUpdateCmd(state *appState) *cobra.Command {
+	var opts mailDraftOptions
+
+	cmd := &cobra.Command{
+		Use:   "update <draft-id>",
+		Short: "Replace the content of a mail draft",
+		Long: `Update replaces the whole draft: fields that are not passed are cleared.
+
+Use "lark mail drafts list --json" to read the current content first.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			if strings.TrimSpace(args[0]) == "" {
+				return errors.New("draft-id is required")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			request, err := opts.request()
+			if err != nil {
+				return err
+			}
+			draftID := strings.TrimSpace(args[0])
+			opts.mailboxID = resolveMailboxID(state, opts.mailboxID)
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, err := tokenFor(cmd.Context(), state, tokenTypesUser)
+			if err != nil {
+				return err
+			}
+			draft, err := state.SDK.UpdateMailDraft(cmd.Context(), token, opts.mailboxID, draftID, request)
+			if err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			payload := map[string]any{"draft": draft}
+			return state.Printer.Print(payload, fmt.Sprintf("draft_id: %s", draft.DraftID))
+		},
+	}
+	opts.bindFlags(cmd)
+	return cmd
+}
+
+func newMailDraftsSendCmd(state *appState) *cobra.Command {
+	var mailboxID string
+
+	cmd := &cobra.Command{
+		Use:   "send <draft-id>",
+		Short: "Send a saved mail draft",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			if strings.TrimSpace(args[0]) == "" {
+				return errors.New("draft-id is required")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			draftID := strings.TrimSpace(args[0])
+			mailboxID = resolveMailboxID(state, mailboxID)
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, err := tokenFor(cmd.Context(), state, tokenTypesUser)
+			if err != nil {
+				return err
+			}
+			messageID, err := state.SDK.SendMailDraft(cmd.Context(), token, mailboxID, draftID)
+			if err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			payload := map[string]any{"draft_id": draftID, "message_id": messageID}
+			return state.Printer.Print(payload, fmt.Sprintf("message_id: %s", messageID))
+		},
+	}
+	annotateAuthServices(cmd, "mail-modify", "mail-send")
+
+	cmd.Flags().StringVar(&mailboxID, "mailbox-id", "", "user mailbox ID (defaults to config default_mailbox_id or 'me')")
+	return cmd
+}
+
+func newMailDraftsDeleteCmd(state *appState) *cobra.Command {
+	var mailboxID string
+
+	cmd := &cobra.Command{
+		Use:   "delete <draft-id>",
+		Short: "Delete a mail draft",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			if strings.TrimSpace(args[0]) == "" {
+				return errors.New("draft-id is required")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			draftID := strings.TrimSpace(args[0])
+			if err := confirmDestructive(cmd, state, fmt.Sprintf("delete draft %s", draftID)); err != nil {
+				return err
+			}
+			mailboxID = resolveMailboxID(state, mailboxID)
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, err := tokenFor(cmd.Context(), state, tokenTypesUser)
+			if err != nil {
+				return err
+			}
+			if err := state.SDK.DeleteMailDraft(cmd.Context(), token, mailboxID, draftID); err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			payload := map[string]any{"draft_id": draftID, "deleted": true}
+			return state.Printer.Print(payload, fmt.Sprintf("deleted draft %s", draftID))
+		},
+	}
+
+	cmd.Flags().StringVar(&mailboxID, "mailbox-id", "", "user mailbox ID (defaults to config default_mailbox_id or 'me')")
+	return cmd
+}
+
+func formatMailDraftLine(draft larksdk.MailDraft) string {
+	subject := strings.TrimSpace(draft.Subject)
+	if subject == "" {
+		subject = "(no subject)"
+	}
+	to := make([]string, 0, len(draft.To))
+	for _, address := range draft.To {
+		to = append(to, formatMailAddressDisplay(address))
+	}
+	recipients := strings.Join(to, ", ")
+	if recipients == "" {
+		recipients = "-"
+	}
+	updatedAt := strings.TrimSpace(draft.UpdatedAt)
+	if updatedAt == "" {
+		updatedAt = "-"
+	}
+	return strings.Join([]string{draft.DraftID, subject, recipients, updatedAt}, "\t")
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMailDraftsCreateAndUpdateCommand(t *testing.T) {
+	var created, updated map[string]any
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/drafts":
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"draft_id": "d1"}})
+		case r.Method == http.MethodPut && r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/drafts/d1":
+			if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{}})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{"drafts", "create", "--subject", "Plan", "--to", "a@example.com", "--text", "notes"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("drafts create error: %v", err)
+	}
+	if created["subject"] != "Plan" || created["body_plain_text"] != "notes" {
+		t.Fatalf("unexpected create payload: %#v", created)
+	}
+	if to, _ := created["to"].([]any); len(to) != 1 {
+		t.Fatalf("unexpected recipients: %#v", created["to"])
+	}
+	if !strings.Contains(buf.String(), "draft_id: d1") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+
+	buf.Reset()
+	cmd = newMailCmd(state)
+	cmd.SetArgs([]string{"drafts", "update", "d1", "--subject", "Plan v2"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("drafts update error: %v", err)
+	}
+	if updated["subject"] != "Plan v2" || updated["to"] != nil {
+		t.Fatalf("unexpected update payload: %#v", updated)
+	}
+	if !strings.Contains(buf.String(), "draft_id: d1") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestMailDraftsListSendDeleteCommand(t *testing.T) {
+	var deleted bool
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/drafts":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{
+				"items": []map[string]any{{"draft_id": "d1", "subject": "Plan", "to": []map[string]any{{"mail_address": "a@example.com"}}}},
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/drafts/d1/send":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"message_id": "msg_9"}})
+		case r.Method == http.MethodDelete && r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/drafts/d2":
+			deleted = true
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{}})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{"drafts", "list"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("drafts list error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "d1\tPlan\ta@example.com\t-") {
+		t.Fatalf("unexpected list output: %q", buf.String())
+	}
+
+	buf.Reset()
+	cmd = newMailCmd(state)
+	cmd.SetArgs([]string{"drafts", "send", "d1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("drafts send error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "message_id: msg_9") {
+		t.Fatalf("unexpected send output: %q", buf.String())
+	}
+
+	state.Force = true
+	cmd = newMailCmd(state)
+	cmd.SetArgs([]string{"drafts", "delete", "d2"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("drafts delete error: %v", err)
+	}
+	if !deleted {
+		t.Fatalf("expected draft delete request")
+	}
+}
+
+func TestMailDraftsCreateRequiresContent(t *testing.T) {
+	var buf bytes.Buffer
+	state := newTestState(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+	}), nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{"drafts", "create"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "is required") {
+		t.Fatalf("expected content error, got %v", err)
+	}
+}
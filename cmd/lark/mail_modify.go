@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+// mailTargetOptions selects messages either by explicit message ids or by a
+// --query-* filter over one folder, so every bulk action shares one selector.
+type mailTargetOptions struct {
+	mailboxID    string
+	queryFolder  string
+	queryFrom    string
+	querySubject string
+	queryUnread  bool
+	queryLimit   int
+	dryRun       bool
+}
+
+func (o *mailTargetOptions) bindFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.mailboxID, "mailbox-id", "", "user mailbox ID (defaults to config default_mailbox_id or 'me')")
+	cmd.Flags().StringVar(&o.queryFolder, "query-folder", "INBOX", "with --query-*, folder to search (system aliases: INBOX/SENT/DRAFT/TRASH/SPAM/ARCHIVED)")
+	cmd.Flags().StringVar(&o.queryFrom, "query-from", "", "select messages whose sender contains this text")
+	cmd.Flags().StringVar(&o.querySubject, "query-subject", "", "select messages whose subject contains this text")
+	cmd.Flags().BoolVar(&o.queryUnread, "query-unread", false, "select unread messages only")
+	cmd.Flags().IntVar(&o.queryLimit, "query-limit", 50, "with --query-*, max number of recent messages to scan")
+	cmd.Flags().BoolVar(&o.dryRun, "dry-run", false, "list the selected messages without changing them")
+}
+
+func (o *mailTargetOptions) hasQuery(cmd *cobra.Command) bool {
+	for _, name := range []string{"query-folder", "query-from", "query-subject", "query-unread"} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *mailTargetOptions) validate(cmd *cobra.Command, args []string) error {
+	query := o.hasQuery(cmd)
+	if len(args) > 0 && query {
+		return flagUsage(cmd, "message ids and --query-* flags are mutually exclusive")
+	}
+	if len(args) == 0 && !query {
+		return flagUsage(cmd, "pass message ids or at least one --query-* filter")
+	}
+	if query && o.queryLimit <= 0 {
+		return flagUsage(cmd, "query-limit must be greater than 0")
+	}
+	return nil
+}
+
+func (o *mailTargetOptions) resolve(ctx context.Context, state *appState, token string, args []string) ([]larksdk.MailMessage, error) {
+	if len(args) > 0 {
+		messages := make([]larksdk.MailMessage, 0, len(args))
+		for _, arg := range args {
+			if id := strings.TrimSpace(arg); id != "" {
+				messages = append(messages, larksdk.MailMessage{MessageID: id})
+			}
+		}
+		return messages, nil
+	}
+	folderID, err := resolveMailFolderID(ctx, state, token, o.mailboxID, o.queryFolder)
+	if err != nil {
+		return nil, err
+	}
+	messages := make([]larksdk.MailMessage, 0)
+	scanned := 0
+	pageToken := ""
+	for scanned < o.queryLimit {
+		pageSize := o.queryLimit - scanned
+		if pageSize > maxMailPageSize {
+			pageSize = maxMailPageSize
+		}
+		result, err := state.SDK.ListMailMessages(ctx, token, larksdk.ListMailMessagesRequest{
+			MailboxID:  o.mailboxID,
+			FolderID:   folderID,
+			PageSize:   pageSize,
+			PageToken:  pageToken,
+			OnlyUnread: o.queryUnread,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.Items {
+			if scanned >= o.queryLimit {
+				break
+			}
+			scanned++
+			if item.MessageID == "" {
+				continue
+			}
+			message, err := state.SDK.GetMailMessage(ctx, token, o.mailboxID, item.MessageID)
+			if err != nil {
+				return nil, err
+			}
+			if message.MessageID == "" {
+				message.MessageID = item.MessageID
+			}
+			stripMailMessageContent(&message)
+			if o.matches(message) {
+				messages = append(messages, message)
+			}
+		}
+		if !result.HasMore || result.PageToken == "" {
+			break
+		}
+		pageToken = result.PageToken
+	}
+	return messages, nil
+}
+
+func (o *mailTargetOptions) matches(message larksdk.MailMessage) bool {
+	if from := strings.ToLower(strings.TrimSpace(o.queryFrom)); from != "" {
+		sender := strings.ToLower(message.From.MailAddress + " " + message.From.Name)
+		if !strings.Contains(sender, from) {
+			return false
+		}
+	}
+	if subject := strings.ToLower(strings.TrimSpace(o.querySubject)); subject != "" {
+		if !strings.Contains(strings.ToLower(message.Subject), subject) {
+			return false
+		}
+	}
+	return true
+}
+
+// mailModifyAction describes one bulk action; folder is a folder id or alias.
+type mailModifyAction struct {
+	name        string
+	isRead      *bool
+	folder      string
+	destructive bool
+}
+
+func newMailMarkReadCmd(state *appState) *cobra.Command {
+	read := true
+	return newMailModifyCmd(state, "mark-read", "Mark messages as read", func(cmd *cobra.Command) mailModifyAction {
+		return mailModifyAction{name: "mark-read", isRead: &read}
+	}, nil)
+}
+
+func newMailMarkUnreadCmd(state *appState) *cobra.Command {
+	unread := false
+	return newMailModifyCmd(state, "mark-unread", "Mark messages as unread", func(cmd *cobra.Command) mailModifyAction {
+		return mailModifyAction{name: "mark-unread", isRead: &unread}
+	}, nil)
+}
+
+func newMailMoveCmd(state *appState) *cobra.Command {
+	var folder string
+	cmd := newMailModifyCmd(state, "move", "Move messages to another folder", func(cmd *cobra.Command) mailModifyAction {
+		return mailModifyAction{name: "move", folder: folder}
+	}, func(cmd *cobra.Command) error {
+		if strings.TrimSpace(folder) == "" {
+			return flagUsage(cmd, "folder is required")
+		}
+		return nil
+	})
+	cmd.Example = "  lark mail move <message-id> --folder ARCHIVED\n  lark mail move --query-from billing@example.com --folder ARCHIVED"
+	cmd.Flags().StringVar(&folder, "folder", "", "destination folder ID (system aliases: INBOX/SENT/DRAFT/TRASH/SPAM/ARCHIVED)")
+	_ = cmd.MarkFlagRequired("folder")
+	return cmd
+}
+
+func newMailDeleteCmd(state *appState) *cobra.Command {
+	return newMailModifyCmd(state, "delete", "Move messages to Trash", func(cmd *cobra.Command) mailModifyAction {
+		return mailModifyAction{name: "delete", folder: "TRASH", destructive: true}
+	}, nil)
+}
+
+func newMailModifyCmd(state *appState, use, short string, action func(*cobra.Command) mailModifyAction, validate func(*cobra.Command) error) *cobra.Command {
+	var opts mailTargetOptions
+
+	cmd := &cobra.Command{
+		Use:   use + " [message-id...]",
+		Short: short,
+		Long: short + `.
+
+- Pass message ids, or select messages with --query-from/--query-subject/--query-unread in --query-folder.
+- Filters scan the latest --query-limit messages; --dry-run lists the selection without changing it.
+- Acting on a filter (and any delete) asks for confirmation unless --force is set.`,
+		Example: fmt.Sprintf("  lark mail %s <message-id>\n  lark mail %s --query-from billing@example.com --dry-run", use, use),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.validate(cmd, args); err != nil {
+				return err
+			}
+			if validate != nil {
+				if err := validate(cmd); err != nil {
+					return err
+				}
+			}
+			act := action(cmd)
+			opts.mailboxID = resolveMailboxID(state, opts.mailboxID)
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			token, err := tokenFor(ctx, state, tokenTypesUser)
+			if err != nil {
+				return err
+			}
+			messages, err := opts.resolve(ctx, state, token, args)
+			if err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			folderID := ""
+			if act.folder != "" {
+				folderID, err = resolveMailFolderAlias(ctx, state, token, opts.mailboxID, act.folder)
+				if err != nil {
+					return withUserScopeHintForCommand(state, err)
+				}
+			}
+
+			if !opts.dryRun && len(messages) > 0 && (act.destructive || opts.hasQuery(cmd)) {
+				if err := confirmDestructive(cmd, state, fmt.Sprintf("%s %d message(s)", act.name, len(messages))); err != nil {
+					return err
+				}
+			}
+			if !opts.dryRun {
+				ids := make([]string, 0, len(messages))
+				for _, message := range messages {
+					ids = append(ids, message.MessageID)
+				}
+				for start := 0; start < len(ids); start += larksdk.MaxMailModifyBatch {
+					end := start + larksdk.MaxMailModifyBatch
+					if end > len(ids) {
+						end = len(ids)
+					}
+					if err := state.SDK.ModifyMailMessages(ctx, token, larksdk.ModifyMailMessagesRequest{
+						MailboxID:  opts.mailboxID,
+						MessageIDs: ids[start:end],
+						IsRead:     act.isRead,
+						FolderID:   folderID,
+					}); err != nil {
+						return withUserScopeHintForCommand(state, err)
+					}
+				}
+			}
+
+			payload := map[string]any{"action": act.name, "dry_run": opts.dryRun, "messages": messages}
+			if folderID != "" {
+				payload["folder_id"] = folderID
+			}
+			lines := make([]string, 0, len(messages))
+			for _, message := range messages {
+				lines = append(lines, formatMailMessageListLine(message))
+			}
+			text := tableText([]string{"message_id", "subject", "from", "internal_date"}, lines, "no messages matched")
+			return state.Printer.Print(payload, text)
+		},
+	}
+	annotateAuthServices(cmd, "mail", "mail-modify")
+	opts.bindFlags(cmd)
+	return cmd
+}
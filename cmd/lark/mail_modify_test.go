@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func mailModifyFoldersResponse() map[string]any {
+	return map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"items": []map[string]any{
+		{"id": "fld_in", "name": "Inbox", "folder_type": "INBOX"},
+		{"id": "fld_archive", "name": "Archived", "folder_type": "ARCHIVED"},
+		{"id": "fld_trash", "name": "Trash", "folder_type": "TRASH"},
+	}}}
+}
+
+func TestMailMarkReadCommandWithIDs(t *testing.T) {
+	var body map[string]any
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/open-apis/mail/v1/user_mailboxes/me/messages/batch_modify" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{}})
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{"mark-read", "m1", "m2"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("mail mark-read error: %v", err)
+	}
+	ids, _ := body["message_ids"].([]any)
+	if len(ids) != 2 || ids[0] != "m1" || ids[1] != "m2" || body["is_read"] != true || body["folder_id"] != nil {
+		t.Fatalf("unexpected payload: %#v", body)
+	}
+	if !strings.Contains(buf.String(), "m1") || !strings.Contains(buf.String(), "m2") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestMailMoveCommandWithQuery(t *testing.T) {
+	messages := map[string]map[string]any{
+		"m1": {"message_id": "m1", "subject": "Invoice 42", "head_from": map[string]any{"mail_address": "billing@example.com"}},
+		"m2": {"message_id": "m2", "subject": "Lunch", "head_from": map[string]any{"mail_address": "bob@example.com"}},
+	}
+	var body map[string]any
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/folders":
+			_ = json.NewEncoder(w).Encode(mailModifyFoldersResponse())
+		case r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/messages" && r.URL.Query().Get("folder_id") == "fld_in":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"items": []string{"m1", "m2"}, "has_more": false}})
+		case r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/messages/batch_modify":
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{}})
+		case strings.HasPrefix(r.URL.Path, "/open-apis/mail/v1/user_mailboxes/me/messages/"):
+			id := strings.TrimPrefix(r.URL.Path, "/open-apis/mail/v1/user_mailboxes/me/messages/")
+			_ = json.NewEncoder(w).Encode(mailMessageResponse(messages[id]))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.String())
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	state.Force = true
+	state.Printer.JSON = true
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{"move", "--query-from", "billing@", "--folder", "ARCHIVED"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("mail move error: %v", err)
+	}
+	ids, _ := body["message_ids"].([]any)
+	if len(ids) != 1 || ids[0] != "m1" || body["folder_id"] != "fld_archive" || body["is_read"] != nil {
+		t.Fatalf("unexpected payload: %#v", body)
+	}
+	var payload struct {
+		Action   string           `json:"action"`
+		FolderID string           `json:"folder_id"`
+		Messages []map[string]any `json:"messages"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if payload.Action != "move" || payload.FolderID != "fld_archive" || len(payload.Messages) != 1 {
+		t.Fatalf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestMailDeleteCommandDryRun(t *testing.T) {
+	messages := map[string]map[string]any{
+		"m1": {"message_id": "m1", "subject": "Weekly digest"},
+		"m2": {"message_id": "m2", "subject": "Contract"},
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/folders":
+			_ = json.NewEncoder(w).Encode(mailModifyFoldersResponse())
+		case r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/messages":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"items": []string{"m1", "m2"}, "has_more": false}})
+		case r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/messages/batch_modify":
+			t.Fatalf("dry run must not modify messages")
+		case strings.HasPrefix(r.URL.Path, "/open-apis/mail/v1/user_mailboxes/me/messages/"):
+			id := strings.TrimPrefix(r.URL.Path, "/open-apis/mail/v1/user_mailboxes/me/messages/")
+			_ = json.NewEncoder(w).Encode(mailMessageResponse(messages[id]))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.String())
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{"delete", "--query-subject", "digest", "--dry-run"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("mail delete error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Weekly digest") || strings.Contains(buf.String(), "Contract") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestMailModifyCommandRequiresTargets(t *testing.T) {
+	var buf bytes.Buffer
+	state := newTestState(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/open-apis/mail/v1/user_mailboxes/me/folders" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(mailModifyFoldersResponse())
+	}), nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{"mark-unread"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "pass message ids") {
+		t.Fatalf("expected missing target error, got %v", err)
+	}
+
+	cmd = newMailCmd(state)
+	cmd.SetArgs([]string{"move", "m1", "--query-from", "x@y", "--folder", "ARCHIVED"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Fatalf("expected mutually exclusive error, got %v", err)
+	}
+
+	state.NoInput = true
+	cmd = newMailCmd(state)
+	cmd.SetArgs([]string{"delete", "m1"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "confirmation required") {
+		t.Fatalf("expected confirmation error, got %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+func newMailPublicMailboxMembersCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "members",
+		Short: "Manage public mailbox members",
+		Long: `Members of a public mailbox can read and send mail as it.
+
+- The public mailbox is identified by its ID or address.
+- Members are users, identified by --user-id-type (default open_id).`,
+	}
+	annotateAuthServices(cmd, "mail-admin")
+	cmd.AddCommand(newMailPublicMailboxMembersListCmd(state))
+	cmd.AddCommand(newMailPublicMailboxMembersAddCmd(state))
+	cmd.AddCommand(newMailPublicMailboxMembersRemoveCmd(state))
+	return cmd
+}
+
+func publicMailboxArgs(cmd *cobra.Command, args []string) error {
+	if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+		return argsUsageError(cmd, err)
+	}
+	if strings.TrimSpace(args[0]) == "" {
+		return errors.New("public-mailbox-id is required")
+	}
+	return nil
+}
+
+func newMailPublicMailboxMembersListCmd(state *appState) *cobra.Command {
+	var userIDType string
+
+	cmd := &cobra.Command{
+		Use:   "list <public-mailbox-id>",
+		Short: "List public mailbox members",
+		Args:  publicMailboxArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mailboxID := strings.TrimSpace(args[0])
+			token, err := tokenFor(cmd.Context(), state, tokenTypesTenant)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			members, err := state.SDK.ListPublicMailboxMembers(cmd.Context(), token, mailboxID, userIDType)
+			if err != nil {
+				return err
+			}
+			payload := map[string]any{"public_mailbox_id": mailboxID, "members": members}
+			lines := make([]string, 0, len(members))
+			for _, member := range members {
+				lines = append(lines, strings.Join([]string{member.MemberID, member.UserID, member.Type}, "\t"))
+			}
+			text := tableText([]string{"member_id", "user_id", "type"}, lines, "no members found")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&userIDType, "user-id-type", "open_id", "user ID type (open_id, union_id, user_id)")
+	return cmd
+}
+
+func newMailPublicMailboxMembersAddCmd(state *appState) *cobra.Command {
+	var users []string
+	var userIDType string
+
+	cmd := &cobra.Command{
+		Use:     "add <public-mailbox-id>",
+		Short:   "Add users to a public mailbox",
+		Example: "  lark mail public-mailboxes members add support@example.com --user ou_xxx --user ou_yyy",
+		Args:    publicMailboxArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mailboxID := strings.TrimSpace(args[0])
+			members := make([]larksdk.PublicMailboxMember, 0, len(users))
+			for _, user := range users {
+				if user = strings.TrimSpace(user); user != "" {
+					members = append(members, larksdk.PublicMailboxMember{UserID: user, Type: "USER"})
+				}
+			}
+			if len(members) == 0 {
+				return flagUsage(cmd, "at least one --user is required")
+			}
+			token, err := tokenFor(cmd.Context(), state, tokenTypesTenant)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			if err := state.SDK.AddPublicMailboxMembers(cmd.Context(), token, mailboxID, userIDType, members); err != nil {
+				return err
+			}
+			payload := map[string]any{"public_mailbox_id": mailboxID, "added": members}
+			return state.Printer.Print(payload, fmt.Sprintf("added %d member(s) to %s", len(members), mailboxID))
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&users, "user", nil, "user ID to add (repeatable)")
+	cmd.Flags().StringVar(&userIDType, "user-id-type", "open_id", "user ID type (open_id, union_id, user_id)")
+	return cmd
+}
+
+func newMailPublicMailboxMembersRemoveCmd(state *appState) *cobra.Command {
+	var users []string
+	var memberIDs []string
+	var userIDType string
+
+	cmd := &cobra.Command{
+		Use:   "remove <public-mailbox-id>",
+		Short: "Remove members from a public mailbox",
+		Long:  `Remove members by --user (looked up in the member list) or by --member-id.`,
+		Args:  publicMailboxArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mailboxID := strings.TrimSpace(args[0])
+			if len(users) == 0 && len(memberIDs) == 0 {
+				return flagUsage(cmd, "--user or --member-id is required")
+			}
+			token, err := tokenFor(cmd.Context(), state, tokenTypesTenant)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			ids := make([]string, 0, len(users)+len(memberIDs))
+			for _, id := range memberIDs {
+				if id = strings.TrimSpace(id); id != "" {
+					ids = append(ids, id)
+				}
+			}
+			if len(users) > 0 {
+				members, err := state.SDK.ListPublicMailboxMembers(cmd.Context(), token, mailboxID, userIDType)
+				if err != nil {
+					return err
+				}
+				byUser := make(map[string]string, len(members))
+				for _, member := range members {
+					if member.UserID != "" {
+						byUser[member.UserID] = member.MemberID
+					}
+				}
+				for _, user := range users {
+					user = strings.TrimSpace(user)
+					if user == "" {
+						continue
+					}
+					memberID, ok := byUser[user]
+					if !ok {
+						return fmt.Errorf("user %s is not a member of %s", user, mailboxID)
+					}
+					ids = append(ids, memberID)
+				}
+			}
+			if len(ids) == 0 {
+				return flagUsage(cmd, "--user or --member-id is required")
+			}
+			if err := confirmDestructive(cmd, state, fmt.Sprintf("remove %d member(s) from %s", len(ids), mailboxID)); err != nil {
+				return err
+			}
+			if err := state.SDK.RemovePublicMailboxMembers(cmd.Context(), token, mailboxID, ids); err != nil {
+				return err
+			}
+			payload := map[string]any{"public_mailbox_id": mailboxID, "removed_member_ids": ids}
+			return state.Printer.Print(payload, fmt.Sprintf("removed %d member(s) from %s", len(ids), mailboxID))
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&users, "user", nil, "user ID to remove (repeatable)")
+	cmd.Flags().StringArrayVar(&memberIDs, "member-id", nil, "member ID to remove (repeatable)")
+	cmd.Flags().StringVar(&userIDType, "user-id-type", "open_id", "user ID type for --user (open_id, union_id, user_id)")
+	return cmd
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func publicMailboxMembersHandler(t *testing.T, calls *[]string, bodies map[string]map[string]any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const prefix = "/open-apis/mail/v1/public_mailboxes/support@example.com/members"
+		if !strings.HasPrefix(r.URL.Path, prefix) {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Fatalf("public mailbox members must use the tenant token")
+		}
+		action := r.Method + " " + strings.TrimPrefix(r.URL.Path, prefix)
+		*calls = append(*calls, action)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			if got := r.URL.Query().Get("user_id_type"); got != "open_id" {
+				t.Fatalf("unexpected user_id_type: %q", got)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{
+				"items": []map[string]any{
+					{"member_id": "m_ann", "user_id": "ou_ann", "type": "USER"},
+					{"member_id": "m_bo", "user_id": "ou_bo", "type": "USER"},
+				},
+				"has_more": false,
+			}})
+			return
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		bodies[action] = body
+		_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{}})
+	}
+}
+
+func TestMailPublicMailboxMembersListAndAdd(t *testing.T) {
+	var calls []string
+	bodies := map[string]map[string]any{}
+	var buf bytes.Buffer
+	state := newTestState(t, publicMailboxMembersHandler(t, &calls, bodies), nil, &buf)
+
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{"public-mailboxes", "members", "list", "support@example.com"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("members list error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "m_ann\tou_ann\tUSER") || !strings.Contains(buf.String(), "m_bo\tou_bo\tUSER") {
+		t.Fatalf("unexpected list output: %q", buf.String())
+	}
+
+	buf.Reset()
+	cmd = newMailCmd(state)
+	cmd.SetArgs([]string{"public-mailboxes", "members", "add", "support@example.com", "--user", "ou_cy", "--user", "ou_di"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("members add error: %v", err)
+	}
+	items, _ := bodies["POST /batch_create"]["items"].([]any)
+	if len(items) != 2 {
+		t.Fatalf("unexpected add payload: %#v", bodies)
+	}
+	first := items[0].(map[string]any)
+	if first["user_id"] != "ou_cy" || first["type"] != "USER" {
+		t.Fatalf("unexpected member: %#v", first)
+	}
+	if !strings.Contains(buf.String(), "added 2 member(s) to support@example.com") {
+		t.Fatalf("unexpected add output: %q", buf.String())
+	}
+
+	cmd = newMailCmd(state)
+	cmd.SetArgs([]string{"public-mailboxes", "members", "add", "support@example.com"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "at least one --user is required") {
+		t.Fatalf("expected missing user error, got %v", err)
+	}
+}
+
+func TestMailPublicMailboxMembersRemoveMixedTargets(t *testing.T) {
+	var calls []string
+	bodies := map[string]map[string]any{}
+	var buf bytes.Buffer
+	state := newTestState(t, publicMailboxMembersHandler(t, &calls, bodies), nil, &buf)
+	state.Force = true
+
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{"public-mailboxes", "members", "remove", "support@example.com", "--user", "ou_bo", "--member-id", "m_old"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("members remove error: %v", err)
+	}
+	if strings.Join(calls, ",") != "GET ,DELETE /batch_delete" {
+		t.Fatalf("unexpected calls: %v", calls)
+	}
+	ids, _ := bodies["DELETE /batch_delete"]["member_id_list"].([]any)
+	if len(ids) != 2 || ids[0] != "m_old" || ids[1] != "m_bo" {
+		t.Fatalf("unexpected remove payload: %#v", bodies)
+	}
+	if !strings.Contains(buf.String(), "removed 2 member(s) from support@example.com") {
+		t.Fatalf("unexpected remove output: %q", buf.String())
+	}
+
+	calls = nil
+	cmd = newMailCmd(state)
+	cmd.SetArgs([]string{"public-mailboxes", "members", "remove", "support@example.com", "--user", "ou_zed"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "user ou_zed is not a member of support@example.com") {
+		t.Fatalf("expected not-a-member error, got %v", err)
+	}
+	if strings.Join(calls, ",") != "GET " {
+		t.Fatalf("nothing should be removed for an unknown user, got %v", calls)
+	}
+}
+
+func TestMailPublicMailboxMembersRemoveByUser(t *testing.T) {
+	var body map[string]any
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/open-apis/mail/v1/public_mailboxes/security@example.com/members":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{
+				"items": []map[string]any{
+					{"member_id": "mem_1", "user_id": "ou_1", "type": "USER"},
+					{"member_id": "mem_2", "user_id": "ou_2", "type": "USER"},
+				},
+				"has_more": false,
+			}})
+		case r.Method == http.MethodDelete && r.URL.Path == "/open-apis/mail/v1/public_mailboxes/security@example.com/members/batch_delete":
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{}})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	state.Force = true
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{"public-mailbox", "members", "remove", "security@example.com", "--user", "ou_2"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("members remove error: %v", err)
+	}
+	ids, _ := body["member_id_list"].([]any)
+	if len(ids) != 1 || ids[0] != "mem_2" {
+		t.Fatalf("unexpected payload: %#v", body)
+	}
+
+	cmd = newMailCmd(state)
+	cmd.SetArgs([]string{"public-mailboxes", "members", "remove", "security@example.com", "--user", "ou_9"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "not a member") {
+		t.Fatalf("expected not a member error, got %v", err)
+	}
+}
@@ -0,0 +1,378 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/mail"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+type mailReplyOptions struct {
+	mailboxID string
+	text      string
+	html      string
+	cc        []string
+	bcc       []string
+	attach    []string
+	inline    []string
+	fromName  string
+}
+
+func (o *mailReplyOptions) bindFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.mailboxID, "mailbox-id", "", "user mailbox ID (defaults to config default_mailbox_id or 'me')")
+	cmd.Flags().StringVar(&o.text, "text", "", "plain text body")
+	cmd.Flags().StringVar(&o.html, "html", "", "HTML body")
+	cmd.Flags().StringArrayVar(&o.cc, "cc", nil, "additional cc email (repeatable)")
+	cmd.Flags().StringArrayVar(&o.bcc, "bcc", nil, "bcc email (repeatable)")
+	cmd.Flags().StringArrayVar(&o.attach, "attach", nil, "file to attach (repeatable)")
+	cmd.Flags().StringArrayVar(&o.inline, "inline", nil, "image to embed in --html as cid:<file name> (repeatable)")
+	cmd.Flags().StringVar(&o.fromName, "from-name", "", "display name for From header")
+}
+
+func newMailReplyCmd(state *appState) *cobra.Command {
+	return newMailReplyCommand(state, false)
+}
+
+func newMailReplyAllCmd(state *appState) *cobra.Command {
+	return newMailReplyCommand(state, true)
+}
+
+func newMailReplyCommand(state *appState, all bool) *cobra.Command {
+	var opts mailReplyOptions
+	var noQuote bool
+
+	use, short := "reply <message-id>", "Reply to the sender of a message"
+	if all {
+		use, short = "reply-all <message-id>", "Reply to the sender and all recipients of a message"
+	}
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long: `Reply threads the new message under the original.
+
+- In-Reply-To and References are set from the original message.
+- The original body is quoted below --text and --html; use --no-quote to skip it.
+- reply-all also addresses the original To and Cc, minus your own mailbox.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			if strings.TrimSpace(args[0]) == "" {
+				return errors.New("message-id is required")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.text == "" && opts.html == "" {
+				return errors.New("text or html is required")
+			}
+			if len(opts.inline) > 0 && opts.html == "" {
+				return errors.New("inline requires html")
+			}
+			attachments, err := buildMailAttachments(opts.attach, opts.inline)
+			if err != nil {
+				return err
+			}
+			opts.mailboxID = resolveMailboxID(state, opts.mailboxID)
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			token, err := tokenFor(ctx, state, tokenTypesUser)
+			if err != nil {
+				return err
+			}
+			original, err := state.SDK.GetMailMessage(ctx, token, opts.mailboxID, strings.TrimSpace(args[0]))
+			if err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			from := mailSelfAddress(ctx, state, token, opts.mailboxID, opts.fromName)
+			self := ""
+			if from != nil {
+				self = from.Address
+			}
+			to, cc := mailReplyRecipients(original, self, all)
+			if len(to) == 0 {
+				return errors.New("original message has no sender to reply to")
+			}
+			cc = appendMailAddressInputs(cc, buildMailAddressInputs(opts.cc))
+
+			compose := mailCompose{
+				From:        from,
+				To:          to,
+				CC:          cc,
+				BCC:         buildMailAddressInputs(opts.bcc),
+				Subject:     mailSubjectWithPrefix("Re:", original.Subject),
+				Text:        opts.text,
+				HTML:        opts.html,
+				Attachments: attachments,
+			}
+			compose.InReplyTo, compose.References = mailMessageReferences(original)
+			if !noQuote {
+				if compose.Text != "" {
+					compose.Text += "\n\n" + quoteMailText(original)
+				}
+				if compose.HTML != "" {
+					compose.HTML += "<br>" + quoteMailHTML(original)
+				}
+			}
+			messageID, err := sendMailCompose(ctx, state, token, opts.mailboxID, compose)
+			if err != nil {
+				return err
+			}
+			return printMailComposeResult(state, messageID, original, compose)
+		},
+	}
+	annotateAuthServices(cmd, "mail", "mail-send")
+
+	opts.bindFlags(cmd)
+	cmd.Flags().BoolVar(&noQuote, "no-quote", false, "do not quote the original message")
+	return cmd
+}
+
+func newMailForwardCmd(state *appState) *cobra.Command {
+	var opts mailReplyOptions
+	var to []string
+	var noAttachments bool
+
+	cmd := &cobra.Command{
+		Use:   "forward <message-id> --to <email>",
+		Short: "Forward a message",
+		Long: `Forward sends the original message to new recipients.
+
+- --text/--html add a note above the forwarded content.
+- Original attachments (including inline images) are kept unless --no-attachments is set.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			if strings.TrimSpace(args[0]) == "" {
+				return errors.New("message-id is required")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			toInputs := buildMailAddressInputs(to)
+			if len(toInputs) == 0 {
+				return errors.New("to is required")
+			}
+			if len(opts.inline) > 0 && opts.html == "" {
+				return errors.New("inline requires html")
+			}
+			attachments, err := buildMailAttachments(opts.attach, opts.inline)
+			if err != nil {
+				return err
+			}
+			opts.mailboxID = resolveMailboxID(state, opts.mailboxID)
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			token, err := tokenFor(ctx, state, tokenTypesUser)
+			if err != nil {
+				return err
+			}
+			original, err := state.SDK.GetMailMessage(ctx, token, opts.mailboxID, strings.TrimSpace(args[0]))
+			if err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			if !noAttachments {
+				kept, err := fetchMailAttachments(ctx, state, token, opts.mailboxID, original)
+				if err != nil {
+					return withUserScopeHintForCommand(state, err)
+				}
+				attachments = append(kept, attachments...)
+			}
+
+			compose := mailCompose{
+				From:        mailSelfAddress(ctx, state, token, opts.mailboxID, opts.fromName),
+				To:          toInputs,
+				CC:          buildMailAddressInputs(opts.cc),
+				BCC:         buildMailAddressInputs(opts.bcc),
+				Subject:     mailSubjectWithPrefix("Fwd:", original.Subject),
+				Text:        forwardMailText(original),
+				Attachments: attachments,
+			}
+			if opts.text != "" {
+				compose.Text = opts.text + "\n\n" + compose.Text
+			}
+			if opts.html != "" || original.BodyHTML != "" {
+				note := opts.html
+				if note == "" && opts.text != "" {
+					note = "<div>" + strings.ReplaceAll(html.EscapeString(opts.text), "\n", "<br>") + "</div>"
+				}
+				compose.HTML = note + "<br>" + forwardMailHTML(original)
+			}
+			messageID, err := sendMailCompose(ctx, state, token, opts.mailboxID, compose)
+			if err != nil {
+				return err
+			}
+			return printMailComposeResult(state, messageID, original, compose)
+		},
+	}
+	annotateAuthServices(cmd, "mail", "mail-send")
+
+	opts.bindFlags(cmd)
+	cmd.Flags().StringArrayVar(&to, "to", nil, "recipient email (repeatable)")
+	cmd.Flags().BoolVar(&noAttachments, "no-attachments", false, "do not forward the original attachments")
+	return cmd
+}
+
+func sendMailCompose(ctx context.Context, state *appState, token, mailboxID string, compose mailCompose) (string, error) {
+	eml, err := buildMailEML(compose)
+	if err != nil {
+		return "", err
+	}
+	messageID, err := state.SDK.SendMail(ctx, token, mailboxID, larksdk.SendMailRequest{
+		Raw: base64.URLEncoding.EncodeToString(eml),
+		BCC: compose.BCC,
+	})
+	if err != nil {
+		return "", withUserScopeHintForCommand(state, err)
+	}
+	return messageID, nil
+}
+
+func printMailComposeResult(state *appState, messageID string, original larksdk.MailMessage, compose mailCompose) error {
+	payload := map[string]any{
+		"message_id":          messageID,
+		"original_message_id": original.MessageID,
+		"subject":             compose.Subject,
+		"to":                  compose.To,
+	}
+	if len(compose.CC) > 0 {
+		payload["cc"] = compose.CC
+	}
+	if compose.InReplyTo != "" {
+		payload["in_reply_to"] = compose.InReplyTo
+	}
+	text := tableTextRow(
+		[]string{"message_id", "subject", "to"},
+		[]string{messageID, compose.Subject, formatMailAddressHeader(compose.To)},
+	)
+	return state.Printer.Print(payload, text)
+}
+
+// mailSelfAddress looks up the mailbox address for the From header and for
+// dropping ourselves from reply-all. It is best effort: the send API fills in
+// From itself when the lookup is not permitted.
+func mailSelfAddress(ctx context.Context, state *appState, token, mailboxID, fromName string) *mail.Address {
+	mailbox, err := state.SDK.GetMailbox(ctx, token, mailboxID)
+	if err != nil {
+		debugf(state, "mail: mailbox lookup failed: %v\n", err)
+		return nil
+	}
+	address := firstNonEmpty(mailbox.PrimaryEmail, mailbox.MailAddress, mailbox.Email)
+	if address == "" {
+		return nil
+	}
+	name := fromName
+	if name == "" {
+		name = mailbox.DisplayName
+	}
+	return &mail.Address{Name: name, Address: address}
+}
+
+func mailReplyRecipients(original larksdk.MailMessage, self string, all bool) ([]larksdk.MailAddressInput, []larksdk.MailAddressInput) {
+	isSelf := func(address string) bool {
+		return self != "" && strings.EqualFold(strings.TrimSpace(address), self)
+	}
+	to := make([]larksdk.MailAddressInput, 0)
+	if original.From.MailAddress != "" && !isSelf(original.From.MailAddress) {
+		to = append(to, larksdk.MailAddressInput{MailAddress: original.From.MailAddress, Name: original.From.Name})
+	} else {
+		// Replying to a message we sent goes back to its recipients.
+		for _, address := range original.To {
+			to = append(to, larksdk.MailAddressInput{MailAddress: address.MailAddress, Name: address.Name})
+		}
+	}
+	if !all {
+		return to, nil
+	}
+	for _, address := range original.To {
+		if isSelf(address.MailAddress) {
+			continue
+		}
+		to = appendMailAddressInputs(to, []larksdk.MailAddressInput{{MailAddress: address.MailAddress, Name: address.Name}})
+	}
+	cc := make([]larksdk.MailAddressInput, 0, len(original.CC))
+	for _, address := range original.CC {
+		if isSelf(address.MailAddress) || containsMailAddress(to, address.MailAddress) {
+			continue
+		}
+		cc = appendMailAddressInputs(cc, []larksdk.MailAddressInput{{MailAddress: address.MailAddress, Name: address.Name}})
+	}
+	return to, cc
+}
+
+func appendMailAddressInputs(list []larksdk.MailAddressInput, values []larksdk.MailAddressInput) []larksdk.MailAddressInput {
+	for _, value := range values {
+		if value.MailAddress == "" || containsMailAddress(list, value.MailAddress) {
+			continue
+		}
+		list = append(list, value)
+	}
+	return list
+}
+
+func containsMailAddress(list []larksdk.MailAddressInput, address string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item.MailAddress, address) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchMailAttachments returns the original attachments with their content,
+// downloading any whose body was not included in the message.
+func fetchMailAttachments(ctx context.Context, state *appState, token, mailboxID string, message larksdk.MailMessage) ([]larksdk.MailAttachment, error) {
+	if len(message.Attachments) == 0 {
+		return nil, nil
+	}
+	missing := make([]string, 0, len(message.Attachments))
+	for _, attachment := range message.Attachments {
+		if attachment.Body == "" {
+			missing = append(missing, attachment.ID)
+		}
+	}
+	urlByID := map[string]string{}
+	if len(missing) > 0 {
+		urls, err := state.SDK.GetMailAttachmentDownloadURLs(ctx, token, mailboxID, message.MessageID, missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range urls.Items {
+			urlByID[item.AttachmentID] = item.DownloadURL
+		}
+	}
+	attachments := make([]larksdk.MailAttachment, 0, len(message.Attachments))
+	for _, attachment := range message.Attachments {
+		if attachment.Body == "" {
+			downloadURL := urlByID[attachment.ID]
+			if downloadURL == "" {
+				return nil, fmt.Errorf("no download url returned for attachment %s", attachment.ID)
+			}
+			reader, err := state.SDK.DownloadMailAttachment(ctx, downloadURL)
+			if err != nil {
+				return nil, err
+			}
+			data, err := io.ReadAll(reader)
+			reader.Close()
+			if err != nil {
+				return nil, err
+			}
+			attachment.Body = base64.URLEncoding.EncodeToString(data)
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+func decodeSentEML(t *testing.T, r *http.Request) *mail.Message {
+	t.Helper()
+	var payload map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	raw, _ := payload["raw"].(string)
+	data, err := base64.URLEncoding.DecodeString(raw)
+	if err != nil {
+		t.Fatalf("decode raw: %v", err)
+	}
+	message, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("parse eml: %v", err)
+	}
+	return message
+}
+
+func mailMessageResponse(message map[string]any) map[string]any {
+	return map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"message": message}}
+}
+
+func TestMailReplyAllCommand(t *testing.T) {
+	var sent *mail.Message
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/messages/msg_1":
+			_ = json.NewEncoder(w).Encode(mailMessageResponse(map[string]any{
+				"message_id":      "msg_1",
+				"subject":         "Budget",
+				"smtp_message_id": "abc@mail.example.com",
+				"internal_date":   "1760000000000",
+				"body_plain_text": base64.URLEncoding.EncodeToString([]byte("Numbers attached.")),
+				"head_from":       map[string]any{"mail_address": "alice@example.com", "name": "Alice"},
+				"to":              []map[string]any{{"mail_address": "me@example.com"}, {"mail_address": "bob@example.com"}},
+				"cc":              []map[string]any{{"mail_address": "carol@example.com"}, {"mail_address": "ME@example.com"}},
+			}))
+		case r.Method == http.MethodGet && r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{"user_mailbox": map[string]any{"mailbox_id": "me", "primary_email": "me@example.com"}},
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/messages/send":
+			sent = decodeSentEML(t, r)
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"message_id": "msg_2"}})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{"reply-all", "msg_1", "--text", "Looks good."})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("mail reply-all error: %v", err)
+	}
+	if sent == nil {
+		t.Fatal("expected send request")
+	}
+	if got := sent.Header.Get("Subject"); got != "Re: Budget" {
+		t.Fatalf("unexpected subject: %q", got)
+	}
+	if got := sent.Header.Get("In-Reply-To"); got != "<abc@mail.example.com>" {
+		t.Fatalf("unexpected in-reply-to: %q", got)
+	}
+	if got := sent.Header.Get("References"); got != "<abc@mail.example.com>" {
+		t.Fatalf("unexpected references: %q", got)
+	}
+	if got := sent.Header.Get("To"); got != "\"Alice\" <alice@example.com>, <bob@example.com>" {
+		t.Fatalf("unexpected to: %q", got)
+	}
+	if got := sent.Header.Get("Cc"); got != "<carol@example.com>" {
+		t.Fatalf("unexpected cc: %q", got)
+	}
+	if got := sent.Header.Get("From"); got != "<me@example.com>" {
+		t.Fatalf("unexpected from: %q", got)
+	}
+	body, _ := io.ReadAll(sent.Body)
+	if !strings.Contains(string(body), "Looks good.") || !strings.Contains(string(body), "> Numbers attached.") || !strings.Contains(string(body), "Alice <alice@example.com> wrote:") {
+		t.Fatalf("unexpected body: %q", body)
+	}
+	if !strings.Contains(buf.String(), "msg_2") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestMailReplyBccIsNotInEML(t *testing.T) {
+	var payload map[string]any
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/messages/msg_1":
+			_ = json.NewEncoder(w).Encode(mailMessageResponse(map[string]any{
+				"message_id": "msg_1",
+				"subject":    "Budget",
+				"head_from":  map[string]any{"mail_address": "alice@example.com"},
+			}))
+		case r.Method == http.MethodGet && r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"user_mailbox": map[string]any{"primary_email": "me@example.com"}}})
+		case r.Method == http.MethodPost && r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/messages/send":
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"message_id": "msg_2"}})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{"reply", "msg_1", "--text", "Noted.", "--bcc", "audit@example.com"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("mail reply error: %v", err)
+	}
+	raw, _ := payload["raw"].(string)
+	data, err := base64.URLEncoding.DecodeString(raw)
+	if err != nil {
+		t.Fatalf("decode raw: %v", err)
+	}
+	if strings.Contains(strings.ToLower(string(data)), "bcc") || strings.Contains(string(data), "audit@example.com") {
+		t.Fatalf("bcc leaked into eml:\n%s", data)
+	}
+	bcc, _ := payload["bcc"].([]any)
+	if len(bcc) != 1 || bcc[0].(map[string]any)["mail_address"] != "audit@example.com" {
+		t.Fatalf("expected bcc recipient field, got %#v", payload["bcc"])
+	}
+}
+
+func TestMailTextEntityNormalisesLineEndings(t *testing.T) {
+	entity := mailTextEntity("text/plain", "one\r\ntwo\nthree\r\n")
+	if got := string(entity.body); got != "one\r\ntwo\r\nthree\r\n" {
+		t.Fatalf("expected CRLF line endings, got %q", got)
+	}
+}
+
+func TestMailForwardKeepsAttachments(t *testing.T) {
+	var sent *mail.Message
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/messages/msg_1":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(mailMessageResponse(map[string]any{
+				"message_id":      "msg_1",
+				"subject":         "Invoice",
+				"body_plain_text": "See invoice",
+				"head_from":       map[string]any{"mail_address": "vendor@example.com"},
+				"attachments":     []map[string]any{{"id": "att_1", "filename": "invoice.pdf"}},
+			}))
+		case r.Method == http.MethodGet && r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 99991672, "msg": "no permission"})
+		case r.Method == http.MethodGet && r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/messages/msg_1/attachments/download_url":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{"download_urls": []map[string]any{{"attachment_id": "att_1", "download_url": "http://files.test/att_1"}}},
+			})
+		case r.URL.Path == "/att_1":
+			_, _ = w.Write([]byte("PDFDATA"))
+		case r.Method == http.MethodPost && r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/messages/send":
+			sent = decodeSentEML(t, r)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"message_id": "msg_3"}})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{"forward", "msg_1", "--to", "finance@example.com", "--text", "FYI"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("mail forward error: %v", err)
+	}
+	if sent == nil {
+		t.Fatal("expected send request")
+	}
+	if got := sent.Header.Get("Subject"); got != "Fwd: Invoice" {
+		t.Fatalf("unexpected subject: %q", got)
+	}
+	if sent.Header.Get("From") != "" {
+		t.Fatalf("expected no From header when mailbox lookup fails")
+	}
+	mediaType, params, err := mime.ParseMediaType(sent.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("unexpected content type: %q %v", sent.Header.Get("Content-Type"), err)
+	}
+	reader := multipart.NewReader(sent.Body, params["boundary"])
+	var text, attachment string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("read part: %v", err)
+		}
+		data, _ := io.ReadAll(part)
+		if part.FileName() == "invoice.pdf" {
+			decoded, _ := base64.StdEncoding.DecodeString(string(data))
+			attachment = string(decoded)
+		} else {
+			text = string(data)
+		}
+	}
+	if attachment != "PDFDATA" {
+		t.Fatalf("unexpected attachment content: %q", attachment)
+	}
+	if !strings.Contains(text, "FYI") || !strings.Contains(text, "Forwarded message") || !strings.Contains(text, "See invoice") {
+		t.Fatalf("unexpected text: %q", text)
+	}
+}
+
+func TestMailThreadCommandOrdersByTime(t *testing.T) {
+	messages := map[string]map[string]any{
+		"m1": {"message_id": "m1", "thread_id": "th_1", "subject": "Re: Plan", "internal_date": "1760000200000", "head_from": map[string]any{"mail_address": "bob@example.com"}},
+		"m2": {"message_id": "m2", "thread_id": "th_2", "subject": "Other", "internal_date": "1760000100000"},
+		"m3": {"message_id": "m3", "thread_id": "th_1", "subject": "Plan", "internal_date": "1760000000000", "head_from": map[string]any{"mail_address": "me@example.com"}},
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/messages" && r.URL.Query().Get("folder_id") == "fld_in":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"items": []string{"m1", "m2"}, "has_more": false}})
+		case r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/messages" && r.URL.Query().Get("folder_id") == "fld_sent":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"items": []string{"m3", "m1"}, "has_more": false}})
+		case strings.HasPrefix(r.URL.Path, "/open-apis/mail/v1/user_mailboxes/me/messages/"):
+			id := strings.TrimPrefix(r.URL.Path, "/open-apis/mail/v1/user_mailboxes/me/messages/")
+			_ = json.NewEncoder(w).Encode(mailMessageResponse(messages[id]))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.String())
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{"thread", "th_1", "--folder-id", "fld_in", "--folder-id", "fld_sent"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("mail thread error: %v", err)
+	}
+	out := buf.String()
+	first := strings.Index(out, "message_id: m3")
+	second := strings.Index(out, "message_id: m1")
+	if first < 0 || second < 0 || first > second || strings.Contains(out, "m2") {
+		t.Fatalf("unexpected thread output: %q", out)
+	}
+}
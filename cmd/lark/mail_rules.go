@@ -0,0 +1,364 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+// Inbox rule enums from the mail API, keyed by the names the CLI accepts.
+var (
+	mailRuleConditionFields = map[string]int{
+		"from":            1,
+		"to":              2,
+		"cc":              3,
+		"to-or-cc":        4,
+		"subject":         6,
+		"body":            7,
+		"attachment-name": 8,
+	}
+	mailRuleOperators = map[string]int{
+		"contains":     1,
+		"not-contains": 2,
+		"starts-with":  3,
+		"ends-with":    4,
+		"is":           5,
+		"is-not":       6,
+	}
+	mailRuleActionTypes = map[string]int{
+		"archive":   1,
+		"delete":    2,
+		"mark-read": 3,
+		"spam":      4,
+		"not-spam":  5,
+		"flag":      7,
+		"move":      9,
+	}
+	mailRuleMatchTypes = map[string]int{
+		"all": 1,
+		"any": 2,
+	}
+)
+
+func newMailRulesCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rules",
+		Short: "Manage inbox rules",
+		Long: `Inbox rules run on incoming mail in evaluation order.
+
+- A rule has conditions (--when field:operator:value) and actions (--do action[:folder]).
+- --match all requires every condition; --match any requires one.
+- reorder takes every rule id in the desired order.`,
+	}
+	annotateAuthServices(cmd, "mail-rules")
+	cmd.AddCommand(newMailRulesListCmd(state))
+	cmd.AddCommand(newMailRulesCreateCmd(state))
+	cmd.AddCommand(newMailRulesDeleteCmd(state))
+	cmd.AddCommand(newMailRulesReorderCmd(state))
+	return cmd
+}
+
+func newMailRulesListCmd(state *appState) *cobra.Command {
+	var mailboxID string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List inbox rules in evaluation order",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mailboxID = resolveMailboxID(state, mailboxID)
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			rules, err := state.SDK.ListMailRules(cmd.Context(), token, larksdk.AccessTokenType(tokenType), mailboxID)
+			if err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			payload := map[string]any{"rules": rules}
+			rows := make([][]string, 0, len(rules))
+			for _, rule := range rules {
+				rows = append(rows, mailRuleRow(rule))
+			}
+			text := tableTextFromRows([]string{"rule_id", "name", "enabled", "match", "conditions", "actions"}, rows, "no rules found")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&mailboxID, "mailbox-id", "", "user mailbox ID (defaults to config default_mailbox_id or 'me')")
+	return cmd
+}
+
+func newMailRulesCreateCmd(state *appState) *cobra.Command {
+	var mailboxID string
+	var name string
+	var when []string
+	var do []string
+	var match string
+	var stop bool
+	var disabled bool
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create an inbox rule",
+		Long: `Create an inbox rule.
+
+- --when fields: from, to, cc, to-or-cc, subject, body, attachment-name.
+- --when operators: contains, not-contains, starts-with, ends-with, is, is-not.
+- --do actions: archive, delete, mark-read, spam, not-spam, flag, move:<folder>.
+- move accepts a folder ID or a system alias (INBOX/SENT/DRAFT/TRASH/SPAM/ARCHIVED).`,
+		Example: `  lark mail rules create --name Invoices --when from:contains:billing@example.com --do move:ARCHIVED --do mark-read
+  lark mail rules create --name Alerts --match any --when subject:contains:alert --when subject:contains:incident --do flag --stop`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				return flagUsage(cmd, "name is required")
+			}
+			if len(when) == 0 {
+				return flagUsage(cmd, "at least one --when condition is required")
+			}
+			if len(do) == 0 {
+				return flagUsage(cmd, "at least one --do action is required")
+			}
+			matchType, ok := mailRuleMatchTypes[strings.ToLower(strings.TrimSpace(match))]
+			if !ok {
+				return flagUsage(cmd, "match must be all or any")
+			}
+			conditions := make([]larksdk.MailRuleCondition, 0, len(when))
+			for _, value := range when {
+				condition, err := parseMailRuleCondition(value)
+				if err != nil {
+					return flagUsage(cmd, err.Error())
+				}
+				conditions = append(conditions, condition)
+			}
+
+			mailboxID = resolveMailboxID(state, mailboxID)
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			actions := make([]larksdk.MailRuleAction, 0, len(do))
+			for _, value := range do {
+				action, folder, err := parseMailRuleAction(value)
+				if err != nil {
+					return flagUsage(cmd, err.Error())
+				}
+				if folder != "" {
+					// Folder lookup needs a user token; tenant callers pass folder IDs.
+					action.Input = folder
+					if tokenType == tokenTypeUser {
+						action.Input, err = resolveMailFolderAlias(cmd.Context(), state, token, mailboxID, folder)
+						if err != nil {
+							return withUserScopeHintForCommand(state, err)
+						}
+					}
+				}
+				actions = append(actions, action)
+			}
+
+			rule, err := state.SDK.CreateMailRule(cmd.Context(), token, larksdk.AccessTokenType(tokenType), mailboxID, larksdk.MailRule{
+				Name:           name,
+				Enabled:        !disabled,
+				StopProcessing: stop,
+				MatchType:      matchType,
+				Conditions:     conditions,
+				Actions:        actions,
+			})
+			if err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			payload := map[string]any{"rule": rule}
+			return state.Printer.Print(payload, tableTextRow([]string{"rule_id", "name", "enabled", "match", "conditions", "actions"}, mailRuleRow(rule)))
+		},
+	}
+
+	cmd.Flags().StringVar(&mailboxID, "mailbox-id", "", "user mailbox ID (defaults to config default_mailbox_id or 'me')")
+	cmd.Flags().StringVar(&name, "name", "", "rule name")
+	cmd.Flags().StringArrayVar(&when, "when", nil, "condition as field:operator:value (repeatable)")
+	cmd.Flags().StringArrayVar(&do, "do", nil, "action, or move:<folder> (repeatable)")
+	cmd.Flags().StringVar(&match, "match", "all", "condition matching: all or any")
+	cmd.Flags().BoolVar(&stop, "stop", false, "skip the remaining rules when this rule matches")
+	cmd.Flags().BoolVar(&disabled, "disabled", false, "create the rule disabled")
+	return cmd
+}
+
+func newMailRulesDeleteCmd(state *appState) *cobra.Command {
+	var mailboxID string
+
+	cmd := &cobra.Command{
+		Use:   "delete <rule-id>",
+		Short: "Delete an inbox rule",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			if strings.TrimSpace(args[0]) == "" {
+				return errors.New("rule-id is required")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ruleID := strings.TrimSpace(args[0])
+			if err := confirmDestructive(cmd, state, fmt.Sprintf("delete rule %s", ruleID)); err != nil {
+				return err
+			}
+			mailboxID = resolveMailboxID(state, mailboxID)
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			if err := state.SDK.DeleteMailRule(cmd.Context(), token, larksdk.AccessTokenType(tokenType), mailboxID, ruleID); err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			payload := map[string]any{"rule_id": ruleID, "deleted": true}
+			return state.Printer.Print(payload, fmt.Sprintf("deleted rule %s", ruleID))
+		},
+	}
+
+	cmd.Flags().StringVar(&mailboxID, "mailbox-id", "", "user mailbox ID (defaults to config default_mailbox_id or 'me')")
+	return cmd
+}
+
+func newMailRulesReorderCmd(state *appState) *cobra.Command {
+	var mailboxID string
+
+	cmd := &cobra.Command{
+		Use:   "reorder <rule-id>...",
+		Short: "Set inbox rule evaluation order",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.MinimumNArgs(1)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ruleIDs := make([]string, 0, len(args))
+			seen := map[string]bool{}
+			for _, arg := range args {
+				id := strings.TrimSpace(arg)
+				if id == "" {
+					continue
+				}
+				if seen[id] {
+					return fmt.Errorf("rule %s is listed more than once", id)
+				}
+				seen[id] = true
+				ruleIDs = append(ruleIDs, id)
+			}
+			if len(ruleIDs) == 0 {
+				return errors.New("rule-id is required")
+			}
+			mailboxID = resolveMailboxID(state, mailboxID)
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			if err := state.SDK.ReorderMailRules(cmd.Context(), token, larksdk.AccessTokenType(tokenType), mailboxID, ruleIDs); err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			payload := map[string]any{"rule_ids": ruleIDs}
+			return state.Printer.Print(payload, "rule order: "+strings.Join(ruleIDs, ", "))
+		},
+	}
+
+	cmd.Flags().StringVar(&mailboxID, "mailbox-id", "", "user mailbox ID (defaults to config default_mailbox_id or 'me')")
+	return cmd
+}
+
+func parseMailRuleCondition(value string) (larksdk.MailRuleCondition, error) {
+	parts := strings.SplitN(value, ":", 3)
+	if len(parts) != 3 || strings.TrimSpace(parts[2]) == "" {
+		return larksdk.MailRuleCondition{}, fmt.Errorf("invalid --when %q (expected field:operator:value)", value)
+	}
+	field, ok := mailRuleConditionFields[strings.ToLower(strings.TrimSpace(parts[0]))]
+	if !ok {
+		return larksdk.MailRuleCondition{}, fmt.Errorf("unknown --when field %q (expected %s)", parts[0], mailRuleNames(mailRuleConditionFields))
+	}
+	operator, ok := mailRuleOperators[strings.ToLower(strings.TrimSpace(parts[1]))]
+	if !ok {
+		return larksdk.MailRuleCondition{}, fmt.Errorf("unknown --when operator %q (expected %s)", parts[1], mailRuleNames(mailRuleOperators))
+	}
+	return larksdk.MailRuleCondition{Type: field, Operator: operator, Input: strings.TrimSpace(parts[2])}, nil
+}
+
+// parseMailRuleAction returns the action and, for move, the unresolved folder.
+func parseMailRuleAction(value string) (larksdk.MailRuleAction, string, error) {
+	name, folder, hasFolder := strings.Cut(strings.TrimSpace(value), ":")
+	actionType, ok := mailRuleActionTypes[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return larksdk.MailRuleAction{}, "", fmt.Errorf("unknown --do action %q (expected %s)", name, mailRuleNames(mailRuleActionTypes))
+	}
+	folder = strings.TrimSpace(folder)
+	if actionType == mailRuleActionTypes["move"] {
+		if folder == "" {
+			return larksdk.MailRuleAction{}, "", errors.New("--do move requires a folder (move:<folder>)")
+		}
+		return larksdk.MailRuleAction{Type: actionType}, folder, nil
+	}
+	if hasFolder {
+		return larksdk.MailRuleAction{}, "", fmt.Errorf("--do %s does not take a value", name)
+	}
+	return larksdk.MailRuleAction{Type: actionType}, "", nil
+}
+
+func mailRuleNames(values map[string]int) string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func mailRuleName(values map[string]int, value int) string {
+	for name, candidate := range values {
+		if candidate == value {
+			return name
+		}
+	}
+	return strconv.Itoa(value)
+}
+
+func mailRuleRow(rule larksdk.MailRule) []string {
+	conditions := make([]string, 0, len(rule.Conditions))
+	for _, condition := range rule.Conditions {
+		conditions = append(conditions, fmt.Sprintf("%s %s %q", mailRuleName(mailRuleConditionFields, condition.Type), mailRuleName(mailRuleOperators, condition.Operator), condition.Input))
+	}
+	actions := make([]string, 0, len(rule.Actions))
+	for _, action := range rule.Actions {
+		label := mailRuleName(mailRuleActionTypes, action.Type)
+		if action.Input != "" {
+			label += ":" + action.Input
+		}
+		actions = append(actions, label)
+	}
+	if rule.StopProcessing {
+		actions = append(actions, "stop")
+	}
+	return []string{
+		rule.RuleID,
+		rule.Name,
+		strconv.FormatBool(rule.Enabled),
+		mailRuleName(mailRuleMatchTypes, rule.MatchType),
+		strings.Join(conditions, "; "),
+		strings.Join(actions, ", "),
+	}
+}
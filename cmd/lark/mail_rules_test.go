@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMailRulesCreateCommand(t *testing.T) {
+	var body map[string]any
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/folders":
+			_ = json.NewEncoder(w).Encode(mailModifyFoldersResponse())
+		case r.Method == http.MethodPost && r.URL.Path == "/open-apis/mail/v1/user_mailboxes/me/rules":
+			if r.Header.Get("Authorization") != "Bearer user-token" {
+				t.Fatalf("unexpected authorization: %s", r.Header.Get("Authorization"))
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			rule := map[string]any{"id": "rule_1"}
+			for key, value := range body {
+				rule[key] = value
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"rule": rule}})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	state.TokenType = "user"
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{
+		"rules", "create",
+		"--name", "Invoices",
+		"--when", "from:contains:billing@example.com",
+		"--when", "subject:starts-with:Invoice: Q3",
+		"--do", "move:ARCHIVED",
+		"--do", "mark-read",
+		"--stop",
+	})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("rules create error: %v", err)
+	}
+
+	rule := body
+	if rule["name"] != "Invoices" || rule["is_enable"] != true || rule["ignore_the_rest_of_rules"] != true {
+		t.Fatalf("unexpected rule: %#v", rule)
+	}
+	condition, _ := rule["condition"].(map[string]any)
+	items, _ := condition["items"].([]any)
+	if condition["match_type"] != float64(1) || len(items) != 2 {
+		t.Fatalf("unexpected condition: %#v", condition)
+	}
+	second, _ := items[1].(map[string]any)
+	if second["type"] != float64(6) || second["operator"] != float64(3) || second["input"] != "Invoice: Q3" {
+		t.Fatalf("unexpected condition item: %#v", second)
+	}
+	action, _ := rule["action"].(map[string]any)
+	actions, _ := action["items"].([]any)
+	move, _ := actions[0].(map[string]any)
+	if len(actions) != 2 || move["type"] != float64(9) || move["input"] != "fld_archive" {
+		t.Fatalf("unexpected actions: %#v", actions)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "rule_1") || !strings.Contains(out, "move:fld_archive, mark-read, stop") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestMailRulesCreateRejectsUnknownField(t *testing.T) {
+	var buf bytes.Buffer
+	state := newTestState(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+	}), nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{"rules", "create", "--name", "x", "--when", "sender:contains:a", "--do", "archive"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "unknown --when field") {
+		t.Fatalf("expected unknown field error, got %v", err)
+	}
+}
+
+func TestMailRulesListAndReorderCommand(t *testing.T) {
+	var reorder map[string]any
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/open-apis/mail/v1/user_mailboxes/ops@example.com/rules":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"items": []map[string]any{{
+				"id":        "rule_1",
+				"name":      "Spam",
+				"is_enable": true,
+				"condition": map[string]any{"match_type": 2, "items": []map[string]any{{"type": 1, "operator": 4, "input": "@spam.test"}}},
+				"action":    map[string]any{"items": []map[string]any{{"type": 4}}},
+			}}}})
+		case r.Method == http.MethodPost && r.URL.Path == "/open-apis/mail/v1/user_mailboxes/ops@example.com/rules/reorder":
+			if err := json.NewDecoder(r.Body).Decode(&reorder); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{}})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	cmd := newMailCmd(state)
+	cmd.SetArgs([]string{"rules", "list", "--mailbox-id", "ops@example.com"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("rules list error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "rule_1") || !strings.Contains(out, `from ends-with "@spam.test"`) || !strings.Contains(out, "spam") {
+		t.Fatalf("unexpected list output: %q", out)
+	}
+
+	cmd = newMailCmd(state)
+	cmd.SetArgs([]string{"rules", "reorder", "rule_2", "rule_1", "--mailbox-id", "ops@example.com"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("rules reorder error: %v", err)
+	}
+	ids, _ := reorder["rule_ids"].([]any)
+	if len(ids) != 2 || ids[0] != "rule_2" || ids[1] != "rule_1" {
+		t.Fatalf("unexpected reorder payload: %#v", reorder)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+func newMailThreadCmd(state *appState) *cobra.Command {
+	var mailboxID string
+	var folderIDs []string
+	var scanLimit int
+
+	cmd := &cobra.Command{
+		Use:   "thread <thread-id>",
+		Short: "Show a mail conversation ordered by time",
+		Long: `Thread collects every message that shares a thread_id.
+
+- The mail API has no thread lookup, so the latest --scan-limit messages of each --folder-id are scanned.
+- Messages are shown oldest first; --json includes full message bodies.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			if strings.TrimSpace(args[0]) == "" {
+				return errors.New("thread-id is required")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if scanLimit <= 0 {
+				return flagUsage(cmd, "scan-limit must be greater than 0")
+			}
+			threadID := strings.TrimSpace(args[0])
+			mailboxID = resolveMailboxID(state, mailboxID)
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			token, err := tokenFor(ctx, state, tokenTypesUser)
+			if err != nil {
+				return err
+			}
+
+			seen := map[string]bool{}
+			messages := make([]larksdk.MailMessage, 0)
+			for _, folder := range folderIDs {
+				folderID, err := resolveMailFolderID(ctx, state, token, mailboxID, folder)
+				if err != nil {
+					return withUserScopeHintForCommand(state, err)
+				}
+				scanned := 0
+				pageToken := ""
+				for scanned < scanLimit {
+					pageSize := scanLimit - scanned
+					if pageSize > maxMailPageSize {
+						pageSize = maxMailPageSize
+					}
+					result, err := state.SDK.ListMailMessages(ctx, token, larksdk.ListMailMessagesRequest{
+						MailboxID: mailboxID,
+						FolderID:  folderID,
+						PageSize:  pageSize,
+						PageToken: pageToken,
+					})
+					if err != nil {
+						return withUserScopeHintForCommand(state, err)
+					}
+					for _, item := range result.Items {
+						if scanned >= scanLimit {
+							break
+						}
+						scanned++
+						if item.MessageID == "" || seen[item.MessageID] {
+							continue
+						}
+						seen[item.MessageID] = true
+						message, err := state.SDK.GetMailMessage(ctx, token, mailboxID, item.MessageID)
+						if err != nil {
+							return withUserScopeHintForCommand(state, err)
+						}
+						if message.ThreadID != threadID {
+							continue
+						}
+						if message.MessageID == "" {
+							message.MessageID = item.MessageID
+						}
+						message.Raw = ""
+						messages = append(messages, message)
+					}
+					if !result.HasMore || result.PageToken == "" {
+						break
+					}
+					pageToken = result.PageToken
+				}
+			}
+			sortMailMessagesByTime(messages)
+
+			payload := map[string]any{"thread_id": threadID, "messages": messages}
+			return state.Printer.Print(payload, formatMailThread(messages))
+		},
+	}
+
+	cmd.Flags().StringVar(&mailboxID, "mailbox-id", "", "user mailbox ID (defaults to config default_mailbox_id or 'me')")
+	cmd.Flags().StringArrayVar(&folderIDs, "folder-id", []string{"INBOX", "SENT"}, "folder to scan (repeatable; system aliases: INBOX/SENT/DRAFT/TRASH/SPAM/ARCHIVED)")
+	cmd.Flags().IntVar(&scanLimit, "scan-limit", 100, "max number of recent messages to scan per folder")
+	return cmd
+}
+
+func sortMailMessagesByTime(messages []larksdk.MailMessage) {
+	sort.SliceStable(messages, func(i, j int) bool {
+		left, leftOK := mailMessageTime(messages[i])
+		right, rightOK := mailMessageTime(messages[j])
+		if leftOK && rightOK {
+			return left.Before(right)
+		}
+		return leftOK && !rightOK
+	})
+}
+
+func formatMailThread(messages []larksdk.MailMessage) string {
+	if len(messages) == 0 {
+		return "no messages found in thread"
+	}
+	blocks := make([]string, 0, len(messages))
+	for i, message := range messages {
+		lines := []string{
+			fmt.Sprintf("[%d] %s  %s", i+1, formatMailMessageDate(message), formatMailAddressDisplay(message.From)),
+			"message_id: " + message.MessageID,
+			"subject: " + message.Subject,
+		}
+		if body := strings.TrimSpace(mailOriginalText(message)); body != "" {
+			lines = append(lines, "", body)
+		}
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+	return strings.Join(blocks, "\n\n")
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -25,13 +26,16 @@ func newMeetingsCmd(state *appState) *cobra.Command {
 
 - meeting_id identifies a meeting; meeting_no is the join number.
 - Meetings have time ranges, status, and participants.
-- list defaults to the last 6 months when start/end are omitted; create/update manage meeting details.`,
+- list defaults to the last 6 months when start/end are omitted; create/update manage meeting details.
+- recording manages cloud recordings; report aggregates attendance and usage over a time range.`,
 	}
 	cmd.AddCommand(newMeetingInfoCmd(state))
 	cmd.AddCommand(newMeetingListCmd(state))
 	cmd.AddCommand(newMeetingCreateCmd(state))
 	cmd.AddCommand(newMeetingUpdateCmd(state))
 	cmd.AddCommand(newMeetingDeleteCmd(state))
+	cmd.AddCommand(newMeetingRecordingCmd(state))
+	cmd.AddCommand(newMeetingReportCmd(state))
 	return cmd
 }
 
@@ -67,27 +71,9 @@ func newMeetingInfoCmd(state *appState) *cobra.Command {
 				return err
 			}
 
-			meetingID := input
-			// If the user provided a meeting number (commonly 9 digits), resolve it to
-			// a meeting_id via the documented list_by_no API.
-			if isLikelyMeetingNo(input) {
-				now := time.Now().UTC()
-				// list_by_no requires a bounded time window; use a recent window by default.
-				startUnix := now.AddDate(0, 0, -30).Unix()
-				endUnix := now.Unix()
-				resolved, resolveErr := state.SDK.ListMeetingsByNo(cmd.Context(), token, larksdk.ListMeetingsByNoRequest{
-					MeetingNo: input,
-					StartTime: strconv.FormatInt(startUnix, 10),
-					EndTime:   strconv.FormatInt(endUnix, 10),
-					PageSize:  20,
-				})
-				if resolveErr != nil {
-					return withUserScopeHintForCommand(state, resolveErr)
-				}
-				if len(resolved.Items) == 0 || strings.TrimSpace(resolved.Items[0].ID) == "" {
-					return fmt.Errorf("meeting not found for meeting_no %q", input)
-				}
-				meetingID = resolved.Items[0].ID
+			meetingID, err := resolveMeetingID(cmd.Context(), state, token, input)
+			if err != nil {
+				return err
 			}
 
 			meeting, err := state.SDK.GetMeeting(cmd.Context(), token, larksdk.GetMeetingRequest{
@@ -123,6 +109,32 @@ func newMeetingInfoCmd(state *appState) *cobra.Command {
 	return cmd
 }
 
+// resolveMeetingID returns input unchanged unless it looks like a meeting number
+// (commonly 9 digits), which is resolved to a meeting_id via the documented
+// list_by_no API.
+func resolveMeetingID(ctx context.Context, state *appState, token, input string) (string, error) {
+	if !isLikelyMeetingNo(input) {
+		return input, nil
+	}
+	now := time.Now().UTC()
+	// list_by_no requires a bounded time window; use a recent window by default.
+	startUnix := now.AddDate(0, 0, -30).Unix()
+	endUnix := now.Unix()
+	resolved, err := state.SDK.ListMeetingsByNo(ctx, token, larksdk.ListMeetingsByNoRequest{
+		MeetingNo: input,
+		StartTime: strconv.FormatInt(startUnix, 10),
+		EndTime:   strconv.FormatInt(endUnix, 10),
+		PageSize:  20,
+	})
+	if err != nil {
+		return "", withUserScopeHintForCommand(state, err)
+	}
+	if len(resolved.Items) == 0 || strings.TrimSpace(resolved.Items[0].ID) == "" {
+		return "", fmt.Errorf("meeting not found for meeting_no %q", input)
+	}
+	return resolved.Items[0].ID, nil
+}
+
 func isLikelyMeetingNo(value string) bool {
 	value = strings.TrimSpace(value)
 	if value == "" {
@@ -324,6 +336,13 @@ func newMeetingCreateCmd(state *appState) *cobra.Command {
 			if tokenType == tokenTypeTenant && ownerID == "" {
 				return flagUsage(cmd, "owner-id is required when using tenant access token")
 			}
+			if ownerID != "" {
+				owners, err := resolveUserRefs(cmd.Context(), state, []string{ownerID}, userIDType)
+				if err != nil {
+					return err
+				}
+				ownerID = owners[0]
+			}
 			endUnix, err := parseMeetingTime(endTime)
 			if err != nil {
 				return flagUsage(cmd, fmt.Sprintf("invalid end time: %v", err))
@@ -351,7 +370,7 @@ func newMeetingCreateCmd(state *appState) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&endTime, "end-time", "", "end time (RFC3339 or unix seconds)")
-	cmd.Flags().StringVar(&ownerID, "owner-id", "", "owner user ID (required for tenant token)")
+	cmd.Flags().StringVar(&ownerID, "owner-id", "", "owner user ID, email, or @name (required for tenant token)")
 	cmd.Flags().StringVar(&userIDType, "user-id-type", "", "user ID type (user_id, union_id, open_id)")
 	cmd.Flags().StringVar(&topic, "topic", "", "meeting topic")
 	cmd.Flags().IntVar(&meetingInitialType, "meeting-initial-type", 0, "meeting initial type")
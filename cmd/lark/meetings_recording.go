@@ -0,0 +1,213 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+func newMeetingRecordingCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "recording",
+		Short: "Manage meeting recordings",
+		Long: `Recordings are cloud recordings of a meeting.
+
+- Meetings are identified by meeting_id or a recent meeting number.
+- start/stop act on an ongoing meeting and need a user token of a host.
+- share grants view access to users, chats, the tenant, or anyone with the link.`,
+	}
+	annotateAuthServices(cmd, "vc-recording")
+	cmd.AddCommand(newMeetingRecordingGetCmd(state))
+	cmd.AddCommand(newMeetingRecordingStartCmd(state))
+	cmd.AddCommand(newMeetingRecordingStopCmd(state))
+	cmd.AddCommand(newMeetingRecordingShareCmd(state))
+	return cmd
+}
+
+func meetingIDArgs(cmd *cobra.Command, args []string) error {
+	if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+		return argsUsageError(cmd, err)
+	}
+	if strings.TrimSpace(args[0]) == "" {
+		return argsUsageError(cmd, errors.New("meeting-id is required"))
+	}
+	return nil
+}
+
+func newMeetingRecordingGetCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get <meeting-id>",
+		Short: "Show a meeting recording",
+		Args:  meetingIDArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, tokenTypeValue, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			meetingID, err := resolveMeetingID(cmd.Context(), state, token, strings.TrimSpace(args[0]))
+			if err != nil {
+				return err
+			}
+			recording, err := state.SDK.GetMeetingRecording(cmd.Context(), token, larksdk.AccessTokenType(tokenTypeValue), meetingID)
+			if err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			if recording.MeetingID == "" {
+				recording.MeetingID = meetingID
+			}
+			payload := map[string]any{"recording": recording}
+			text := tableTextRow(
+				[]string{"meeting_id", "recording_id", "duration", "url"},
+				[]string{recording.MeetingID, recording.ID, formatRecordingDuration(recording.Duration), recording.URL},
+			)
+			return state.Printer.Print(payload, text)
+		},
+	}
+	return cmd
+}
+
+func newMeetingRecordingStartCmd(state *appState) *cobra.Command {
+	var timezone int
+
+	cmd := &cobra.Command{
+		Use:   "start <meeting-id>",
+		Short: "Start recording an ongoing meeting",
+		Args:  meetingIDArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if timezone < -12 || timezone > 14 {
+				return flagUsage(cmd, "timezone must be between -12 and 14")
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, err := tokenFor(cmd.Context(), state, tokenTypesUser)
+			if err != nil {
+				return err
+			}
+			meetingID, err := resolveMeetingID(cmd.Context(), state, token, strings.TrimSpace(args[0]))
+			if err != nil {
+				return err
+			}
+			if err := state.SDK.StartMeetingRecording(cmd.Context(), token, meetingID, timezone); err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			payload := map[string]any{"meeting_id": meetingID, "recording": "started"}
+			return state.Printer.Print(payload, fmt.Sprintf("started recording %s", meetingID))
+		},
+	}
+
+	_, offset := time.Now().Zone()
+	cmd.Flags().IntVar(&timezone, "timezone", offset/3600, "UTC offset in hours for the recording file name")
+	return cmd
+}
+
+func newMeetingRecordingStopCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stop <meeting-id>",
+		Short: "Stop recording an ongoing meeting",
+		Args:  meetingIDArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, err := tokenFor(cmd.Context(), state, tokenTypesUser)
+			if err != nil {
+				return err
+			}
+			meetingID, err := resolveMeetingID(cmd.Context(), state, token, strings.TrimSpace(args[0]))
+			if err != nil {
+				return err
+			}
+			if err := state.SDK.StopMeetingRecording(cmd.Context(), token, meetingID); err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			payload := map[string]any{"meeting_id": meetingID, "recording": "stopped"}
+			return state.Printer.Print(payload, fmt.Sprintf("stopped recording %s", meetingID))
+		},
+	}
+	return cmd
+}
+
+func newMeetingRecordingShareCmd(state *appState) *cobra.Command {
+	var users []string
+	var chats []string
+	var tenant bool
+	var public bool
+	var revoke bool
+	var userIDType string
+
+	cmd := &cobra.Command{
+		Use:   "share <meeting-id>",
+		Short: "Grant or revoke view access to a recording",
+		Example: `  lark meetings recording share 6911188411932033028 --user ou_xxx --chat oc_xxx
+  lark meetings recording share 6911188411932033028 --tenant
+  lark meetings recording share 6911188411932033028 --public --revoke`,
+		Args: meetingIDArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			objects := make([]larksdk.RecordingPermissionObject, 0, len(users)+len(chats)+2)
+			for _, user := range users {
+				if user = strings.TrimSpace(user); user != "" {
+					objects = append(objects, larksdk.RecordingPermissionObject{ID: user, Type: 1, Permission: 1})
+				}
+			}
+			for _, chat := range chats {
+				if chat = strings.TrimSpace(chat); chat != "" {
+					objects = append(objects, larksdk.RecordingPermissionObject{ID: chat, Type: 2, Permission: 1})
+				}
+			}
+			if tenant {
+				objects = append(objects, larksdk.RecordingPermissionObject{Type: 3, Permission: 1})
+			}
+			if public {
+				objects = append(objects, larksdk.RecordingPermissionObject{Type: 4, Permission: 1})
+			}
+			if len(objects) == 0 {
+				return flagUsage(cmd, "at least one of --user, --chat, --tenant, or --public is required")
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, err := tokenFor(cmd.Context(), state, tokenTypesUser)
+			if err != nil {
+				return err
+			}
+			meetingID, err := resolveMeetingID(cmd.Context(), state, token, strings.TrimSpace(args[0]))
+			if err != nil {
+				return err
+			}
+			if err := state.SDK.SetMeetingRecordingPermission(cmd.Context(), token, meetingID, objects, revoke, userIDType); err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+			action := "granted"
+			if revoke {
+				action = "revoked"
+			}
+			payload := map[string]any{"meeting_id": meetingID, "action": action, "permission_objects": objects}
+			return state.Printer.Print(payload, fmt.Sprintf("%s view access for %d target(s) on recording %s", action, len(objects), meetingID))
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&users, "user", nil, "user ID to share with (repeatable)")
+	cmd.Flags().StringArrayVar(&chats, "chat", nil, "chat ID to share with (repeatable)")
+	cmd.Flags().BoolVar(&tenant, "tenant", false, "share with everyone in the tenant")
+	cmd.Flags().BoolVar(&public, "public", false, "share with anyone who has the link")
+	cmd.Flags().BoolVar(&revoke, "revoke", false, "revoke access instead of granting it")
+	cmd.Flags().StringVar(&userIDType, "user-id-type", "", "user ID type for --user (user_id, union_id, open_id)")
+	return cmd
+}
+
+func formatRecordingDuration(ms string) string {
+	value, err := time.ParseDuration(strings.TrimSpace(ms) + "ms")
+	if err != nil || strings.TrimSpace(ms) == "" {
+		return ms
+	}
+	return value.Round(time.Second).String()
+}
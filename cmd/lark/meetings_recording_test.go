@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const recordingMeetingID = "6911188411932033028"
+
+func TestMeetingRecordingGetCommand(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/open-apis/vc/v1/meetings/"+recordingMeetingID+"/recording" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{
+			"recording": map[string]any{"url": "https://meetings.feishu.cn/minutes/obcn1", "duration": "3725000"},
+		}})
+	})
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newMeetingsCmd(state)
+	cmd.SetArgs([]string{"recording", "get", recordingMeetingID})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("meetings recording get error: %v", err)
+	}
+	if !strings.Contains(buf.String(), recordingMeetingID+"\t\t1h2m5s\thttps://meetings.feishu.cn/minutes/obcn1") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestMeetingRecordingStartStopUseUserToken(t *testing.T) {
+	var calls []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer user-token" {
+			t.Fatalf("recording control must use the user token")
+		}
+		calls = append(calls, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/open-apis/vc/v1/meetings/"+recordingMeetingID))
+		if strings.HasSuffix(r.URL.Path, "/start") {
+			var payload map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			if payload["timezone"] != float64(8) {
+				t.Fatalf("unexpected payload: %#v", payload)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok"})
+	})
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+
+	cmd := newMeetingsCmd(state)
+	cmd.SetArgs([]string{"recording", "start", recordingMeetingID, "--timezone", "8"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("meetings recording start error: %v", err)
+	}
+	cmd = newMeetingsCmd(state)
+	cmd.SetArgs([]string{"recording", "stop", recordingMeetingID})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("meetings recording stop error: %v", err)
+	}
+	if strings.Join(calls, ",") != "PATCH /recording/start,PATCH /recording/stop" {
+		t.Fatalf("unexpected calls: %v", calls)
+	}
+	if !strings.Contains(buf.String(), "started recording "+recordingMeetingID) || !strings.Contains(buf.String(), "stopped recording "+recordingMeetingID) {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+
+	cmd = newMeetingsCmd(state)
+	cmd.SetArgs([]string{"recording", "start", recordingMeetingID, "--timezone", "15"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "timezone must be between -12 and 14") {
+		t.Fatalf("expected timezone error, got %v", err)
+	}
+}
+
+func TestMeetingRecordingShareCommand(t *testing.T) {
+	var body map[string]any
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/open-apis/vc/v1/meetings/"+recordingMeetingID+"/recording/set_permission" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer user-token" {
+			t.Fatalf("unexpected authorization: %s", r.Header.Get("Authorization"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{}})
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	cmd := newMeetingsCmd(state)
+	cmd.SetArgs([]string{"recording", "share", recordingMeetingID, "--user", "ou_1", "--tenant"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("meetings recording share error: %v", err)
+	}
+	objects, _ := body["permission_objects"].([]any)
+	if len(objects) != 2 || body["action_type"] != float64(0) {
+		t.Fatalf("unexpected payload: %#v", body)
+	}
+	user, _ := objects[0].(map[string]any)
+	tenant, _ := objects[1].(map[string]any)
+	if user["id"] != "ou_1" || user["type"] != float64(1) || user["permission"] != float64(1) || tenant["type"] != float64(3) {
+		t.Fatalf("unexpected permission objects: %#v", objects)
+	}
+	if !strings.Contains(buf.String(), "granted view access for 2 target(s)") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestMeetingRecordingShareRevoke(t *testing.T) {
+	var payload map[string]any
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/open-apis/vc/v1/meetings/"+recordingMeetingID+"/recording/set_permission" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.URL.Query().Get("user_id_type"); got != "open_id" {
+			t.Fatalf("unexpected user_id_type: %q", got)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok"})
+	})
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+
+	cmd := newMeetingsCmd(state)
+	cmd.SetArgs([]string{"recording", "share", recordingMeetingID, "--user", "ou_ann", "--chat", "oc_team", "--tenant", "--revoke", "--user-id-type", "open_id"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("meetings recording share error: %v", err)
+	}
+	if payload["action_type"] != float64(1) {
+		t.Fatalf("expected revoke action, got %#v", payload)
+	}
+	objects, _ := payload["permission_objects"].([]any)
+	if len(objects) != 3 {
+		t.Fatalf("unexpected permission objects: %#v", payload["permission_objects"])
+	}
+	user := objects[0].(map[string]any)
+	chat := objects[1].(map[string]any)
+	tenant := objects[2].(map[string]any)
+	if user["id"] != "ou_ann" || user["type"] != float64(1) || chat["id"] != "oc_team" || chat["type"] != float64(2) || tenant["type"] != float64(3) {
+		t.Fatalf("unexpected permission objects: %#v", objects)
+	}
+	if _, ok := tenant["id"]; ok {
+		t.Fatalf("tenant grant must not carry an id: %#v", tenant)
+	}
+	if !strings.Contains(buf.String(), "revoked view access for 3 target(s) on recording "+recordingMeetingID) {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+
+	cmd = newMeetingsCmd(state)
+	cmd.SetArgs([]string{"recording", "share", recordingMeetingID})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "at least one of --user") {
+		t.Fatalf("expected missing target error, got %v", err)
+	}
+}
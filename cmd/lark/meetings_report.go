@@ -0,0 +1,385 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+const (
+	meetingParticipantsPageSize = 100
+	meetingListTimeLayout       = "2006.01.02 15:04:05 (GMT-07:00)"
+)
+
+type meetingReportOptions struct {
+	start   string
+	end     string
+	topic   string
+	outPath string
+}
+
+func (o *meetingReportOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.start, "start", "", "meetings starting at or after (RFC3339 or unix seconds)")
+	cmd.Flags().StringVar(&o.end, "end", "", "meetings starting before (RFC3339 or unix seconds)")
+	cmd.Flags().StringVar(&o.topic, "topic", "", "only meetings whose topic contains this text")
+	cmd.Flags().StringVar(&o.outPath, "out", "", "write the report as CSV to this path (or - for stdout)")
+	_ = cmd.MarkFlagRequired("start")
+	_ = cmd.MarkFlagRequired("end")
+}
+
+func (o *meetingReportOptions) timeRange(cmd *cobra.Command) (string, string, error) {
+	startUnix, err := parseMeetingTime(strings.TrimSpace(o.start))
+	if err != nil {
+		return "", "", flagUsage(cmd, fmt.Sprintf("invalid start time: %v", err))
+	}
+	endUnix, err := parseMeetingTime(strings.TrimSpace(o.end))
+	if err != nil {
+		return "", "", flagUsage(cmd, fmt.Sprintf("invalid end time: %v", err))
+	}
+	if endUnix <= startUnix {
+		return "", "", flagUsage(cmd, "end time must be after start time")
+	}
+	return strconv.FormatInt(startUnix, 10), strconv.FormatInt(endUnix, 10), nil
+}
+
+// meetings lists every meeting in the range, filtered by topic.
+func (o *meetingReportOptions) meetings(ctx context.Context, state *appState, token, start, end string) ([]larksdk.MeetingListItem, error) {
+	topic := strings.ToLower(strings.TrimSpace(o.topic))
+	meetings := []larksdk.MeetingListItem{}
+	pageToken := ""
+	for {
+		result, err := state.SDK.ListMeetings(ctx, token, larksdk.ListMeetingsRequest{
+			StartTime: start,
+			EndTime:   end,
+			PageSize:  meetingListMaxPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, meeting := range result.Items {
+			if topic != "" && !strings.Contains(strings.ToLower(meeting.Topic), topic) {
+				continue
+			}
+			meetings = append(meetings, meeting)
+		}
+		if !result.HasMore || result.PageToken == "" {
+			break
+		}
+		pageToken = result.PageToken
+	}
+	return meetings, nil
+}
+
+// meetingInstanceWindow returns the unix start and end of one meeting from
+// the meeting list, which reports times as "2006.01.02 15:04:05 (GMT+08:00)".
+// Meetings still in progress have no end time and use rangeEnd.
+func meetingInstanceWindow(meeting larksdk.MeetingListItem, rangeEnd string) (string, string, bool) {
+	startUnix, ok := parseMeetingListTime(meeting.StartTime)
+	if !ok {
+		return "", "", false
+	}
+	end := rangeEnd
+	if endUnix, ok := parseMeetingListTime(meeting.EndTime); ok && endUnix >= startUnix {
+		end = strconv.FormatInt(endUnix, 10)
+	}
+	return strconv.FormatInt(startUnix, 10), end, true
+}
+
+func parseMeetingListTime(raw string) (int64, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return 0, false
+	}
+	if unix, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return unix, true
+	}
+	parsed, err := time.Parse(meetingListTimeLayout, raw)
+	if err != nil {
+		return 0, false
+	}
+	return parsed.Unix(), true
+}
+
+// write prints the report as CSV when --out is set, otherwise as a table.
+func (o *meetingReportOptions) write(state *appState, payload map[string]any, header []string, rows [][]string, empty string) error {
+	outPath := strings.TrimSpace(o.outPath)
+	if outPath == "" {
+		return state.Printer.Print(payload, tableTextFromRows(header, rows, empty))
+	}
+	if outPath == "-" {
+		return writeCSV(state.Printer.Writer, header, rows)
+	}
+	file, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	if err := writeCSV(file, header, rows); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	payload["output_path"] = outPath
+	return state.Printer.Print(payload, fmt.Sprintf("wrote %d row(s) to %s", len(rows), outPath))
+}
+
+func newMeetingReportCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Aggregate meeting attendance and usage",
+		Long: `Reports cover meetings that started between --start and --end.
+
+- participants lists every join/leave of every participant, one row per session.
+- usage ranks meetings by duration and attendee count.
+- --topic narrows the meetings; --out writes CSV.`,
+	}
+	cmd.AddCommand(newMeetingReportParticipantsCmd(state))
+	cmd.AddCommand(newMeetingReportUsageCmd(state))
+	return cmd
+}
+
+func newMeetingReportParticipantsCmd(state *appState) *cobra.Command {
+	var opts meetingReportOptions
+	var userIDType string
+
+	cmd := &cobra.Command{
+		Use:     "participants",
+		Short:   "Export participant join/leave times across meetings",
+		Example: `  lark meetings report participants --start 2024-05-01T00:00:00Z --end 2024-06-01T00:00:00Z --topic Training --out attendance.csv`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			start, end, err := opts.timeRange(cmd)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, tokenTypeValue, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			meetings, err := opts.meetings(cmd.Context(), state, token, start, end)
+			if err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+
+			type participantRow struct {
+				MeetingNo string `json:"meeting_no"`
+				Topic     string `json:"topic"`
+				larksdk.MeetingParticipantRecord
+			}
+			records := []participantRow{}
+			rows := [][]string{}
+			queried := map[string]bool{}
+			for _, meeting := range meetings {
+				meetingNo := meeting.MeetingNo
+				if meetingNo == "" {
+					meetingNo = meeting.ID
+				}
+				// Recurring meetings and personal rooms reuse the meeting
+				// number, so query each instance by its own times. When they
+				// cannot be parsed, query the number once for the whole range.
+				queryStart, queryEnd := start, end
+				if instanceStart, instanceEnd, ok := meetingInstanceWindow(meeting, end); ok {
+					queryStart, queryEnd = instanceStart, instanceEnd
+				}
+				key := meetingNo + "|" + queryStart + "|" + queryEnd
+				if queried[key] {
+					continue
+				}
+				queried[key] = true
+				pageToken := ""
+				for {
+					result, err := state.SDK.ListMeetingParticipants(cmd.Context(), token, larksdk.AccessTokenType(tokenTypeValue), larksdk.ListMeetingParticipantsRequest{
+						StartTime:  queryStart,
+						EndTime:    queryEnd,
+						MeetingNo:  meetingNo,
+						PageSize:   meetingParticipantsPageSize,
+						PageToken:  pageToken,
+						UserIDType: userIDType,
+					})
+					if err != nil {
+						return withUserScopeHintForCommand(state, fmt.Errorf("meeting %s: %w", meetingNo, err))
+					}
+					for _, participant := range result.Items {
+						records = append(records, participantRow{MeetingNo: meetingNo, Topic: meeting.Topic, MeetingParticipantRecord: participant})
+						rows = append(rows, []string{
+							meetingNo,
+							meeting.Topic,
+							participant.Name,
+							participant.UserID,
+							participant.EmployeeID,
+							participant.Email,
+							participant.Department,
+							participant.JoinTime,
+							participant.LeaveTime,
+							participant.TimeInMeeting,
+							strconv.FormatBool(participant.IsExternal),
+						})
+					}
+					if !result.HasMore || result.PageToken == "" {
+						break
+					}
+					pageToken = result.PageToken
+				}
+			}
+
+			header := []string{"meeting_no", "topic", "participant", "user_id", "employee_id", "email", "department", "join_time", "leave_time", "time_in_meeting", "external"}
+			payload := map[string]any{"start_time": start, "end_time": end, "meetings": len(meetings), "participants": records}
+			return opts.write(state, payload, header, rows, "no participants found")
+		},
+	}
+
+	opts.addFlags(cmd)
+	cmd.Flags().StringVar(&userIDType, "user-id-type", "", "user ID type (user_id, union_id, open_id)")
+	return cmd
+}
+
+func newMeetingReportUsageCmd(state *appState) *cobra.Command {
+	var opts meetingReportOptions
+	var top int
+	var sortBy string
+
+	cmd := &cobra.Command{
+		Use:     "usage",
+		Short:   "Rank meetings by duration and attendee count",
+		Example: `  lark meetings report usage --start 2024-05-01T00:00:00Z --end 2024-06-01T00:00:00Z --top 20 --sort participants`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if top < 0 {
+				return flagUsage(cmd, "top must be 0 or greater")
+			}
+			sortBy = strings.ToLower(strings.TrimSpace(sortBy))
+			if sortBy != "duration" && sortBy != "participants" {
+				return flagUsage(cmd, "sort must be duration or participants")
+			}
+			start, end, err := opts.timeRange(cmd)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, err := tokenFor(cmd.Context(), state, tokenTypesTenantOrUser)
+			if err != nil {
+				return err
+			}
+			meetings, err := opts.meetings(cmd.Context(), state, token, start, end)
+			if err != nil {
+				return withUserScopeHintForCommand(state, err)
+			}
+
+			total := len(meetings)
+			totalSeconds := int64(0)
+			for _, meeting := range meetings {
+				totalSeconds += parseMeetingDuration(meeting.Duration)
+			}
+			byDuration := func(i, j int) bool {
+				return parseMeetingDuration(meetings[i].Duration) > parseMeetingDuration(meetings[j].Duration)
+			}
+			byParticipants := func(i, j int) bool {
+				return parseMeetingCount(meetings[i].ParticipantCount) > parseMeetingCount(meetings[j].ParticipantCount)
+			}
+			sort.SliceStable(meetings, func(i, j int) bool {
+				if sortBy == "participants" {
+					if byParticipants(i, j) || byParticipants(j, i) {
+						return byParticipants(i, j)
+					}
+					return byDuration(i, j)
+				}
+				if byDuration(i, j) || byDuration(j, i) {
+					return byDuration(i, j)
+				}
+				return byParticipants(i, j)
+			})
+			if top > 0 && len(meetings) > top {
+				meetings = meetings[:top]
+			}
+
+			rows := make([][]string, 0, len(meetings))
+			for _, meeting := range meetings {
+				meetingNo := meeting.MeetingNo
+				if meetingNo == "" {
+					meetingNo = meeting.ID
+				}
+				rows = append(rows, []string{meetingNo, meeting.Topic, meeting.Organizer, meeting.StartTime, meeting.Duration, meeting.ParticipantCount})
+			}
+			header := []string{"meeting_no", "topic", "organizer", "start_time", "duration", "participants"}
+			payload := map[string]any{
+				"start_time":             start,
+				"end_time":               end,
+				"total_meetings":         total,
+				"total_duration_seconds": totalSeconds,
+				"meetings":               meetings,
+			}
+			if strings.TrimSpace(opts.outPath) == "" && !state.Printer.JSON {
+				text := tableTextFromRows(header, rows, "no meetings found")
+				text += fmt.Sprintf("\n%d meeting(s), %s in total", total, formatMeetingSeconds(totalSeconds))
+				return state.Printer.Print(payload, text)
+			}
+			return opts.write(state, payload, header, rows, "no meetings found")
+		},
+	}
+
+	opts.addFlags(cmd)
+	cmd.Flags().IntVar(&top, "top", 10, "number of meetings to show (0 for all)")
+	cmd.Flags().StringVar(&sortBy, "sort", "duration", "rank by duration or participants")
+	return cmd
+}
+
+// parseMeetingDuration reads "HH:MM:SS" (as returned by the meeting list) or
+// plain seconds.
+func parseMeetingDuration(value string) int64 {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return seconds
+	}
+	total := int64(0)
+	for _, part := range strings.Split(value, ":") {
+		n, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil {
+			return 0
+		}
+		total = total*60 + n
+	}
+	return total
+}
+
+func parseMeetingCount(value string) int64 {
+	n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+func formatMeetingSeconds(seconds int64) string {
+	return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+}
+
+func writeCSV(w io.Writer, header []string, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func meetingReportHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/open-apis/vc/v1/meeting_list":
+			if r.URL.Query().Get("start_time") != "1714521600" || r.URL.Query().Get("end_time") != "1717200000" {
+				t.Fatalf("unexpected range: %s", r.URL.RawQuery)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{
+				"meeting_list": []map[string]any{
+					{"meeting_id": "111111111", "meeting_topic": "Training: Security", "organizer": "Ann", "meeting_duration": "01:00:00", "number_of_participants": "12"},
+					{"meeting_id": "222222222", "meeting_topic": "Standup", "organizer": "Bo", "meeting_duration": "00:15:00", "number_of_participants": "30"},
+					{"meeting_id": "333333333", "meeting_topic": "Training: Onboarding", "organizer": "Ann", "meeting_duration": "02:00:00", "number_of_participants": "5"},
+				},
+				"has_more": false,
+			}})
+		case "/open-apis/vc/v1/participant_list":
+			meetingNo := r.URL.Query().Get("meeting_no")
+			if meetingNo == "222222222" {
+				t.Fatalf("topic filter should skip meeting %s", meetingNo)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{
+				"participants": []map[string]any{
+					{"participant_name": "Cy " + meetingNo[:1], "user_id": "ou_" + meetingNo[:1], "join_time": "2024.05.02 10:00:00", "leave_time": "2024.05.02 11:00:00", "time_in_meeting": "01:00:00"},
+				},
+				"has_more": false,
+			}})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func TestMeetingReportParticipantsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	state := newTestState(t, meetingReportHandler(t), nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	outPath := filepath.Join(t.TempDir(), "attendance.csv")
+	cmd := newMeetingsCmd(state)
+	cmd.SetArgs([]string{"report", "participants", "--start", "2024-05-01T00:00:00Z", "--end", "1717200000", "--topic", "training", "--out", outPath})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("meetings report participants error: %v", err)
+	}
+	file, err := os.Open(outPath)
+	if err != nil {
+		t.Fatalf("open csv: %v", err)
+	}
+	defer file.Close()
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	if len(records) != 3 || records[0][0] != "meeting_no" || records[0][7] != "join_time" {
+		t.Fatalf("unexpected csv: %#v", records)
+	}
+	if records[1][0] != "111111111" || records[1][1] != "Training: Security" || records[1][2] != "Cy 1" || records[1][7] != "2024.05.02 10:00:00" {
+		t.Fatalf("unexpected first row: %#v", records[1])
+	}
+	if records[2][0] != "333333333" {
+		t.Fatalf("unexpected second row: %#v", records[2])
+	}
+	if !strings.Contains(buf.String(), "wrote 2 row(s) to "+outPath) {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestMeetingReportParticipantsPerRecurringInstance(t *testing.T) {
+	var windows []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/open-apis/vc/v1/meeting_list":
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{
+				"meeting_list": []map[string]any{
+					{"meeting_id": "444444444", "meeting_topic": "Weekly", "meeting_start_time": "2024.05.06 10:00:00 (GMT+08:00)", "meeting_end_time": "2024.05.06 10:30:00 (GMT+08:00)"},
+					{"meeting_id": "444444444", "meeting_topic": "Weekly", "meeting_start_time": "2024.05.13 10:00:00 (GMT+08:00)", "meeting_end_time": "2024.05.13 10:30:00 (GMT+08:00)"},
+					{"meeting_id": "555555555", "meeting_topic": "Room"},
+					{"meeting_id": "555555555", "meeting_topic": "Room"},
+				},
+				"has_more": false,
+			}})
+		case "/open-apis/vc/v1/participant_list":
+			query := r.URL.Query()
+			window := query.Get("meeting_no") + ":" + query.Get("meeting_start_time") + "-" + query.Get("meeting_end_time")
+			windows = append(windows, window)
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{
+				"participants": []map[string]any{{"participant_name": window}},
+				"has_more":     false,
+			}})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	cmd := newMeetingsCmd(state)
+	cmd.SetArgs([]string{"report", "participants", "--start", "1714521600", "--end", "1717200000", "--out", "-"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("meetings report participants error: %v", err)
+	}
+	want := []string{
+		"444444444:1714960800-1714962600",
+		"444444444:1715565600-1715567400",
+		"555555555:1714521600-1717200000",
+	}
+	if strings.Join(windows, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected participant queries: %v", windows)
+	}
+	if rows := strings.Count(strings.TrimSpace(buf.String()), "\n"); rows != 3 {
+		t.Fatalf("expected 3 rows, got %d: %q", rows, buf.String())
+	}
+}
+
+func TestMeetingReportUsageRanksMeetings(t *testing.T) {
+	var buf bytes.Buffer
+	state := newTestState(t, meetingReportHandler(t), nil, &buf)
+	withUserAccount(state.Config, defaultUserAccountName, "user-token", "refresh-token", time.Now().Add(2*time.Hour).Unix(), "")
+	cmd := newMeetingsCmd(state)
+	cmd.SetArgs([]string{"report", "usage", "--start", "1714521600", "--end", "1717200000", "--top", "2"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("meetings report usage error: %v", err)
+	}
+	out := buf.String()
+	first := strings.Index(out, "333333333")
+	second := strings.Index(out, "111111111")
+	if first < 0 || second < 0 || first > second || strings.Contains(out, "222222222") {
+		t.Fatalf("unexpected ranking: %q", out)
+	}
+	if !strings.Contains(out, "3 meeting(s), 3:15:00 in total") {
+		t.Fatalf("unexpected summary: %q", out)
+	}
+
+	buf.Reset()
+	cmd = newMeetingsCmd(state)
+	cmd.SetArgs([]string{"report", "usage", "--start", "1714521600", "--end", "1717200000", "--sort", "participants", "--top", "1", "--out", "-"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("meetings report usage csv error: %v", err)
+	}
+	if buf.String() != "meeting_no,topic,organizer,start_time,duration,participants\n222222222,Standup,Bo,,00:15:00,30\n" {
+		t.Fatalf("unexpected csv: %q", buf.String())
+	}
+}
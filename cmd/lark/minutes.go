@@ -18,12 +18,16 @@ func newMinutesCmd(state *appState) *cobra.Command {
 
 - minute_token identifies a Minutes file; URL opens it.
 - list reads Minutes entries from Drive folders.
-- update manages sharing permissions; delete removes the file.`,
+- update manages sharing permissions; delete removes the file.
+- transcript exports speaker-labelled text (txt/srt/vtt/json) or a Docs document; media download saves the recording.`,
 	}
 	cmd.AddCommand(newMinutesInfoCmd(state))
 	cmd.AddCommand(newMinutesListCmd(state))
 	cmd.AddCommand(newMinutesDeleteCmd(state))
 	cmd.AddCommand(newMinutesUpdateCmd(state))
+	cmd.AddCommand(newMinutesTranscriptCmd(state))
+	cmd.AddCommand(newMinutesStatsCmd(state))
+	cmd.AddCommand(newMinutesMediaCmd(state))
 	return cmd
 }
 
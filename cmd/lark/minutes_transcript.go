@@ -0,0 +1,438 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	larkdocx "github.com/larksuite/oapi-sdk-go/v3/service/docx/v1"
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+// minuteTranscriptDocBatch bounds the segments converted per docx insert; the
+// descendant API accepts at most 1000 blocks per call.
+const minuteTranscriptDocBatch = 300
+
+type minuteTranscriptSegment struct {
+	Index   int    `json:"index"`
+	StartMS int64  `json:"start_ms"`
+	EndMS   int64  `json:"end_ms"`
+	Speaker string `json:"speaker,omitempty"`
+	Text    string `json:"text"`
+}
+
+func minuteTokenArgs(cmd *cobra.Command, args []string) error {
+	if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+		return argsUsageError(cmd, err)
+	}
+	if strings.TrimSpace(args[0]) == "" {
+		return argsUsageError(cmd, errors.New("minute-token is required"))
+	}
+	return nil
+}
+
+func newMinutesTranscriptCmd(state *appState) *cobra.Command {
+	var format string
+	var outPath string
+	var toDoc string
+	var title string
+	var speakers bool
+	var timestamps bool
+
+	cmd := &cobra.Command{
+		Use:   "transcript <minute-token>",
+		Short: "Export a Minutes transcript",
+		Long: `Export the transcript with speaker names and timestamps.
+
+- --format txt and srt are returned by the API as is; vtt and json are converted from srt.
+- --out writes to a file; without it the transcript is printed.
+- --to-doc <folder-id> creates a Docs document in that folder (root for the Drive root) with one paragraph per segment.`,
+		Example: `  lark minutes transcript obcnxxxx --format srt --out meeting.srt
+  lark minutes transcript obcnxxxx --format json
+  lark minutes transcript obcnxxxx --to-doc fldcnxxxx --title "Weekly sync transcript"`,
+		Args: minuteTokenArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			minuteToken := strings.TrimSpace(args[0])
+			format = strings.ToLower(strings.TrimSpace(format))
+			switch format {
+			case "txt", "srt", "vtt", "json":
+			default:
+				return flagUsage(cmd, "format must be txt, srt, vtt, or json")
+			}
+			toDoc = strings.TrimSpace(toDoc)
+			outPath = strings.TrimSpace(outPath)
+			if cmd.Flags().Changed("to-doc") && outPath != "" {
+				return flagUsage(cmd, "--to-doc and --out cannot be used together")
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, tokenTypeValue, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			tokenType := larksdk.AccessTokenType(tokenTypeValue)
+
+			apiFormat := format
+			if format == "vtt" || format == "json" || cmd.Flags().Changed("to-doc") {
+				apiFormat = "srt"
+			}
+			data, err := state.SDK.GetMinuteTranscript(cmd.Context(), token, tokenType, larksdk.GetMinuteTranscriptRequest{
+				MinuteToken:   minuteToken,
+				FileFormat:    apiFormat,
+				NeedSpeaker:   speakers,
+				NeedTimestamp: timestamps,
+			})
+			if err != nil {
+				return err
+			}
+
+			if cmd.Flags().Changed("to-doc") {
+				segments := parseMinuteSRT(string(data), speakers)
+				if strings.TrimSpace(title) == "" {
+					title = "Transcript " + minuteToken
+				}
+				folderID := toDoc
+				if strings.EqualFold(folderID, "root") {
+					folderID = ""
+				}
+				doc, err := state.SDK.CreateDocxDocument(cmd.Context(), token, tokenType, larksdk.CreateDocxDocumentRequest{
+					Title:       title,
+					FolderToken: folderID,
+				})
+				if err != nil {
+					return err
+				}
+				blocks := 0
+				for start := 0; start < len(segments); start += minuteTranscriptDocBatch {
+					end := start + minuteTranscriptDocBatch
+					if end > len(segments) {
+						end = len(segments)
+					}
+					inserted, err := appendMinuteTranscriptBlocks(cmd, state, token, tokenType, doc.DocumentID, segments[start:end])
+					if err != nil {
+						return fmt.Errorf("document %s created, but inserting the transcript failed: %w", doc.DocumentID, err)
+					}
+					blocks += inserted
+				}
+				if doc.URL == "" && doc.DocumentID != "" {
+					doc.URL = docxDriveURL(cmd.Context(), state, tokenTypeValue, token, doc.DocumentID)
+				}
+				payload := map[string]any{
+					"minute_token":    minuteToken,
+					"document":        doc,
+					"segments":        len(segments),
+					"inserted_blocks": blocks,
+				}
+				text := tableTextRow(
+					[]string{"minute_token", "document_id", "segments", "url"},
+					[]string{minuteToken, doc.DocumentID, fmt.Sprintf("%d", len(segments)), doc.URL},
+				)
+				return state.Printer.Print(payload, text)
+			}
+
+			var segments []minuteTranscriptSegment
+			switch format {
+			case "vtt":
+				data = []byte(minuteSRTToVTT(string(data)))
+			case "json":
+				segments = parseMinuteSRT(string(data), speakers)
+				encoded, err := json.MarshalIndent(map[string]any{"minute_token": minuteToken, "segments": segments}, "", "  ")
+				if err != nil {
+					return err
+				}
+				data = append(encoded, '\n')
+			}
+
+			if outPath == "" || outPath == "-" {
+				if state.Printer.JSON {
+					payload := map[string]any{"minute_token": minuteToken, "format": format}
+					if segments != nil {
+						payload["segments"] = segments
+					} else {
+						payload["transcript"] = string(data)
+					}
+					return state.Printer.Print(payload, "")
+				}
+				_, err := state.Printer.Writer.Write(data)
+				return err
+			}
+			if info, err := os.Stat(outPath); err == nil && info.IsDir() {
+				outPath = filepath.Join(outPath, minuteToken+"."+format)
+			}
+			if err := os.WriteFile(outPath, data, 0o644); err != nil {
+				return err
+			}
+			payload := map[string]any{
+				"minute_token":  minuteToken,
+				"format":        format,
+				"output_path":   outPath,
+				"bytes_written": len(data),
+			}
+			text := tableTextRow(
+				[]string{"minute_token", "output_path", "bytes_written"},
+				[]string{minuteToken, outPath, fmt.Sprintf("%d", len(data))},
+			)
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "txt", "transcript format (txt|srt|vtt|json)")
+	cmd.Flags().StringVar(&outPath, "out", "", "output file path or directory (default: stdout)")
+	cmd.Flags().StringVar(&toDoc, "to-doc", "", "create a Docs document with the transcript in this Drive folder (root for the Drive root)")
+	cmd.Flags().StringVar(&title, "title", "", "document title for --to-doc (default: Transcript <minute-token>)")
+	cmd.Flags().BoolVar(&speakers, "speakers", true, "include speaker names")
+	cmd.Flags().BoolVar(&timestamps, "timestamps", true, "include timestamps in txt output")
+	return cmd
+}
+
+func newMinutesStatsCmd(state *appState) *cobra.Command {
+	var userIDType string
+
+	cmd := &cobra.Command{
+		Use:   "stats <minute-token>",
+		Short: "Show Minutes view statistics",
+		Args:  minuteTokenArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			minuteToken := strings.TrimSpace(args[0])
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, tokenTypeValue, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			stats, err := state.SDK.GetMinuteStatistics(cmd.Context(), token, larksdk.AccessTokenType(tokenTypeValue), minuteToken, userIDType)
+			if err != nil {
+				return err
+			}
+			payload := map[string]any{"minute_token": minuteToken, "statistics": stats}
+			text := tableTextRow(
+				[]string{"minute_token", "user_views", "page_views", "viewers"},
+				[]string{minuteToken, stats.UserViewCount, stats.PageViewCount, fmt.Sprintf("%d", len(stats.UserViews))},
+			)
+			if len(stats.UserViews) > 0 {
+				lines := make([]string, 0, len(stats.UserViews))
+				for _, view := range stats.UserViews {
+					lines = append(lines, fmt.Sprintf("%s\t%s", view.UserID, formatMessageTime(view.ViewTime)))
+				}
+				text += "\n\n" + tableText([]string{"user_id", "last_viewed"}, lines, "")
+			}
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&userIDType, "user-id-type", "", "user ID type (user_id, union_id, open_id)")
+	return cmd
+}
+
+func newMinutesMediaCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "media",
+		Short: "Download Minutes recordings",
+	}
+	cmd.AddCommand(newMinutesMediaDownloadCmd(state))
+	return cmd
+}
+
+func newMinutesMediaDownloadCmd(state *appState) *cobra.Command {
+	var outPath string
+
+	cmd := &cobra.Command{
+		Use:     "download <minute-token> --out <path>",
+		Short:   "Download the Minutes audio/video recording",
+		Example: "  lark minutes media download obcnxxxx --out ./recordings/",
+		Args:    minuteTokenArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			minuteToken := strings.TrimSpace(args[0])
+			outPath = strings.TrimSpace(outPath)
+			if outPath == "" {
+				return flagUsage(cmd, "out is required")
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, tokenTypeValue, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			downloadURL, err := state.SDK.GetMinuteMediaURL(cmd.Context(), token, larksdk.AccessTokenType(tokenTypeValue), minuteToken)
+			if err != nil {
+				return err
+			}
+			reader, err := state.SDK.DownloadMinuteMedia(cmd.Context(), downloadURL)
+			if err != nil {
+				return err
+			}
+			defer reader.Close()
+
+			writeStdout := outPath == "-"
+			if !writeStdout {
+				if info, err := os.Stat(outPath); err == nil && info.IsDir() {
+					outPath = filepath.Join(outPath, minuteToken+".mp4")
+				}
+			}
+			var out io.Writer
+			if writeStdout {
+				out = cmd.OutOrStdout()
+			} else {
+				outFile, err := os.Create(outPath)
+				if err != nil {
+					return err
+				}
+				defer outFile.Close()
+				out = outFile
+			}
+			written, err := io.Copy(out, reader)
+			if err != nil {
+				return err
+			}
+			if writeStdout {
+				if state.Verbose {
+					fmt.Fprintf(errWriter(state), "wrote %d bytes to stdout\n", written)
+				}
+				return nil
+			}
+			payload := map[string]any{
+				"minute_token":  minuteToken,
+				"output_path":   outPath,
+				"bytes_written": written,
+			}
+			text := tableTextRow(
+				[]string{"minute_token", "output_path", "bytes_written"},
+				[]string{minuteToken, outPath, fmt.Sprintf("%d", written)},
+			)
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&outPath, "out", "", "output file path or directory (or - for stdout)")
+	_ = cmd.MarkFlagRequired("out")
+	return cmd
+}
+
+// appendMinuteTranscriptBlocks converts segments to Docx blocks and appends
+// them to the end of the document.
+func appendMinuteTranscriptBlocks(cmd *cobra.Command, state *appState, token string, tokenType larksdk.AccessTokenType, documentID string, segments []minuteTranscriptSegment) (int, error) {
+	if len(segments) == 0 {
+		return 0, nil
+	}
+	var builder strings.Builder
+	for _, segment := range segments {
+		builder.WriteString(minuteSegmentMarkdown(segment))
+		builder.WriteString("\n\n")
+	}
+	converted, err := state.SDK.ConvertDocxContent(cmd.Context(), token, tokenType, "markdown", builder.String())
+	if err != nil {
+		return 0, err
+	}
+	if converted == nil || len(converted.Blocks) == 0 {
+		return 0, nil
+	}
+	scrubDocxTableMergeInfo(converted.Blocks)
+	_, err = state.SDK.CreateDocxBlockDescendant(cmd.Context(), token, tokenType, documentID, documentID, &larkdocx.CreateDocumentBlockDescendantReqBody{
+		ChildrenId:  converted.FirstLevelBlockIds,
+		Descendants: converted.Blocks,
+	}, -1, "", "")
+	if err != nil {
+		return 0, err
+	}
+	return len(converted.Blocks), nil
+}
+
+func minuteSegmentMarkdown(segment minuteTranscriptSegment) string {
+	text := strings.Join(strings.Fields(segment.Text), " ")
+	stamp := formatMinuteOffset(segment.StartMS)
+	if segment.Speaker != "" {
+		return fmt.Sprintf("**%s** `%s` %s", segment.Speaker, stamp, text)
+	}
+	return fmt.Sprintf("`%s` %s", stamp, text)
+}
+
+var minuteSRTTimeLine = regexp.MustCompile(`^(\d+):(\d{2}):(\d{2})[,.](\d{3})\s*-->\s*(\d+):(\d{2}):(\d{2})[,.](\d{3})`)
+
+// parseMinuteSRT reads SRT cues. With speakers, a cue's first line is the
+// speaker when the cue has several lines; otherwise a "Name: text" prefix is
+// split off.
+func parseMinuteSRT(data string, speakers bool) []minuteTranscriptSegment {
+	data = strings.TrimPrefix(strings.ReplaceAll(data, "\r\n", "\n"), "\ufeff")
+	segments := []minuteTranscriptSegment{}
+	for _, block := range strings.Split(data, "\n\n") {
+		lines := strings.Split(strings.TrimSpace(block), "\n")
+		timeIndex := -1
+		for i, line := range lines {
+			if minuteSRTTimeLine.MatchString(strings.TrimSpace(line)) {
+				timeIndex = i
+				break
+			}
+		}
+		if timeIndex < 0 {
+			continue
+		}
+		match := minuteSRTTimeLine.FindStringSubmatch(strings.TrimSpace(lines[timeIndex]))
+		segment := minuteTranscriptSegment{
+			Index:   len(segments) + 1,
+			StartMS: srtMillis(match[1:5]),
+			EndMS:   srtMillis(match[5:9]),
+		}
+		if timeIndex > 0 {
+			if index, err := strconv.Atoi(strings.TrimSpace(lines[timeIndex-1])); err == nil {
+				segment.Index = index
+			}
+		}
+		body := lines[timeIndex+1:]
+		if speakers && len(body) > 1 {
+			segment.Speaker = strings.TrimSuffix(strings.TrimSpace(body[0]), ":")
+			body = body[1:]
+		}
+		segment.Text = strings.TrimSpace(strings.Join(body, "\n"))
+		if speakers && segment.Speaker == "" {
+			segment.Speaker, segment.Text = splitMinuteSpeaker(segment.Text)
+		}
+		segments = append(segments, segment)
+	}
+	return segments
+}
+
+func splitMinuteSpeaker(text string) (string, string) {
+	for _, sep := range []string{": ", "："} {
+		if idx := strings.Index(text, sep); idx > 0 && idx <= 64 && !strings.ContainsAny(text[:idx], "\n.,?!") {
+			return strings.TrimSpace(text[:idx]), strings.TrimSpace(text[idx+len(sep):])
+		}
+	}
+	return "", text
+}
+
+func srtMillis(parts []string) int64 {
+	values := make([]int64, len(parts))
+	for i, part := range parts {
+		values[i], _ = strconv.ParseInt(part, 10, 64)
+	}
+	return ((values[0]*60+values[1])*60+values[2])*1000 + values[3]
+}
+
+// minuteSRTToVTT rewrites SRT as WebVTT: a header line and "." as the
+// millisecond separator in cue timings.
+func minuteSRTToVTT(data string) string {
+	data = strings.TrimPrefix(strings.ReplaceAll(data, "\r\n", "\n"), "\ufeff")
+	lines := strings.Split(data, "\n")
+	for i, line := range lines {
+		if minuteSRTTimeLine.MatchString(strings.TrimSpace(line)) {
+			lines[i] = strings.ReplaceAll(line, ",", ".")
+		}
+	}
+	return "WEBVTT\n\n" + strings.TrimLeft(strings.Join(lines, "\n"), "\n")
+}
+
+func formatMinuteOffset(ms int64) string {
+	seconds := ms / 1000
+	return fmt.Sprintf("%02d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const minuteTestSRT = "1\r\n00:00:01,000 --> 00:00:04,500\r\nAlice: Good morning, everyone.\r\n\r\n2\r\n00:01:02,250 --> 00:01:05,000\r\nBob: Status: all green.\r\n"
+
+func TestMinutesTranscriptFormats(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/open-apis/minutes/v1/minutes/m1/transcript" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.URL.Query().Get("need_speaker") != "true" || r.URL.Query().Get("need_timestamp") != "true" {
+			t.Fatalf("unexpected query: %s", r.URL.RawQuery)
+		}
+		if r.URL.Query().Get("file_format") != "srt" {
+			t.Fatalf("expected srt request, got %q", r.URL.Query().Get("file_format"))
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write([]byte(minuteTestSRT))
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newMinutesCmd(state)
+	cmd.SetArgs([]string{"transcript", "m1", "--format", "vtt"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("minutes transcript vtt error: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "WEBVTT\n\n1\n00:00:01.000 --> 00:00:04.500\nAlice: Good morning, everyone.") {
+		t.Fatalf("unexpected vtt: %q", buf.String())
+	}
+
+	outPath := filepath.Join(t.TempDir(), "m1.json")
+	buf.Reset()
+	cmd = newMinutesCmd(state)
+	cmd.SetArgs([]string{"transcript", "m1", "--format", "json", "--out", outPath})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("minutes transcript json error: %v", err)
+	}
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	var parsed struct {
+		Segments []minuteTranscriptSegment `json:"segments"`
+	}
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	want := []minuteTranscriptSegment{
+		{Index: 1, StartMS: 1000, EndMS: 4500, Speaker: "Alice", Text: "Good morning, everyone."},
+		{Index: 2, StartMS: 62250, EndMS: 65000, Speaker: "Bob", Text: "Status: all green."},
+	}
+	if len(parsed.Segments) != len(want) || parsed.Segments[0] != want[0] || parsed.Segments[1] != want[1] {
+		t.Fatalf("unexpected segments: %#v", parsed.Segments)
+	}
+	if !strings.Contains(buf.String(), outPath) {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestMinutesTranscriptToDoc(t *testing.T) {
+	var converted string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/open-apis/minutes/v1/minutes/m1/transcript":
+			_, _ = w.Write([]byte(minuteTestSRT))
+			return
+		case r.Method == http.MethodPost && r.URL.Path == "/open-apis/docx/v1/documents":
+			var payload map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			if payload["folder_token"] != "fld1" || payload["title"] != "Transcript m1" {
+				t.Fatalf("unexpected create payload: %#v", payload)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{
+				"document": map[string]any{"document_id": "doc1", "title": "Transcript m1", "url": "https://example.com/doc1"},
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == "/open-apis/docx/v1/documents/blocks/convert":
+			var payload map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			converted, _ = payload["content"].(string)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{
+				"first_level_block_ids": []string{"tmp1", "tmp2"},
+				"blocks": []map[string]any{
+					{"block_id": "tmp1", "block_type": 2, "text": map[string]any{"elements": []map[string]any{{"text_run": map[string]any{"content": "a"}}}}},
+					{"block_id": "tmp2", "block_type": 2, "text": map[string]any{"elements": []map[string]any{{"text_run": map[string]any{"content": "b"}}}}},
+				},
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == "/open-apis/docx/v1/documents/doc1/blocks/doc1/descendant":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"document_revision_id": 2}})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newMinutesCmd(state)
+	cmd.SetArgs([]string{"transcript", "m1", "--to-doc", "fld1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("minutes transcript --to-doc error: %v", err)
+	}
+	if !strings.Contains(converted, "**Alice** `00:00:01` Good morning, everyone.") || !strings.Contains(converted, "**Bob** `00:01:02` Status: all green.") {
+		t.Fatalf("unexpected converted markdown: %q", converted)
+	}
+	if !strings.Contains(buf.String(), "m1\tdoc1\t2\thttps://example.com/doc1") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestMinutesStatsAndMediaDownload(t *testing.T) {
+	var mediaURL string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/open-apis/minutes/v1/minutes/m1/statistics":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"statistics": map[string]any{
+				"user_view_count": "2",
+				"page_view_count": "5",
+				"user_view_list":  []map[string]any{{"user_id": "ou_1", "view_time": "1700000000000"}, {"user_id": "ou_2"}},
+			}}})
+		case "/open-apis/minutes/v1/minutes/m1/media":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"download_url": mediaURL}})
+		case "/signed/m1.mp4":
+			if r.Header.Get("Authorization") != "" {
+				t.Fatalf("pre-signed download must not send a token")
+			}
+			_, _ = w.Write([]byte("video-bytes"))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	mediaURL = state.Config.BaseURL + "/signed/m1.mp4"
+	cmd := newMinutesCmd(state)
+	cmd.SetArgs([]string{"stats", "m1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("minutes stats error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "m1\t2\t5\t2") || !strings.Contains(buf.String(), "ou_1") {
+		t.Fatalf("unexpected stats output: %q", buf.String())
+	}
+
+	outDir := t.TempDir()
+	buf.Reset()
+	cmd = newMinutesCmd(state)
+	cmd.SetArgs([]string{"media", "download", "m1", "--out", outDir})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("minutes media download error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(outDir, "m1.mp4"))
+	if err != nil || string(data) != "video-bytes" {
+		t.Fatalf("unexpected media file: %q, %v", data, err)
+	}
+}
@@ -347,20 +347,13 @@ func resolveMessageSenderNames(ctx context.Context, state *appState, token strin
 	if len(lookups) == 0 {
 		return nil
 	}
+	resolver := newIdentityResolver(state, token, tokenTypeTenant)
+	defer resolver.save()
 	names := make(map[string]string, len(lookups))
 	for _, item := range lookups {
-		user, err := state.SDK.GetContactUser(ctx, token, larksdk.GetContactUserRequest{
-			UserID:     item.id,
-			UserIDType: item.idType,
-		})
-		if err != nil {
-			continue
-		}
-		name := strings.TrimSpace(user.Name)
-		if name == "" {
-			continue
+		if name := resolver.userName(ctx, item.id, item.idType); name != "" {
+			names[item.key] = name
 		}
-		names[item.key] = name
 	}
 	return names
 }
@@ -166,8 +166,8 @@ func newMsgSearchCmd(state *appState) *cobra.Command {
 			text := output.Notice(output.NoticeInfo, "no messages found", nil)
 			if len(messages) > 0 {
 				var senderNames map[string]string
-				if !state.JSON && state.Config != nil && cachedTokenValid(state.Config, time.Now()) {
-					senderNames = resolveMessageSenderNames(cmd.Context(), state, state.Config.TenantAccessToken, messages)
+				if tenantToken, expiresAt := cachedTenantToken(state); !state.JSON && tokenUnexpired(tenantToken, expiresAt, time.Now()) {
+					senderNames = resolveMessageSenderNames(cmd.Context(), state, tenantToken, messages)
 				}
 				styles := newMessageFormatStyles(state.Printer.Styled)
 				displays := make([]messageDisplay, 0, len(messages))
@@ -0,0 +1,476 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+const (
+	meetingRoomPageSize      = 100
+	meetingRoomLevelMGetSize = 50
+	meetingRoomRootLevelID   = "0"
+)
+
+// meetingRoomInfo is a meeting room with its level path resolved to names.
+type meetingRoomInfo struct {
+	RoomID    string                     `json:"room_id"`
+	Name      string                     `json:"name"`
+	Capacity  int                        `json:"capacity"`
+	Building  string                     `json:"building,omitempty"`
+	Floor     string                     `json:"floor,omitempty"`
+	Levels    []string                   `json:"levels,omitempty"`
+	LevelIDs  []string                   `json:"level_ids,omitempty"`
+	Equipment []string                   `json:"equipment,omitempty"`
+	Disabled  bool                       `json:"disabled,omitempty"`
+	Busy      []larksdk.CalendarFreeBusy `json:"busy,omitempty"`
+}
+
+type meetingRoomFilter struct {
+	Building    string
+	Floor       string
+	MinCapacity int
+	Equipment   []string
+	RoomIDs     []string
+}
+
+func newRoomsCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rooms",
+		Aliases: []string{"room"},
+		Short:   "Discover meeting rooms",
+		Long: `Meeting rooms are bookable resources organized by building and floor.
+
+- room_id identifies a room; pass it to calendars create/search --room/--room-id.
+- --building/--floor match a level name or room_level_id (case-insensitive).
+- --capacity filters to rooms that seat at least N people.
+- availability checks each room's free/busy status for a time range.
+
+Canonical command name: rooms (alias: room).`,
+	}
+	annotateAuthServices(cmd, "vc-room")
+	cmd.AddCommand(newRoomsListCmd(state))
+	cmd.AddCommand(newRoomsSearchCmd(state))
+	cmd.AddCommand(newRoomsAvailabilityCmd(state))
+	return cmd
+}
+
+func newRoomsListCmd(state *appState) *cobra.Command {
+	var filter meetingRoomFilter
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List meeting rooms",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if limit <= 0 {
+				return flagUsage(cmd, "limit must be greater than 0")
+			}
+			token, err := tokenFor(cmd.Context(), state, tokenTypesTenant)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			rooms, err := listMeetingRoomInfos(cmd.Context(), state, token, "", filter)
+			if err != nil {
+				return err
+			}
+			if len(rooms) > limit {
+				rooms = rooms[:limit]
+			}
+			payload := map[string]any{"rooms": rooms}
+			text := tableTextFromRows(meetingRoomHeaders, meetingRoomRows(rooms), "no rooms found")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	registerMeetingRoomFilterFlags(cmd, &filter)
+	cmd.Flags().IntVar(&limit, "limit", 200, "max number of rooms to return")
+	return cmd
+}
+
+func newRoomsSearchCmd(state *appState) *cobra.Command {
+	var keyword string
+	var filter meetingRoomFilter
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "search <keyword>",
+		Short: "Search meeting rooms by name",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			keyword = strings.TrimSpace(args[0])
+			if keyword == "" {
+				return argsUsageError(cmd, errors.New("keyword is required"))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if limit <= 0 {
+				return flagUsage(cmd, "limit must be greater than 0")
+			}
+			token, err := tokenFor(cmd.Context(), state, tokenTypesTenant)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			rooms, err := listMeetingRoomInfos(cmd.Context(), state, token, keyword, filter)
+			if err != nil {
+				return err
+			}
+			if len(rooms) > limit {
+				rooms = rooms[:limit]
+			}
+			payload := map[string]any{"rooms": rooms}
+			text := tableTextFromRows(meetingRoomHeaders, meetingRoomRows(rooms), "no rooms found")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	registerMeetingRoomFilterFlags(cmd, &filter)
+	cmd.Flags().IntVar(&limit, "limit", 50, "max number of rooms to return")
+	return cmd
+}
+
+func newRoomsAvailabilityCmd(state *appState) *cobra.Command {
+	var filter meetingRoomFilter
+	var start string
+	var end string
+	var freeOnly bool
+
+	cmd := &cobra.Command{
+		Use:   "availability",
+		Short: "Show which meeting rooms are free in a time range",
+		Long: `Show which meeting rooms are free in a time range.
+
+- Rooms are selected with --room and/or the building/floor/capacity/equipment filters.
+- Times accept RFC3339, unix seconds, or relative offsets (e.g. +2h).
+
+Example:
+  lark rooms availability --building HQ --capacity 6 \
+    --start 2026-03-02T14:00:00+08:00 --end 2026-03-02T15:00:00+08:00 --free-only`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if start == "" || end == "" {
+				return flagUsage(cmd, "start and end times are required")
+			}
+			startTime, endTime, err := parseCalendarRange(cmd, start, end)
+			if err != nil {
+				return err
+			}
+			token, err := tokenFor(cmd.Context(), state, tokenTypesTenant)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			rooms, err := listMeetingRoomInfos(cmd.Context(), state, token, "", filter)
+			if err != nil {
+				return err
+			}
+			if err := fetchMeetingRoomBusy(cmd.Context(), state, token, tokenTypeTenant, rooms, startTime, endTime); err != nil {
+				return err
+			}
+			if freeOnly {
+				free := make([]meetingRoomInfo, 0, len(rooms))
+				for _, room := range rooms {
+					if len(room.Busy) == 0 && !room.Disabled {
+						free = append(free, room)
+					}
+				}
+				rooms = free
+			}
+			payload := map[string]any{
+				"time_min": startTime.Format(time.RFC3339),
+				"time_max": endTime.Format(time.RFC3339),
+				"rooms":    rooms,
+			}
+			rows := make([][]string, 0, len(rooms))
+			for _, room := range rooms {
+				rows = append(rows, []string{room.RoomID, room.Name, strconv.Itoa(room.Capacity), infoValue(room.Building), infoValue(room.Floor), meetingRoomAvailability(room)})
+			}
+			text := tableTextFromRows([]string{"room_id", "name", "capacity", "building", "floor", "availability"}, rows, "no rooms found")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	registerMeetingRoomFilterFlags(cmd, &filter)
+	cmd.Flags().StringArrayVar(&filter.RoomIDs, "room", nil, "meeting room id (repeatable)")
+	cmd.Flags().StringVar(&start, "start", "", "range start (RFC3339, unix seconds, or relative)")
+	cmd.Flags().StringVar(&end, "end", "", "range end (RFC3339, unix seconds, or relative)")
+	cmd.Flags().BoolVar(&freeOnly, "free-only", false, "only show rooms that are free for the whole range")
+	return cmd
+}
+
+var meetingRoomHeaders = []string{"room_id", "name", "capacity", "building", "floor", "equipment"}
+
+func registerMeetingRoomFilterFlags(cmd *cobra.Command, filter *meetingRoomFilter) {
+	cmd.Flags().StringVar(&filter.Building, "building", "", "building name or room_level_id")
+	cmd.Flags().StringVar(&filter.Floor, "floor", "", "floor name or room_level_id")
+	cmd.Flags().IntVar(&filter.MinCapacity, "capacity", 0, "minimum capacity")
+	cmd.Flags().StringArrayVar(&filter.Equipment, "equipment", nil, "required equipment name (repeatable)")
+}
+
+// listMeetingRoomInfos lists (or searches, when keyword is set) rooms, resolves
+// their level names, and applies the filter.
+func listMeetingRoomInfos(ctx context.Context, state *appState, token, keyword string, filter meetingRoomFilter) ([]meetingRoomInfo, error) {
+	rooms := make([]larksdk.MeetingRoom, 0)
+	pageToken := ""
+	for {
+		var result larksdk.ListMeetingRoomsResult
+		var err error
+		if keyword != "" {
+			result, err = state.SDK.SearchMeetingRooms(ctx, token, larksdk.AccessTokenTenant, larksdk.SearchMeetingRoomsRequest{
+				Keyword:   keyword,
+				PageSize:  meetingRoomPageSize,
+				PageToken: pageToken,
+			})
+		} else {
+			result, err = state.SDK.ListMeetingRooms(ctx, token, larksdk.AccessTokenTenant, larksdk.ListMeetingRoomsRequest{
+				PageSize:  meetingRoomPageSize,
+				PageToken: pageToken,
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+		rooms = append(rooms, result.Items...)
+		if !result.HasMore || result.PageToken == "" || result.PageToken == pageToken {
+			break
+		}
+		pageToken = result.PageToken
+	}
+	levelNames, err := resolveMeetingRoomLevelNames(ctx, state, token, rooms)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]meetingRoomInfo, 0, len(rooms))
+	for _, room := range rooms {
+		info := newMeetingRoomInfo(room, levelNames)
+		if filter.matches(info) {
+			infos = append(infos, info)
+		}
+	}
+	return infos, nil
+}
+
+func resolveMeetingRoomLevelNames(ctx context.Context, state *appState, token string, rooms []larksdk.MeetingRoom) (map[string]string, error) {
+	ids := make([]string, 0)
+	seen := map[string]struct{}{}
+	for _, room := range rooms {
+		for _, id := range meetingRoomLevelIDs(room) {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+	names := make(map[string]string, len(ids))
+	for startIdx := 0; startIdx < len(ids); startIdx += meetingRoomLevelMGetSize {
+		endIdx := startIdx + meetingRoomLevelMGetSize
+		if endIdx > len(ids) {
+			endIdx = len(ids)
+		}
+		levels, err := state.SDK.GetMeetingRoomLevels(ctx, token, larksdk.AccessTokenTenant, ids[startIdx:endIdx])
+		if err != nil {
+			return nil, err
+		}
+		for _, level := range levels {
+			names[level.RoomLevelID] = level.Name
+		}
+	}
+	return names, nil
+}
+
+func meetingRoomLevelIDs(room larksdk.MeetingRoom) []string {
+	ids := make([]string, 0, len(room.Path)+1)
+	for _, id := range append(append([]string(nil), room.Path...), room.RoomLevelID) {
+		id = strings.TrimSpace(id)
+		if id == "" || id == meetingRoomRootLevelID || containsString(ids, id) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// newMeetingRoomInfo treats the first level below the root as the building and
+// the room's own level as the floor.
+func newMeetingRoomInfo(room larksdk.MeetingRoom, levelNames map[string]string) meetingRoomInfo {
+	info := meetingRoomInfo{
+		RoomID:   room.RoomID,
+		Name:     room.Name,
+		Capacity: room.Capacity,
+		LevelIDs: meetingRoomLevelIDs(room),
+	}
+	for _, id := range info.LevelIDs {
+		name := levelNames[id]
+		if name == "" {
+			name = id
+		}
+		info.Levels = append(info.Levels, name)
+	}
+	if len(info.Levels) > 0 {
+		info.Building = info.Levels[0]
+	}
+	if len(info.Levels) > 1 {
+		info.Floor = info.Levels[len(info.Levels)-1]
+	}
+	for _, device := range room.Device {
+		if name := strings.TrimSpace(device.Name); name != "" {
+			info.Equipment = append(info.Equipment, name)
+		}
+	}
+	if room.RoomStatus != nil && !room.RoomStatus.Status {
+		info.Disabled = true
+	}
+	return info
+}
+
+func (f meetingRoomFilter) matches(room meetingRoomInfo) bool {
+	if len(f.RoomIDs) > 0 && !containsString(f.RoomIDs, room.RoomID) {
+		return false
+	}
+	if f.MinCapacity > 0 && room.Capacity < f.MinCapacity {
+		return false
+	}
+	if f.Building != "" && !meetingRoomLevelMatches(f.Building, room.Building, firstString(room.LevelIDs)) {
+		return false
+	}
+	if f.Floor != "" {
+		floorID := ""
+		if len(room.LevelIDs) > 1 {
+			floorID = room.LevelIDs[len(room.LevelIDs)-1]
+		}
+		if !meetingRoomLevelMatches(f.Floor, room.Floor, floorID) {
+			return false
+		}
+	}
+	for _, want := range f.Equipment {
+		want = strings.TrimSpace(want)
+		if want == "" {
+			continue
+		}
+		found := false
+		for _, have := range room.Equipment {
+			if strings.EqualFold(have, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func meetingRoomLevelMatches(want, name, id string) bool {
+	want = strings.TrimSpace(want)
+	return strings.EqualFold(want, name) || (id != "" && want == id)
+}
+
+func firstString(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func fetchMeetingRoomBusy(ctx context.Context, state *appState, token string, accessType tokenType, rooms []meetingRoomInfo, start, end time.Time) error {
+	owners := make([]calendarBusyOwner, 0, len(rooms))
+	for _, room := range rooms {
+		owners = append(owners, calendarBusyOwner{Input: room.RoomID, RoomID: room.RoomID})
+	}
+	if err := fetchCalendarBusy(ctx, state, token, accessType, owners, start, end, nil); err != nil {
+		return err
+	}
+	for i := range rooms {
+		rooms[i].Busy = owners[i].Busy
+	}
+	return nil
+}
+
+// findAvailableMeetingRoom returns the smallest enabled room matching filter
+// that is free for [start, end). Candidates are checked in order and the
+// search stops at the first free room.
+func findAvailableMeetingRoom(ctx context.Context, state *appState, start, end time.Time, filter meetingRoomFilter) (meetingRoomInfo, error) {
+	token, err := tokenFor(ctx, state, tokenTypesTenant)
+	if err != nil {
+		return meetingRoomInfo{}, err
+	}
+	rooms, err := listMeetingRoomInfos(ctx, state, token, "", filter)
+	if err != nil {
+		return meetingRoomInfo{}, err
+	}
+	candidates := make([]meetingRoomInfo, 0, len(rooms))
+	for _, room := range rooms {
+		if !room.Disabled {
+			candidates = append(candidates, room)
+		}
+	}
+	if len(candidates) == 0 {
+		return meetingRoomInfo{}, errors.New("no meeting rooms match the given filters")
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Capacity != candidates[j].Capacity {
+			return candidates[i].Capacity < candidates[j].Capacity
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	for i := range candidates {
+		if err := fetchMeetingRoomBusy(ctx, state, token, tokenTypeTenant, candidates[i:i+1], start, end); err != nil {
+			return meetingRoomInfo{}, err
+		}
+		if len(candidates[i].Busy) == 0 {
+			return candidates[i], nil
+		}
+	}
+	return meetingRoomInfo{}, fmt.Errorf("no free meeting room among %d matching rooms", len(candidates))
+}
+
+func meetingRoomRows(rooms []meetingRoomInfo) [][]string {
+	rows := make([][]string, 0, len(rooms))
+	for _, room := range rooms {
+		rows = append(rows, []string{
+			room.RoomID,
+			room.Name,
+			strconv.Itoa(room.Capacity),
+			infoValue(room.Building),
+			infoValue(room.Floor),
+			infoValue(strings.Join(room.Equipment, ",")),
+		})
+	}
+	return rows
+}
+
+func meetingRoomAvailability(room meetingRoomInfo) string {
+	if room.Disabled {
+		return "disabled"
+	}
+	if len(room.Busy) == 0 {
+		return "free"
+	}
+	parts := make([]string, 0, len(room.Busy))
+	for _, busy := range room.Busy {
+		parts = append(parts, busy.StartTime+"~"+busy.EndTime)
+	}
+	return "busy " + strings.Join(parts, ",")
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeMeetingRoomFixtures(t *testing.T, w http.ResponseWriter, r *http.Request) bool {
+	t.Helper()
+	switch r.URL.Path {
+	case "/open-apis/vc/v1/rooms":
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"code": 0,
+			"msg":  "ok",
+			"data": map[string]any{
+				"rooms": []map[string]any{
+					{"room_id": "omm_big", "name": "Atlas", "capacity": 12, "room_level_id": "omb_hq_3", "path": []string{"0", "omb_hq", "omb_hq_3"}, "device": []map[string]any{{"name": "TV"}, {"name": "Whiteboard"}}},
+					{"room_id": "omm_small", "name": "Bolt", "capacity": 6, "room_level_id": "omb_hq_2", "path": []string{"0", "omb_hq", "omb_hq_2"}, "device": []map[string]any{{"name": "TV"}}},
+					{"room_id": "omm_tiny", "name": "Cube", "capacity": 2, "room_level_id": "omb_hq_2", "path": []string{"0", "omb_hq", "omb_hq_2"}},
+					{"room_id": "omm_remote", "name": "Delta", "capacity": 8, "room_level_id": "omb_lab_1", "path": []string{"0", "omb_lab", "omb_lab_1"}},
+				},
+				"has_more": false,
+			},
+		})
+	case "/open-apis/vc/v1/room_levels/mget":
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"code": 0,
+			"msg":  "ok",
+			"data": map[string]any{
+				"items": []map[string]any{
+					{"room_level_id": "omb_hq", "name": "HQ"},
+					{"room_level_id": "omb_hq_2", "name": "2F"},
+					{"room_level_id": "omb_hq_3", "name": "3F"},
+					{"room_level_id": "omb_lab", "name": "Lab"},
+					{"room_level_id": "omb_lab_1", "name": "1F"},
+				},
+			},
+		})
+	default:
+		return false
+	}
+	return true
+}
+
+func TestRoomsListFiltersByBuildingCapacityAndEquipment(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !writeMeetingRoomFixtures(t, w, r) {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newRoomsCmd(state)
+	cmd.SetArgs([]string{"list", "--building", "hq", "--capacity", "4", "--equipment", "tv"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("rooms list error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "omm_big\tAtlas\t12\tHQ\t3F\tTV,Whiteboard") || !strings.Contains(out, "omm_small\tBolt\t6\tHQ\t2F\tTV") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+	if strings.Contains(out, "omm_tiny") || strings.Contains(out, "omm_remote") {
+		t.Fatalf("expected filters to drop rooms: %q", out)
+	}
+}
+
+func TestRoomsAvailabilityFreeOnly(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if writeMeetingRoomFixtures(t, w, r) {
+			return
+		}
+		if r.URL.Path != "/open-apis/calendar/v4/freebusy/list" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		busy := []map[string]any{}
+		if payload["room_id"] == "omm_big" {
+			busy = append(busy, map[string]any{"start_time": "2026-03-02T06:00:00Z", "end_time": "2026-03-02T07:00:00Z"})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"freebusy_list": busy}})
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newRoomsCmd(state)
+	cmd.SetArgs([]string{"availability", "--building", "HQ", "--start", "2026-03-02T06:00:00Z", "--end", "2026-03-02T07:00:00Z", "--free-only"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("rooms availability error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "omm_small") || !strings.Contains(out, "omm_tiny") {
+		t.Fatalf("expected free rooms in output: %q", out)
+	}
+	if strings.Contains(out, "omm_big") || strings.Contains(out, "omm_remote") {
+		t.Fatalf("expected busy/other-building rooms to be dropped: %q", out)
+	}
+}
+
+func TestCalendarCreateBooksAutoRoom(t *testing.T) {
+	var attendeePayload struct {
+		Attendees []map[string]any `json:"attendees"`
+	}
+	var checked []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if writeMeetingRoomFixtures(t, w, r) {
+			return
+		}
+		switch r.URL.Path {
+		case "/open-apis/calendar/v4/freebusy/list":
+			var payload map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			checked = append(checked, fmt.Sprint(payload["room_id"]))
+			busy := []map[string]any{}
+			if payload["room_id"] == "omm_small" {
+				busy = append(busy, map[string]any{"start_time": "2026-03-02T06:30:00Z", "end_time": "2026-03-02T07:00:00Z"})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"freebusy_list": busy}})
+		case "/open-apis/calendar/v4/calendars/cal_1/events":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{"event": map[string]any{"event_id": "evt_1", "summary": "Sync", "status": "confirmed"}},
+			})
+		case "/open-apis/calendar/v4/calendars/cal_1/events/evt_1/attendees":
+			if err := json.NewDecoder(r.Body).Decode(&attendeePayload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{}})
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newCalendarCmd(state)
+	cmd.SetArgs([]string{
+		"create", "--calendar-id", "cal_1", "--summary", "Sync",
+		"--start", "2026-03-02T06:00:00Z", "--end", "2026-03-02T07:00:00Z",
+		"--room", "auto", "--capacity", "6", "--building", "HQ",
+	})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("create error: %v", err)
+	}
+	if len(attendeePayload.Attendees) != 1 || attendeePayload.Attendees[0]["type"] != "resource" || attendeePayload.Attendees[0]["room_id"] != "omm_big" {
+		t.Fatalf("unexpected attendees: %+v", attendeePayload.Attendees)
+	}
+	if !strings.Contains(buf.String(), "omm_big") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+	if strings.Join(checked, ",") != "omm_small,omm_big" {
+		t.Fatalf("expected the search to stop at the first free room, checked %v", checked)
+	}
+}
+
+func TestFindAvailableMeetingRoomStopsAtFirstFreeRoom(t *testing.T) {
+	var checked []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if writeMeetingRoomFixtures(t, w, r) {
+			return
+		}
+		if r.URL.Path != "/open-apis/calendar/v4/freebusy/list" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		checked = append(checked, fmt.Sprint(payload["room_id"]))
+		_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"freebusy_list": []map[string]any{}}})
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	start := time.Date(2026, 3, 2, 6, 0, 0, 0, time.UTC)
+	room, err := findAvailableMeetingRoom(context.Background(), state, start, start.Add(time.Hour), meetingRoomFilter{})
+	if err != nil {
+		t.Fatalf("findAvailableMeetingRoom error: %v", err)
+	}
+	if room.RoomID != "omm_tiny" || strings.Join(checked, ",") != "omm_tiny" {
+		t.Fatalf("expected only the smallest room checked, got %s after %v", room.RoomID, checked)
+	}
+}
+
+func TestCalendarCreateRoomFiltersRequireAuto(t *testing.T) {
+	var buf bytes.Buffer
+	state := newTestState(t, http.NotFoundHandler(), nil, &buf)
+	cmd := newCalendarCmd(state)
+	cmd.SetArgs([]string{"create", "--summary", "Sync", "--start", "2026-03-02T06:00:00Z", "--end", "2026-03-02T07:00:00Z", "--capacity", "6"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	if err := cmd.Execute(); err == nil {
+		t.Fatalf("expected error for --capacity without --room auto")
+	}
+}
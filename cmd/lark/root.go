@@ -14,6 +14,7 @@ import (
 	"github.com/spf13/pflag"
 
 	"lark/internal/config"
+	"lark/internal/credfile"
 	"lark/internal/larksdk"
 	"lark/internal/output"
 )
@@ -30,6 +31,7 @@ type appState struct {
 	NoInput        bool
 	TokenType      string
 	UserAccount    string
+	TenantKey      string
 	Printer        output.Printer
 	ErrWriter      io.Writer
 	SDK            *larksdk.Client
@@ -38,6 +40,9 @@ type appState struct {
 	BaseURL        string
 	baseURLPersist string
 
+	refreshExpiryWarned bool
+	credentials         *credfile.Bundle
+
 	// Command is the invoked command path (space-separated, excluding the root
 	// binary name). Example: "mail send".
 	Command string
@@ -82,6 +87,10 @@ func newRootCmd() *cobra.Command {
 			if state.UserAccount == "" {
 				state.UserAccount = strings.TrimSpace(os.Getenv("LARK_ACCOUNT"))
 			}
+			if state.TenantKey == "" {
+				state.TenantKey = os.Getenv("LARK_TENANT_KEY")
+			}
+			state.TenantKey = strings.TrimSpace(state.TenantKey)
 			if state.ConfigPath == "" {
 				path, err := config.DefaultPathForProfile(state.Profile)
 				if err != nil {
@@ -94,14 +103,21 @@ func newRootCmd() *cobra.Command {
 				return err
 			}
 			state.Config = cfg
+			if err := applyCredentialsFile(state, cfg); err != nil {
+				return err
+			}
 			if err := applyBaseURLOverrides(state, cfg); err != nil {
 				return err
 			}
-			if err := hydrateAppSecretFromKeyring(state); err != nil {
+			var sdkOpts []larksdk.Option
+			if state.credentials != nil {
+				sdkOpts = append(sdkOpts, larksdk.WithoutAppSecret())
+			} else if err := hydrateAppSecretFromKeyring(state); err != nil && state.Command != "auth doctor" {
+				// auth doctor reports keychain failures as a check instead.
 				return err
 			}
 			handleAutoUpdate(state)
-			sdkClient, err := larksdk.New(cfg)
+			sdkClient, err := larksdk.New(cfg, sdkOpts...)
 			if err == nil {
 				state.SDK = sdkClient
 			} else {
@@ -110,7 +126,7 @@ func newRootCmd() *cobra.Command {
 					fmt.Fprintf(errWriter(state), "SDK disabled: %v\n", err)
 				}
 			}
-			return nil
+			return enforceCredentialsScopes(state)
 		},
 	}
 	cmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
@@ -134,6 +150,7 @@ func newRootCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(&state.NoInput, "no-input", false, "disable prompts (use --force to proceed)")
 	cmd.PersistentFlags().StringVar(&state.TokenType, "token-type", "auto", "access token type (auto|tenant|user)")
 	cmd.PersistentFlags().StringVar(&state.UserAccount, "account", "", "user account label (default: config default or LARK_ACCOUNT)")
+	cmd.PersistentFlags().StringVar(&state.TenantKey, "tenant-key", "", "tenant to act for with a marketplace app (env: LARK_TENANT_KEY)")
 	cmd.PersistentFlags().StringVar(&state.Platform, "platform", "", "platform (feishu|lark)")
 	cmd.PersistentFlags().StringVar(&state.BaseURL, "base-url", "", "base URL override")
 	cmd.MarkFlagsMutuallyExclusive("json", "plain")
@@ -146,11 +163,13 @@ func newRootCmd() *cobra.Command {
 	cmd.AddCommand(newMsgCmd(state))
 	cmd.AddCommand(newChatsCmd(state))
 	cmd.AddCommand(newUsersCmd(state))
+	cmd.AddCommand(newIDsCmd(state))
 	cmd.AddCommand(newDriveCmd(state))
 	cmd.AddCommand(newDocsCmd(state))
 	cmd.AddCommand(newSheetsCmd(state))
 	cmd.AddCommand(newCalendarCmd(state))
 	cmd.AddCommand(newMeetingsCmd(state))
+	cmd.AddCommand(newRoomsCmd(state))
 	cmd.AddCommand(newTasksCmd(state))
 	cmd.AddCommand(newTasklistsCmd(state))
 	cmd.AddCommand(newWikiCmd(state))
@@ -257,6 +276,10 @@ func (state *appState) saveConfig() error {
 	if state.Config == nil {
 		return errors.New("config is required")
 	}
+	// Credentials files are read-only; never persist the derived config.
+	if state.credentials != nil {
+		return nil
+	}
 	cfg := *state.Config
 	// Runtime overrides (--base-url/--platform) must not mutate persisted config.
 	// Always restore the originally loaded base URL (even if empty).
@@ -334,10 +357,16 @@ func requireCredentials(state *appState) error {
 }
 
 func cachedTokenValid(cfg *config.Config, now time.Time) bool {
-	if cfg.TenantAccessToken == "" || cfg.TenantAccessTokenExpiresAt == 0 {
+	return tokenUnexpired(cfg.TenantAccessToken, cfg.TenantAccessTokenExpiresAt, now)
+}
+
+// tokenUnexpired reports whether token is set and valid for at least another
+// minute.
+func tokenUnexpired(token string, expiresAt int64, now time.Time) bool {
+	if token == "" || expiresAt == 0 {
 		return false
 	}
-	return cfg.TenantAccessTokenExpiresAt > now.Add(60*time.Second).Unix()
+	return expiresAt > now.Add(60*time.Second).Unix()
 }
 
 func cachedUserTokenValid(token userToken, now time.Time) bool {
@@ -348,9 +377,18 @@ func cachedUserTokenValid(token userToken, now time.Time) bool {
 }
 
 func ensureTenantToken(ctx context.Context, state *appState) (string, error) {
+	if state != nil && state.credentials != nil {
+		return credentialsAccessToken(state, tokenTypeTenant)
+	}
 	if err := requireCredentials(state); err != nil {
 		return "", err
 	}
+	if state.Config.IsMarketplace() {
+		return ensureMarketplaceTenantToken(ctx, state)
+	}
+	if state.TenantKey != "" {
+		return "", errors.New("--tenant-key only applies to marketplace apps; run `lark config set --app-type marketplace` first")
+	}
 	if cachedTokenValid(state.Config, time.Now()) {
 		return state.Config.TenantAccessToken, nil
 	}
@@ -379,6 +417,9 @@ func ensureTenantToken(ctx context.Context, state *appState) (string, error) {
 }
 
 func ensureUserToken(ctx context.Context, state *appState) (string, error) {
+	if state != nil && state.credentials != nil {
+		return credentialsAccessToken(state, tokenTypeUser)
+	}
 	if err := requireCredentials(state); err != nil {
 		return "", err
 	}
@@ -387,8 +428,39 @@ func ensureUserToken(ctx context.Context, state *appState) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	if ok && cachedUserTokenValid(stored, time.Now()) {
-		return stored.AccessToken, nil
+	if !ok || !cachedUserTokenValid(stored, time.Now()) {
+		stored, err = refreshUserToken(ctx, state, account, false)
+		if err != nil {
+			return "", err
+		}
+	}
+	warnUserRefreshTokenExpiry(state, account, time.Now())
+	return stored.AccessToken, nil
+}
+
+// refreshUserToken exchanges the account's refresh token for a new access
+// token. Refreshes are serialized in-process and across processes, and the
+// stored token is re-read under the lock: refresh tokens rotate, so a second
+// refresh with an already-used token would fail and clear the account.
+// Unless force is set, a token refreshed meanwhile by someone else is reused.
+func refreshUserToken(ctx context.Context, state *appState, account string, force bool) (userToken, error) {
+	userTokenRefreshMu.Lock()
+	defer userTokenRefreshMu.Unlock()
+	release, err := acquireFileLock(ctx, userTokenLockPath(state.ConfigPath))
+	if err != nil {
+		return userToken{}, err
+	}
+	defer release()
+	if err := reloadUserAccounts(state); err != nil {
+		return userToken{}, err
+	}
+
+	stored, ok, err := loadUserToken(state, account)
+	if err != nil {
+		return userToken{}, err
+	}
+	if !force && ok && cachedUserTokenValid(stored, time.Now()) {
+		return stored, nil
 	}
 	acct, _ := loadUserAccount(state.Config, account)
 	refreshToken := stored.RefreshToken
@@ -396,7 +468,7 @@ func ensureUserToken(ctx context.Context, state *appState) (string, error) {
 		refreshToken = acct.RefreshTokenValue()
 	}
 	if refreshToken == "" {
-		return "", expireUserToken(state, account, errors.New("refresh token missing"))
+		return userToken{}, expireUserToken(state, account, errors.New("refresh token missing"))
 	}
 	if state.Verbose {
 		fmt.Fprintln(errWriter(state), "refreshing user access token")
@@ -406,36 +478,48 @@ func ensureUserToken(ctx context.Context, state *appState) (string, error) {
 		var err error
 		sdk, err = larksdk.New(state.Config)
 		if err != nil {
-			return "", fmt.Errorf("init sdk: %w", err)
+			return userToken{}, fmt.Errorf("init sdk: %w", err)
 		}
 		state.SDK = sdk
 	}
-	token, newRefreshToken, expiresIn, err := sdk.RefreshUserAccessToken(ctx, refreshToken)
+	refreshed, err := sdk.RefreshUserAccessToken(ctx, refreshToken)
 	if err != nil {
-		return "", expireUserToken(state, account, err)
+		// Keep the refresh token on network and server errors; it is only
+		// cleared once the OAuth server has rejected it.
+		var refreshErr *larksdk.RefreshAccessTokenError
+		if errors.As(err, &refreshErr) && refreshErr.InvalidGrant() {
+			return userToken{}, expireUserToken(state, account, err)
+		}
+		return userToken{}, err
 	}
+	now := time.Now()
 	newToken := userToken{
-		AccessToken:  token,
+		AccessToken:  refreshed.AccessToken,
 		RefreshToken: refreshToken,
-		ExpiresAt:    time.Now().Add(time.Duration(expiresIn) * time.Second).Unix(),
+		ExpiresAt:    now.Add(time.Duration(refreshed.ExpiresIn) * time.Second).Unix(),
+		Scope:        stored.Scope,
 	}
-	if newRefreshToken != "" {
-		newToken.RefreshToken = newRefreshToken
+	if refreshed.RefreshToken != "" {
+		newToken.RefreshToken = refreshed.RefreshToken
 		if acct.UserRefreshTokenPayload != nil {
 			if userTokenBackend(state.Config) == "file" {
-				acct.UserRefreshTokenPayload.RefreshToken = newRefreshToken
+				acct.UserRefreshTokenPayload.RefreshToken = refreshed.RefreshToken
+			}
+			acct.UserRefreshTokenPayload.CreatedAt = now.Unix()
+			acct.UserRefreshTokenPayload.ExpiresAt = 0
+			if refreshed.RefreshTokenExpiresIn > 0 {
+				acct.UserRefreshTokenPayload.ExpiresAt = now.Add(time.Duration(refreshed.RefreshTokenExpiresIn) * time.Second).Unix()
 			}
-			acct.UserRefreshTokenPayload.CreatedAt = time.Now().Unix()
 			saveUserAccount(state.Config, account, acct)
 		}
 	}
 	if err := storeUserToken(state, account, newToken); err != nil {
-		return "", err
+		return userToken{}, err
 	}
 	if err := state.saveConfig(); err != nil {
-		return "", err
+		return userToken{}, err
 	}
-	return token, nil
+	return newToken, nil
 }
 
 func expireUserToken(state *appState, account string, cause error) error {
@@ -42,6 +42,8 @@ func newSheetsCmd(state *appState) *cobra.Command {
 	cmd.AddCommand(newSheetsSearchCmd(state))
 	cmd.AddCommand(newSheetsListCmd(state))
 	cmd.AddCommand(newSheetsCommentCmd(state))
+	cmd.AddCommand(newSheetsConditionalFormatCmd(state))
+	cmd.AddCommand(newSheetsProtectCmd(state))
 	return cmd
 }
 
@@ -0,0 +1,426 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+var sheetConditionFormatRuleTypes = []string{"containsBlanks", "notContainsBlanks", "duplicateValues", "uniqueValues", "cellIs", "containsText", "timePeriod"}
+
+func newSheetsConditionalFormatCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "conditional-format",
+		Aliases: []string{"cf"},
+		Short:   "Manage conditional formatting rules",
+		Long: `Conditional formats style cells that match a rule (e.g. cellIs lessThan =TODAY()).
+
+- Rules belong to a sheet (tab) and apply to one or more A1 ranges.
+- cf_id identifies a rule within its sheet.`,
+	}
+	cmd.AddCommand(newSheetsConditionalFormatListCmd(state))
+	cmd.AddCommand(newSheetsConditionalFormatCreateCmd(state))
+	cmd.AddCommand(newSheetsConditionalFormatDeleteCmd(state))
+	return cmd
+}
+
+func newSheetsConditionalFormatListCmd(state *appState) *cobra.Command {
+	var spreadsheetID string
+	var sheetIDs []string
+
+	cmd := &cobra.Command{
+		Use:   "list <spreadsheet-token>",
+		Short: "List conditional formats",
+		Example: `  lark sheets conditional-format list <spreadsheet-token>
+  lark sheets conditional-format list <spreadsheet-token> --sheet-id <sheet-id>`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			token, _, err := parseResourceRef(args[0])
+			if err != nil {
+				return err
+			}
+			spreadsheetID = strings.TrimSpace(token)
+			if spreadsheetID == "" {
+				return argsUsageError(cmd, errors.New("spreadsheet-token is required"))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token, tokenTypeValue, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			ids := normalizeSheetIDs(sheetIDs)
+			if len(ids) == 0 {
+				sheets, err := state.SDK.ListSpreadsheetSheets(cmd.Context(), token, larksdk.AccessTokenType(tokenTypeValue), spreadsheetID)
+				if err != nil {
+					return err
+				}
+				for _, sheet := range sheets {
+					if id := strings.TrimSpace(sheet.SheetID); id != "" {
+						ids = append(ids, id)
+					}
+				}
+			}
+			if len(ids) == 0 {
+				return state.Printer.Print(map[string]any{"condition_formats": []larksdk.SheetConditionFormatEntry{}}, "no conditional formats found")
+			}
+			entries, err := state.SDK.ListSheetConditionFormats(cmd.Context(), token, larksdk.AccessTokenType(tokenTypeValue), spreadsheetID, ids)
+			if err != nil {
+				return err
+			}
+			if entries == nil {
+				entries = []larksdk.SheetConditionFormatEntry{}
+			}
+			payload := map[string]any{"condition_formats": entries}
+			return state.Printer.Print(payload, formatSheetConditionFormats(entries))
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&sheetIDs, "sheet-id", nil, "sheet id to inspect (repeatable; default: all sheets)")
+	return cmd
+}
+
+func newSheetsConditionalFormatCreateCmd(state *appState) *cobra.Command {
+	var spreadsheetID string
+	var sheetRanges []string
+	var sheetID string
+	var ruleType string
+	var operator string
+	var formulas []string
+	var text string
+	var timePeriod string
+	var bold bool
+	var italic bool
+	var underline bool
+	var strikethrough bool
+	var foreColor string
+	var backColor string
+
+	cmd := &cobra.Command{
+		Use:   "create <spreadsheet-token> <range>...",
+		Short: "Create a conditional format",
+		Long: `Create a conditional format on one or more ranges of the same sheet.
+
+Rule types: containsBlanks, notContainsBlanks, duplicateValues, uniqueValues,
+cellIs (--operator + --formula), containsText (--operator + --text), timePeriod (--time-period).`,
+		Example: `  lark sheets conditional-format create <spreadsheet-token> "<sheet-id>!D2:D200" \
+    --rule-type cellIs --operator lessThan --formula "=TODAY()" --back-color "#F54A45"`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.MinimumNArgs(2)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			token, _, err := parseResourceRef(args[0])
+			if err != nil {
+				return err
+			}
+			spreadsheetID = strings.TrimSpace(token)
+			if spreadsheetID == "" {
+				return argsUsageError(cmd, errors.New("spreadsheet-token is required"))
+			}
+			sheetRanges = sheetRanges[:0]
+			for _, arg := range args[1:] {
+				if value := strings.TrimSpace(arg); value != "" {
+					sheetRanges = append(sheetRanges, value)
+				}
+			}
+			if len(sheetRanges) == 0 {
+				return argsUsageError(cmd, errors.New("range is required"))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ruleType = strings.TrimSpace(ruleType)
+			if err := validateOneOf(cmd, "rule-type", ruleType, sheetConditionFormatRuleTypes); err != nil {
+				return err
+			}
+			ranges, targetSheetID, err := resolveSheetRangesForSheet(sheetRanges, sheetID)
+			if err != nil {
+				return err
+			}
+			attr, err := buildSheetConditionFormatAttr(ruleType, operator, formulas, text, timePeriod)
+			if err != nil {
+				return flagUsage(cmd, err.Error())
+			}
+			format := larksdk.SheetConditionFormat{
+				Ranges:   ranges,
+				RuleType: ruleType,
+				Style:    buildSheetConditionFormatStyle(bold, italic, underline, strikethrough, foreColor, backColor),
+			}
+			if attr != nil {
+				format.Attrs = []larksdk.SheetConditionFormatAttr{*attr}
+			}
+			if format.Style == nil {
+				return flagUsage(cmd, "at least one style flag is required (--bold, --italic, --underline, --strikethrough, --fore-color, --back-color)")
+			}
+			token, tokenTypeValue, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			results, err := state.SDK.CreateSheetConditionFormats(cmd.Context(), token, larksdk.AccessTokenType(tokenTypeValue), spreadsheetID, []larksdk.SheetConditionFormatEntry{
+				{SheetID: targetSheetID, ConditionFormat: format},
+			})
+			if err != nil {
+				return err
+			}
+			if err := sheetConditionFormatResultsError("create condition format", results); err != nil {
+				return err
+			}
+			payload := map[string]any{"results": results}
+			return state.Printer.Print(payload, formatSheetConditionFormatResults(results))
+		},
+	}
+
+	cmd.Flags().StringVar(&sheetID, "sheet-id", "", "sheet id to prefix ranges (use with ranges like A1:B2)")
+	cmd.Flags().StringVar(&ruleType, "rule-type", "", "rule type (containsBlanks, notContainsBlanks, duplicateValues, uniqueValues, cellIs, containsText, timePeriod)")
+	cmd.Flags().StringVar(&operator, "operator", "", "operator for cellIs (equal, notEqual, greaterThan, lessThan, between, ...) or containsText (containsText, notContains, is, beginsWith, endsWith)")
+	cmd.Flags().StringArrayVar(&formulas, "formula", nil, "formula/value for cellIs (repeatable; between uses two)")
+	cmd.Flags().StringVar(&text, "text", "", "text for containsText")
+	cmd.Flags().StringVar(&timePeriod, "time-period", "", "time period for timePeriod (yesterday, today, tomorrow, last7Days)")
+	cmd.Flags().BoolVar(&bold, "bold", false, "bold font")
+	cmd.Flags().BoolVar(&italic, "italic", false, "italic font")
+	cmd.Flags().BoolVar(&underline, "underline", false, "underline text")
+	cmd.Flags().BoolVar(&strikethrough, "strikethrough", false, "strike through text")
+	cmd.Flags().StringVar(&foreColor, "fore-color", "", "font color (hex, e.g. #FFFFFF)")
+	cmd.Flags().StringVar(&backColor, "back-color", "", "background color (hex, e.g. #F54A45)")
+	_ = cmd.MarkFlagRequired("rule-type")
+	registerEnumCompletion(cmd, "rule-type", sheetConditionFormatRuleTypes)
+	return cmd
+}
+
+func newSheetsConditionalFormatDeleteCmd(state *appState) *cobra.Command {
+	var spreadsheetID string
+	var sheetID string
+	var cfIDs []string
+
+	cmd := &cobra.Command{
+		Use:     "delete <spreadsheet-token> <sheet-id> <cf-id>...",
+		Short:   "Delete conditional formats",
+		Example: `  lark sheets conditional-format delete <spreadsheet-token> <sheet-id> <cf-id>`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.MinimumNArgs(3)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			token, _, err := parseResourceRef(args[0])
+			if err != nil {
+				return err
+			}
+			spreadsheetID = strings.TrimSpace(token)
+			sheetID = strings.TrimSpace(args[1])
+			if spreadsheetID == "" {
+				return argsUsageError(cmd, errors.New("spreadsheet-token is required"))
+			}
+			if sheetID == "" {
+				return argsUsageError(cmd, errors.New("sheet-id is required"))
+			}
+			cfIDs = cfIDs[:0]
+			for _, arg := range args[2:] {
+				if value := strings.TrimSpace(arg); value != "" {
+					cfIDs = append(cfIDs, value)
+				}
+			}
+			if len(cfIDs) == 0 {
+				return argsUsageError(cmd, errors.New("cf-id is required"))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := confirmDestructive(cmd, state, fmt.Sprintf("delete %d conditional format(s) from %s/%s", len(cfIDs), spreadsheetID, sheetID)); err != nil {
+				return err
+			}
+			token, tokenTypeValue, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			refs := make([]larksdk.SheetConditionFormatRef, 0, len(cfIDs))
+			for _, id := range cfIDs {
+				refs = append(refs, larksdk.SheetConditionFormatRef{SheetID: sheetID, CfID: id})
+			}
+			results, err := state.SDK.DeleteSheetConditionFormats(cmd.Context(), token, larksdk.AccessTokenType(tokenTypeValue), spreadsheetID, refs)
+			if err != nil {
+				return err
+			}
+			if err := sheetConditionFormatResultsError("delete condition format", results); err != nil {
+				return err
+			}
+			payload := map[string]any{"results": results}
+			return state.Printer.Print(payload, formatSheetConditionFormatResults(results))
+		},
+	}
+
+	return cmd
+}
+
+func normalizeSheetIDs(values []string) []string {
+	out := make([]string, 0, len(values))
+	seen := map[string]struct{}{}
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			if _, ok := seen[part]; ok {
+				continue
+			}
+			seen[part] = struct{}{}
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// resolveSheetRangesForSheet resolves ranges (with --sheet-id prefixing) and
+// ensures they all target the same sheet.
+func resolveSheetRangesForSheet(sheetRanges []string, sheetID string) ([]string, string, error) {
+	ranges := make([]string, 0, len(sheetRanges))
+	target := ""
+	for _, raw := range sheetRanges {
+		resolved, err := resolveSheetRange(raw, sheetID)
+		if err != nil {
+			return nil, "", err
+		}
+		prefix, _ := splitSheetRange(resolved)
+		rangeSheetID := strings.TrimSuffix(prefix, "!")
+		if target == "" {
+			target = rangeSheetID
+		} else if target != rangeSheetID {
+			return nil, "", fmt.Errorf("ranges must target the same sheet (got %s and %s)", target, rangeSheetID)
+		}
+		ranges = append(ranges, resolved)
+	}
+	return ranges, target, nil
+}
+
+func buildSheetConditionFormatAttr(ruleType, operator string, formulas []string, text, timePeriod string) (*larksdk.SheetConditionFormatAttr, error) {
+	operator = strings.TrimSpace(operator)
+	text = strings.TrimSpace(text)
+	timePeriod = strings.TrimSpace(timePeriod)
+	cleanFormulas := make([]string, 0, len(formulas))
+	for _, formula := range formulas {
+		if value := strings.TrimSpace(formula); value != "" {
+			cleanFormulas = append(cleanFormulas, value)
+		}
+	}
+	switch ruleType {
+	case "cellIs":
+		if operator == "" {
+			return nil, errors.New("--operator is required for cellIs")
+		}
+		if len(cleanFormulas) == 0 {
+			return nil, errors.New("--formula is required for cellIs")
+		}
+		return &larksdk.SheetConditionFormatAttr{Operator: operator, Formula: cleanFormulas}, nil
+	case "containsText":
+		if operator == "" {
+			return nil, errors.New("--operator is required for containsText")
+		}
+		if text == "" {
+			return nil, errors.New("--text is required for containsText")
+		}
+		return &larksdk.SheetConditionFormatAttr{Operator: operator, Text: text}, nil
+	case "timePeriod":
+		if timePeriod == "" {
+			return nil, errors.New("--time-period is required for timePeriod")
+		}
+		return &larksdk.SheetConditionFormatAttr{TimePeriod: timePeriod}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func buildSheetConditionFormatStyle(bold, italic, underline, strikethrough bool, foreColor, backColor string) *larksdk.SheetConditionFormatStyle {
+	style := &larksdk.SheetConditionFormatStyle{
+		ForeColor: strings.TrimSpace(foreColor),
+		BackColor: strings.TrimSpace(backColor),
+	}
+	if bold || italic {
+		style.Font = &larksdk.SheetConditionFormatFont{Bold: bold, Italic: italic}
+	}
+	// text_decoration: 1=underline, 2=strikethrough, 3=both.
+	if underline {
+		style.TextDecoration |= 1
+	}
+	if strikethrough {
+		style.TextDecoration |= 2
+	}
+	if style.Font == nil && style.TextDecoration == 0 && style.ForeColor == "" && style.BackColor == "" {
+		return nil
+	}
+	return style
+}
+
+func sheetConditionFormatResultsError(op string, results []larksdk.SheetConditionFormatResult) error {
+	failures := make([]string, 0)
+	for _, result := range results {
+		if result.ResCode == 0 {
+			continue
+		}
+		failures = append(failures, fmt.Sprintf("%s/%s (code=%d): %s", result.SheetID, result.CfID, result.ResCode, result.ResMsg))
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s failed: %s", op, strings.Join(failures, "; "))
+}
+
+func formatSheetConditionFormats(entries []larksdk.SheetConditionFormatEntry) string {
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		format := entry.ConditionFormat
+		rows = append(rows, []string{
+			entry.SheetID,
+			infoValue(format.CfID),
+			infoValue(format.RuleType),
+			infoValue(strings.Join(format.Ranges, ",")),
+			infoValue(formatSheetConditionFormatAttrs(format.Attrs)),
+		})
+	}
+	return tableTextFromRows([]string{"sheet_id", "cf_id", "rule_type", "ranges", "condition"}, rows, "no conditional formats found")
+}
+
+func formatSheetConditionFormatAttrs(attrs []larksdk.SheetConditionFormatAttr) string {
+	parts := make([]string, 0, len(attrs))
+	for _, attr := range attrs {
+		fields := make([]string, 0, 3)
+		if attr.Operator != "" {
+			fields = append(fields, attr.Operator)
+		}
+		if len(attr.Formula) > 0 {
+			fields = append(fields, strings.Join(attr.Formula, ","))
+		}
+		if attr.Text != "" {
+			fields = append(fields, fmt.Sprintf("%q", attr.Text))
+		}
+		if attr.TimePeriod != "" {
+			fields = append(fields, attr.TimePeriod)
+		}
+		if len(fields) > 0 {
+			parts = append(parts, strings.Join(fields, " "))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+func formatSheetConditionFormatResults(results []larksdk.SheetConditionFormatResult) string {
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		rows = append(rows, []string{result.SheetID, infoValue(result.CfID), fmt.Sprintf("%d", result.ResCode)})
+	}
+	return tableTextFromRows([]string{"sheet_id", "cf_id", "res_code"}, rows, "ok")
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"lark/internal/config"
+	"lark/internal/larksdk"
+	"lark/internal/output"
+	"lark/internal/testutil"
+)
+
+func TestSheetsConditionalFormatCreateCommand(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/open-apis/sheets/v2/spreadsheets/spreadsheet/condition_formats/batch_create" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Fatalf("unexpected method: %s", r.Method)
+		}
+		var payload struct {
+			SheetConditionFormats []struct {
+				SheetID         string `json:"sheet_id"`
+				ConditionFormat struct {
+					Ranges   []string `json:"ranges"`
+					RuleType string   `json:"rule_type"`
+					Attrs    []struct {
+						Operator string   `json:"operator"`
+						Formula  []string `json:"formula"`
+					} `json:"attrs"`
+					Style struct {
+						BackColor string `json:"back_color"`
+					} `json:"style"`
+				} `json:"condition_format"`
+			} `json:"sheet_condition_formats"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		if len(payload.SheetConditionFormats) != 1 {
+			t.Fatalf("unexpected formats: %+v", payload.SheetConditionFormats)
+		}
+		entry := payload.SheetConditionFormats[0]
+		if entry.SheetID != "s1" {
+			t.Fatalf("unexpected sheet id: %s", entry.SheetID)
+		}
+		if len(entry.ConditionFormat.Ranges) != 1 || entry.ConditionFormat.Ranges[0] != "s1!D2:D200" {
+			t.Fatalf("unexpected ranges: %v", entry.ConditionFormat.Ranges)
+		}
+		if entry.ConditionFormat.RuleType != "cellIs" {
+			t.Fatalf("unexpected rule type: %s", entry.ConditionFormat.RuleType)
+		}
+		if len(entry.ConditionFormat.Attrs) != 1 || entry.ConditionFormat.Attrs[0].Operator != "lessThan" || entry.ConditionFormat.Attrs[0].Formula[0] != "=TODAY()" {
+			t.Fatalf("unexpected attrs: %+v", entry.ConditionFormat.Attrs)
+		}
+		if entry.ConditionFormat.Style.BackColor != "#F54A45" {
+			t.Fatalf("unexpected style: %+v", entry.ConditionFormat.Style)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"code": 0,
+			"msg":  "ok",
+			"data": map[string]any{
+				"responses": []map[string]any{
+					{"sheet_id": "s1", "cf_id": "cf1", "res_code": 0, "res_msg": "success"},
+				},
+			},
+		})
+	})
+	httpClient, baseURL := testutil.NewTestClient(handler)
+
+	var buf bytes.Buffer
+	state := &appState{
+		Config: &config.Config{
+			AppID:                      "app",
+			AppSecret:                  "secret",
+			BaseURL:                    baseURL,
+			TenantAccessToken:          "token",
+			TenantAccessTokenExpiresAt: time.Now().Add(2 * time.Hour).Unix(),
+		},
+		Printer: output.Printer{Writer: &buf},
+	}
+	sdkClient, err := larksdk.New(state.Config, larksdk.WithHTTPClient(httpClient))
+	if err != nil {
+		t.Fatalf("sdk client error: %v", err)
+	}
+	state.SDK = sdkClient
+
+	cmd := newSheetsCmd(state)
+	cmd.SetArgs([]string{
+		"conditional-format", "create", "spreadsheet", "D2:D200",
+		"--sheet-id", "s1",
+		"--rule-type", "cellIs",
+		"--operator", "lessThan",
+		"--formula", "=TODAY()",
+		"--back-color", "#F54A45",
+	})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("conditional-format create error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "cf1") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestSheetsConditionalFormatListDefaultsToAllSheets(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/open-apis/sheets/v3/spreadsheets/spreadsheet/sheets/query":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"sheets": []map[string]any{
+						{"sheet_id": "s1", "title": "Budget"},
+						{"sheet_id": "s2", "title": "Actuals"},
+					},
+				},
+			})
+		case "/open-apis/sheets/v2/spreadsheets/spreadsheet/condition_formats":
+			if got := r.URL.Query().Get("sheet_ids"); got != "s1,s2" {
+				t.Fatalf("unexpected sheet_ids: %q", got)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"sheet_condition_formats": []map[string]any{
+						{
+							"sheet_id": "s1",
+							"condition_format": map[string]any{
+								"cf_id":     "cf1",
+								"ranges":    []string{"s1!D2:D200"},
+								"rule_type": "cellIs",
+								"attrs":     []map[string]any{{"operator": "lessThan", "formula": []string{"=TODAY()"}}},
+							},
+						},
+					},
+				},
+			})
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+	httpClient, baseURL := testutil.NewTestClient(handler)
+
+	var buf bytes.Buffer
+	state := &appState{
+		Config: &config.Config{
+			AppID:                      "app",
+			AppSecret:                  "secret",
+			BaseURL:                    baseURL,
+			TenantAccessToken:          "token",
+			TenantAccessTokenExpiresAt: time.Now().Add(2 * time.Hour).Unix(),
+		},
+		Printer: output.Printer{Writer: &buf},
+	}
+	sdkClient, err := larksdk.New(state.Config, larksdk.WithHTTPClient(httpClient))
+	if err != nil {
+		t.Fatalf("sdk client error: %v", err)
+	}
+	state.SDK = sdkClient
+
+	cmd := newSheetsCmd(state)
+	cmd.SetArgs([]string{"cf", "list", "spreadsheet"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("conditional-format list error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "cf1") || !strings.Contains(out, "lessThan =TODAY()") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestBuildSheetConditionFormatAttrRequiresRuleInputs(t *testing.T) {
+	if _, err := buildSheetConditionFormatAttr("cellIs", "lessThan", nil, "", ""); err == nil {
+		t.Fatalf("expected error for cellIs without formula")
+	}
+	if _, err := buildSheetConditionFormatAttr("containsText", "", nil, "x", ""); err == nil {
+		t.Fatalf("expected error for containsText without operator")
+	}
+	attr, err := buildSheetConditionFormatAttr("duplicateValues", "", nil, "", "")
+	if err != nil || attr != nil {
+		t.Fatalf("unexpected attr for duplicateValues: %+v, %v", attr, err)
+	}
+}
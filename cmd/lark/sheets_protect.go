@@ -0,0 +1,362 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+var sheetProtectDimensionValues = []string{"ROWS", "COLUMNS"}
+
+func newSheetsProtectCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "protect",
+		Short: "Manage protected ranges",
+		Long: `Protected ranges lock rows or columns of a sheet so only listed editors can change them.
+
+- protect_id identifies a protected range within the spreadsheet.
+- --start-index is 0-based (like rows/cols); list output shows the API's 1-based start/end.
+- Editors accept open_id values or emails (resolved to open_id via the contact API).`,
+	}
+	cmd.AddCommand(newSheetsProtectAddCmd(state))
+	cmd.AddCommand(newSheetsProtectListCmd(state))
+	cmd.AddCommand(newSheetsProtectRemoveCmd(state))
+	return cmd
+}
+
+func newSheetsProtectAddCmd(state *appState) *cobra.Command {
+	var spreadsheetID string
+	var sheetID string
+	var dimension string
+	var startIndex int
+	var count int
+	var editors []string
+	var lockInfo string
+
+	cmd := &cobra.Command{
+		Use:   "add <spreadsheet-token> <sheet-id>",
+		Short: "Protect rows or columns of a sheet",
+		Example: `  lark sheets protect add <spreadsheet-token> <sheet-id> --dimension COLUMNS --start-index 3 --count 2 \
+    --editor finance-lead@example.com --lock-info "formula columns"`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(2)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			token, _, err := parseResourceRef(args[0])
+			if err != nil {
+				return err
+			}
+			spreadsheetID = strings.TrimSpace(token)
+			sheetID = strings.TrimSpace(args[1])
+			if spreadsheetID == "" {
+				return argsUsageError(cmd, errors.New("spreadsheet-token is required"))
+			}
+			if sheetID == "" {
+				return argsUsageError(cmd, errors.New("sheet-id is required"))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dimension = strings.ToUpper(strings.TrimSpace(dimension))
+			if err := validateOneOf(cmd, "dimension", dimension, sheetProtectDimensionValues); err != nil {
+				return err
+			}
+			if startIndex < 0 {
+				return usageErrorWithUsage(cmd, "start-index must be >= 0", "", cmd.UsageString())
+			}
+			if count <= 0 {
+				return usageErrorWithUsage(cmd, "count must be greater than 0", "", cmd.UsageString())
+			}
+			token, tokenTypeValue, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			editorIDs, err := resolveEditorOpenIDs(cmd.Context(), state, editors)
+			if err != nil {
+				return err
+			}
+			req := larksdk.AddSheetProtectedDimensionRequest{
+				Dimension: larksdk.SheetProtectedDimension{
+					SheetID:        sheetID,
+					MajorDimension: dimension,
+					StartIndex:     startIndex + 1,
+					EndIndex:       startIndex + count,
+				},
+				Users:    editorIDs,
+				LockInfo: strings.TrimSpace(lockInfo),
+			}
+			if len(editorIDs) > 0 {
+				req.UserIDType = "open_id"
+			}
+			protected, err := state.SDK.AddSheetProtectedDimension(cmd.Context(), token, larksdk.AccessTokenType(tokenTypeValue), spreadsheetID, req)
+			if err != nil {
+				return err
+			}
+			payload := map[string]any{"protected_range": protected, "editors": editorIDs}
+			text := tableTextRow(
+				[]string{"protect_id", "sheet_id", "dimension", "start_index", "count", "editors"},
+				[]string{
+					infoValue(protected.ProtectID),
+					sheetID,
+					dimension,
+					fmt.Sprintf("%d", startIndex),
+					fmt.Sprintf("%d", count),
+					infoValue(strings.Join(editorIDs, ",")),
+				},
+			)
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&dimension, "dimension", "ROWS", "dimension to protect (ROWS or COLUMNS)")
+	cmd.Flags().IntVar(&startIndex, "start-index", 0, "start row/column index (0-based)")
+	cmd.Flags().IntVar(&count, "count", 1, "number of rows/columns to protect")
+	cmd.Flags().StringArrayVar(&editors, "editor", nil, "allowed editor (email or open_id; repeatable)")
+	cmd.Flags().StringVar(&lockInfo, "lock-info", "", "note shown for the protected range")
+	_ = cmd.MarkFlagRequired("start-index")
+	registerEnumCompletion(cmd, "dimension", sheetProtectDimensionValues)
+	return cmd
+}
+
+func newSheetsProtectListCmd(state *appState) *cobra.Command {
+	var spreadsheetID string
+	var sheetIDs []string
+
+	cmd := &cobra.Command{
+		Use:     "list <spreadsheet-token>",
+		Short:   "List protected ranges",
+		Example: `  lark sheets protect list <spreadsheet-token> --sheet-id <sheet-id>`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			token, _, err := parseResourceRef(args[0])
+			if err != nil {
+				return err
+			}
+			spreadsheetID = strings.TrimSpace(token)
+			if spreadsheetID == "" {
+				return argsUsageError(cmd, errors.New("spreadsheet-token is required"))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token, tokenTypeValue, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			ranges, err := state.SDK.ListSheetProtectedRanges(cmd.Context(), token, larksdk.AccessTokenType(tokenTypeValue), spreadsheetID)
+			if err != nil {
+				return err
+			}
+			filter := map[string]struct{}{}
+			for _, id := range normalizeSheetIDs(sheetIDs) {
+				filter[id] = struct{}{}
+			}
+			protectIDs := make([]string, 0, len(ranges))
+			filtered := make([]larksdk.SheetProtectedRange, 0, len(ranges))
+			for _, item := range ranges {
+				if len(filter) > 0 {
+					if _, ok := filter[item.SheetID]; !ok {
+						continue
+					}
+				}
+				filtered = append(filtered, item)
+				if item.ProtectID != "" {
+					protectIDs = append(protectIDs, item.ProtectID)
+				}
+			}
+			if len(protectIDs) > 0 {
+				detailed, err := state.SDK.GetSheetProtectedRanges(cmd.Context(), token, larksdk.AccessTokenType(tokenTypeValue), spreadsheetID, protectIDs, "openId")
+				if err != nil {
+					return err
+				}
+				filtered = mergeSheetProtectedRanges(filtered, detailed)
+			}
+			payload := map[string]any{"protected_ranges": filtered}
+			return state.Printer.Print(payload, formatSheetProtectedRanges(filtered))
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&sheetIDs, "sheet-id", nil, "only show ranges of this sheet (repeatable)")
+	return cmd
+}
+
+func newSheetsProtectRemoveCmd(state *appState) *cobra.Command {
+	var spreadsheetID string
+	var protectIDs []string
+
+	cmd := &cobra.Command{
+		Use:     "remove <spreadsheet-token> <protect-id>...",
+		Aliases: []string{"delete"},
+		Short:   "Remove protected ranges",
+		Example: `  lark sheets protect remove <spreadsheet-token> <protect-id>`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.MinimumNArgs(2)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			token, _, err := parseResourceRef(args[0])
+			if err != nil {
+				return err
+			}
+			spreadsheetID = strings.TrimSpace(token)
+			if spreadsheetID == "" {
+				return argsUsageError(cmd, errors.New("spreadsheet-token is required"))
+			}
+			protectIDs = protectIDs[:0]
+			for _, arg := range args[1:] {
+				if value := strings.TrimSpace(arg); value != "" {
+					protectIDs = append(protectIDs, value)
+				}
+			}
+			if len(protectIDs) == 0 {
+				return argsUsageError(cmd, errors.New("protect-id is required"))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := confirmDestructive(cmd, state, fmt.Sprintf("remove %d protected range(s) from %s", len(protectIDs), spreadsheetID)); err != nil {
+				return err
+			}
+			token, tokenTypeValue, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			deleted, err := state.SDK.DeleteSheetProtectedRanges(cmd.Context(), token, larksdk.AccessTokenType(tokenTypeValue), spreadsheetID, protectIDs)
+			if err != nil {
+				return err
+			}
+			if deleted == nil {
+				deleted = []string{}
+			}
+			payload := map[string]any{"deleted": deleted}
+			return state.Printer.Print(payload, fmt.Sprintf("ok: removed %s", strings.Join(deleted, ", ")))
+		},
+	}
+
+	return cmd
+}
+
+// resolveEditorOpenIDs converts editor values to open_ids. Emails are resolved
+// in one batch via the contact API (tenant token); other values pass through.
+func resolveEditorOpenIDs(ctx context.Context, state *appState, values []string) ([]string, error) {
+	ids := make([]string, 0, len(values))
+	emails := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if strings.Contains(value, "@") {
+			emails = append(emails, value)
+			continue
+		}
+		ids = append(ids, value)
+	}
+	if len(emails) == 0 {
+		return ids, nil
+	}
+	byEmail, err := resolveEmailOpenIDs(ctx, state, emails)
+	if err != nil {
+		return nil, err
+	}
+	for _, email := range emails {
+		ids = append(ids, byEmail[strings.ToLower(email)])
+	}
+	return ids, nil
+}
+
+// resolveEmailOpenIDs looks up open_ids for emails in one batch and returns
+// them keyed by lower-cased email. Unknown emails are reported together.
+func resolveEmailOpenIDs(ctx context.Context, state *appState, emails []string) (map[string]string, error) {
+	tenantToken, err := ensureTenantToken(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	users, err := state.SDK.BatchGetUserIDs(ctx, tenantToken, larksdk.BatchGetUserIDRequest{Emails: emails})
+	if err != nil {
+		return nil, err
+	}
+	byEmail := map[string]string{}
+	for _, user := range users {
+		if user.UserID == "" || user.Email == "" {
+			continue
+		}
+		byEmail[strings.ToLower(user.Email)] = user.UserID
+	}
+	missing := make([]string, 0)
+	for _, email := range emails {
+		if _, ok := byEmail[strings.ToLower(email)]; !ok {
+			missing = append(missing, email)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("no user found for email(s): %s", strings.Join(missing, ", "))
+	}
+	return byEmail, nil
+}
+
+func mergeSheetProtectedRanges(base []larksdk.SheetProtectedRange, detailed []larksdk.SheetProtectedRange) []larksdk.SheetProtectedRange {
+	byID := make(map[string]larksdk.SheetProtectedRange, len(detailed))
+	for _, item := range detailed {
+		byID[item.ProtectID] = item
+	}
+	out := make([]larksdk.SheetProtectedRange, 0, len(base))
+	for _, item := range base {
+		if detail, ok := byID[item.ProtectID]; ok {
+			if detail.Editors != nil {
+				item.Editors = detail.Editors
+			}
+			if item.Dimension == nil {
+				item.Dimension = detail.Dimension
+			}
+			if item.LockInfo == "" {
+				item.LockInfo = detail.LockInfo
+			}
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+func formatSheetProtectedRanges(ranges []larksdk.SheetProtectedRange) string {
+	rows := make([][]string, 0, len(ranges))
+	for _, item := range ranges {
+		dimension, start, end := "-", "-", "-"
+		if item.Dimension != nil {
+			dimension = infoValue(item.Dimension.MajorDimension)
+			start = fmt.Sprintf("%d", item.Dimension.StartIndex)
+			end = fmt.Sprintf("%d", item.Dimension.EndIndex)
+		}
+		editors := make([]string, 0)
+		if item.Editors != nil {
+			for _, user := range item.Editors.Users {
+				editors = append(editors, user.MemberID)
+			}
+		}
+		rows = append(rows, []string{
+			item.ProtectID,
+			infoValue(item.SheetID),
+			dimension,
+			start,
+			end,
+			infoValue(strings.Join(editors, ",")),
+			infoValue(item.LockInfo),
+		})
+	}
+	return tableTextFromRows([]string{"protect_id", "sheet_id", "dimension", "start", "end", "editors", "lock_info"}, rows, "no protected ranges found")
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"lark/internal/config"
+	"lark/internal/larksdk"
+	"lark/internal/output"
+	"lark/internal/testutil"
+)
+
+func TestSheetsProtectAddResolvesEditorEmails(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/open-apis/contact/v3/users/batch_get_id":
+			var payload struct {
+				Emails []string `json:"emails"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			if len(payload.Emails) != 1 || payload.Emails[0] != "lead@example.com" {
+				t.Fatalf("unexpected emails: %v", payload.Emails)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"user_list": []map[string]any{{"user_id": "ou_lead", "email": "lead@example.com"}},
+				},
+			})
+		case "/open-apis/sheets/v2/spreadsheets/spreadsheet/protected_dimension":
+			if got := r.URL.Query().Get("user_id_type"); got != "open_id" {
+				t.Fatalf("unexpected user_id_type: %q", got)
+			}
+			var payload struct {
+				AddProtectedDimension []struct {
+					Dimension larksdk.SheetProtectedDimension `json:"dimension"`
+					Users     []string                        `json:"users"`
+					LockInfo  string                          `json:"lockInfo"`
+				} `json:"addProtectedDimension"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			if len(payload.AddProtectedDimension) != 1 {
+				t.Fatalf("unexpected payload: %+v", payload)
+			}
+			item := payload.AddProtectedDimension[0]
+			want := larksdk.SheetProtectedDimension{SheetID: "s1", MajorDimension: "COLUMNS", StartIndex: 4, EndIndex: 5}
+			if item.Dimension != want {
+				t.Fatalf("unexpected dimension: %+v", item.Dimension)
+			}
+			if len(item.Users) != 2 || item.Users[0] != "ou_admin" || item.Users[1] != "ou_lead" {
+				t.Fatalf("unexpected users: %v", item.Users)
+			}
+			if item.LockInfo != "formulas" {
+				t.Fatalf("unexpected lock info: %q", item.LockInfo)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"addProtectedDimension": []map[string]any{
+						{"protectId": "p1", "dimension": item.Dimension, "lockInfo": "formulas"},
+					},
+				},
+			})
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+	httpClient, baseURL := testutil.NewTestClient(handler)
+
+	var buf bytes.Buffer
+	state := &appState{
+		Config: &config.Config{
+			AppID:                      "app",
+			AppSecret:                  "secret",
+			BaseURL:                    baseURL,
+			TenantAccessToken:          "token",
+			TenantAccessTokenExpiresAt: time.Now().Add(2 * time.Hour).Unix(),
+		},
+		Printer: output.Printer{Writer: &buf},
+	}
+	sdkClient, err := larksdk.New(state.Config, larksdk.WithHTTPClient(httpClient))
+	if err != nil {
+		t.Fatalf("sdk client error: %v", err)
+	}
+	state.SDK = sdkClient
+
+	cmd := newSheetsCmd(state)
+	cmd.SetArgs([]string{
+		"protect", "add", "spreadsheet", "s1",
+		"--dimension", "columns",
+		"--start-index", "3",
+		"--count", "2",
+		"--editor", "ou_admin",
+		"--editor", "lead@example.com",
+		"--lock-info", "formulas",
+	})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("protect add error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "p1") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestSheetsProtectListMergesEditors(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/open-apis/sheets/v2/spreadsheets/spreadsheet/metainfo":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"sheets": []map[string]any{
+						{
+							"sheetId": "s1",
+							"protectedRange": []map[string]any{
+								{"protectId": "p1", "lockInfo": "formulas", "dimension": map[string]any{"sheetId": "s1", "majorDimension": "COLUMNS", "startIndex": 4, "endIndex": 5}},
+							},
+						},
+						{
+							"sheetId": "s2",
+							"protectedRange": []map[string]any{
+								{"protectId": "p2", "dimension": map[string]any{"sheetId": "s2", "majorDimension": "ROWS", "startIndex": 1, "endIndex": 1}},
+							},
+						},
+					},
+				},
+			})
+		case "/open-apis/sheets/v2/spreadsheets/spreadsheet/protected_range_batch_get":
+			if got := r.URL.Query().Get("protectIds"); got != "p1" {
+				t.Fatalf("unexpected protectIds: %q", got)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"protectedRanges": []map[string]any{
+						{"protectId": "p1", "editors": map[string]any{"users": []map[string]any{{"memberType": "openId", "memberId": "ou_lead"}}}},
+					},
+				},
+			})
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+	httpClient, baseURL := testutil.NewTestClient(handler)
+
+	var buf bytes.Buffer
+	state := &appState{
+		Config: &config.Config{
+			AppID:                      "app",
+			AppSecret:                  "secret",
+			BaseURL:                    baseURL,
+			TenantAccessToken:          "token",
+			TenantAccessTokenExpiresAt: time.Now().Add(2 * time.Hour).Unix(),
+		},
+		Printer: output.Printer{Writer: &buf},
+	}
+	sdkClient, err := larksdk.New(state.Config, larksdk.WithHTTPClient(httpClient))
+	if err != nil {
+		t.Fatalf("sdk client error: %v", err)
+	}
+	state.SDK = sdkClient
+
+	cmd := newSheetsCmd(state)
+	cmd.SetArgs([]string{"protect", "list", "spreadsheet", "--sheet-id", "s1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("protect list error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "p1") || !strings.Contains(out, "ou_lead") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+	if strings.Contains(out, "p2") {
+		t.Fatalf("expected sheet filter to drop p2: %q", out)
+	}
+}
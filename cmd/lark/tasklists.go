@@ -19,13 +19,16 @@ func newTasklistsCmd(state *appState) *cobra.Command {
 		Long: `Task lists group related tasks.
 
 - tasklist-guid identifies a list (UUID-like string).
-- create/update support members and ownership updates.`,
+- create/update support members and ownership updates.
+- sections manage board columns; fields show and set custom field values.`,
 	}
 	cmd.AddCommand(newTasklistListCmd(state))
 	cmd.AddCommand(newTasklistCreateCmd(state))
 	cmd.AddCommand(newTasklistInfoCmd(state))
 	cmd.AddCommand(newTasklistUpdateCmd(state))
 	cmd.AddCommand(newTasklistDeleteCmd(state))
+	cmd.AddCommand(newTasklistSectionsCmd(state))
+	cmd.AddCommand(newTasklistFieldsCmd(state))
 	return cmd
 }
 
@@ -0,0 +1,404 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+func newTasklistFieldsCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "fields",
+		Aliases: []string{"field", "custom-fields"},
+		Short:   "Show and set task list custom fields",
+		Long: `Custom fields are typed columns defined on a task list.
+
+- list shows field definitions (and select options).
+- values shows each task's field values; tasks are fetched one by one, so keep --limit small.
+- set takes --field <name|guid>=<value>; select options match by name or guid, an empty value clears the field.`,
+	}
+	cmd.AddCommand(newTasklistFieldsListCmd(state))
+	cmd.AddCommand(newTasklistFieldsValuesCmd(state))
+	cmd.AddCommand(newTasklistFieldsSetCmd(state))
+	return cmd
+}
+
+func newTasklistFieldsListCmd(state *appState) *cobra.Command {
+	var tasklistGUID string
+
+	cmd := &cobra.Command{
+		Use:   "list <tasklist-guid>",
+		Short: "List custom field definitions",
+		Args:  taskIDArgs(&tasklistGUID, "tasklist-guid"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			fields, err := listTasklistCustomFields(cmd.Context(), state, token, tokenType, tasklistGUID)
+			if err != nil {
+				return err
+			}
+			payload := map[string]any{"tasklist_guid": tasklistGUID, "fields": fields}
+			rows := make([][]string, 0, len(fields))
+			for _, field := range fields {
+				rows = append(rows, []string{field.GUID, field.Name, field.Type, infoValue(strings.Join(taskCustomFieldOptionNames(field), ","))})
+			}
+			text := tableTextFromRows([]string{"field_guid", "name", "type", "options"}, rows, "no custom fields found")
+			return state.Printer.Print(payload, text)
+		},
+	}
+	return cmd
+}
+
+func newTasklistFieldsValuesCmd(state *appState) *cobra.Command {
+	var tasklistGUID string
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "values <tasklist-guid>",
+		Short: "Show custom field values for tasks in a task list",
+		Args:  taskIDArgs(&tasklistGUID, "tasklist-guid"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if limit <= 0 {
+				return flagUsage(cmd, "limit must be greater than 0")
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			token, tokenType, err := resolveAccessToken(ctx, state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			fields, err := listTasklistCustomFields(ctx, state, token, tokenType, tasklistGUID)
+			if err != nil {
+				return err
+			}
+			summaries, err := listTasklistTasks(ctx, state, token, tokenType, larksdk.ListTasklistTasksRequest{TasklistGUID: tasklistGUID}, limit)
+			if err != nil {
+				return err
+			}
+			tasks := make([]larksdk.Task, 0, len(summaries))
+			for _, summary := range summaries {
+				task, err := getTaskForToken(ctx, state, token, tokenType, larksdk.GetTaskRequest{TaskGUID: summary.GUID, UserIDType: "open_id"})
+				if err != nil {
+					return err
+				}
+				tasks = append(tasks, task)
+			}
+
+			headers := []string{"task_guid", "summary"}
+			for _, field := range fields {
+				headers = append(headers, field.Name)
+			}
+			rows := make([][]string, 0, len(tasks))
+			items := make([]map[string]any, 0, len(tasks))
+			for _, task := range tasks {
+				row := []string{task.GUID, task.Summary}
+				values := map[string]string{}
+				for _, field := range fields {
+					value := formatTaskCustomFieldValue(field, findTaskCustomFieldValue(task.CustomFields, field.GUID))
+					values[field.Name] = value
+					row = append(row, infoValue(value))
+				}
+				rows = append(rows, row)
+				items = append(items, map[string]any{"task_guid": task.GUID, "summary": task.Summary, "values": values, "custom_fields": task.CustomFields})
+			}
+			payload := map[string]any{"tasklist_guid": tasklistGUID, "fields": fields, "tasks": items}
+			text := tableTextFromRows(headers, rows, "no tasks found")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 50, "max number of tasks to include")
+	return cmd
+}
+
+func newTasklistFieldsSetCmd(state *appState) *cobra.Command {
+	var assignments []string
+
+	cmd := &cobra.Command{
+		Use:   "set <tasklist-guid> <task-guid>",
+		Short: "Set custom field values on a task",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(2)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			if strings.TrimSpace(args[0]) == "" || strings.TrimSpace(args[1]) == "" {
+				return argsUsageError(cmd, errors.New("tasklist-guid and task-guid are required"))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tasklistGUID := strings.TrimSpace(args[0])
+			taskGUID := strings.TrimSpace(args[1])
+			if len(assignments) == 0 {
+				return flagUsage(cmd, "at least one --field is required")
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			token, tokenType, err := resolveAccessToken(ctx, state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			fields, err := listTasklistCustomFields(ctx, state, token, tokenType, tasklistGUID)
+			if err != nil {
+				return err
+			}
+			values := make([]map[string]any, 0, len(assignments))
+			for _, assignment := range assignments {
+				key, raw, ok := strings.Cut(assignment, "=")
+				if !ok || strings.TrimSpace(key) == "" {
+					return flagUsage(cmd, fmt.Sprintf("invalid --field %q (expected <name|guid>=<value>)", assignment))
+				}
+				field, err := findTaskCustomField(fields, key)
+				if err != nil {
+					return err
+				}
+				value, err := buildTaskCustomFieldValue(field, raw)
+				if err != nil {
+					return err
+				}
+				values = append(values, value)
+			}
+			req := larksdk.UpdateTaskRequest{
+				TaskGUID:     taskGUID,
+				Task:         map[string]any{"custom_fields": values},
+				UpdateFields: []string{"custom_fields"},
+				UserIDType:   "open_id",
+			}
+			var task larksdk.Task
+			switch tokenType {
+			case tokenTypeTenant:
+				task, err = state.SDK.UpdateTask(ctx, token, req)
+			case tokenTypeUser:
+				task, err = state.SDK.UpdateTaskWithUserToken(ctx, token, req)
+			default:
+				return fmt.Errorf("unsupported token type %s", tokenType)
+			}
+			if err != nil {
+				return err
+			}
+			rows := make([][]string, 0, len(fields))
+			for _, field := range fields {
+				value := findTaskCustomFieldValue(task.CustomFields, field.GUID)
+				if value == nil {
+					continue
+				}
+				rows = append(rows, []string{field.Name, infoValue(formatTaskCustomFieldValue(field, value))})
+			}
+			payload := map[string]any{"task_guid": taskGUID, "custom_fields": task.CustomFields}
+			text := tableTextFromRows([]string{"field", "value"}, rows, "no custom field values")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&assignments, "field", nil, "field assignment <name|guid>=<value> (repeatable)")
+	return cmd
+}
+
+func listTasklistCustomFields(ctx context.Context, state *appState, token string, tokenType tokenType, tasklistGUID string) ([]larksdk.TaskCustomField, error) {
+	fields := make([]larksdk.TaskCustomField, 0)
+	pageToken := ""
+	for {
+		result, err := state.SDK.ListTaskCustomFields(ctx, token, larksdk.AccessTokenType(tokenType), larksdk.ListTaskCustomFieldsRequest{
+			TasklistGUID: tasklistGUID,
+			PageSize:     100,
+			PageToken:    pageToken,
+			UserIDType:   "open_id",
+		})
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, result.Items...)
+		if !result.HasMore || result.PageToken == "" {
+			break
+		}
+		pageToken = result.PageToken
+	}
+	return fields, nil
+}
+
+func findTaskCustomField(fields []larksdk.TaskCustomField, key string) (larksdk.TaskCustomField, error) {
+	key = strings.TrimSpace(key)
+	for _, field := range fields {
+		if field.GUID == key {
+			return field, nil
+		}
+	}
+	for _, field := range fields {
+		if strings.EqualFold(field.Name, key) {
+			return field, nil
+		}
+	}
+	return larksdk.TaskCustomField{}, fmt.Errorf("custom field %q not found in task list", key)
+}
+
+func findTaskCustomFieldValue(values []larksdk.TaskCustomFieldValue, fieldGUID string) *larksdk.TaskCustomFieldValue {
+	for i := range values {
+		if values[i].GUID == fieldGUID {
+			return &values[i]
+		}
+	}
+	return nil
+}
+
+func taskCustomFieldOptions(field larksdk.TaskCustomField) []larksdk.TaskCustomFieldOption {
+	switch {
+	case field.SingleSelectSetting != nil:
+		return field.SingleSelectSetting.Options
+	case field.MultiSelectSetting != nil:
+		return field.MultiSelectSetting.Options
+	default:
+		return nil
+	}
+}
+
+func taskCustomFieldOptionNames(field larksdk.TaskCustomField) []string {
+	options := taskCustomFieldOptions(field)
+	names := make([]string, 0, len(options))
+	for _, option := range options {
+		if !option.IsHidden {
+			names = append(names, option.Name)
+		}
+	}
+	return names
+}
+
+func resolveTaskCustomFieldOption(field larksdk.TaskCustomField, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	options := taskCustomFieldOptions(field)
+	for _, option := range options {
+		if option.GUID == value {
+			return option.GUID, nil
+		}
+	}
+	for _, option := range options {
+		if strings.EqualFold(option.Name, value) {
+			return option.GUID, nil
+		}
+	}
+	return "", fmt.Errorf("option %q not found in field %q", value, field.Name)
+}
+
+// buildTaskCustomFieldValue converts a CLI value into the API value object for
+// field. Values are maps so that an empty value can clear the field.
+func buildTaskCustomFieldValue(field larksdk.TaskCustomField, raw string) (map[string]any, error) {
+	raw = strings.TrimSpace(raw)
+	value := map[string]any{"guid": field.GUID}
+	switch field.Type {
+	case "number":
+		if raw != "" {
+			if _, err := strconv.ParseFloat(raw, 64); err != nil {
+				return nil, fmt.Errorf("field %q expects a number, got %q", field.Name, raw)
+			}
+		}
+		value["number_value"] = raw
+	case "text":
+		value["text_value"] = raw
+	case "datetime":
+		if raw == "" {
+			value["datetime_value"] = ""
+			break
+		}
+		ms, err := parseTaskTimestamp(raw)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", field.Name, err)
+		}
+		value["datetime_value"] = strconv.FormatInt(ms, 10)
+	case "member":
+		members := make([]larksdk.TaskMember, 0)
+		for _, id := range splitCommaValues(raw) {
+			members = append(members, larksdk.TaskMember{ID: id, Type: "user"})
+		}
+		value["member_value"] = members
+	case "single_select":
+		if raw == "" {
+			value["single_select_value"] = ""
+			break
+		}
+		guid, err := resolveTaskCustomFieldOption(field, raw)
+		if err != nil {
+			return nil, err
+		}
+		value["single_select_value"] = guid
+	case "multi_select":
+		guids := make([]string, 0)
+		for _, name := range splitCommaValues(raw) {
+			guid, err := resolveTaskCustomFieldOption(field, name)
+			if err != nil {
+				return nil, err
+			}
+			guids = append(guids, guid)
+		}
+		value["multi_select_value"] = guids
+	default:
+		return nil, fmt.Errorf("field %q has unsupported type %q", field.Name, field.Type)
+	}
+	return value, nil
+}
+
+func formatTaskCustomFieldValue(field larksdk.TaskCustomField, value *larksdk.TaskCustomFieldValue) string {
+	if value == nil {
+		return ""
+	}
+	optionName := func(guid string) string {
+		for _, option := range taskCustomFieldOptions(field) {
+			if option.GUID == guid {
+				return option.Name
+			}
+		}
+		return guid
+	}
+	switch field.Type {
+	case "number":
+		return value.NumberValue
+	case "text":
+		return value.TextValue
+	case "datetime":
+		return formatTaskMillis(value.DatetimeValue)
+	case "member":
+		ids := make([]string, 0, len(value.MemberValue))
+		for _, member := range value.MemberValue {
+			ids = append(ids, member.ID)
+		}
+		return strings.Join(ids, ",")
+	case "single_select":
+		if value.SingleSelectValue == "" {
+			return ""
+		}
+		return optionName(value.SingleSelectValue)
+	case "multi_select":
+		names := make([]string, 0, len(value.MultiSelectValue))
+		for _, guid := range value.MultiSelectValue {
+			names = append(names, optionName(guid))
+		}
+		return strings.Join(names, ",")
+	default:
+		return ""
+	}
+}
+
+func splitCommaValues(raw string) []string {
+	parts := strings.Split(raw, ",")
+	values := make([]string, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func taskCustomFieldsResponse() map[string]any {
+	return map[string]any{
+		"code": 0,
+		"msg":  "ok",
+		"data": map[string]any{
+			"items": []map[string]any{
+				{
+					"guid": "f1",
+					"name": "Priority",
+					"type": "single_select",
+					"single_select_setting": map[string]any{
+						"options": []map[string]any{
+							{"guid": "o1", "name": "High"},
+							{"guid": "o2", "name": "Low"},
+						},
+					},
+				},
+				{"guid": "f2", "name": "Points", "type": "number"},
+			},
+			"has_more": false,
+		},
+	}
+}
+
+func TestTasklistFieldsSetResolvesOptionNames(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/open-apis/task/v2/custom_fields":
+			if r.URL.Query().Get("resource_id") != "tl1" {
+				t.Fatalf("unexpected query: %s", r.URL.RawQuery)
+			}
+			_ = json.NewEncoder(w).Encode(taskCustomFieldsResponse())
+		case r.Method == http.MethodPatch && r.URL.Path == "/open-apis/task/v2/tasks/t1":
+			var payload struct {
+				Task struct {
+					CustomFields []map[string]any `json:"custom_fields"`
+				} `json:"task"`
+				UpdateFields []string `json:"update_fields"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			if len(payload.UpdateFields) != 1 || payload.UpdateFields[0] != "custom_fields" || len(payload.Task.CustomFields) != 2 {
+				t.Fatalf("unexpected payload: %+v", payload)
+			}
+			if payload.Task.CustomFields[0]["single_select_value"] != "o1" || payload.Task.CustomFields[1]["number_value"] != "3" {
+				t.Fatalf("unexpected custom fields: %+v", payload.Task.CustomFields)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{"task": map[string]any{
+					"guid": "t1",
+					"custom_fields": []map[string]any{
+						{"guid": "f1", "type": "single_select", "single_select_value": "o1"},
+						{"guid": "f2", "type": "number", "number_value": "3"},
+					},
+				}},
+			})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newTasklistsCmd(state)
+	cmd.SetArgs([]string{"fields", "set", "tl1", "t1", "--field", "priority=high", "--field", "Points=3"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("fields set error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "Priority") || !strings.Contains(out, "High") || !strings.Contains(out, "3") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestTasklistFieldsSetRejectsUnknownOption(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/open-apis/task/v2/custom_fields" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(taskCustomFieldsResponse())
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newTasklistsCmd(state)
+	cmd.SetArgs([]string{"fields", "set", "tl1", "t1", "--field", "Priority=Urgent"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "Urgent") {
+		t.Fatalf("expected option error, got %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+func newTasklistSectionsCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "sections",
+		Aliases: []string{"section"},
+		Short:   "Manage task list sections",
+		Long: `Sections are the columns of a task list board.
+
+- list and create take a tasklist-guid; update and delete take a section-guid.
+- --before/--after position a section relative to another section-guid.
+- Deleting a section moves its tasks to the default section.`,
+	}
+	cmd.AddCommand(newTasklistSectionsListCmd(state))
+	cmd.AddCommand(newTasklistSectionsCreateCmd(state))
+	cmd.AddCommand(newTasklistSectionsUpdateCmd(state))
+	cmd.AddCommand(newTasklistSectionsDeleteCmd(state))
+	return cmd
+}
+
+func newTasklistSectionsListCmd(state *appState) *cobra.Command {
+	var tasklistGUID string
+
+	cmd := &cobra.Command{
+		Use:   "list <tasklist-guid>",
+		Short: "List sections of a task list",
+		Args:  taskIDArgs(&tasklistGUID, "tasklist-guid"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			sections, err := listTasklistSections(cmd.Context(), state, token, tokenType, tasklistGUID)
+			if err != nil {
+				return err
+			}
+			payload := map[string]any{"tasklist_guid": tasklistGUID, "sections": sections}
+			rows := make([][]string, 0, len(sections))
+			for _, section := range sections {
+				rows = append(rows, []string{section.GUID, section.Name, fmt.Sprintf("%t", section.IsDefault), infoValue(formatTaskMillis(section.CreatedAt))})
+			}
+			text := tableTextFromRows([]string{"section_guid", "name", "default", "created_at"}, rows, "no sections found")
+			return state.Printer.Print(payload, text)
+		},
+	}
+	return cmd
+}
+
+func newTasklistSectionsCreateCmd(state *appState) *cobra.Command {
+	var tasklistGUID string
+	var name string
+	var before string
+	var after string
+
+	cmd := &cobra.Command{
+		Use:   "create <tasklist-guid>",
+		Short: "Create a section in a task list",
+		Args:  taskIDArgs(&tasklistGUID, "tasklist-guid"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.TrimSpace(name) == "" {
+				return flagUsage(cmd, "name is required")
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			section, err := state.SDK.CreateTaskSection(cmd.Context(), token, larksdk.AccessTokenType(tokenType), larksdk.CreateTaskSectionRequest{
+				TasklistGUID: tasklistGUID,
+				Name:         name,
+				InsertBefore: before,
+				InsertAfter:  after,
+			})
+			if err != nil {
+				return err
+			}
+			payload := map[string]any{"section": section}
+			text := tableTextRow([]string{"section_guid", "name", "tasklist_guid"}, []string{section.GUID, section.Name, tasklistGUID})
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "section name")
+	cmd.Flags().StringVar(&before, "before", "", "insert before this section-guid")
+	cmd.Flags().StringVar(&after, "after", "", "insert after this section-guid")
+	cmd.MarkFlagsMutuallyExclusive("before", "after")
+	_ = cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func newTasklistSectionsUpdateCmd(state *appState) *cobra.Command {
+	var sectionGUID string
+	var name string
+	var before string
+	var after string
+
+	cmd := &cobra.Command{
+		Use:   "update <section-guid>",
+		Short: "Rename or reorder a section",
+		Args:  taskIDArgs(&sectionGUID, "section-guid"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.TrimSpace(name) == "" && strings.TrimSpace(before) == "" && strings.TrimSpace(after) == "" {
+				return flagUsage(cmd, "at least one of --name, --before or --after is required")
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			section, err := state.SDK.UpdateTaskSection(cmd.Context(), token, larksdk.AccessTokenType(tokenType), larksdk.UpdateTaskSectionRequest{
+				SectionGUID:  sectionGUID,
+				Name:         name,
+				InsertBefore: before,
+				InsertAfter:  after,
+			})
+			if err != nil {
+				return err
+			}
+			payload := map[string]any{"section": section}
+			text := tableTextRow([]string{"section_guid", "name"}, []string{sectionGUID, section.Name})
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "new section name")
+	cmd.Flags().StringVar(&before, "before", "", "move before this section-guid")
+	cmd.Flags().StringVar(&after, "after", "", "move after this section-guid")
+	cmd.MarkFlagsMutuallyExclusive("before", "after")
+	return cmd
+}
+
+func newTasklistSectionsDeleteCmd(state *appState) *cobra.Command {
+	var sectionGUID string
+
+	cmd := &cobra.Command{
+		Use:   "delete <section-guid>",
+		Short: "Delete a section",
+		Args:  taskIDArgs(&sectionGUID, "section-guid"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := confirmDestructive(cmd, state, fmt.Sprintf("delete section %s", sectionGUID)); err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if err := state.SDK.DeleteTaskSection(cmd.Context(), token, larksdk.AccessTokenType(tokenType), sectionGUID); err != nil {
+				return err
+			}
+			payload := map[string]any{"section_guid": sectionGUID, "deleted": true}
+			text := tableTextRow([]string{"section_guid", "deleted"}, []string{sectionGUID, "true"})
+			return state.Printer.Print(payload, text)
+		},
+	}
+	return cmd
+}
+
+func listTasklistSections(ctx context.Context, state *appState, token string, tokenType tokenType, tasklistGUID string) ([]larksdk.TaskSection, error) {
+	sections := make([]larksdk.TaskSection, 0)
+	pageToken := ""
+	for {
+		result, err := state.SDK.ListTaskSections(ctx, token, larksdk.AccessTokenType(tokenType), larksdk.ListTaskSectionsRequest{
+			TasklistGUID: tasklistGUID,
+			PageSize:     100,
+			PageToken:    pageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, result.Items...)
+		if !result.HasMore || result.PageToken == "" {
+			break
+		}
+		pageToken = result.PageToken
+	}
+	return sections, nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestTasklistSectionsListCommand(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/open-apis/task/v2/sections" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.URL.Query().Get("resource_id") != "tl1" {
+			t.Fatalf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"code": 0,
+			"msg":  "ok",
+			"data": map[string]any{
+				"items": []map[string]any{
+					{"guid": "s1", "name": "Todo", "is_default": true},
+					{"guid": "s2", "name": "Doing"},
+				},
+				"has_more": false,
+			},
+		})
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newTasklistsCmd(state)
+	cmd.SetArgs([]string{"sections", "list", "tl1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("sections list error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "s1") || !strings.Contains(out, "Doing") || !strings.Contains(out, "true") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestTasklistSectionsCreateCommand(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/open-apis/task/v2/sections" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		if payload["name"] != "Review" || payload["resource_type"] != "tasklist" || payload["resource_id"] != "tl1" || payload["insert_after"] != "s2" {
+			t.Fatalf("unexpected payload: %+v", payload)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"code": 0,
+			"msg":  "ok",
+			"data": map[string]any{"section": map[string]any{"guid": "s3", "name": "Review"}},
+		})
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newTasklistsCmd(state)
+	cmd.SetArgs([]string{"sections", "create", "tl1", "--name", "Review", "--after", "s2"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("sections create error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "s3") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
@@ -23,14 +23,25 @@ func newTasksCmd(state *appState) *cobra.Command {
 		Long: `Tasks are personal or shared work items.
 
 - task-guid identifies a task (UUID-like string).
-- list returns "my_tasks" and requires a user access token.
-- create/update support due/start timestamps (ms or RFC3339); use --*-all-day for date-only.`,
+- list returns "my_tasks" and requires a user access token; --tasklist lists a team task list.
+- list --tasklist <guid> --group-by section renders a board; move changes a task's section.
+- sync keeps a Markdown checklist file in step with a task list.
+- create/update support due/start timestamps (ms or RFC3339); use --*-all-day for date-only.
+- info renders the subtask tree along with dependencies, reminders and attachments.
+- subtasks, deps, comments, reminders and attach manage a task's related records.`,
 	}
 	cmd.AddCommand(newTaskCreateCmd(state))
 	cmd.AddCommand(newTaskInfoCmd(state))
 	cmd.AddCommand(newTaskUpdateCmd(state))
 	cmd.AddCommand(newTaskDeleteCmd(state))
 	cmd.AddCommand(newTaskListCmd(state))
+	cmd.AddCommand(newTaskSubtasksCmd(state))
+	cmd.AddCommand(newTaskDepsCmd(state))
+	cmd.AddCommand(newTaskCommentsCmd(state))
+	cmd.AddCommand(newTaskRemindersCmd(state))
+	cmd.AddCommand(newTaskAttachCmd(state))
+	cmd.AddCommand(newTaskMoveCmd(state))
+	cmd.AddCommand(newTaskSyncCmd(state))
 	return cmd
 }
 
@@ -70,6 +81,15 @@ func newTaskCreateCmd(state *appState) *cobra.Command {
 			if memberType == "" {
 				memberType = "user"
 			}
+			if memberType == "user" {
+				var err error
+				if assignees, err = resolveUserRefs(cmd.Context(), state, assignees, userIDType); err != nil {
+					return err
+				}
+				if followers, err = resolveUserRefs(cmd.Context(), state, followers, userIDType); err != nil {
+					return err
+				}
+			}
 			members, err := buildTaskMembers(memberType, assignees, followers, membersJSON)
 			if err != nil {
 				return err
@@ -177,8 +197,8 @@ func newTaskCreateCmd(state *appState) *cobra.Command {
 	cmd.Flags().StringVar(&clientToken, "client-token", "", "idempotency token")
 	cmd.Flags().StringVar(&userIDType, "user-id-type", "open_id", "user ID type (open_id, union_id, user_id)")
 	cmd.Flags().StringVar(&memberType, "member-type", "user", "member type for --assignee/--follower (user or app)")
-	cmd.Flags().StringArrayVar(&assignees, "assignee", nil, "assignee member IDs (repeatable)")
-	cmd.Flags().StringArrayVar(&followers, "follower", nil, "follower member IDs (repeatable)")
+	cmd.Flags().StringArrayVar(&assignees, "assignee", nil, "assignee member ID, email, or @name (repeatable)")
+	cmd.Flags().StringArrayVar(&followers, "follower", nil, "follower member ID, email, or @name (repeatable)")
 	cmd.Flags().StringVar(&membersJSON, "members-json", "", "raw members JSON array (advanced)")
 	cmd.Flags().StringArrayVar(&tasklists, "tasklist", nil, "tasklist reference: <tasklist-guid>[:section-guid] (repeatable)")
 	_ = cmd.MarkFlagRequired("summary")
@@ -187,10 +207,11 @@ func newTaskCreateCmd(state *appState) *cobra.Command {
 
 func newTaskInfoCmd(state *appState) *cobra.Command {
 	var userIDType string
+	var depth int
 
 	cmd := &cobra.Command{
 		Use:   "info <task-guid>",
-		Short: "Show task details",
+		Short: "Show task details with its subtask tree",
 		Args: func(cmd *cobra.Command, args []string) error {
 			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
 				return argsUsageError(cmd, err)
@@ -202,8 +223,8 @@ func newTaskInfoCmd(state *appState) *cobra.Command {
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			taskGUID := strings.TrimSpace(args[0])
-			if err := confirmDestructive(cmd, state, fmt.Sprintf("delete task %s", taskGUID)); err != nil {
-				return err
+			if depth < 0 {
+				return flagUsage(cmd, "depth must be greater than or equal to 0")
 			}
 			if state.SDK == nil {
 				return errors.New("sdk client is required")
@@ -213,30 +234,37 @@ func newTaskInfoCmd(state *appState) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			req := larksdk.GetTaskRequest{
+			task, err := getTaskForToken(ctx, state, token, tokenType, larksdk.GetTaskRequest{
 				TaskGUID:   taskGUID,
 				UserIDType: strings.TrimSpace(userIDType),
-			}
-			var task larksdk.Task
-			switch tokenType {
-			case tokenTypeTenant:
-				task, err = state.SDK.GetTask(ctx, token, req)
-			case tokenTypeUser:
-				task, err = state.SDK.GetTaskWithUserToken(ctx, token, req)
-			default:
-				return fmt.Errorf("unsupported token type %s", tokenType)
-			}
+			})
 			if err != nil {
 				return err
 			}
+			var subtasks []taskTreeNode
+			if task.SubtaskCount > 0 {
+				subtasks, err = fetchTaskSubtaskTree(ctx, state, token, tokenType, task.GUID, 1, depth)
+				if err != nil {
+					return err
+				}
+			}
 			payload := map[string]any{"task": task}
+			if len(subtasks) > 0 {
+				payload["subtasks"] = subtasks
+			}
+			nameTaskMembers(state, []larksdk.Task{task})
 			rows := taskDetailRows(task)
 			text := tableTextFromRows([]string{"name", "value"}, rows, "no task found")
+			if len(subtasks) > 0 {
+				lines := append([]string{"", "subtasks:", formatTaskTreeLabel(task)}, renderTaskTree(subtasks, "")...)
+				text += "\n" + strings.Join(lines, "\n")
+			}
 			return state.Printer.Print(payload, text)
 		},
 	}
 
 	cmd.Flags().StringVar(&userIDType, "user-id-type", "open_id", "user ID type (open_id, union_id, user_id)")
+	cmd.Flags().IntVar(&depth, "depth", 0, "max subtask depth to render (0 = unlimited)")
 	return cmd
 }
 
@@ -452,10 +480,18 @@ func newTaskListCmd(state *appState) *cobra.Command {
 	var completed bool
 	var taskType string
 	var userIDType string
+	var tasklistGUID string
+	var sectionGUID string
+	var groupBy string
 
 	cmd := &cobra.Command{
 		Use:   "list",
-		Short: "List tasks (my tasks)",
+		Short: "List tasks (my tasks or a task list)",
+		Long: `List tasks.
+
+- Without --tasklist, lists "my_tasks" (requires a user access token); --type only applies here.
+- --tasklist lists every task in a team task list; --section narrows to one section.
+- --group-by section renders the task list as a board with one column per section.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if state.SDK == nil {
 				return errors.New("sdk client is required")
@@ -466,16 +502,42 @@ func newTaskListCmd(state *appState) *cobra.Command {
 			if pageSize <= 0 {
 				return errors.New("page-size must be greater than 0")
 			}
-
-			token, err := tokenFor(context.Background(), state, tokenTypesUser)
-			if err != nil {
-				return err
+			if groupBy != "" {
+				if err := validateOneOf(cmd, "group-by", groupBy, taskGroupByValues); err != nil {
+					return err
+				}
+				if tasklistGUID == "" {
+					return flagUsage(cmd, "--group-by requires --tasklist")
+				}
+			}
+			if sectionGUID != "" && tasklistGUID == "" {
+				return flagUsage(cmd, "--section requires --tasklist")
 			}
 			var completedPtr *bool
 			if cmd.Flags().Changed("completed") {
 				v := completed
 				completedPtr = &v
 			}
+			if tasklistGUID != "" {
+				if cmd.Flags().Changed("type") {
+					return flagUsage(cmd, "--type does not apply with --tasklist")
+				}
+				req := larksdk.ListTasklistTasksRequest{
+					TasklistGUID: tasklistGUID,
+					SectionGUID:  sectionGUID,
+					Completed:    completedPtr,
+					UserIDType:   strings.TrimSpace(userIDType),
+				}
+				if cmd.Flags().Changed("page-size") {
+					req.PageSize = pageSize
+				}
+				return runTasklistTasksList(cmd, state, req, groupBy, limit)
+			}
+
+			token, err := tokenFor(context.Background(), state, tokenTypesUser)
+			if err != nil {
+				return err
+			}
 
 			items := make([]larksdk.Task, 0, limit)
 			pageToken := ""
@@ -512,24 +574,33 @@ func newTaskListCmd(state *appState) *cobra.Command {
 				items = items[:limit]
 			}
 
+			nameTaskMembers(state, items)
 			payload := map[string]any{"tasks": items}
-			lines := make([]string, 0, len(items))
-			for _, task := range items {
-				lines = append(lines, fmt.Sprintf("%s\t%s\t%s\t%s\t%s", task.GUID, task.Summary, task.Status, formatTaskTime(task.Due), taskAssignees(task.Members)))
-			}
-			text := tableText([]string{"task_guid", "summary", "status", "due", "assignees"}, lines, "no tasks found")
-			return state.Printer.Print(payload, text)
+			return state.Printer.Print(payload, taskListText(items))
 		},
 	}
 
-	cmd.Flags().IntVar(&limit, "limit", 50, "max number of tasks to return")
+	cmd.Flags().IntVar(&limit, "limit", 50, "max number of tasks to return (per section with --group-by)")
 	cmd.Flags().IntVar(&pageSize, "page-size", 50, "page size per request")
 	cmd.Flags().BoolVar(&completed, "completed", false, "filter by completion status (true/false)")
-	cmd.Flags().StringVar(&taskType, "type", "my_tasks", "task list type (default: my_tasks)")
+	cmd.Flags().StringVar(&taskType, "type", "my_tasks", "task list type without --tasklist (default: my_tasks)")
 	cmd.Flags().StringVar(&userIDType, "user-id-type", "open_id", "user ID type (open_id, union_id, user_id)")
+	cmd.Flags().StringVar(&tasklistGUID, "tasklist", "", "list tasks in this tasklist-guid instead of my_tasks")
+	cmd.Flags().StringVar(&sectionGUID, "section", "", "with --tasklist, only list tasks in this section-guid")
+	cmd.Flags().StringVar(&groupBy, "group-by", "", "with --tasklist, group tasks into a board (section)")
+	cmd.MarkFlagsMutuallyExclusive("section", "group-by")
+	registerEnumCompletion(cmd, "group-by", taskGroupByValues)
 	return cmd
 }
 
+func taskListText(items []larksdk.Task) string {
+	lines := make([]string, 0, len(items))
+	for _, task := range items {
+		lines = append(lines, fmt.Sprintf("%s\t%s\t%s\t%s\t%s", task.GUID, task.Summary, task.Status, formatTaskTime(task.Due), taskAssignees(task.Members)))
+	}
+	return tableText([]string{"task_guid", "summary", "status", "due", "assignees"}, lines, "no tasks found")
+}
+
 func buildTaskMembers(memberType string, assignees, followers []string, membersJSON string) ([]larksdk.TaskMember, error) {
 	if strings.TrimSpace(membersJSON) != "" {
 		var members []larksdk.TaskMember
@@ -681,11 +752,29 @@ func taskMembersByRole(members []larksdk.TaskMember, role string) string {
 		if id == "" {
 			continue
 		}
+		if name := strings.TrimSpace(member.Name); name != "" {
+			id = name
+		}
 		ids = append(ids, id)
 	}
 	return strings.Join(ids, ",")
 }
 
+// nameTaskMembers fills in user member names already in the ID cache so text
+// output shows people instead of IDs; it never calls the API.
+func nameTaskMembers(state *appState, tasks []larksdk.Task) {
+	resolver := newIdentityResolver(state, "", "")
+	for _, task := range tasks {
+		for i, member := range task.Members {
+			if member.Name != "" || (member.Type != "" && member.Type != "user") {
+				continue
+			}
+			id := strings.TrimSpace(member.ID)
+			task.Members[i].Name = resolver.cachedName(detectIdentityField(id), id)
+		}
+	}
+}
+
 func taskDetailRows(task larksdk.Task) [][]string {
 	rows := make([][]string, 0, 12)
 	add := func(name, value string) {
@@ -706,8 +795,35 @@ func taskDetailRows(task larksdk.Task) [][]string {
 	add("repeat_rule", task.RepeatRule)
 	add("assignees", taskAssignees(task.Members))
 	add("followers", taskFollowers(task.Members))
+	add("parent_task_guid", task.ParentTaskGUID)
+	if task.SubtaskCount > 0 {
+		add("subtasks", fmt.Sprintf("%d", task.SubtaskCount))
+	}
+	add("blocked_by", taskDependencyGUIDs(task.Dependencies, "prev"))
+	add("blocks", taskDependencyGUIDs(task.Dependencies, "next"))
+	add("reminder", formatTaskReminders(task.Reminders))
+	add("attachments", taskAttachmentNames(task.Attachments))
 	add("url", task.URL)
 	add("created_at", task.CreatedAt)
 	add("updated_at", task.UpdatedAt)
 	return rows
 }
+
+func formatTaskMillis(raw string) string {
+	if strings.TrimSpace(raw) == "" {
+		return ""
+	}
+	return formatTaskTime(&larksdk.TaskTime{Timestamp: raw})
+}
+
+func taskAttachmentNames(attachments []larksdk.TaskAttachment) string {
+	names := make([]string, 0, len(attachments))
+	for _, attachment := range attachments {
+		name := strings.TrimSpace(attachment.Name)
+		if name == "" {
+			name = attachment.GUID
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ",")
+}
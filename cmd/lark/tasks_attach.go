@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+func newTaskAttachCmd(state *appState) *cobra.Command {
+	var name string
+
+	cmd := &cobra.Command{
+		Use:   "attach <task-guid> <file>",
+		Short: "Upload a file as a task attachment",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(2)(cmd, args); err != nil {
+				return argsUsageError(cmd, err)
+			}
+			if strings.TrimSpace(args[0]) == "" {
+				return argsUsageError(cmd, errors.New("task-guid is required"))
+			}
+			if strings.TrimSpace(args[1]) == "" {
+				return argsUsageError(cmd, errors.New("file is required"))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			taskGUID := strings.TrimSpace(args[0])
+			filePath := args[1]
+			info, err := os.Stat(filePath)
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return fmt.Errorf("file path is a directory: %s", filePath)
+			}
+			if strings.TrimSpace(name) == "" {
+				name = filepath.Base(filePath)
+			}
+			file, err := os.Open(filePath)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			attachments, err := state.SDK.UploadTaskAttachment(cmd.Context(), token, larksdk.AccessTokenType(tokenType), larksdk.UploadTaskAttachmentRequest{
+				TaskGUID:   taskGUID,
+				FileName:   name,
+				File:       file,
+				UserIDType: "open_id",
+			})
+			if err != nil {
+				return err
+			}
+			payload := map[string]any{"task_guid": taskGUID, "attachments": attachments}
+			rows := make([][]string, 0, len(attachments))
+			for _, attachment := range attachments {
+				rows = append(rows, []string{attachment.GUID, attachment.Name, fmt.Sprintf("%d", attachment.Size)})
+			}
+			text := tableTextFromRows([]string{"attachment_guid", "name", "size"}, rows, "no attachments uploaded")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "attachment name (default: file base name)")
+	return cmd
+}
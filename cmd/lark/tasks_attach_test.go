@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTasksAttachKeepsFileName(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/open-apis/task/v2/attachments/upload" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Fatalf("unexpected authorization: %s", r.Header.Get("Authorization"))
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("parse multipart: %v", err)
+		}
+		if r.FormValue("resource_type") != "task" || r.FormValue("resource_id") != "t1" {
+			t.Fatalf("unexpected form: %v", r.MultipartForm.Value)
+		}
+		_, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("form file: %v", err)
+		}
+		if header.Filename != "plan.md" {
+			t.Fatalf("unexpected filename: %s", header.Filename)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"code": 0,
+			"msg":  "ok",
+			"data": map[string]any{"items": []map[string]any{{"guid": "att1", "name": header.Filename, "size": header.Size}}},
+		})
+	})
+
+	path := filepath.Join(t.TempDir(), "plan.md")
+	if err := os.WriteFile(path, []byte("# plan\n"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newTasksCmd(state)
+	cmd.SetArgs([]string{"attach", "t1", path})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("attach error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "att1") || !strings.Contains(buf.String(), "plan.md") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+	"lark/internal/output"
+)
+
+var taskGroupByValues = []string{"section"}
+
+type taskBoardColumn struct {
+	Section larksdk.TaskSection `json:"section"`
+	Tasks   []larksdk.Task      `json:"tasks"`
+}
+
+func runTasklistTasksList(cmd *cobra.Command, state *appState, req larksdk.ListTasklistTasksRequest, groupBy string, limit int) error {
+	ctx := cmd.Context()
+	token, tokenType, err := resolveAccessToken(ctx, state, tokenTypesTenantOrUser, nil)
+	if err != nil {
+		return err
+	}
+	if groupBy != "section" {
+		tasks, err := listTasklistTasks(ctx, state, token, tokenType, req, limit)
+		if err != nil {
+			return err
+		}
+		nameTaskMembers(state, tasks)
+		payload := map[string]any{"tasklist_guid": req.TasklistGUID, "tasks": tasks}
+		if req.SectionGUID != "" {
+			payload["section_guid"] = req.SectionGUID
+		}
+		return state.Printer.Print(payload, taskListText(tasks))
+	}
+
+	sections, err := listTasklistSections(ctx, state, token, tokenType, req.TasklistGUID)
+	if err != nil {
+		return err
+	}
+	columns := make([]taskBoardColumn, 0, len(sections))
+	for _, section := range sections {
+		sectionReq := req
+		sectionReq.SectionGUID = section.GUID
+		tasks, err := listTasklistTasks(ctx, state, token, tokenType, sectionReq, limit)
+		if err != nil {
+			return err
+		}
+		nameTaskMembers(state, tasks)
+		columns = append(columns, taskBoardColumn{Section: section, Tasks: tasks})
+	}
+	payload := map[string]any{"tasklist_guid": req.TasklistGUID, "sections": columns}
+	return state.Printer.Print(payload, taskBoardText(columns, state.Printer))
+}
+
+// listTasklistTasks pages through a tasklist (or section) up to limit tasks.
+// A positive req.PageSize caps the size of each page.
+func listTasklistTasks(ctx context.Context, state *appState, token string, tokenType tokenType, req larksdk.ListTasklistTasksRequest, limit int) ([]larksdk.Task, error) {
+	maxPageSize := maxTasksPageSize
+	if req.SectionGUID != "" {
+		maxPageSize = 50
+	}
+	if req.PageSize > 0 && req.PageSize < maxPageSize {
+		maxPageSize = req.PageSize
+	}
+	tasks := make([]larksdk.Task, 0)
+	req.PageToken = ""
+	for {
+		req.PageSize = limit - len(tasks)
+		if req.PageSize > maxPageSize {
+			req.PageSize = maxPageSize
+		}
+		result, err := state.SDK.ListTasklistTasks(ctx, token, larksdk.AccessTokenType(tokenType), req)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, result.Items...)
+		if len(tasks) >= limit || !result.HasMore || result.PageToken == "" {
+			break
+		}
+		req.PageToken = result.PageToken
+	}
+	if len(tasks) > limit {
+		tasks = tasks[:limit]
+	}
+	return tasks, nil
+}
+
+func taskBoardText(columns []taskBoardColumn, printer output.Printer) string {
+	if len(columns) == 0 {
+		return output.Notice(output.NoticeInfo, "no sections found", nil)
+	}
+	if tablePlain {
+		rows := make([][]string, 0)
+		for _, column := range columns {
+			for _, task := range column.Tasks {
+				rows = append(rows, []string{column.Section.GUID, column.Section.Name, task.GUID, task.Summary, taskStatus(task), formatTaskTime(task.Due)})
+			}
+		}
+		return output.TableTSV([]string{"section_guid", "section", "task_guid", "summary", "status", "due"}, rows)
+	}
+	kanban := make([]output.KanbanColumn, 0, len(columns))
+	for _, column := range columns {
+		cards := make([]string, 0, len(column.Tasks))
+		for _, task := range column.Tasks {
+			cards = append(cards, taskBoardCard(task))
+		}
+		kanban = append(kanban, output.KanbanColumn{Title: column.Section.Name, Cards: cards})
+	}
+	return output.KanbanText(kanban, printer.Styled, output.TerminalWidth(printer.Writer))
+}
+
+func taskBoardCard(task larksdk.Task) string {
+	mark := "[ ]"
+	if taskStatus(task) == "done" {
+		mark = "[x]"
+	}
+	lines := []string{fmt.Sprintf("%s %s", mark, strings.TrimSpace(task.Summary)), task.GUID}
+	if due := formatTaskTime(task.Due); due != "" {
+		lines = append(lines, "due "+due)
+	}
+	if assignees := taskAssignees(task.Members); assignees != "" {
+		lines = append(lines, "@ "+assignees)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// taskStatus falls back to completed_at since tasklist listings omit status.
+func taskStatus(task larksdk.Task) string {
+	if task.Status != "" {
+		return task.Status
+	}
+	if task.CompletedAt != "" && task.CompletedAt != "0" {
+		return "done"
+	}
+	return "todo"
+}
+
+func newTaskMoveCmd(state *appState) *cobra.Command {
+	var taskGUID string
+	var sectionGUID string
+	var tasklistGUID string
+
+	cmd := &cobra.Command{
+		Use:   "move <task-guid>",
+		Short: "Move a task to a task list section",
+		Long: `Move a task to another section (board column).
+
+- The task list is looked up from the section unless --tasklist is given.
+- Tasks not yet in the task list are added to it.`,
+		Args: taskIDArgs(&taskGUID, "task-guid"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sectionGUID = strings.TrimSpace(sectionGUID)
+			if sectionGUID == "" {
+				return flagUsage(cmd, "section is required")
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			token, tokenType, err := resolveAccessToken(ctx, state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			tasklistGUID = strings.TrimSpace(tasklistGUID)
+			if tasklistGUID == "" {
+				section, err := state.SDK.GetTaskSection(ctx, token, larksdk.AccessTokenType(tokenType), sectionGUID)
+				if err != nil {
+					return err
+				}
+				if section.Tasklist == nil || section.Tasklist.GUID == "" {
+					return fmt.Errorf("section %s does not belong to a task list; pass --tasklist", sectionGUID)
+				}
+				tasklistGUID = section.Tasklist.GUID
+			}
+			task, err := state.SDK.AddTaskToTasklist(ctx, token, larksdk.AccessTokenType(tokenType), taskGUID, larksdk.TaskInTasklistInfo{
+				TasklistGUID: tasklistGUID,
+				SectionGUID:  sectionGUID,
+			})
+			if err != nil {
+				return err
+			}
+			payload := map[string]any{"task": task, "tasklist_guid": tasklistGUID, "section_guid": sectionGUID}
+			text := tableTextRow([]string{"task_guid", "tasklist_guid", "section_guid"}, []string{taskGUID, tasklistGUID, sectionGUID})
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&sectionGUID, "section", "", "destination section-guid")
+	cmd.Flags().StringVar(&tasklistGUID, "tasklist", "", "tasklist-guid of the section (default: looked up)")
+	_ = cmd.MarkFlagRequired("section")
+	return cmd
+}
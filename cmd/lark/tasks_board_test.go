@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestTasksListGroupBySectionRendersBoard(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/open-apis/task/v2/sections":
+			if r.URL.Query().Get("resource_type") != "tasklist" || r.URL.Query().Get("resource_id") != "tl1" {
+				t.Fatalf("unexpected query: %s", r.URL.RawQuery)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"items": []map[string]any{
+						{"guid": "s1", "name": "Todo", "is_default": true},
+						{"guid": "s2", "name": "Doing"},
+					},
+					"has_more": false,
+				},
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/open-apis/task/v2/sections/s1/tasks":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"items":    []map[string]any{{"guid": "t1", "summary": "Write spec"}},
+					"has_more": false,
+				},
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/open-apis/task/v2/sections/s2/tasks":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"items":    []map[string]any{{"guid": "t2", "summary": "Ship it", "completed_at": "1700000000000"}},
+					"has_more": false,
+				},
+			})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newTasksCmd(state)
+	cmd.SetArgs([]string{"list", "--tasklist", "tl1", "--group-by", "section"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("tasks list error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Todo (1)", "Doing (1)", "[ ] Write spec", "[x] Ship it", "t1", "t2"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output: %q", want, out)
+		}
+	}
+
+	buf.Reset()
+	state.Printer.JSON = true
+	cmd = newTasksCmd(state)
+	cmd.SetArgs([]string{"list", "--tasklist", "tl1", "--group-by", "section"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("tasks list json error: %v", err)
+	}
+	var payload struct {
+		TasklistGUID string `json:"tasklist_guid"`
+		Sections     []struct {
+			Section struct {
+				GUID string `json:"guid"`
+			} `json:"section"`
+			Tasks []struct {
+				GUID string `json:"guid"`
+			} `json:"tasks"`
+		} `json:"sections"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("decode json: %v (%q)", err, buf.String())
+	}
+	if payload.TasklistGUID != "tl1" || len(payload.Sections) != 2 || payload.Sections[1].Section.GUID != "s2" || payload.Sections[1].Tasks[0].GUID != "t2" {
+		t.Fatalf("unexpected payload: %+v", payload)
+	}
+}
+
+func TestTasksListGroupByRequiresTasklist(t *testing.T) {
+	var buf bytes.Buffer
+	state := newTestState(t, http.NotFoundHandler(), nil, &buf)
+	cmd := newTasksCmd(state)
+	cmd.SetArgs([]string{"list", "--group-by", "section"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--tasklist") {
+		t.Fatalf("expected --tasklist error, got %v", err)
+	}
+}
+
+func TestTasksListTasklistPageSizeAndType(t *testing.T) {
+	var pageSizes []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/open-apis/task/v2/tasklists/tl1/tasks" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		pageSizes = append(pageSizes, r.URL.Query().Get("page_size"))
+		data := map[string]any{"items": []map[string]any{{"guid": "t1", "summary": "Write spec"}}, "has_more": true, "page_token": "p2"}
+		if r.URL.Query().Get("page_token") == "p2" {
+			data = map[string]any{"items": []map[string]any{{"guid": "t2", "summary": "Ship it"}}, "has_more": false}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": data})
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newTasksCmd(state)
+	cmd.SetArgs([]string{"list", "--tasklist", "tl1", "--page-size", "1", "--limit", "5"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("tasks list error: %v", err)
+	}
+	if strings.Join(pageSizes, ",") != "1,1" {
+		t.Fatalf("expected --page-size on every request, got %v", pageSizes)
+	}
+	if !strings.Contains(buf.String(), "t1") || !strings.Contains(buf.String(), "t2") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+
+	pageSizes = nil
+	cmd = newTasksCmd(state)
+	cmd.SetArgs([]string{"list", "--tasklist", "tl1", "--type", "my_tasks"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--type does not apply with --tasklist") {
+		t.Fatalf("expected --type error, got %v", err)
+	}
+	if len(pageSizes) != 0 {
+		t.Fatalf("rejected flags must not reach the API, got %v", pageSizes)
+	}
+}
+
+func TestTasksMoveLooksUpTasklist(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/open-apis/task/v2/sections/s2":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"section": map[string]any{"guid": "s2", "name": "Doing", "tasklist": map[string]any{"guid": "tl1"}},
+				},
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/open-apis/task/v2/tasks/t1/add_tasklist":
+			var payload map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			if payload["tasklist_guid"] != "tl1" || payload["section_guid"] != "s2" {
+				t.Fatalf("unexpected payload: %+v", payload)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{"task": map[string]any{"guid": "t1"}},
+			})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newTasksCmd(state)
+	cmd.SetArgs([]string{"move", "t1", "--section", "s2"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("tasks move error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "tl1") || !strings.Contains(buf.String(), "s2") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
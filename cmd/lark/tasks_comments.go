@@ -0,0 +1,224 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+func newTaskCommentsCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "comments",
+		Aliases: []string{"comment"},
+		Short:   "Manage task comments",
+		Long: `Manage the comment thread of a task.
+
+- list and add take a task-guid; update and delete take a comment-id.
+- add --reply-to <comment-id> posts a reply to an existing comment.`,
+	}
+	cmd.AddCommand(newTaskCommentsListCmd(state))
+	cmd.AddCommand(newTaskCommentsAddCmd(state))
+	cmd.AddCommand(newTaskCommentsUpdateCmd(state))
+	cmd.AddCommand(newTaskCommentsDeleteCmd(state))
+	return cmd
+}
+
+func newTaskCommentsListCmd(state *appState) *cobra.Command {
+	var taskGUID string
+	var limit int
+	var newestFirst bool
+
+	cmd := &cobra.Command{
+		Use:   "list <task-guid>",
+		Short: "List comments on a task",
+		Args:  taskIDArgs(&taskGUID, "task-guid"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if limit <= 0 {
+				return flagUsage(cmd, "limit must be greater than 0")
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			direction := "asc"
+			if newestFirst {
+				direction = "desc"
+			}
+			comments := make([]larksdk.TaskComment, 0)
+			pageToken := ""
+			for {
+				result, err := state.SDK.ListTaskComments(cmd.Context(), token, larksdk.AccessTokenType(tokenType), larksdk.ListTaskCommentsRequest{
+					TaskGUID:   taskGUID,
+					PageSize:   100,
+					PageToken:  pageToken,
+					Direction:  direction,
+					UserIDType: "open_id",
+				})
+				if err != nil {
+					return err
+				}
+				comments = append(comments, result.Items...)
+				if len(comments) >= limit || !result.HasMore || result.PageToken == "" {
+					break
+				}
+				pageToken = result.PageToken
+			}
+			if len(comments) > limit {
+				comments = comments[:limit]
+			}
+			payload := map[string]any{"task_guid": taskGUID, "comments": comments}
+			text := tableTextFromRows([]string{"comment_id", "creator", "created_at", "reply_to", "content"}, taskCommentRows(comments), "no comments found")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 100, "max number of comments to return")
+	cmd.Flags().BoolVar(&newestFirst, "newest-first", false, "list the newest comments first")
+	return cmd
+}
+
+func newTaskCommentsAddCmd(state *appState) *cobra.Command {
+	var taskGUID string
+	var content string
+	var replyTo string
+
+	cmd := &cobra.Command{
+		Use:   "add <task-guid>",
+		Short: "Add a comment to a task",
+		Args:  taskIDArgs(&taskGUID, "task-guid"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.TrimSpace(content) == "" {
+				return flagUsage(cmd, "content is required")
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			comment, err := state.SDK.CreateTaskComment(cmd.Context(), token, larksdk.AccessTokenType(tokenType), larksdk.CreateTaskCommentRequest{
+				TaskGUID:         taskGUID,
+				Content:          content,
+				ReplyToCommentID: replyTo,
+				UserIDType:       "open_id",
+			})
+			if err != nil {
+				return err
+			}
+			payload := map[string]any{"task_guid": taskGUID, "comment": comment}
+			text := tableTextRow([]string{"comment_id", "task_guid", "content"}, []string{comment.ID, taskGUID, comment.Content})
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&content, "content", "", "comment text")
+	cmd.Flags().StringVar(&replyTo, "reply-to", "", "comment ID to reply to")
+	_ = cmd.MarkFlagRequired("content")
+	return cmd
+}
+
+func newTaskCommentsUpdateCmd(state *appState) *cobra.Command {
+	var commentID string
+	var content string
+
+	cmd := &cobra.Command{
+		Use:   "update <comment-id>",
+		Short: "Edit a task comment",
+		Args:  taskIDArgs(&commentID, "comment-id"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.TrimSpace(content) == "" {
+				return flagUsage(cmd, "content is required")
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			comment, err := state.SDK.UpdateTaskComment(cmd.Context(), token, larksdk.AccessTokenType(tokenType), larksdk.UpdateTaskCommentRequest{
+				CommentID:  commentID,
+				Content:    content,
+				UserIDType: "open_id",
+			})
+			if err != nil {
+				return err
+			}
+			payload := map[string]any{"comment": comment}
+			text := tableTextRow([]string{"comment_id", "content", "updated_at"}, []string{commentID, comment.Content, comment.UpdatedAt})
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&content, "content", "", "new comment text")
+	_ = cmd.MarkFlagRequired("content")
+	return cmd
+}
+
+func newTaskCommentsDeleteCmd(state *appState) *cobra.Command {
+	var commentID string
+
+	cmd := &cobra.Command{
+		Use:   "delete <comment-id>",
+		Short: "Delete a task comment",
+		Args:  taskIDArgs(&commentID, "comment-id"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := confirmDestructive(cmd, state, fmt.Sprintf("delete task comment %s", commentID)); err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if err := state.SDK.DeleteTaskComment(cmd.Context(), token, larksdk.AccessTokenType(tokenType), commentID); err != nil {
+				return err
+			}
+			payload := map[string]any{"comment_id": commentID, "deleted": true}
+			text := tableTextRow([]string{"comment_id", "deleted"}, []string{commentID, "true"})
+			return state.Printer.Print(payload, text)
+		},
+	}
+	return cmd
+}
+
+func taskIDArgs(value *string, name string) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return argsUsageError(cmd, err)
+		}
+		*value = strings.TrimSpace(args[0])
+		if *value == "" {
+			return argsUsageError(cmd, errors.New(name+" is required"))
+		}
+		return nil
+	}
+}
+
+func taskCommentRows(comments []larksdk.TaskComment) [][]string {
+	rows := make([][]string, 0, len(comments))
+	for _, comment := range comments {
+		creator := ""
+		if comment.Creator != nil {
+			creator = comment.Creator.ID
+		}
+		rows = append(rows, []string{
+			infoValue(comment.ID),
+			infoValue(creator),
+			infoValue(formatTaskMillis(comment.CreatedAt)),
+			infoValue(comment.ReplyToCommentID),
+			infoValue(comment.Content),
+		})
+	}
+	return rows
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestTasksCommentsAddCommand(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/open-apis/task/v2/comments" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		if payload["resource_type"] != "task" || payload["resource_id"] != "t1" || payload["content"] != "LGTM" || payload["reply_to_comment_id"] != "c0" {
+			t.Fatalf("unexpected payload: %+v", payload)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"code": 0,
+			"msg":  "ok",
+			"data": map[string]any{"comment": map[string]any{"id": "c1", "content": "LGTM"}},
+		})
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newTasksCmd(state)
+	cmd.SetArgs([]string{"comments", "add", "t1", "--content", "LGTM", "--reply-to", "c0"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("comments add error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "c1") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestTasksCommentsListCommand(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/open-apis/task/v2/comments" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("resource_type") != "task" || query.Get("resource_id") != "t1" || query.Get("direction") != "asc" {
+			t.Fatalf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"code": 0,
+			"msg":  "ok",
+			"data": map[string]any{
+				"items": []map[string]any{
+					{"id": "c1", "content": "first", "creator": map[string]any{"id": "ou_1", "type": "user"}, "created_at": "1700000000000"},
+					{"id": "c2", "content": "second", "reply_to_comment_id": "c1"},
+				},
+				"has_more": false,
+			},
+		})
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newTasksCmd(state)
+	cmd.SetArgs([]string{"comments", "list", "t1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("comments list error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"ou_1", "2023-11-14T22:13:20Z", "second"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output: %q", want, out)
+		}
+	}
+}
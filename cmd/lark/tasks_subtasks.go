@@ -0,0 +1,504 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+type taskTreeNode struct {
+	Task     larksdk.Task   `json:"task"`
+	Subtasks []taskTreeNode `json:"subtasks,omitempty"`
+}
+
+func newTaskSubtasksCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "subtasks",
+		Aliases: []string{"subtask"},
+		Short:   "Manage subtasks",
+		Long: `Subtasks are tasks nested under a parent task.
+
+- add creates a subtask under <parent-guid>; nest deeper by passing a subtask guid.
+- list shows direct subtasks; use --recursive to walk the whole hierarchy.`,
+	}
+	cmd.AddCommand(newTaskSubtasksAddCmd(state))
+	cmd.AddCommand(newTaskSubtasksListCmd(state))
+	return cmd
+}
+
+func newTaskSubtasksAddCmd(state *appState) *cobra.Command {
+	var parentGUID string
+	var summary string
+	var description string
+	var due string
+	var dueAllDay bool
+	var start string
+	var startAllDay bool
+	var memberType string
+	var assignees []string
+	var followers []string
+	var clientToken string
+
+	cmd := &cobra.Command{
+		Use:   "add <parent-guid>",
+		Short: "Create a subtask",
+		Args:  taskIDArgs(&parentGUID, "parent-guid"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.TrimSpace(summary) == "" {
+				return flagUsage(cmd, "summary is required")
+			}
+			if memberType == "" || memberType == "user" {
+				var err error
+				if assignees, err = resolveUserRefs(cmd.Context(), state, assignees, "open_id"); err != nil {
+					return err
+				}
+				if followers, err = resolveUserRefs(cmd.Context(), state, followers, "open_id"); err != nil {
+					return err
+				}
+			}
+			members, err := buildTaskMembers(memberType, assignees, followers, "")
+			if err != nil {
+				return err
+			}
+			dueTime, err := buildTaskTime(cmd, due, "due-all-day", dueAllDay)
+			if err != nil {
+				return err
+			}
+			startTime, err := buildTaskTime(cmd, start, "start-all-day", startAllDay)
+			if err != nil {
+				return err
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			task, err := state.SDK.CreateTaskSubtask(cmd.Context(), token, larksdk.AccessTokenType(tokenType), parentGUID, larksdk.CreateTaskRequest{
+				Summary:     strings.TrimSpace(summary),
+				Description: strings.TrimSpace(description),
+				Due:         dueTime,
+				Start:       startTime,
+				Members:     members,
+				ClientToken: strings.TrimSpace(clientToken),
+				UserIDType:  "open_id",
+			})
+			if err != nil {
+				return err
+			}
+			payload := map[string]any{"parent_task_guid": parentGUID, "task": task}
+			text := tableTextRow(
+				[]string{"task_guid", "parent_task_guid", "summary", "due"},
+				[]string{task.GUID, parentGUID, task.Summary, formatTaskTime(task.Due)},
+			)
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&summary, "summary", "", "subtask summary/title")
+	cmd.Flags().StringVar(&description, "description", "", "subtask description")
+	cmd.Flags().StringVar(&due, "due", "", "due timestamp in ms/seconds or RFC3339")
+	cmd.Flags().BoolVar(&dueAllDay, "due-all-day", false, "treat due timestamp as date-only")
+	cmd.Flags().StringVar(&start, "start", "", "start timestamp in ms/seconds or RFC3339")
+	cmd.Flags().BoolVar(&startAllDay, "start-all-day", false, "treat start timestamp as date-only")
+	cmd.Flags().StringVar(&memberType, "member-type", "user", "member type for --assignee/--follower (user or app)")
+	cmd.Flags().StringArrayVar(&assignees, "assignee", nil, "assignee member ID, email, or @name (repeatable)")
+	cmd.Flags().StringArrayVar(&followers, "follower", nil, "follower member ID, email, or @name (repeatable)")
+	cmd.Flags().StringVar(&clientToken, "client-token", "", "idempotency token")
+	_ = cmd.MarkFlagRequired("summary")
+	return cmd
+}
+
+func newTaskSubtasksListCmd(state *appState) *cobra.Command {
+	var parentGUID string
+	var recursive bool
+	var depth int
+
+	cmd := &cobra.Command{
+		Use:   "list <parent-guid>",
+		Short: "List subtasks of a task",
+		Args:  taskIDArgs(&parentGUID, "parent-guid"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if depth < 0 {
+				return flagUsage(cmd, "depth must be greater than or equal to 0")
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			maxDepth := 1
+			if recursive {
+				maxDepth = depth
+			}
+			nodes, err := fetchTaskSubtaskTree(cmd.Context(), state, token, tokenType, parentGUID, 1, maxDepth)
+			if err != nil {
+				return err
+			}
+			payload := map[string]any{"parent_task_guid": parentGUID, "subtasks": nodes}
+			if !recursive {
+				rows := make([][]string, 0, len(nodes))
+				for _, node := range nodes {
+					nameTaskMembers(state, []larksdk.Task{node.Task})
+					rows = append(rows, []string{node.Task.GUID, node.Task.Summary, node.Task.Status, formatTaskTime(node.Task.Due), taskAssignees(node.Task.Members)})
+				}
+				text := tableTextFromRows([]string{"task_guid", "summary", "status", "due", "assignees"}, rows, "no subtasks found")
+				return state.Printer.Print(payload, text)
+			}
+			text := strings.Join(renderTaskTree(nodes, ""), "\n")
+			if text == "" {
+				text = "no subtasks found"
+			}
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().BoolVar(&recursive, "recursive", false, "walk nested subtasks and render a tree")
+	cmd.Flags().IntVar(&depth, "depth", 0, "max depth with --recursive (0 = unlimited)")
+	return cmd
+}
+
+func newTaskDepsCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "deps",
+		Aliases: []string{"dependencies"},
+		Short:   "Manage blocking relations between tasks",
+		Long: `Dependencies record which tasks block each other.
+
+- --blocked-by <guid> means <guid> must finish before <task-guid>.
+- --blocks <guid> means <task-guid> must finish before <guid>.`,
+	}
+	cmd.AddCommand(newTaskDepsAddCmd(state))
+	cmd.AddCommand(newTaskDepsRemoveCmd(state))
+	return cmd
+}
+
+func newTaskDepsAddCmd(state *appState) *cobra.Command {
+	var taskGUID string
+	var blockedBy []string
+	var blocks []string
+
+	cmd := &cobra.Command{
+		Use:   "add <task-guid>",
+		Short: "Add blocking relations to a task",
+		Args:  taskIDArgs(&taskGUID, "task-guid"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			deps := buildTaskDependencies(blockedBy, blocks)
+			if len(deps) == 0 {
+				return flagUsage(cmd, "at least one --blocked-by or --blocks is required")
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			result, err := state.SDK.AddTaskDependencies(cmd.Context(), token, larksdk.AccessTokenType(tokenType), taskGUID, deps)
+			if err != nil {
+				return err
+			}
+			if len(result) == 0 {
+				result = deps
+			}
+			payload := map[string]any{"task_guid": taskGUID, "dependencies": result}
+			text := tableTextFromRows([]string{"task_guid", "relation", "other_task_guid"}, taskDependencyRows(taskGUID, result), "no dependencies")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&blockedBy, "blocked-by", nil, "task guid that blocks this task (repeatable)")
+	cmd.Flags().StringArrayVar(&blocks, "blocks", nil, "task guid blocked by this task (repeatable)")
+	return cmd
+}
+
+func newTaskDepsRemoveCmd(state *appState) *cobra.Command {
+	var taskGUID string
+	var blockedBy []string
+	var blocks []string
+
+	cmd := &cobra.Command{
+		Use:     "remove <task-guid>",
+		Aliases: []string{"delete"},
+		Short:   "Remove blocking relations from a task",
+		Args:    taskIDArgs(&taskGUID, "task-guid"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			deps := buildTaskDependencies(blockedBy, blocks)
+			if len(deps) == 0 {
+				return flagUsage(cmd, "at least one --blocked-by or --blocks is required")
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			if _, err := state.SDK.RemoveTaskDependencies(cmd.Context(), token, larksdk.AccessTokenType(tokenType), taskGUID, deps); err != nil {
+				return err
+			}
+			payload := map[string]any{"task_guid": taskGUID, "removed": deps}
+			text := tableTextFromRows([]string{"task_guid", "relation", "other_task_guid"}, taskDependencyRows(taskGUID, deps), "no dependencies")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&blockedBy, "blocked-by", nil, "task guid that blocks this task (repeatable)")
+	cmd.Flags().StringArrayVar(&blocks, "blocks", nil, "task guid blocked by this task (repeatable)")
+	return cmd
+}
+
+func newTaskRemindersCmd(state *appState) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "reminders",
+		Aliases: []string{"reminder"},
+		Short:   "Manage task reminders",
+		Long: `Reminders fire relative to the task due time; a task holds at most one.
+
+- set replaces any existing reminder.
+- --before accepts minutes (30) or a duration (15m, 2h, 1d); 0 fires at the due time.`,
+	}
+	cmd.AddCommand(newTaskRemindersSetCmd(state))
+	return cmd
+}
+
+func newTaskRemindersSetCmd(state *appState) *cobra.Command {
+	var taskGUID string
+	var before string
+	var clear bool
+
+	cmd := &cobra.Command{
+		Use:   "set <task-guid>",
+		Short: "Set or clear the reminder of a task",
+		Args:  taskIDArgs(&taskGUID, "task-guid"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if clear == cmd.Flags().Changed("before") {
+				return flagUsage(cmd, "exactly one of --before or --clear is required")
+			}
+			minutes := 0
+			if !clear {
+				value, err := parseTaskReminderMinutes(before)
+				if err != nil {
+					return flagUsage(cmd, err.Error())
+				}
+				minutes = value
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			task, err := getTaskForToken(cmd.Context(), state, token, tokenType, larksdk.GetTaskRequest{TaskGUID: taskGUID, UserIDType: "open_id"})
+			if err != nil {
+				return err
+			}
+			if !clear && task.Due == nil {
+				return fmt.Errorf("task %s has no due time; set one with `lark tasks update %s --due ...` first", taskGUID, taskGUID)
+			}
+			ids := make([]string, 0, len(task.Reminders))
+			for _, reminder := range task.Reminders {
+				if strings.TrimSpace(reminder.ID) != "" {
+					ids = append(ids, reminder.ID)
+				}
+			}
+			if len(ids) > 0 {
+				task, err = state.SDK.RemoveTaskReminders(cmd.Context(), token, larksdk.AccessTokenType(tokenType), taskGUID, ids)
+				if err != nil {
+					return err
+				}
+			}
+			if !clear {
+				task, err = state.SDK.AddTaskReminders(cmd.Context(), token, larksdk.AccessTokenType(tokenType), taskGUID, []larksdk.TaskReminder{{RelativeFireMinute: minutes}})
+				if err != nil {
+					return err
+				}
+			}
+			payload := map[string]any{"task_guid": taskGUID, "reminders": task.Reminders}
+			text := tableTextRow([]string{"task_guid", "reminder"}, []string{taskGUID, infoValue(formatTaskReminders(task.Reminders))})
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&before, "before", "", "fire this long before the due time (minutes or duration, e.g. 30, 2h, 1d)")
+	cmd.Flags().BoolVar(&clear, "clear", false, "remove the reminder")
+	return cmd
+}
+
+func getTaskForToken(ctx context.Context, state *appState, token string, tokenType tokenType, req larksdk.GetTaskRequest) (larksdk.Task, error) {
+	switch tokenType {
+	case tokenTypeTenant:
+		return state.SDK.GetTask(ctx, token, req)
+	case tokenTypeUser:
+		return state.SDK.GetTaskWithUserToken(ctx, token, req)
+	default:
+		return larksdk.Task{}, fmt.Errorf("unsupported token type %s", tokenType)
+	}
+}
+
+// fetchTaskSubtaskTree lists subtasks of parentGUID and descends into those
+// reporting their own subtasks, up to maxDepth levels (0 = unlimited).
+func fetchTaskSubtaskTree(ctx context.Context, state *appState, token string, tokenType tokenType, parentGUID string, depth, maxDepth int) ([]taskTreeNode, error) {
+	tasks := make([]larksdk.Task, 0)
+	pageToken := ""
+	for {
+		result, err := state.SDK.ListTaskSubtasks(ctx, token, larksdk.AccessTokenType(tokenType), larksdk.ListTaskSubtasksRequest{
+			TaskGUID:   parentGUID,
+			PageSize:   50,
+			PageToken:  pageToken,
+			UserIDType: "open_id",
+		})
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, result.Items...)
+		if !result.HasMore || result.PageToken == "" {
+			break
+		}
+		pageToken = result.PageToken
+	}
+	nodes := make([]taskTreeNode, 0, len(tasks))
+	for _, task := range tasks {
+		node := taskTreeNode{Task: task}
+		if task.SubtaskCount > 0 && (maxDepth == 0 || depth < maxDepth) {
+			children, err := fetchTaskSubtaskTree(ctx, state, token, tokenType, task.GUID, depth+1, maxDepth)
+			if err != nil {
+				return nil, err
+			}
+			node.Subtasks = children
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+func renderTaskTree(nodes []taskTreeNode, prefix string) []string {
+	lines := make([]string, 0)
+	for i, node := range nodes {
+		last := i == len(nodes)-1
+		branch := "|- "
+		childPrefix := prefix + "|  "
+		if last {
+			branch = "`- "
+			childPrefix = prefix + "   "
+		}
+		lines = append(lines, prefix+branch+formatTaskTreeLabel(node.Task))
+		if len(node.Subtasks) > 0 {
+			lines = append(lines, renderTaskTree(node.Subtasks, childPrefix)...)
+		}
+	}
+	return lines
+}
+
+func formatTaskTreeLabel(task larksdk.Task) string {
+	mark := "[ ]"
+	if taskStatus(task) == "done" {
+		mark = "[x]"
+	}
+	summary := strings.TrimSpace(task.Summary)
+	if summary == "" {
+		summary = "untitled"
+	}
+	label := fmt.Sprintf("%s %s [%s]", mark, summary, task.GUID)
+	if due := formatTaskTime(task.Due); due != "" {
+		label += " due " + due
+	}
+	return label
+}
+
+func buildTaskDependencies(blockedBy, blocks []string) []larksdk.TaskDependency {
+	deps := make([]larksdk.TaskDependency, 0, len(blockedBy)+len(blocks))
+	for _, guid := range normalizeAttendeeValues(blockedBy) {
+		deps = append(deps, larksdk.TaskDependency{Type: "prev", TaskGUID: guid})
+	}
+	for _, guid := range normalizeAttendeeValues(blocks) {
+		deps = append(deps, larksdk.TaskDependency{Type: "next", TaskGUID: guid})
+	}
+	return deps
+}
+
+func taskDependencyRows(taskGUID string, deps []larksdk.TaskDependency) [][]string {
+	rows := make([][]string, 0, len(deps))
+	for _, dep := range deps {
+		rows = append(rows, []string{taskGUID, infoValue(taskDependencyRelation(dep.Type)), dep.TaskGUID})
+	}
+	return rows
+}
+
+func taskDependencyRelation(depType string) string {
+	switch depType {
+	case "prev":
+		return "blocked_by"
+	case "next":
+		return "blocks"
+	default:
+		return depType
+	}
+}
+
+func taskDependencyGUIDs(deps []larksdk.TaskDependency, depType string) string {
+	guids := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		if dep.Type == depType && dep.TaskGUID != "" {
+			guids = append(guids, dep.TaskGUID)
+		}
+	}
+	return strings.Join(guids, ",")
+}
+
+func parseTaskReminderMinutes(raw string) (int, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return 0, errors.New("--before is required")
+	}
+	if minutes, err := strconv.Atoi(raw); err == nil {
+		if minutes < 0 {
+			return 0, errors.New("--before must not be negative")
+		}
+		return minutes, nil
+	}
+	var duration time.Duration
+	if days, ok := strings.CutSuffix(raw, "d"); ok {
+		count, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid --before %q", raw)
+		}
+		duration = time.Duration(count) * 24 * time.Hour
+	} else {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return 0, fmt.Errorf("invalid --before %q", raw)
+		}
+		duration = parsed
+	}
+	if duration < 0 {
+		return 0, errors.New("--before must not be negative")
+	}
+	if duration%time.Minute != 0 {
+		return 0, errors.New("--before must be a whole number of minutes")
+	}
+	return int(duration / time.Minute), nil
+}
+
+func formatTaskReminders(reminders []larksdk.TaskReminder) string {
+	parts := make([]string, 0, len(reminders))
+	for _, reminder := range reminders {
+		if reminder.RelativeFireMinute == 0 {
+			parts = append(parts, "at due")
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%dm before due", reminder.RelativeFireMinute))
+	}
+	return strings.Join(parts, ",")
+}
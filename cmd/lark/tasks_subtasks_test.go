@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"lark/internal/larksdk"
+)
+
+func TestTasksSubtasksAddCommand(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/open-apis/task/v2/tasks/parent/subtasks" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		if payload["summary"] != "Write spec" {
+			t.Fatalf("unexpected payload: %+v", payload)
+		}
+		members, _ := payload["members"].([]any)
+		if len(members) != 1 {
+			t.Fatalf("unexpected members: %+v", payload["members"])
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"code": 0,
+			"msg":  "ok",
+			"data": map[string]any{
+				"subtask": map[string]any{"guid": "child", "summary": "Write spec", "parent_task_guid": "parent"},
+			},
+		})
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newTasksCmd(state)
+	cmd.SetArgs([]string{"subtasks", "add", "parent", "--summary", "Write spec", "--assignee", "ou_1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("subtasks add error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "child") || !strings.Contains(buf.String(), "parent") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestTasksInfoRendersSubtaskTree(t *testing.T) {
+	var subtaskCalls []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/open-apis/task/v2/tasks/root":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"task": map[string]any{
+						"guid":          "root",
+						"summary":       "Sprint 12",
+						"subtask_count": 2,
+						"dependencies":  []map[string]any{{"type": "prev", "task_guid": "design"}},
+						"reminders":     []map[string]any{{"id": "r1", "relative_fire_minute": 30}},
+					},
+				},
+			})
+		case "/open-apis/task/v2/tasks/root/subtasks":
+			subtaskCalls = append(subtaskCalls, "root")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"items": []map[string]any{
+						{"guid": "a", "summary": "Backend", "subtask_count": 1},
+						{"guid": "b", "summary": "Frontend", "status": "done"},
+					},
+					"has_more": false,
+				},
+			})
+		case "/open-apis/task/v2/tasks/a/subtasks":
+			subtaskCalls = append(subtaskCalls, "a")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"items":    []map[string]any{{"guid": "a1", "summary": "API"}},
+					"has_more": false,
+				},
+			})
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	// info is read-only: it must not ask for the confirmation that the
+	// baseline copied from tasks delete.
+	state.NoInput = true
+	cmd := newTasksCmd(state)
+	cmd.SetArgs([]string{"info", "root"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("info error: %v", err)
+	}
+	if strings.Join(subtaskCalls, ",") != "root,a" {
+		t.Fatalf("unexpected subtask calls: %v", subtaskCalls)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"blocked_by",
+		"30m before due",
+		"|- [ ] Backend [a]",
+		"|  `- [ ] API [a1]",
+		"`- [x] Frontend [b]",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output: %q", want, out)
+		}
+	}
+}
+
+func TestTasksDepsAddCommand(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/open-apis/task/v2/tasks/t1/add_dependencies" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		var payload struct {
+			Dependencies []larksdk.TaskDependency `json:"dependencies"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		want := []larksdk.TaskDependency{{Type: "prev", TaskGUID: "t0"}, {Type: "next", TaskGUID: "t2"}}
+		if len(payload.Dependencies) != 2 || payload.Dependencies[0] != want[0] || payload.Dependencies[1] != want[1] {
+			t.Fatalf("unexpected dependencies: %+v", payload.Dependencies)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"code": 0,
+			"msg":  "ok",
+			"data": map[string]any{"dependencies": payload.Dependencies},
+		})
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newTasksCmd(state)
+	cmd.SetArgs([]string{"deps", "add", "t1", "--blocked-by", "t0", "--blocks", "t2"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("deps add error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "blocked_by") || !strings.Contains(out, "blocks") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestTasksRemindersSetReplacesExisting(t *testing.T) {
+	var calls []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/open-apis/task/v2/tasks/t1":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"task": map[string]any{
+						"guid":      "t1",
+						"due":       map[string]any{"timestamp": "1700000000000"},
+						"reminders": []map[string]any{{"id": "r1", "relative_fire_minute": 15}},
+					},
+				},
+			})
+		case "/open-apis/task/v2/tasks/t1/remove_reminders":
+			var payload struct {
+				ReminderIDs []string `json:"reminder_ids"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			if len(payload.ReminderIDs) != 1 || payload.ReminderIDs[0] != "r1" {
+				t.Fatalf("unexpected reminder ids: %v", payload.ReminderIDs)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"task": map[string]any{"guid": "t1"}}})
+		case "/open-apis/task/v2/tasks/t1/add_reminders":
+			var payload struct {
+				Reminders []larksdk.TaskReminder `json:"reminders"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			if len(payload.Reminders) != 1 || payload.Reminders[0].RelativeFireMinute != 120 {
+				t.Fatalf("unexpected reminders: %+v", payload.Reminders)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"task": map[string]any{"guid": "t1", "reminders": []map[string]any{{"id": "r2", "relative_fire_minute": 120}}},
+				},
+			})
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newTasksCmd(state)
+	cmd.SetArgs([]string{"reminders", "set", "t1", "--before", "2h"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("reminders set error: %v", err)
+	}
+	if len(calls) != 3 {
+		t.Fatalf("unexpected calls: %v", calls)
+	}
+	if !strings.Contains(buf.String(), "120m before due") {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestParseTaskReminderMinutes(t *testing.T) {
+	cases := map[string]int{"0": 0, "30": 30, "15m": 15, "2h": 120, "1d": 1440}
+	for raw, want := range cases {
+		got, err := parseTaskReminderMinutes(raw)
+		if err != nil || got != want {
+			t.Fatalf("parseTaskReminderMinutes(%q) = %d, %v; want %d", raw, got, err, want)
+		}
+	}
+	for _, raw := range []string{"", "-5", "90s", "soon"} {
+		if _, err := parseTaskReminderMinutes(raw); err == nil {
+			t.Fatalf("expected error for %q", raw)
+		}
+	}
+}
@@ -0,0 +1,409 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"lark/internal/larksdk"
+)
+
+var taskSyncPreferValues = []string{"local", "remote"}
+
+var (
+	taskSyncItemPattern    = regexp.MustCompile(`^(\s*)([-*+]) \[([ xX])\]\s?(.*)$`)
+	taskSyncMappingPattern = regexp.MustCompile(`\s*<!--\s*lark:task=(\S+?)(?:\s+sync=([0-9a-f]+))?\s*-->`)
+	taskSyncDuePattern     = regexp.MustCompile(`\s*@due\(([^)]*)\)`)
+)
+
+// taskSyncItem is one Markdown checkbox line of a synced file.
+type taskSyncItem struct {
+	Line    int
+	Indent  string
+	Bullet  string
+	Done    bool
+	Summary string
+	Due     string
+	GUID    string
+	Synced  string
+
+	// dueTime is the remote due time an item was pulled from.
+	dueTime *larksdk.TaskTime
+}
+
+type taskSyncChange struct {
+	Action   string `json:"action"`
+	TaskGUID string `json:"task_guid,omitempty"`
+	Summary  string `json:"summary"`
+	Line     int    `json:"line,omitempty"`
+}
+
+func newTaskSyncCmd(state *appState) *cobra.Command {
+	var file string
+	var tasklistGUID string
+	var prefer string
+	var dryRun bool
+	var tz string
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Sync a Markdown checklist with a task list",
+		Long: `Sync keeps a Markdown checklist and a task list in step.
+
+- Items are "- [ ] summary @due(2026-10-20)" lines; "[x]" marks a completed task.
+- Each synced item carries a "<!-- lark:task=<guid> sync=<hash> -->" comment; keep it to preserve the mapping.
+- Items without a mapping are created in the task list; open remote tasks missing from the file are appended.
+- Changes are pushed or pulled per item; when both sides changed, --prefer decides.
+- Items whose task left the task list are reported as missing and left untouched.`,
+		Example: `  lark tasks sync --file TODO.md --tasklist <tasklist-guid>
+  lark tasks sync --file TODO.md --tasklist <tasklist-guid> --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file = strings.TrimSpace(file)
+			tasklistGUID = strings.TrimSpace(tasklistGUID)
+			if file == "" {
+				return flagUsage(cmd, "file is required")
+			}
+			if tasklistGUID == "" {
+				return flagUsage(cmd, "tasklist is required")
+			}
+			if err := validateOneOf(cmd, "prefer", prefer, taskSyncPreferValues); err != nil {
+				return err
+			}
+			loc := time.Local
+			if strings.TrimSpace(tz) != "" {
+				loaded, err := time.LoadLocation(strings.TrimSpace(tz))
+				if err != nil {
+					return flagUsage(cmd, fmt.Sprintf("invalid time zone %q", tz))
+				}
+				loc = loaded
+			}
+			content, err := os.ReadFile(file)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("read %s: %w", file, err)
+			}
+			lines, items, err := parseTaskSyncFile(string(content))
+			if err != nil {
+				return fmt.Errorf("%s: %w", file, err)
+			}
+			if _, err := requireSDK(state); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			token, tokenType, err := resolveAccessToken(ctx, state, tokenTypesTenantOrUser, nil)
+			if err != nil {
+				return err
+			}
+			remote, err := listTasklistTasks(ctx, state, token, tokenType, larksdk.ListTasklistTasksRequest{TasklistGUID: tasklistGUID, UserIDType: "open_id"}, math.MaxInt32)
+			if err != nil {
+				return err
+			}
+			syncer := &taskSyncer{
+				ctx:          ctx,
+				state:        state,
+				token:        token,
+				tokenType:    tokenType,
+				tasklistGUID: tasklistGUID,
+				preferLocal:  prefer == "local",
+				dryRun:       dryRun,
+				loc:          loc,
+			}
+			lines, changes, err := syncer.run(lines, items, remote)
+			if err != nil {
+				return err
+			}
+			written := false
+			if !dryRun && len(changes) > 0 {
+				if err := os.WriteFile(file, []byte(strings.Join(lines, "\n")), 0o644); err != nil {
+					return fmt.Errorf("write %s: %w", file, err)
+				}
+				written = true
+			}
+			payload := map[string]any{
+				"file":          file,
+				"tasklist_guid": tasklistGUID,
+				"dry_run":       dryRun,
+				"written":       written,
+				"changes":       changes,
+			}
+			rows := make([][]string, 0, len(changes))
+			for _, change := range changes {
+				line := ""
+				if change.Line > 0 {
+					line = strconv.Itoa(change.Line)
+				}
+				rows = append(rows, []string{change.Action, infoValue(change.TaskGUID), change.Summary, infoValue(line)})
+			}
+			text := tableTextFromRows([]string{"action", "task_guid", "summary", "line"}, rows, "already in sync")
+			return state.Printer.Print(payload, text)
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "Markdown file to sync (created if missing)")
+	cmd.Flags().StringVar(&tasklistGUID, "tasklist", "", "tasklist-guid to sync with")
+	cmd.Flags().StringVar(&prefer, "prefer", "local", "side that wins when both changed (local|remote)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show planned changes without applying them")
+	cmd.Flags().StringVar(&tz, "tz", "", "IANA time zone for @due dates (default: local)")
+	_ = cmd.MarkFlagRequired("file")
+	_ = cmd.MarkFlagRequired("tasklist")
+	registerEnumCompletion(cmd, "prefer", taskSyncPreferValues)
+	return cmd
+}
+
+type taskSyncer struct {
+	ctx          context.Context
+	state        *appState
+	token        string
+	tokenType    tokenType
+	tasklistGUID string
+	preferLocal  bool
+	dryRun       bool
+	loc          *time.Location
+}
+
+func (s *taskSyncer) run(lines []string, items []taskSyncItem, remote []larksdk.Task) ([]string, []taskSyncChange, error) {
+	remoteByGUID := make(map[string]larksdk.Task, len(remote))
+	for _, task := range remote {
+		remoteByGUID[task.GUID] = task
+	}
+	seen := make(map[string]bool, len(items))
+	changes := make([]taskSyncChange, 0)
+
+	for _, item := range items {
+		if item.GUID == "" {
+			if !s.dryRun {
+				task, err := s.create(item)
+				if err != nil {
+					return nil, nil, err
+				}
+				item.GUID = task.GUID
+			}
+			changes = append(changes, taskSyncChange{Action: "create", TaskGUID: item.GUID, Summary: item.Summary, Line: item.Line})
+			if item.GUID != "" {
+				lines[item.Line-1] = renderTaskSyncItem(item)
+			}
+			continue
+		}
+		seen[item.GUID] = true
+		task, ok := remoteByGUID[item.GUID]
+		if !ok {
+			changes = append(changes, taskSyncChange{Action: "missing", TaskGUID: item.GUID, Summary: item.Summary, Line: item.Line})
+			continue
+		}
+		pulled := taskSyncItemFromTask(task, s.loc)
+		local := taskSyncFingerprint(item)
+		upstream := taskSyncFingerprint(pulled)
+		switch {
+		case local == upstream:
+			if item.Synced != local {
+				lines[item.Line-1] = renderTaskSyncItem(item)
+				changes = append(changes, taskSyncChange{Action: "link", TaskGUID: item.GUID, Summary: item.Summary, Line: item.Line})
+			}
+		case item.Synced == upstream || (item.Synced != local && s.preferLocal):
+			if !s.dryRun {
+				if err := s.push(item, pulled); err != nil {
+					return nil, nil, err
+				}
+			}
+			lines[item.Line-1] = renderTaskSyncItem(item)
+			changes = append(changes, taskSyncChange{Action: "push", TaskGUID: item.GUID, Summary: item.Summary, Line: item.Line})
+		default:
+			pulled.Line, pulled.Indent, pulled.Bullet = item.Line, item.Indent, item.Bullet
+			lines[item.Line-1] = renderTaskSyncItem(pulled)
+			changes = append(changes, taskSyncChange{Action: "pull", TaskGUID: item.GUID, Summary: pulled.Summary, Line: item.Line})
+		}
+	}
+
+	for _, task := range remote {
+		if seen[task.GUID] || taskStatus(task) == "done" {
+			continue
+		}
+		if len(lines) > 0 && lines[len(lines)-1] == "" {
+			lines = lines[:len(lines)-1]
+		}
+		item := taskSyncItemFromTask(task, s.loc)
+		item.Line = len(lines) + 1
+		lines = append(lines, renderTaskSyncItem(item), "")
+		changes = append(changes, taskSyncChange{Action: "add", TaskGUID: task.GUID, Summary: item.Summary, Line: item.Line})
+	}
+	return lines, changes, nil
+}
+
+func (s *taskSyncer) create(item taskSyncItem) (larksdk.Task, error) {
+	req := larksdk.CreateTaskRequest{
+		Summary:    item.Summary,
+		Due:        taskSyncDueTime(item.Due, nil, s.loc),
+		Tasklists:  []larksdk.TaskInTasklistInfo{{TasklistGUID: s.tasklistGUID}},
+		UserIDType: "open_id",
+	}
+	if item.Done {
+		completedAt := strconv.FormatInt(time.Now().UnixMilli(), 10)
+		req.CompletedAt = &completedAt
+	}
+	switch s.tokenType {
+	case tokenTypeTenant:
+		return s.state.SDK.CreateTask(s.ctx, s.token, req)
+	case tokenTypeUser:
+		return s.state.SDK.CreateTaskWithUserToken(s.ctx, s.token, req)
+	default:
+		return larksdk.Task{}, fmt.Errorf("unsupported token type %s", s.tokenType)
+	}
+}
+
+// push updates only the fields where the local item differs from remote.
+func (s *taskSyncer) push(item, remote taskSyncItem) error {
+	taskPayload := map[string]any{}
+	updateFields := make([]string, 0, 3)
+	if item.Summary != remote.Summary {
+		taskPayload["summary"] = item.Summary
+		updateFields = append(updateFields, "summary")
+	}
+	if item.Due != remote.Due {
+		if due := taskSyncDueTime(item.Due, remote.dueTime, s.loc); due != nil {
+			taskPayload["due"] = due
+		}
+		updateFields = append(updateFields, "due")
+	}
+	if item.Done != remote.Done {
+		completedAt := "0"
+		if item.Done {
+			completedAt = strconv.FormatInt(time.Now().UnixMilli(), 10)
+		}
+		taskPayload["completed_at"] = completedAt
+		updateFields = append(updateFields, "completed_at")
+	}
+	if len(updateFields) == 0 {
+		return nil
+	}
+	req := larksdk.UpdateTaskRequest{
+		TaskGUID:     item.GUID,
+		Task:         taskPayload,
+		UpdateFields: updateFields,
+		UserIDType:   "open_id",
+	}
+	var err error
+	switch s.tokenType {
+	case tokenTypeTenant:
+		_, err = s.state.SDK.UpdateTask(s.ctx, s.token, req)
+	case tokenTypeUser:
+		_, err = s.state.SDK.UpdateTaskWithUserToken(s.ctx, s.token, req)
+	default:
+		err = fmt.Errorf("unsupported token type %s", s.tokenType)
+	}
+	return err
+}
+
+// parseTaskSyncFile splits content into lines and extracts checkbox items.
+// Item.Line is 1-based and indexes into the returned lines.
+func parseTaskSyncFile(content string) ([]string, []taskSyncItem, error) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	lines := strings.Split(content, "\n")
+	if content == "" {
+		lines = []string{}
+	}
+	items := make([]taskSyncItem, 0)
+	for i, line := range lines {
+		match := taskSyncItemPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		item := taskSyncItem{
+			Line:   i + 1,
+			Indent: match[1],
+			Bullet: match[2],
+			Done:   match[3] != " ",
+		}
+		rest := match[4]
+		if mapping := taskSyncMappingPattern.FindStringSubmatch(rest); mapping != nil {
+			item.GUID = mapping[1]
+			item.Synced = mapping[2]
+			rest = taskSyncMappingPattern.ReplaceAllString(rest, "")
+		}
+		if due := taskSyncDuePattern.FindStringSubmatch(rest); due != nil {
+			item.Due = strings.TrimSpace(due[1])
+			if _, err := time.Parse("2006-01-02", item.Due); err != nil {
+				return nil, nil, fmt.Errorf("line %d: invalid @due(%s) (expected YYYY-MM-DD)", i+1, item.Due)
+			}
+			rest = taskSyncDuePattern.ReplaceAllString(rest, "")
+		}
+		item.Summary = strings.Join(strings.Fields(rest), " ")
+		if item.Summary == "" {
+			continue
+		}
+		items = append(items, item)
+	}
+	return lines, items, nil
+}
+
+func renderTaskSyncItem(item taskSyncItem) string {
+	bullet := item.Bullet
+	if bullet == "" {
+		bullet = "-"
+	}
+	mark := " "
+	if item.Done {
+		mark = "x"
+	}
+	line := fmt.Sprintf("%s%s [%s] %s", item.Indent, bullet, mark, item.Summary)
+	if item.Due != "" {
+		line += fmt.Sprintf(" @due(%s)", item.Due)
+	}
+	if item.GUID != "" {
+		line += fmt.Sprintf(" <!-- lark:task=%s sync=%s -->", item.GUID, taskSyncFingerprint(item))
+	}
+	return line
+}
+
+func taskSyncItemFromTask(task larksdk.Task, loc *time.Location) taskSyncItem {
+	item := taskSyncItem{
+		Done:    taskStatus(task) == "done",
+		Summary: strings.Join(strings.Fields(task.Summary), " "),
+		GUID:    task.GUID,
+	}
+	if task.Due != nil && task.Due.Timestamp != "" {
+		if ms, err := strconv.ParseInt(task.Due.Timestamp, 10, 64); err == nil {
+			item.Due = time.UnixMilli(ms).In(loc).Format("2006-01-02")
+			item.dueTime = task.Due
+		}
+	}
+	return item
+}
+
+// taskSyncFingerprint hashes the synced fields so each side's edits can be
+// detected against the state recorded at the last sync.
+func taskSyncFingerprint(item taskSyncItem) string {
+	hash := fnv.New32a()
+	_, _ = fmt.Fprintf(hash, "%s\x00%t\x00%s", item.Summary, item.Done, item.Due)
+	return fmt.Sprintf("%08x", hash.Sum32())
+}
+
+// taskSyncDueTime converts an @due date to a task due time in loc. When the
+// remote task already has a due time, only its date changes: the time of day
+// and all-day flag are kept.
+func taskSyncDueTime(due string, previous *larksdk.TaskTime, loc *time.Location) *larksdk.TaskTime {
+	if due == "" {
+		return nil
+	}
+	parsed, err := time.ParseInLocation("2006-01-02", due, loc)
+	if err != nil {
+		return nil
+	}
+	allDay := true
+	if previous != nil {
+		allDay = previous.IsAllDay != nil && *previous.IsAllDay
+		if ms, err := strconv.ParseInt(previous.Timestamp, 10, 64); err == nil && !allDay {
+			clock := time.UnixMilli(ms).In(loc)
+			parsed = time.Date(parsed.Year(), parsed.Month(), parsed.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, loc)
+		}
+	}
+	return &larksdk.TaskTime{Timestamp: strconv.FormatInt(parsed.UnixMilli(), 10), IsAllDay: &allDay}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTaskSyncFile(t *testing.T) {
+	content := "# Todo\n\n- [ ] Write spec @due(2026-10-20)\n  * [x] Review <!-- lark:task=t1 sync=0011aabb -->\n- [ ] \nnot an item\n"
+	lines, items, err := parseTaskSyncFile(content)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if len(lines) != 7 || len(items) != 2 {
+		t.Fatalf("unexpected parse: %d lines, %+v", len(lines), items)
+	}
+	if items[0].Summary != "Write spec" || items[0].Due != "2026-10-20" || items[0].Done || items[0].Line != 3 {
+		t.Fatalf("unexpected first item: %+v", items[0])
+	}
+	if items[1].Summary != "Review" || !items[1].Done || items[1].GUID != "t1" || items[1].Synced != "0011aabb" || items[1].Indent != "  " || items[1].Bullet != "*" {
+		t.Fatalf("unexpected second item: %+v", items[1])
+	}
+	rendered := renderTaskSyncItem(items[1])
+	if !strings.HasPrefix(rendered, "  * [x] Review <!-- lark:task=t1 sync=") {
+		t.Fatalf("unexpected render: %q", rendered)
+	}
+
+	if _, _, err := parseTaskSyncFile("- [ ] Bad @due(tomorrow)\n"); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Fatalf("expected due error, got %v", err)
+	}
+}
+
+func TestTasksSyncCommand(t *testing.T) {
+	pushed := taskSyncItem{Summary: "Review", Due: "2026-10-21", GUID: "t2"}
+	pulledBase := taskSyncItem{Summary: "Deploy", GUID: "t3"}
+	content := strings.Join([]string{
+		"# Todo",
+		"- [ ] Write spec @due(2026-10-20)",
+		"- [x] Review @due(2026-10-21) <!-- lark:task=t2 sync=" + taskSyncFingerprint(pushed) + " -->",
+		"- [ ] Deploy <!-- lark:task=t3 sync=" + taskSyncFingerprint(pulledBase) + " -->",
+		"",
+	}, "\n")
+	path := filepath.Join(t.TempDir(), "TODO.md")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	var created, updated map[string]any
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/open-apis/task/v2/tasklists/tl1/tasks":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{
+					"items": []map[string]any{
+						{"guid": "t2", "summary": "Review", "due": map[string]any{"timestamp": "1792540800000", "is_all_day": true}},
+						{"guid": "t3", "summary": "Deploy to prod"},
+						{"guid": "t4", "summary": "Remote only"},
+						{"guid": "t5", "summary": "Old", "completed_at": "1700000000000"},
+					},
+					"has_more": false,
+				},
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/open-apis/task/v2/tasks":
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				t.Fatalf("decode create: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{"task": map[string]any{"guid": "t1", "summary": "Write spec"}},
+			})
+		case r.Method == http.MethodPatch && r.URL.Path == "/open-apis/task/v2/tasks/t2":
+			if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+				t.Fatalf("decode update: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"code": 0,
+				"msg":  "ok",
+				"data": map[string]any{"task": map[string]any{"guid": "t2"}},
+			})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newTasksCmd(state)
+	cmd.SetArgs([]string{"sync", "--file", path, "--tasklist", "tl1", "--tz", "UTC"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("tasks sync error: %v", err)
+	}
+
+	tasklists, _ := created["tasklists"].([]any)
+	if created["summary"] != "Write spec" || len(tasklists) != 1 || created["due"] == nil {
+		t.Fatalf("unexpected create payload: %+v", created)
+	}
+	fields, _ := updated["update_fields"].([]any)
+	if len(fields) != 1 || fields[0] != "completed_at" {
+		t.Fatalf("unexpected update payload: %+v", updated)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read file: %v", err)
+	}
+	got := string(data)
+	for _, want := range []string{
+		"- [ ] Write spec @due(2026-10-20) <!-- lark:task=t1 sync=",
+		"- [x] Review @due(2026-10-21) <!-- lark:task=t2 sync=",
+		"- [ ] Deploy to prod <!-- lark:task=t3 sync=",
+		"- [ ] Remote only <!-- lark:task=t4 sync=",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in file:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Old") || !strings.HasSuffix(got, "\n") {
+		t.Fatalf("unexpected file:\n%s", got)
+	}
+	out := buf.String()
+	for _, want := range []string{"create", "push", "pull", "add"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output: %q", want, out)
+		}
+	}
+}
+
+func TestTasksSyncKeepsTimedDueInZone(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	// 08:00 in Tokyo is still the previous day in UTC.
+	remoteDue := time.Date(2026, 10, 21, 8, 0, 0, 0, tokyo)
+	synced := taskSyncItem{Summary: "Prep", Due: "2026-10-21", GUID: "t1"}
+	content := "- [ ] Prep @due(2026-10-22) <!-- lark:task=t1 sync=" + taskSyncFingerprint(synced) + " -->\n"
+	path := filepath.Join(t.TempDir(), "TODO.md")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	var updated map[string]any
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/open-apis/task/v2/tasklists/tl1/tasks":
+			due := map[string]any{"timestamp": strconv.FormatInt(remoteDue.UnixMilli(), 10), "is_all_day": false}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{
+				"items": []map[string]any{
+					{"guid": "t1", "summary": "Prep", "due": due},
+					{"guid": "t2", "summary": "Remote only", "due": due},
+				},
+				"has_more": false,
+			}})
+		case r.Method == http.MethodPatch && r.URL.Path == "/open-apis/task/v2/tasks/t1":
+			if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+				t.Fatalf("decode update: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"code": 0, "msg": "ok", "data": map[string]any{"task": map[string]any{"guid": "t1"}}})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	var buf bytes.Buffer
+	state := newTestState(t, handler, nil, &buf)
+	cmd := newTasksCmd(state)
+	cmd.SetArgs([]string{"sync", "--file", path, "--tasklist", "tl1", "--tz", "Asia/Tokyo"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("tasks sync error: %v", err)
+	}
+
+	task, _ := updated["task"].(map[string]any)
+	due, _ := task["due"].(map[string]any)
+	want := strconv.FormatInt(remoteDue.AddDate(0, 0, 1).UnixMilli(), 10)
+	if due["timestamp"] != want || due["is_all_day"] != false {
+		t.Fatalf("expected the due date moved with its time of day kept, got %+v", updated)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read file: %v", err)
+	}
+	if !strings.Contains(string(data), "- [ ] Remote only @due(2026-10-21) <!-- lark:task=t2") {
+		t.Fatalf("expected the pulled due date in the sync zone:\n%s", data)
+	}
+
+	cmd = newTasksCmd(state)
+	cmd.SetArgs([]string{"sync", "--file", path, "--tasklist", "tl1", "--tz", "Mars/Olympus"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid time zone") {
+		t.Fatalf("expected time zone error, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"lark/internal/config"
+	"lark/internal/larksdk"
+	"lark/internal/output"
+	"lark/internal/testutil"
+)
+
+// newTestState returns an appState whose SDK talks to handler. A nil cfg
+// gets a cached tenant token; app credentials and the base URL are always
+// filled in. Output and warnings both go to out.
+func newTestState(t *testing.T, handler http.Handler, cfg *config.Config, out *bytes.Buffer) *appState {
+	t.Helper()
+	if cfg == nil {
+		cfg = &config.Config{
+			TenantAccessToken:          "token",
+			TenantAccessTokenExpiresAt: time.Now().Add(2 * time.Hour).Unix(),
+		}
+	}
+	httpClient, baseURL := testutil.NewTestClient(handler)
+	cfg.AppID = "app"
+	cfg.AppSecret = "secret"
+	cfg.BaseURL = baseURL
+	sdkClient, err := larksdk.New(cfg, larksdk.WithHTTPClient(httpClient))
+	if err != nil {
+		t.Fatalf("sdk client error: %v", err)
+	}
+	return &appState{
+		ConfigPath: filepath.Join(t.TempDir(), "config.json"),
+		Config:     cfg,
+		SDK:        sdkClient,
+		Printer:    output.Printer{Writer: out},
+		ErrWriter:  out,
+	}
+}
@@ -93,6 +93,9 @@ func resolveAccessToken(ctx context.Context, state *appState, allowed []tokenTyp
 	} else {
 		if chosen == tokenTypeAuto {
 			chosen = normalizeDefaultTokenType(state.Config.DefaultTokenType)
+			if state.credentials != nil {
+				chosen = tokenType(state.credentials.TokenType)
+			}
 		}
 		if _, ok := allowedSet[chosen]; !ok {
 			return "", chosen, fmt.Errorf("token type %s not supported; supported: %s", chosen, allowedLabel)
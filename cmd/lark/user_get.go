@@ -17,7 +17,7 @@ func newUserInfoCmd(state *appState) *cobra.Command {
 			if _, err := requireSDK(state); err != nil {
 				return err
 			}
-			token, err := tokenFor(cmd.Context(), state, tokenTypesTenantOrUser)
+			token, tokenType, err := resolveAccessToken(cmd.Context(), state, tokenTypesTenantOrUser, nil)
 			if err != nil {
 				return err
 			}
@@ -29,7 +29,7 @@ func newUserInfoCmd(state *appState) *cobra.Command {
 				request.UserID = userID
 				request.UserIDType = "user_id"
 			}
-			user, err := state.SDK.GetContactUser(cmd.Context(), token, request)
+			user, err := state.SDK.GetContactUser(cmd.Context(), token, larksdk.AccessTokenType(tokenType), request)
 			if err != nil {
 				return err
 			}
@@ -7,7 +7,7 @@ import (
 )
 
 func preflightUserScopes(state *appState) error {
-	if state == nil || state.Config == nil {
+	if state == nil || state.Config == nil || state.credentials != nil {
 		return nil
 	}
 	account := resolveUserAccountName(state)
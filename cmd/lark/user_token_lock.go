@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"lark/internal/config"
+)
+
+// userTokenRefreshMu serializes refreshes inside one process; the lock file
+// below serializes them across processes sharing the same config.
+var userTokenRefreshMu sync.Mutex
+
+var (
+	userTokenLockTimeout  = 30 * time.Second
+	userTokenLockStaleAge = 2 * time.Minute
+	userTokenLockRetry    = 50 * time.Millisecond
+)
+
+func userTokenLockPath(configPath string) string {
+	if configPath == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(configPath), filepath.Base(configPath)+".refresh.lock")
+}
+
+// acquireFileLock creates path exclusively, waiting for other holders to
+// release it. The lock file records a per-call owner token, so a holder only
+// ever removes its own lock. Locks older than userTokenLockStaleAge are
+// treated as left behind by a crashed process and broken.
+func acquireFileLock(ctx context.Context, path string) (func(), error) {
+	if path == "" {
+		return func() {}, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	owner := strconv.Itoa(os.Getpid()) + "-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	deadline := time.Now().Add(userTokenLockTimeout)
+	for {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			_, writeErr := file.WriteString(owner)
+			closeErr := file.Close()
+			if writeErr == nil {
+				writeErr = closeErr
+			}
+			if writeErr != nil {
+				_ = os.Remove(path)
+				return nil, writeErr
+			}
+			return func() { releaseFileLock(path, owner) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if info, statErr := os.Stat(path); statErr == nil && time.Since(info.ModTime()) > userTokenLockStaleAge {
+			breakStaleFileLock(path, owner)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for token lock %s; remove it if no other lark process is running", path)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(userTokenLockRetry):
+		}
+	}
+}
+
+// breakStaleFileLock moves a stale lock aside rather than deleting it. Rename
+// is atomic, so when several processes find the same stale lock only one
+// takes it; a process that instead moved a lock just re-created by a live
+// holder puts it back.
+func breakStaleFileLock(path, owner string) {
+	aside := path + "." + owner + ".stale"
+	if err := os.Rename(path, aside); err != nil {
+		return
+	}
+	if info, err := os.Stat(aside); err == nil && time.Since(info.ModTime()) <= userTokenLockStaleAge {
+		_ = os.Link(aside, path)
+	}
+	_ = os.Remove(aside)
+}
+
+// releaseFileLock removes the lock only if owner still holds it: a lock that
+// was broken as stale and re-acquired by another process is left alone.
+func releaseFileLock(path, owner string) {
+	aside := path + "." + owner + ".release"
+	if err := os.Rename(path, aside); err != nil {
+		return
+	}
+	if data, err := os.ReadFile(aside); err != nil || string(data) != owner {
+		_ = os.Link(aside, path)
+	}
+	_ = os.Remove(aside)
+}
+
+// reloadUserAccounts replaces the in-memory accounts with the copies on disk
+// so tokens rotated by another process are seen before deciding to refresh,
+// and are not overwritten with stale values when the config is saved.
+func reloadUserAccounts(state *appState) error {
+	if state.ConfigPath == "" {
+		return nil
+	}
+	if _, err := os.Stat(state.ConfigPath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	disk, err := config.Load(state.ConfigPath)
+	if err != nil {
+		return err
+	}
+	for name, acct := range disk.UserAccounts {
+		if acct != nil {
+			saveUserAccount(state.Config, name, *acct)
+		}
+	}
+	return nil
+}
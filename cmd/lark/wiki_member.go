@@ -130,6 +130,11 @@ func newWikiMemberAddCmd(state *appState) *cobra.Command {
 			memberType = strings.TrimSpace(memberType)
 			memberID = strings.TrimSpace(memberID)
 			memberRole = strings.TrimSpace(memberRole)
+			resolvedID, err := resolveMemberRef(cmd.Context(), state, memberType, memberID)
+			if err != nil {
+				return err
+			}
+			memberID = resolvedID
 			needNotificationSet := cmd.Flags().Changed("need-notification")
 			req := larksdk.CreateWikiSpaceMemberRequest{
 				SpaceID:             spaceID,
@@ -7,47 +7,74 @@ import "strings"
 //
 // Keys are space-separated command paths. Values are service names.
 var commandServiceMap = map[string][]string{
-	"drive":                      {"drive-read"},
-	"drive search":               {"drive-read"},
-	"drive download":             {"drive-download"},
-	"drive upload":               {"drive-write"},
-	"drive share":                {"drive-admin"},
-	"drive permissions":          {"drive-admin"},
-	"drive comment":              {"drive-read"},
-	"drive comment add":          {"drive-write"},
-	"drive comment update":       {"drive-write"},
-	"drive comment reply":        {"drive-write"},
-	"drive comment reply-update": {"drive-write"},
-	"drive export":               {"drive-download"},
-	"docs":                       {"docs"},
-	"docs export":                {"drive-download"},
-	"sheets":                     {"sheets"},
-	"mail":                       {"mail"},
-	"mail send":                  {"mail-send"},
-	"mail public-mailboxes":      {"mail-public"},
-	"mail mailboxes":             {"mail-public"},
-	"wiki":                       {"wiki"},
-	"base":                       {"base"},
-	"bases":                      {"base"},
-	"calendar":                   {"calendar"},
-	"calendars":                  {"calendar"},
-	"tasks":                      {"task"},
-	"tasks create":               {"task-write"},
-	"tasks update":               {"task-write"},
-	"tasks delete":               {"task-write"},
-	"tasklists":                  {"tasklist"},
-	"tasklists create":           {"tasklist-write"},
-	"tasklists update":           {"tasklist-write"},
-	"tasklists delete":           {"tasklist-write"},
-	"chats":                      {"im"},
-	"messages":                   {"im"},
-	"msg":                        {"im"},
-	"msg search":                 {"search-message"},
-	"messages search":            {"search-message"},
-	"users search":               {"search-user"},
-	"meetings":                   {"vc-meeting"},
-	"meetings info":              {"vc-meeting"},
-	"meetings list":              {"vc-meeting"},
+	"drive":                         {"drive-read"},
+	"drive search":                  {"drive-read"},
+	"drive download":                {"drive-download"},
+	"drive upload":                  {"drive-write"},
+	"drive share":                   {"drive-admin"},
+	"drive permissions":             {"drive-admin"},
+	"drive comment":                 {"drive-read"},
+	"drive comment add":             {"drive-write"},
+	"drive comment update":          {"drive-write"},
+	"drive comment reply":           {"drive-write"},
+	"drive comment reply-update":    {"drive-write"},
+	"drive export":                  {"drive-download"},
+	"docs":                          {"docs"},
+	"docs export":                   {"drive-download"},
+	"sheets":                        {"sheets"},
+	"mail":                          {"mail"},
+	"mail send":                     {"mail-send"},
+	"mail reply":                    {"mail", "mail-send"},
+	"mail reply-all":                {"mail", "mail-send"},
+	"mail forward":                  {"mail", "mail-send"},
+	"mail drafts":                   {"mail", "mail-modify"},
+	"mail drafts send":              {"mail-modify", "mail-send"},
+	"mail mark-read":                {"mail", "mail-modify"},
+	"mail mark-unread":              {"mail", "mail-modify"},
+	"mail move":                     {"mail", "mail-modify"},
+	"mail delete":                   {"mail", "mail-modify"},
+	"mail rules":                    {"mail-rules"},
+	"mail aliases":                  {"mail-admin"},
+	"mail public-mailboxes":         {"mail-public"},
+	"mail public-mailboxes members": {"mail-admin"},
+	"mail mailboxes":                {"mail-public"},
+	"wiki":                          {"wiki"},
+	"base":                          {"base"},
+	"bases":                         {"base"},
+	"calendar":                      {"calendar"},
+	"calendars":                     {"calendar"},
+	"tasks":                         {"task"},
+	"tasks create":                  {"task-write"},
+	"tasks update":                  {"task-write"},
+	"tasks delete":                  {"task-write"},
+	"tasks subtasks add":            {"task-write"},
+	"tasks deps":                    {"task-write"},
+	"tasks comments add":            {"task-write"},
+	"tasks comments update":         {"task-write"},
+	"tasks comments delete":         {"task-write"},
+	"tasks reminders":               {"task-write"},
+	"tasks attach":                  {"task-write"},
+	"tasks move":                    {"task-write"},
+	"tasks sync":                    {"task-write"},
+	"tasklists":                     {"tasklist"},
+	"tasklists create":              {"tasklist-write"},
+	"tasklists update":              {"tasklist-write"},
+	"tasklists delete":              {"tasklist-write"},
+	"tasklists sections create":     {"tasklist-write"},
+	"tasklists sections update":     {"tasklist-write"},
+	"tasklists sections delete":     {"tasklist-write"},
+	"tasklists fields set":          {"task-write"},
+	"chats":                         {"im"},
+	"messages":                      {"im"},
+	"msg":                           {"im"},
+	"msg search":                    {"search-message"},
+	"messages search":               {"search-message"},
+	"users search":                  {"search-user"},
+	"meetings":                      {"vc-meeting"},
+	"meetings info":                 {"vc-meeting"},
+	"meetings list":                 {"vc-meeting"},
+	"meetings recording":            {"vc-recording"},
+	"rooms":                         {"vc-room"},
 
 	// Internal aliases (not currently exposed as CLI roots).
 	"im": {"im"},
@@ -15,7 +15,13 @@ func TestServicesForCommandPathMapping(t *testing.T) {
 		{path: []string{"sheets"}, want: []string{"sheets"}},
 		{path: []string{"mail"}, want: []string{"mail"}},
 		{path: []string{"mail", "send"}, want: []string{"mail-send"}},
+		{path: []string{"mail", "drafts", "send"}, want: []string{"mail-modify", "mail-send"}},
+		{path: []string{"mail", "move"}, want: []string{"mail", "mail-modify"}},
 		{path: []string{"mail", "public-mailboxes"}, want: []string{"mail-public"}},
+		{path: []string{"mail", "public-mailboxes", "members", "add"}, want: []string{"mail-admin"}},
+		{path: []string{"mail", "rules", "create"}, want: []string{"mail-rules"}},
+		{path: []string{"meetings", "recording", "start"}, want: []string{"vc-recording"}},
+		{path: []string{"meetings", "report", "usage"}, want: []string{"vc-meeting"}},
 		{path: []string{"mail", "mailboxes"}, want: []string{"mail-public"}},
 		{path: []string{"wiki"}, want: []string{"wiki"}},
 		{path: []string{"base"}, want: []string{"base"}},
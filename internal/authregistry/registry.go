@@ -271,8 +271,20 @@ var Registry = map[string]ServiceDef{
 		RequiresOffline: true,
 	},
 	"mail-send":   {Name: "mail send", TokenTypes: []TokenType{TokenUser}, RequiredUserScopes: []string{"mail:user_mailbox.message:send"}, RequiresOffline: true},
+	"mail-modify": {Name: "mail modify", TokenTypes: []TokenType{TokenUser}, RequiredUserScopes: []string{"mail:user_mailbox.message:modify"}, RequiresOffline: true},
 	"mail-public": {Name: "mail public", TokenTypes: []TokenType{TokenTenant}},
-	"wiki":        {Name: "wiki", TokenTypes: []TokenType{TokenTenant, TokenUser}, RequiredUserScopes: []string{"wiki:wiki"}, UserScopes: ServiceScopeSet{Full: []string{"wiki:wiki"}, Readonly: []string{"wiki:wiki:readonly"}}, RequiresOffline: true},
+	"mail-admin":  {Name: "mail admin", TokenTypes: []TokenType{TokenTenant}},
+	"mail-rules": {
+		Name:               "mail rules",
+		TokenTypes:         []TokenType{TokenTenant, TokenUser},
+		RequiredUserScopes: []string{"mail:user_mailbox.rule:read"},
+		UserScopes: ServiceScopeSet{
+			Readonly: []string{"mail:user_mailbox.rule:read"},
+			Full:     []string{"mail:user_mailbox.rule:read", "mail:user_mailbox.rule:write"},
+		},
+		RequiresOffline: true,
+	},
+	"wiki": {Name: "wiki", TokenTypes: []TokenType{TokenTenant, TokenUser}, RequiredUserScopes: []string{"wiki:wiki"}, UserScopes: ServiceScopeSet{Full: []string{"wiki:wiki"}, Readonly: []string{"wiki:wiki:readonly"}}, RequiresOffline: true},
 	"vc-meeting": {
 		Name:       "vc meeting",
 		TokenTypes: []TokenType{TokenUser},
@@ -289,7 +301,18 @@ var Registry = map[string]ServiceDef{
 		},
 		RequiresOffline: true,
 	},
-	"base": {Name: "base", TokenTypes: []TokenType{TokenTenant}},
+	"vc-recording": {
+		Name:               "vc recording",
+		TokenTypes:         []TokenType{TokenTenant, TokenUser},
+		RequiredUserScopes: []string{"vc:record:readonly"},
+		UserScopes: ServiceScopeSet{
+			Readonly: []string{"vc:record:readonly"},
+			Full:     []string{"vc:record:readonly", "vc:record"},
+		},
+		RequiresOffline: true,
+	},
+	"vc-room": {Name: "vc room", TokenTypes: []TokenType{TokenTenant}},
+	"base":    {Name: "base", TokenTypes: []TokenType{TokenTenant}},
 }
 
 // AllServiceNames returns all known service names in stable-sorted order.
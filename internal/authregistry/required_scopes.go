@@ -42,6 +42,33 @@ func RequiredUserScopesFromServicesReport(services []string) (scopes []string, u
 	return uniqueSorted(scopes), uniqueSorted(missing), nil
 }
 
+// RequiredScopesForTokenReport is like RequiredUserScopesFromServicesReport,
+// but reports undeclared services for the given token type.
+//
+// Lark scope strings are shared by tenant and user tokens, so the declared
+// RequiredUserScopes also describe a service's tenant requirements. For
+// TokenTenant, every service without declared scopes is reported as
+// undeclared, including tenant-only services, so callers that must stay
+// within a scope set can fail closed.
+func RequiredScopesForTokenReport(services []string, tokenType TokenType) (scopes []string, undeclared []string, err error) {
+	if tokenType != TokenTenant {
+		return RequiredUserScopesFromServicesReport(services)
+	}
+	services = normalizeServices(services)
+	var missing []string
+	for _, name := range services {
+		def, ok := Registry[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown service %q", name)
+		}
+		if def.RequiredUserScopes == nil {
+			missing = append(missing, name)
+		}
+		scopes = append(scopes, def.RequiredUserScopes...)
+	}
+	return uniqueSorted(scopes), uniqueSorted(missing), nil
+}
+
 // RequiresOfflineFromServices reports whether any of the given services declares
 // RequiresOffline.
 func RequiresOfflineFromServices(services []string) (bool, error) {
@@ -112,3 +112,24 @@ func TestRequiresOfflineFromServices(t *testing.T) {
 		t.Fatalf("RequiresOfflineFromServices(drive,base)=false, want true")
 	}
 }
+
+func TestRequiredScopesForTokenReportTenantFailsClosed(t *testing.T) {
+	scopes, undeclared, err := RequiredScopesForTokenReport([]string{"im", "base", "vc-room"}, TokenTenant)
+	if err != nil {
+		t.Fatalf("RequiredScopesForTokenReport() err=%v", err)
+	}
+	if !reflect.DeepEqual(scopes, []string{"im:chat:read"}) {
+		t.Fatalf("scopes=%v", scopes)
+	}
+	if !reflect.DeepEqual(undeclared, []string{"base", "vc-room"}) {
+		t.Fatalf("undeclared=%v, want tenant-only services", undeclared)
+	}
+
+	_, undeclared, err = RequiredScopesForTokenReport([]string{"im", "base"}, TokenUser)
+	if err != nil {
+		t.Fatalf("RequiredScopesForTokenReport(user) err=%v", err)
+	}
+	if len(undeclared) != 0 {
+		t.Fatalf("user report should ignore tenant-only services, got %v", undeclared)
+	}
+}
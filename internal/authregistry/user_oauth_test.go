@@ -7,7 +7,7 @@ import (
 
 func TestListUserOAuthServicesStableSorted(t *testing.T) {
 	got := ListUserOAuthServices()
-	want := []string{"calendar", "docs", "docx", "drive-admin", "drive-download", "drive-read", "drive-write", "im", "mail", "mail-send", "search-docs", "search-message", "search-user", "sheets", "task", "task-write", "tasklist", "tasklist-write", "vc-meeting", "wiki"}
+	want := []string{"calendar", "docs", "docx", "drive-admin", "drive-download", "drive-read", "drive-write", "im", "mail", "mail-modify", "mail-rules", "mail-send", "search-docs", "search-message", "search-user", "sheets", "task", "task-write", "tasklist", "tasklist-write", "vc-meeting", "vc-recording", "wiki"}
 	if !reflect.DeepEqual(got, want) {
 		t.Fatalf("ListUserOAuthServices()=%v, want %v", got, want)
 	}
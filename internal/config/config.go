@@ -30,6 +30,16 @@ type Config struct {
 	TenantAccessToken          string   `json:"tenant_access_token"`
 	TenantAccessTokenExpiresAt int64    `json:"tenant_access_token_expires_at"`
 
+	// AppType is self_built (default) or marketplace (store/ISV apps).
+	// Marketplace apps obtain tenant tokens per tenant_key using the
+	// app_ticket pushed to their event callback.
+	AppType                 string                  `json:"app_type,omitempty"`
+	AppTicket               string                  `json:"app_ticket,omitempty"`
+	AppTicketUpdatedAt      int64                   `json:"app_ticket_updated_at,omitempty"`
+	AppAccessToken          string                  `json:"app_access_token,omitempty"`
+	AppAccessTokenExpiresAt int64                   `json:"app_access_token_expires_at,omitempty"`
+	TenantTokens            map[string]*TenantToken `json:"tenant_tokens,omitempty"`
+
 	UserAccounts map[string]*UserAccount `json:"user_accounts,omitempty"`
 
 	// UserAccountBuckets maps a "client bucket" (app_id + base_url + profile)
@@ -37,6 +47,22 @@ type Config struct {
 	UserAccountBuckets map[string]string `json:"user_account_buckets,omitempty"`
 }
 
+const (
+	AppTypeSelfBuilt   = "self_built"
+	AppTypeMarketplace = "marketplace"
+)
+
+// TenantToken is a cached tenant access token for one marketplace tenant.
+type TenantToken struct {
+	AccessToken string `json:"access_token"`
+	ExpiresAt   int64  `json:"expires_at"`
+}
+
+// IsMarketplace reports whether the config is for a marketplace app.
+func (cfg *Config) IsMarketplace() bool {
+	return cfg != nil && cfg.AppType == AppTypeMarketplace
+}
+
 type UserAccount struct {
 	UserAccessToken          string                   `json:"user_access_token,omitempty"`
 	UserAccessTokenScope     string                   `json:"user_access_token_scope,omitempty"`
@@ -51,6 +77,9 @@ type UserRefreshTokenPayload struct {
 	Services     []string `json:"services,omitempty"`
 	Scopes       string   `json:"scopes,omitempty"`
 	CreatedAt    int64    `json:"created_at,omitempty"`
+	// ExpiresAt is the refresh token expiry (unix seconds) when the OAuth
+	// server reports one.
+	ExpiresAt int64 `json:"expires_at,omitempty"`
 }
 
 func (acct *UserAccount) RefreshTokenValue() string {
@@ -184,6 +213,7 @@ func normalizeDefaults(cfg *Config) {
 	if cfg.DefaultUserAccount == "" {
 		cfg.DefaultUserAccount = "default"
 	}
+	cfg.AppType = strings.ToLower(strings.TrimSpace(cfg.AppType))
 	switch strings.ToLower(strings.TrimSpace(cfg.DefaultTokenType)) {
 	case "tenant", "user":
 		cfg.DefaultTokenType = strings.ToLower(strings.TrimSpace(cfg.DefaultTokenType))
@@ -0,0 +1,131 @@
+package credfile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// EnvPath names the environment variable that points the CLI at a credentials
+// file instead of the app credentials in config.
+const EnvPath = "LARK_CREDENTIALS_FILE"
+
+// Version is the bundle format version written by Save.
+const Version = 1
+
+// Token types a bundle may carry.
+const (
+	TokenTenant = "tenant"
+	TokenUser   = "user"
+)
+
+// Bundle is a short-lived, scope-restricted credential that can be used
+// without the app secret or a refresh token.
+//
+// Note: keep this stable; bundles are copied between machines.
+type Bundle struct {
+	Version     int      `json:"version"`
+	AppID       string   `json:"app_id"`
+	BaseURL     string   `json:"base_url"`
+	TokenType   string   `json:"token_type"`
+	AccessToken string   `json:"access_token"`
+	Scopes      []string `json:"scopes"`
+	Account     string   `json:"account,omitempty"`
+	IssuedAt    int64    `json:"issued_at"`
+	ExpiresAt   int64    `json:"expires_at"`
+}
+
+// Validate reports whether the bundle is well-formed and unexpired at now.
+func (b *Bundle) Validate(now time.Time) error {
+	if b == nil {
+		return errors.New("credentials bundle is empty")
+	}
+	if b.Version != Version {
+		return fmt.Errorf("unsupported credentials bundle version %d (expected %d)", b.Version, Version)
+	}
+	if b.TokenType != TokenTenant && b.TokenType != TokenUser {
+		return fmt.Errorf("invalid credentials token_type %q (expected tenant or user)", b.TokenType)
+	}
+	if strings.TrimSpace(b.AccessToken) == "" {
+		return errors.New("credentials bundle has no access_token")
+	}
+	if len(b.Scopes) == 0 {
+		return errors.New("credentials bundle declares no scopes")
+	}
+	if b.Expired(now) {
+		return fmt.Errorf("credentials expired at %s", time.Unix(b.ExpiresAt, 0).UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// Expired reports whether the bundle is past its expiry at now.
+func (b *Bundle) Expired(now time.Time) bool {
+	return b == nil || b.ExpiresAt == 0 || now.Unix() >= b.ExpiresAt
+}
+
+// MissingScopes returns the entries of required that the bundle does not
+// declare, in order.
+func (b *Bundle) MissingScopes(required []string) []string {
+	declared := map[string]struct{}{}
+	if b != nil {
+		for _, scope := range b.Scopes {
+			declared[scope] = struct{}{}
+		}
+	}
+	var missing []string
+	for _, scope := range required {
+		if _, ok := declared[scope]; !ok {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// Load reads a bundle from path. It does not validate it.
+func Load(path string) (*Bundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var b Bundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("parse credentials file %s: %w", path, err)
+	}
+	return &b, nil
+}
+
+// Marshal encodes the bundle as indented JSON.
+func Marshal(b *Bundle) ([]byte, error) {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// Save writes the bundle to path with owner-only permissions.
+func Save(path string, b *Bundle) error {
+	data, err := Marshal(b)
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return err
+		}
+	}
+	// The bundle holds a live access token; keep it private.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package credfile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSaveLoadValidate(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	path := filepath.Join(t.TempDir(), "ci", "token.json")
+	b := &Bundle{
+		Version:     Version,
+		AppID:       "cli_app",
+		BaseURL:     "https://open.feishu.cn",
+		TokenType:   TokenTenant,
+		AccessToken: "t-123",
+		Scopes:      []string{"im:chat:read", "im:message:send"},
+		IssuedAt:    now.Unix(),
+		ExpiresAt:   now.Add(time.Hour).Unix(),
+	}
+	if err := Save(path, b); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected 0600, got %v", info.Mode().Perm())
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if err := loaded.Validate(now); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if missing := loaded.MissingScopes([]string{"im:chat:read", "drive:drive"}); len(missing) != 1 || missing[0] != "drive:drive" {
+		t.Fatalf("unexpected missing scopes: %v", missing)
+	}
+	if err := loaded.Validate(now.Add(2 * time.Hour)); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Fatalf("expected expiry error, got %v", err)
+	}
+}
+
+func TestValidateRejectsMalformedBundles(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	valid := Bundle{Version: Version, TokenType: TokenUser, AccessToken: "u", Scopes: []string{"a"}, ExpiresAt: now.Add(time.Minute).Unix()}
+	cases := map[string]func(b *Bundle){
+		"version":    func(b *Bundle) { b.Version = 2 },
+		"token_type": func(b *Bundle) { b.TokenType = "app" },
+		"token":      func(b *Bundle) { b.AccessToken = "" },
+		"scopes":     func(b *Bundle) { b.Scopes = nil },
+		"expiry":     func(b *Bundle) { b.ExpiresAt = 0 },
+	}
+	for name, mutate := range cases {
+		b := valid
+		mutate(&b)
+		if err := b.Validate(now); err == nil {
+			t.Fatalf("%s: expected validation error", name)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package idcache
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultTTL is how long a resolved identity is trusted before it is looked
+// up again.
+const DefaultTTL = 24 * time.Hour
+
+// Fields an identity can be looked up by.
+const (
+	FieldOpenID  = "open_id"
+	FieldUnionID = "union_id"
+	FieldUserID  = "user_id"
+	FieldEmail   = "email"
+	FieldMobile  = "mobile"
+	FieldChatID  = "chat_id"
+)
+
+// Identity is everything known about one user or chat.
+type Identity struct {
+	Kind           string `json:"kind"`
+	OpenID         string `json:"open_id,omitempty"`
+	UnionID        string `json:"union_id,omitempty"`
+	UserID         string `json:"user_id,omitempty"`
+	Email          string `json:"email,omitempty"`
+	Mobile         string `json:"mobile,omitempty"`
+	ChatID         string `json:"chat_id,omitempty"`
+	Name           string `json:"name,omitempty"`
+	ResolvedAtUnix int64  `json:"resolved_at_unix"`
+}
+
+// Value returns the identity's value for field, or "name".
+func (i Identity) Value(field string) string {
+	switch field {
+	case FieldOpenID:
+		return i.OpenID
+	case FieldUnionID:
+		return i.UnionID
+	case FieldUserID:
+		return i.UserID
+	case FieldEmail:
+		return i.Email
+	case FieldMobile:
+		return i.Mobile
+	case FieldChatID:
+		return i.ChatID
+	case "name":
+		return i.Name
+	}
+	return ""
+}
+
+// Cache maps every known field value to its identity. It is persisted next
+// to the config file and scoped to one app, since open_id is app-specific,
+// and to one tenant for marketplace apps, which serve many tenants.
+//
+// Note: keep this stable; it's user-facing state.
+type Cache struct {
+	AppID     string              `json:"app_id,omitempty"`
+	TenantKey string              `json:"tenant_key,omitempty"`
+	Entries   map[string]Identity `json:"entries"`
+
+	dirty bool
+}
+
+func key(field, value string) string {
+	value = strings.TrimSpace(value)
+	if field == FieldEmail {
+		value = strings.ToLower(value)
+	}
+	return field + ":" + value
+}
+
+// Lookup returns the identity indexed under field=value if it is younger
+// than ttl.
+func (c *Cache) Lookup(field, value string, ttl time.Duration, now time.Time) (Identity, bool) {
+	if c == nil || strings.TrimSpace(value) == "" {
+		return Identity{}, false
+	}
+	identity, ok := c.Entries[key(field, value)]
+	if !ok {
+		return Identity{}, false
+	}
+	if ttl > 0 && now.Sub(time.Unix(identity.ResolvedAtUnix, 0)) >= ttl {
+		return Identity{}, false
+	}
+	return identity, true
+}
+
+// Put indexes identity under every field it carries.
+func (c *Cache) Put(identity Identity) {
+	if c == nil {
+		return
+	}
+	if c.Entries == nil {
+		c.Entries = map[string]Identity{}
+	}
+	for _, field := range []string{FieldOpenID, FieldUnionID, FieldUserID, FieldEmail, FieldMobile, FieldChatID} {
+		if value := identity.Value(field); value != "" {
+			c.Entries[key(field, value)] = identity
+		}
+	}
+	c.dirty = true
+}
+
+// Clear drops every entry.
+func (c *Cache) Clear() {
+	if c == nil {
+		return
+	}
+	c.Entries = map[string]Identity{}
+	c.dirty = true
+}
+
+// Prune drops entries older than ttl and returns how many were removed.
+func (c *Cache) Prune(ttl time.Duration, now time.Time) int {
+	if c == nil || ttl <= 0 {
+		return 0
+	}
+	removed := 0
+	for k, identity := range c.Entries {
+		if now.Sub(time.Unix(identity.ResolvedAtUnix, 0)) >= ttl {
+			delete(c.Entries, k)
+			removed++
+		}
+	}
+	if removed > 0 {
+		c.dirty = true
+	}
+	return removed
+}
+
+// Dirty reports whether the cache changed since it was loaded.
+func (c *Cache) Dirty() bool {
+	return c != nil && c.dirty
+}
+
+// PathForConfig returns the cache file that belongs to configPath. Each
+// tenant of a marketplace app gets its own file, so switching --tenant-key
+// does not discard the other tenants' entries.
+func PathForConfig(configPath, tenantKey string) string {
+	name := "id_cache.json"
+	if tenantKey != "" {
+		name = "id_cache." + tenantFileName(tenantKey) + ".json"
+	}
+	return filepath.Join(filepath.Dir(configPath), name)
+}
+
+func tenantFileName(tenantKey string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, tenantKey)
+}
+
+// Load reads the cache for configPath. A missing or corrupted file, or one
+// written for another app or tenant, yields an empty cache.
+func Load(configPath, appID, tenantKey string) (*Cache, error) {
+	empty := &Cache{AppID: appID, TenantKey: tenantKey, Entries: map[string]Identity{}}
+	if strings.TrimSpace(configPath) == "" {
+		return empty, nil
+	}
+	b, err := os.ReadFile(PathForConfig(configPath, tenantKey))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return empty, nil
+		}
+		return nil, err
+	}
+	var c Cache
+	if err := json.Unmarshal(b, &c); err != nil {
+		return empty, nil
+	}
+	if c.AppID != appID || c.TenantKey != tenantKey {
+		return empty, nil
+	}
+	if c.Entries == nil {
+		c.Entries = map[string]Identity{}
+	}
+	return &c, nil
+}
+
+// Save writes the cache for configPath. It is a no-op without a config path.
+func Save(configPath string, c *Cache) error {
+	if c == nil || strings.TrimSpace(configPath) == "" {
+		return nil
+	}
+	p := PathForConfig(configPath, c.TenantKey)
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	// The cache holds emails and phone numbers; keep it private.
+	tmp := p + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, p); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	c.dirty = false
+	return nil
+}
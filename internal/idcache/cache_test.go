@@ -0,0 +1,89 @@
+package idcache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCacheRoundTripAndTTL(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	now := time.Unix(1700000000, 0)
+
+	c, err := Load(configPath, "app", "")
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	c.Put(Identity{Kind: "user", OpenID: "ou_1", UserID: "u1", Email: "Ada@Example.com", Name: "Ada", ResolvedAtUnix: now.Unix()})
+	if !c.Dirty() {
+		t.Fatalf("expected dirty cache after Put")
+	}
+	if err := Save(configPath, c); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	info, err := os.Stat(PathForConfig(configPath, ""))
+	if err != nil {
+		t.Fatalf("stat cache: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected private cache file, got %v", info.Mode().Perm())
+	}
+
+	loaded, err := Load(configPath, "app", "")
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	identity, ok := loaded.Lookup(FieldEmail, "ada@example.com", DefaultTTL, now.Add(time.Hour))
+	if !ok || identity.OpenID != "ou_1" || identity.Value("name") != "Ada" {
+		t.Fatalf("unexpected lookup: %#v, %v", identity, ok)
+	}
+	if _, ok := loaded.Lookup(FieldUserID, "u1", DefaultTTL, now.Add(DefaultTTL)); ok {
+		t.Fatalf("expected expired entry")
+	}
+	if removed := loaded.Prune(DefaultTTL, now.Add(DefaultTTL)); removed != 3 {
+		t.Fatalf("expected 3 pruned keys, got %d", removed)
+	}
+
+	other, err := Load(configPath, "other-app", "")
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(other.Entries) != 0 {
+		t.Fatalf("expected cache of another app to be ignored")
+	}
+}
+
+func TestCacheIsScopedPerTenant(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	now := time.Unix(1700000000, 0)
+
+	alpha, err := Load(configPath, "app", "tk/alpha")
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	alpha.Put(Identity{Kind: "user", OpenID: "ou_alpha", Email: "ada@example.com", ResolvedAtUnix: now.Unix()})
+	if err := Save(configPath, alpha); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if filepath.Base(PathForConfig(configPath, "tk/alpha")) != "id_cache.tk_alpha.json" {
+		t.Fatalf("unexpected tenant cache path: %s", PathForConfig(configPath, "tk/alpha"))
+	}
+
+	for _, tenant := range []string{"tk-beta", ""} {
+		other, err := Load(configPath, "app", tenant)
+		if err != nil {
+			t.Fatalf("Load returned error: %v", err)
+		}
+		if _, ok := other.Lookup(FieldEmail, "ada@example.com", DefaultTTL, now); ok {
+			t.Fatalf("tenant %q must not see entries of tk/alpha", tenant)
+		}
+	}
+	reloaded, err := Load(configPath, "app", "tk/alpha")
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if identity, ok := reloaded.Lookup(FieldEmail, "ada@example.com", DefaultTTL, now); !ok || identity.OpenID != "ou_alpha" {
+		t.Fatalf("expected tenant entry kept, got %#v, %v", identity, ok)
+	}
+}
@@ -17,6 +17,48 @@ import (
 type RefreshAccessTokenError struct {
 	Code int
 	Msg  string
+	// OAuthError is the OAuth "error" field, e.g. "invalid_grant".
+	OAuthError string
+}
+
+// refreshTokenRejectedCodes are OAuth error codes meaning the refresh token
+// itself is invalid, expired, revoked or already used.
+var refreshTokenRejectedCodes = map[int]bool{
+	20026: true,
+	20037: true,
+	20064: true,
+	20073: true,
+}
+
+// refreshTokenRejectedPhrases are messages, with "refresh_token" spelled
+// "refresh token", that reject the refresh token when no code says so.
+var refreshTokenRejectedPhrases = []string{
+	"invalid refresh token",
+	"refresh token is invalid",
+	"refresh token has been used",
+	"refresh token expired",
+	"refresh token is expired",
+	"refresh token has expired",
+	"refresh token revoked",
+	"refresh token has been revoked",
+}
+
+// InvalidGrant reports whether the refresh token was rejected, as opposed to
+// a transient or app-level failure. Only then is the stored token useless.
+func (e *RefreshAccessTokenError) InvalidGrant() bool {
+	if e == nil {
+		return false
+	}
+	if e.OAuthError == "invalid_grant" || refreshTokenRejectedCodes[e.Code] {
+		return true
+	}
+	msg := strings.ReplaceAll(strings.ToLower(e.Msg), "refresh_token", "refresh token")
+	for _, phrase := range refreshTokenRejectedPhrases {
+		if strings.Contains(msg, phrase) {
+			return true
+		}
+	}
+	return false
 }
 
 func (e *RefreshAccessTokenError) Error() string {
@@ -53,16 +95,24 @@ func (c *Client) TenantAccessToken(ctx context.Context) (string, int64, error) {
 	return resp.TenantAccessToken, int64(resp.Expire), nil
 }
 
-func (c *Client) RefreshUserAccessToken(ctx context.Context, refreshToken string) (string, string, int64, error) {
+// UserTokenRefresh is the result of exchanging a refresh token.
+type UserTokenRefresh struct {
+	AccessToken           string
+	RefreshToken          string
+	ExpiresIn             int64
+	RefreshTokenExpiresIn int64
+}
+
+func (c *Client) RefreshUserAccessToken(ctx context.Context, refreshToken string) (UserTokenRefresh, error) {
 	if !c.available() {
-		return "", "", 0, ErrUnavailable
+		return UserTokenRefresh{}, ErrUnavailable
 	}
 	if refreshToken == "" {
-		return "", "", 0, errors.New("refresh token is required")
+		return UserTokenRefresh{}, errors.New("refresh token is required")
 	}
 	endpoint, err := c.oauthTokenURL()
 	if err != nil {
-		return "", "", 0, err
+		return UserTokenRefresh{}, err
 	}
 	payload := map[string]string{
 		"grant_type":    "refresh_token",
@@ -72,11 +122,11 @@ func (c *Client) RefreshUserAccessToken(ctx context.Context, refreshToken string
 	}
 	body, err := json.Marshal(payload)
 	if err != nil {
-		return "", "", 0, err
+		return UserTokenRefresh{}, err
 	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
 	if err != nil {
-		return "", "", 0, err
+		return UserTokenRefresh{}, err
 	}
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 
@@ -86,19 +136,19 @@ func (c *Client) RefreshUserAccessToken(ctx context.Context, refreshToken string
 	}
 	resp, err := httpClient.Do(req)
 	if err != nil {
-		return "", "", 0, err
+		return UserTokenRefresh{}, err
 	}
 	defer resp.Body.Close()
 	data, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", "", 0, err
+		return UserTokenRefresh{}, err
 	}
 	var parsed oauthTokenResponse
 	if err := json.Unmarshal(data, &parsed); err != nil {
 		if resp.StatusCode < 200 || resp.StatusCode > 299 {
-			return "", "", 0, fmt.Errorf("refresh access token failed: %s", strings.TrimSpace(string(data)))
+			return UserTokenRefresh{}, fmt.Errorf("refresh access token failed: %s", strings.TrimSpace(string(data)))
 		}
-		return "", "", 0, err
+		return UserTokenRefresh{}, err
 	}
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
 		msg := parsed.ErrorDescription
@@ -111,7 +161,7 @@ func (c *Client) RefreshUserAccessToken(ctx context.Context, refreshToken string
 		if msg == "" {
 			msg = strings.TrimSpace(string(data))
 		}
-		return "", "", 0, &RefreshAccessTokenError{Code: parsed.Code, Msg: msg}
+		return UserTokenRefresh{}, &RefreshAccessTokenError{Code: parsed.Code, Msg: msg, OAuthError: parsed.Error}
 	}
 	if parsed.Code != 0 || parsed.Error != "" {
 		msg := parsed.ErrorDescription
@@ -121,15 +171,20 @@ func (c *Client) RefreshUserAccessToken(ctx context.Context, refreshToken string
 		if msg == "" {
 			msg = parsed.Msg
 		}
-		return "", "", 0, &RefreshAccessTokenError{Code: parsed.Code, Msg: msg}
+		return UserTokenRefresh{}, &RefreshAccessTokenError{Code: parsed.Code, Msg: msg, OAuthError: parsed.Error}
 	}
 	if parsed.AccessToken == "" {
-		return "", "", 0, errors.New("refresh access token failed: missing access_token")
+		return UserTokenRefresh{}, errors.New("refresh access token failed: missing access_token")
 	}
 	if parsed.ExpiresIn <= 0 {
-		return "", "", 0, errors.New("refresh access token failed: invalid expires_in")
-	}
-	return parsed.AccessToken, parsed.RefreshToken, parsed.ExpiresIn, nil
+		return UserTokenRefresh{}, errors.New("refresh access token failed: invalid expires_in")
+	}
+	return UserTokenRefresh{
+		AccessToken:           parsed.AccessToken,
+		RefreshToken:          parsed.RefreshToken,
+		ExpiresIn:             parsed.ExpiresIn,
+		RefreshTokenExpiresIn: parsed.RefreshExpiresIn,
+	}, nil
 }
 
 type oauthTokenResponse struct {
@@ -138,6 +193,7 @@ type oauthTokenResponse struct {
 	AccessToken      string `json:"access_token"`
 	ExpiresIn        int64  `json:"expires_in"`
 	RefreshToken     string `json:"refresh_token"`
+	RefreshExpiresIn int64  `json:"refresh_token_expires_in"`
 	Error            string `json:"error"`
 	ErrorDescription string `json:"error_description"`
 }
@@ -0,0 +1,28 @@
+package larksdk
+
+import "testing"
+
+func TestRefreshAccessTokenErrorInvalidGrant(t *testing.T) {
+	cases := []struct {
+		err  *RefreshAccessTokenError
+		want bool
+	}{
+		{&RefreshAccessTokenError{Code: 20064, Msg: "refresh token revoked", OAuthError: "invalid_grant"}, true},
+		{&RefreshAccessTokenError{OAuthError: "invalid_grant"}, true},
+		{&RefreshAccessTokenError{Code: 20037, Msg: "expired"}, true},
+		{&RefreshAccessTokenError{Msg: "The refresh_token has been used"}, true},
+		{&RefreshAccessTokenError{Msg: "Refresh token expired"}, true},
+		{&RefreshAccessTokenError{Msg: "refresh token refused by upstream, retry later"}, false},
+		{&RefreshAccessTokenError{Msg: "unused refresh_token slot"}, false},
+		{&RefreshAccessTokenError{Code: 20001, Msg: "invalid parameter: refresh_token is required"}, false},
+		{&RefreshAccessTokenError{Code: 20050, Msg: "internal server error", OAuthError: "server_error"}, false},
+		{&RefreshAccessTokenError{Code: 99991400, Msg: "request trigger frequency limit"}, false},
+		{&RefreshAccessTokenError{Msg: "invalid client secret", OAuthError: "invalid_client"}, false},
+		{nil, false},
+	}
+	for _, tc := range cases {
+		if got := tc.err.InvalidGrant(); got != tc.want {
+			t.Fatalf("InvalidGrant(%#v) = %v, want %v", tc.err, got, tc.want)
+		}
+	}
+}
@@ -740,16 +740,23 @@ func (c *Client) CreateCalendarEventAttendees(ctx context.Context, token string,
 		return err
 	}
 
+	payload := map[string]any{"attendees": req.Attendees}
+	if req.NeedNotification != nil {
+		payload["need_notification"] = *req.NeedNotification
+	}
 	apiReq := &larkcore.ApiReq{
 		ApiPath:                   "/open-apis/calendar/v4/calendars/:calendar_id/events/:event_id/attendees",
 		HttpMethod:                http.MethodPost,
 		PathParams:                larkcore.PathParams{},
 		QueryParams:               larkcore.QueryParams{},
-		Body:                      map[string]any{"attendees": req.Attendees},
+		Body:                      payload,
 		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
 	}
 	apiReq.PathParams.Set("calendar_id", req.CalendarID)
 	apiReq.PathParams.Set("event_id", req.EventID)
+	if req.UserIDType != "" {
+		apiReq.QueryParams.Set("user_id_type", req.UserIDType)
+	}
 
 	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
 	if err != nil {
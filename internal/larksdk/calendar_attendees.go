@@ -0,0 +1,223 @@
+package larksdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+)
+
+type ListCalendarEventAttendeesRequest struct {
+	CalendarID string
+	EventID    string
+	UserIDType string
+	PageSize   int
+	PageToken  string
+}
+
+type ListCalendarEventAttendeesResult struct {
+	Items     []CalendarEventAttendee
+	PageToken string
+	HasMore   bool
+}
+
+// CalendarEventAttendeeDeleteID identifies an attendee to remove by type,
+// either via attendee_id or the type-specific id field.
+type CalendarEventAttendeeDeleteID struct {
+	Type            string `json:"type"`
+	AttendeeID      string `json:"attendee_id,omitempty"`
+	UserID          string `json:"user_id,omitempty"`
+	ChatID          string `json:"chat_id,omitempty"`
+	RoomID          string `json:"room_id,omitempty"`
+	ThirdPartyEmail string `json:"third_party_email,omitempty"`
+}
+
+type DeleteCalendarEventAttendeesRequest struct {
+	CalendarID       string
+	EventID          string
+	UserIDType       string
+	AttendeeIDs      []string
+	DeleteIDs        []CalendarEventAttendeeDeleteID
+	NeedNotification *bool
+}
+
+type listCalendarEventAttendeesResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *listCalendarEventAttendeesResponseData `json:"data"`
+}
+
+type listCalendarEventAttendeesResponseData struct {
+	Items     []CalendarEventAttendee `json:"items"`
+	PageToken string                  `json:"page_token"`
+	HasMore   bool                    `json:"has_more"`
+}
+
+func (r *listCalendarEventAttendeesResponse) Success() bool {
+	return r.Code == 0
+}
+
+func (c *Client) ListCalendarEventAttendees(ctx context.Context, token string, tokenType AccessTokenType, req ListCalendarEventAttendeesRequest) (ListCalendarEventAttendeesResult, error) {
+	if !c.available() || c.coreConfig == nil {
+		return ListCalendarEventAttendeesResult{}, ErrUnavailable
+	}
+	if req.CalendarID == "" {
+		return ListCalendarEventAttendeesResult{}, errors.New("calendar id is required")
+	}
+	if req.EventID == "" {
+		return ListCalendarEventAttendeesResult{}, errors.New("event id is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return ListCalendarEventAttendeesResult{}, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/calendar/v4/calendars/:calendar_id/events/:event_id/attendees",
+		HttpMethod:                http.MethodGet,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	apiReq.PathParams.Set("calendar_id", req.CalendarID)
+	apiReq.PathParams.Set("event_id", req.EventID)
+	if req.UserIDType != "" {
+		apiReq.QueryParams.Set("user_id_type", req.UserIDType)
+	}
+	if req.PageSize > 0 {
+		apiReq.QueryParams.Set("page_size", fmt.Sprintf("%d", req.PageSize))
+	}
+	if req.PageToken != "" {
+		apiReq.QueryParams.Set("page_token", req.PageToken)
+	}
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return ListCalendarEventAttendeesResult{}, err
+	}
+	if apiResp == nil {
+		return ListCalendarEventAttendeesResult{}, errors.New("list calendar event attendees failed: empty response")
+	}
+	resp := &listCalendarEventAttendeesResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return ListCalendarEventAttendeesResult{}, err
+	}
+	if !resp.Success() {
+		return ListCalendarEventAttendeesResult{}, apiError("list calendar event attendees", resp.Code, resp.Msg)
+	}
+	result := ListCalendarEventAttendeesResult{}
+	if resp.Data != nil {
+		result.Items = resp.Data.Items
+		result.PageToken = resp.Data.PageToken
+		result.HasMore = resp.Data.HasMore
+	}
+	return result, nil
+}
+
+func (c *Client) DeleteCalendarEventAttendees(ctx context.Context, token string, tokenType AccessTokenType, req DeleteCalendarEventAttendeesRequest) error {
+	if !c.available() || c.coreConfig == nil {
+		return ErrUnavailable
+	}
+	if req.CalendarID == "" {
+		return errors.New("calendar id is required")
+	}
+	if req.EventID == "" {
+		return errors.New("event id is required")
+	}
+	if len(req.AttendeeIDs) == 0 && len(req.DeleteIDs) == 0 {
+		return errors.New("attendees are required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{}
+	if len(req.AttendeeIDs) > 0 {
+		payload["attendee_ids"] = req.AttendeeIDs
+	}
+	if len(req.DeleteIDs) > 0 {
+		payload["delete_ids"] = req.DeleteIDs
+	}
+	if req.NeedNotification != nil {
+		payload["need_notification"] = *req.NeedNotification
+	}
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/calendar/v4/calendars/:calendar_id/events/:event_id/attendees/batch_delete",
+		HttpMethod:                http.MethodPost,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		Body:                      payload,
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	apiReq.PathParams.Set("calendar_id", req.CalendarID)
+	apiReq.PathParams.Set("event_id", req.EventID)
+	if req.UserIDType != "" {
+		apiReq.QueryParams.Set("user_id_type", req.UserIDType)
+	}
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return err
+	}
+	if apiResp == nil {
+		return errors.New("delete calendar event attendees failed: empty response")
+	}
+	resp := &calendarEmptyResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return err
+	}
+	if !resp.Success() {
+		return apiError("delete calendar event attendees", resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+// ReplyCalendarEvent sets the caller's RSVP status (accept|decline|tentative).
+// The API only accepts user access tokens.
+func (c *Client) ReplyCalendarEvent(ctx context.Context, userAccessToken, calendarID, eventID, rsvpStatus string) error {
+	if !c.available() || c.coreConfig == nil {
+		return ErrUnavailable
+	}
+	if userAccessToken == "" {
+		return errors.New("user access token is required")
+	}
+	if calendarID == "" {
+		return errors.New("calendar id is required")
+	}
+	if eventID == "" {
+		return errors.New("event id is required")
+	}
+	if rsvpStatus == "" {
+		return errors.New("rsvp status is required")
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/calendar/v4/calendars/:calendar_id/events/:event_id/reply",
+		HttpMethod:                http.MethodPost,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		Body:                      map[string]any{"rsvp_status": rsvpStatus},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeUser},
+	}
+	apiReq.PathParams.Set("calendar_id", calendarID)
+	apiReq.PathParams.Set("event_id", eventID)
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, larkcore.WithUserAccessToken(userAccessToken))
+	if err != nil {
+		return err
+	}
+	if apiResp == nil {
+		return errors.New("reply calendar event failed: empty response")
+	}
+	resp := &calendarEmptyResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return err
+	}
+	if !resp.Success() {
+		return apiError("reply calendar event", resp.Code, resp.Msg)
+	}
+	return nil
+}
@@ -0,0 +1,569 @@
+package larksdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+)
+
+type ListCalendarsRequest struct {
+	PageSize  int
+	PageToken string
+	SyncToken string
+}
+
+type ListCalendarsResult struct {
+	Items     []Calendar
+	PageToken string
+	HasMore   bool
+	SyncToken string
+}
+
+// CalendarRequest carries the mutable calendar fields for create/update.
+// Nil pointers are omitted from the request body.
+type CalendarRequest struct {
+	Summary      *string
+	Description  *string
+	Permissions  string
+	Color        *int
+	SummaryAlias *string
+}
+
+type CalendarACLScope struct {
+	Type   string `json:"type"`
+	UserID string `json:"user_id,omitempty"`
+}
+
+type CalendarACL struct {
+	ACLID string           `json:"acl_id"`
+	Role  string           `json:"role"`
+	Scope CalendarACLScope `json:"scope"`
+}
+
+type ListCalendarACLsResult struct {
+	Items     []CalendarACL
+	PageToken string
+	HasMore   bool
+}
+
+type listCalendarsResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *listCalendarsResponseData `json:"data"`
+}
+
+type listCalendarsResponseData struct {
+	CalendarList []Calendar `json:"calendar_list"`
+	PageToken    string     `json:"page_token"`
+	HasMore      bool       `json:"has_more"`
+	SyncToken    string     `json:"sync_token"`
+}
+
+func (r *listCalendarsResponse) Success() bool {
+	return r.Code == 0
+}
+
+type getCalendarResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *Calendar `json:"data"`
+}
+
+func (r *getCalendarResponse) Success() bool {
+	return r.Code == 0
+}
+
+type calendarMutationResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *calendarMutationResponseData `json:"data"`
+}
+
+type calendarMutationResponseData struct {
+	Calendar Calendar `json:"calendar"`
+}
+
+func (r *calendarMutationResponse) Success() bool {
+	return r.Code == 0
+}
+
+type listCalendarACLsResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *listCalendarACLsResponseData `json:"data"`
+}
+
+type listCalendarACLsResponseData struct {
+	Acls      []CalendarACL `json:"acls"`
+	PageToken string        `json:"page_token"`
+	HasMore   bool          `json:"has_more"`
+}
+
+func (r *listCalendarACLsResponse) Success() bool {
+	return r.Code == 0
+}
+
+type createCalendarACLResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *CalendarACL `json:"data"`
+}
+
+func (r *createCalendarACLResponse) Success() bool {
+	return r.Code == 0
+}
+
+type calendarEmptyResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+}
+
+func (r *calendarEmptyResponse) Success() bool {
+	return r.Code == 0
+}
+
+// ListCalendars lists the calendars the caller owns or subscribes to.
+func (c *Client) ListCalendars(ctx context.Context, token string, tokenType AccessTokenType, req ListCalendarsRequest) (ListCalendarsResult, error) {
+	if !c.available() || c.coreConfig == nil {
+		return ListCalendarsResult{}, ErrUnavailable
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return ListCalendarsResult{}, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/calendar/v4/calendars",
+		HttpMethod:                http.MethodGet,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	if req.PageSize > 0 {
+		apiReq.QueryParams.Set("page_size", fmt.Sprintf("%d", req.PageSize))
+	}
+	if req.PageToken != "" {
+		apiReq.QueryParams.Set("page_token", req.PageToken)
+	}
+	if req.SyncToken != "" {
+		apiReq.QueryParams.Set("sync_token", req.SyncToken)
+	}
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return ListCalendarsResult{}, err
+	}
+	if apiResp == nil {
+		return ListCalendarsResult{}, errors.New("list calendars failed: empty response")
+	}
+	resp := &listCalendarsResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return ListCalendarsResult{}, err
+	}
+	if !resp.Success() {
+		return ListCalendarsResult{}, apiError("list calendars", resp.Code, resp.Msg)
+	}
+	result := ListCalendarsResult{}
+	if resp.Data != nil {
+		result.Items = resp.Data.CalendarList
+		result.PageToken = resp.Data.PageToken
+		result.HasMore = resp.Data.HasMore
+		result.SyncToken = resp.Data.SyncToken
+	}
+	return result, nil
+}
+
+func (c *Client) GetCalendar(ctx context.Context, token string, tokenType AccessTokenType, calendarID string) (Calendar, error) {
+	if !c.available() || c.coreConfig == nil {
+		return Calendar{}, ErrUnavailable
+	}
+	if calendarID == "" {
+		return Calendar{}, errors.New("calendar id is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return Calendar{}, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/calendar/v4/calendars/:calendar_id",
+		HttpMethod:                http.MethodGet,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	apiReq.PathParams.Set("calendar_id", calendarID)
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return Calendar{}, err
+	}
+	if apiResp == nil {
+		return Calendar{}, errors.New("get calendar failed: empty response")
+	}
+	resp := &getCalendarResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return Calendar{}, err
+	}
+	if !resp.Success() {
+		return Calendar{}, apiError("get calendar", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil {
+		return Calendar{}, errors.New("get calendar response missing data")
+	}
+	return *resp.Data, nil
+}
+
+func (c *Client) CreateCalendar(ctx context.Context, token string, tokenType AccessTokenType, req CalendarRequest) (Calendar, error) {
+	if !c.available() || c.coreConfig == nil {
+		return Calendar{}, ErrUnavailable
+	}
+	if req.Summary == nil || *req.Summary == "" {
+		return Calendar{}, errors.New("summary is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return Calendar{}, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/calendar/v4/calendars",
+		HttpMethod:                http.MethodPost,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		Body:                      calendarRequestPayload(req),
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	return c.doCalendarMutation(ctx, apiReq, option, "create calendar")
+}
+
+func (c *Client) UpdateCalendar(ctx context.Context, token string, tokenType AccessTokenType, calendarID string, req CalendarRequest) (Calendar, error) {
+	if !c.available() || c.coreConfig == nil {
+		return Calendar{}, ErrUnavailable
+	}
+	if calendarID == "" {
+		return Calendar{}, errors.New("calendar id is required")
+	}
+	payload := calendarRequestPayload(req)
+	if len(payload) == 0 {
+		return Calendar{}, errors.New("at least one field must be updated")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return Calendar{}, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/calendar/v4/calendars/:calendar_id",
+		HttpMethod:                http.MethodPatch,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		Body:                      payload,
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	apiReq.PathParams.Set("calendar_id", calendarID)
+	return c.doCalendarMutation(ctx, apiReq, option, "update calendar")
+}
+
+func (c *Client) DeleteCalendar(ctx context.Context, token string, tokenType AccessTokenType, calendarID string) error {
+	return c.calendarAction(ctx, token, tokenType, http.MethodDelete, "/open-apis/calendar/v4/calendars/:calendar_id", "delete calendar", map[string]string{"calendar_id": calendarID})
+}
+
+func (c *Client) SubscribeCalendar(ctx context.Context, token string, tokenType AccessTokenType, calendarID string) (Calendar, error) {
+	if !c.available() || c.coreConfig == nil {
+		return Calendar{}, ErrUnavailable
+	}
+	if calendarID == "" {
+		return Calendar{}, errors.New("calendar id is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return Calendar{}, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/calendar/v4/calendars/:calendar_id/subscribe",
+		HttpMethod:                http.MethodPost,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	apiReq.PathParams.Set("calendar_id", calendarID)
+	calendar, err := c.doCalendarMutation(ctx, apiReq, option, "subscribe calendar")
+	if err != nil {
+		return Calendar{}, err
+	}
+	if calendar.CalendarID == "" {
+		calendar.CalendarID = calendarID
+	}
+	return calendar, nil
+}
+
+func (c *Client) UnsubscribeCalendar(ctx context.Context, token string, tokenType AccessTokenType, calendarID string) error {
+	return c.calendarAction(ctx, token, tokenType, http.MethodPost, "/open-apis/calendar/v4/calendars/:calendar_id/unsubscribe", "unsubscribe calendar", map[string]string{"calendar_id": calendarID})
+}
+
+// ListCalendarACLs lists access control entries of a calendar. Scope user ids use userIDType.
+func (c *Client) ListCalendarACLs(ctx context.Context, token string, tokenType AccessTokenType, calendarID, userIDType string, pageSize int, pageToken string) (ListCalendarACLsResult, error) {
+	if !c.available() || c.coreConfig == nil {
+		return ListCalendarACLsResult{}, ErrUnavailable
+	}
+	if calendarID == "" {
+		return ListCalendarACLsResult{}, errors.New("calendar id is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return ListCalendarACLsResult{}, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/calendar/v4/calendars/:calendar_id/acls",
+		HttpMethod:                http.MethodGet,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	apiReq.PathParams.Set("calendar_id", calendarID)
+	if userIDType != "" {
+		apiReq.QueryParams.Set("user_id_type", userIDType)
+	}
+	if pageSize > 0 {
+		apiReq.QueryParams.Set("page_size", fmt.Sprintf("%d", pageSize))
+	}
+	if pageToken != "" {
+		apiReq.QueryParams.Set("page_token", pageToken)
+	}
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return ListCalendarACLsResult{}, err
+	}
+	if apiResp == nil {
+		return ListCalendarACLsResult{}, errors.New("list calendar acls failed: empty response")
+	}
+	resp := &listCalendarACLsResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return ListCalendarACLsResult{}, err
+	}
+	if !resp.Success() {
+		return ListCalendarACLsResult{}, apiError("list calendar acls", resp.Code, resp.Msg)
+	}
+	result := ListCalendarACLsResult{}
+	if resp.Data != nil {
+		result.Items = resp.Data.Acls
+		result.PageToken = resp.Data.PageToken
+		result.HasMore = resp.Data.HasMore
+	}
+	return result, nil
+}
+
+// CreateCalendarACL grants role to a user on a calendar.
+func (c *Client) CreateCalendarACL(ctx context.Context, token string, tokenType AccessTokenType, calendarID, userIDType string, acl CalendarACL) (CalendarACL, error) {
+	if !c.available() || c.coreConfig == nil {
+		return CalendarACL{}, ErrUnavailable
+	}
+	if calendarID == "" {
+		return CalendarACL{}, errors.New("calendar id is required")
+	}
+	if acl.Role == "" {
+		return CalendarACL{}, errors.New("role is required")
+	}
+	if acl.Scope.UserID == "" {
+		return CalendarACL{}, errors.New("scope user id is required")
+	}
+	if acl.Scope.Type == "" {
+		acl.Scope.Type = "user"
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return CalendarACL{}, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/calendar/v4/calendars/:calendar_id/acls",
+		HttpMethod:                http.MethodPost,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		Body:                      map[string]any{"role": acl.Role, "scope": acl.Scope},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	apiReq.PathParams.Set("calendar_id", calendarID)
+	if userIDType != "" {
+		apiReq.QueryParams.Set("user_id_type", userIDType)
+	}
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return CalendarACL{}, err
+	}
+	if apiResp == nil {
+		return CalendarACL{}, errors.New("create calendar acl failed: empty response")
+	}
+	resp := &createCalendarACLResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return CalendarACL{}, err
+	}
+	if !resp.Success() {
+		return CalendarACL{}, apiError("create calendar acl", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil {
+		return CalendarACL{}, errors.New("create calendar acl response missing data")
+	}
+	return *resp.Data, nil
+}
+
+func (c *Client) DeleteCalendarACL(ctx context.Context, token string, tokenType AccessTokenType, calendarID, aclID string) error {
+	return c.calendarAction(ctx, token, tokenType, http.MethodDelete, "/open-apis/calendar/v4/calendars/:calendar_id/acls/:acl_id", "delete calendar acl", map[string]string{"calendar_id": calendarID, "acl_id": aclID})
+}
+
+func calendarRequestPayload(req CalendarRequest) map[string]any {
+	payload := map[string]any{}
+	if req.Summary != nil {
+		payload["summary"] = *req.Summary
+	}
+	if req.Description != nil {
+		payload["description"] = *req.Description
+	}
+	if req.Permissions != "" {
+		payload["permissions"] = req.Permissions
+	}
+	if req.Color != nil {
+		payload["color"] = *req.Color
+	}
+	if req.SummaryAlias != nil {
+		payload["summary_alias"] = *req.SummaryAlias
+	}
+	return payload
+}
+
+func (c *Client) doCalendarMutation(ctx context.Context, apiReq *larkcore.ApiReq, option larkcore.RequestOptionFunc, op string) (Calendar, error) {
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return Calendar{}, err
+	}
+	if apiResp == nil {
+		return Calendar{}, errors.New(op + " failed: empty response")
+	}
+	resp := &calendarMutationResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return Calendar{}, err
+	}
+	if !resp.Success() {
+		return Calendar{}, apiError(op, resp.Code, resp.Msg)
+	}
+	if resp.Data == nil {
+		return Calendar{}, nil
+	}
+	return resp.Data.Calendar, nil
+}
+
+// calendarAction performs a request whose response carries no data.
+// Every path param must be non-empty.
+func (c *Client) calendarAction(ctx context.Context, token string, tokenType AccessTokenType, method, path, op string, pathParams map[string]string) error {
+	if !c.available() || c.coreConfig == nil {
+		return ErrUnavailable
+	}
+	for name, value := range pathParams {
+		if value == "" {
+			return fmt.Errorf("%s is required", strings.ReplaceAll(name, "_", " "))
+		}
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   path,
+		HttpMethod:                method,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	for name, value := range pathParams {
+		apiReq.PathParams.Set(name, value)
+	}
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return err
+	}
+	if apiResp == nil {
+		return errors.New(op + " failed: empty response")
+	}
+	resp := &calendarEmptyResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return err
+	}
+	if !resp.Success() {
+		return apiError(op, resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+type searchCalendarsResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *searchCalendarsResponseData `json:"data"`
+}
+
+type searchCalendarsResponseData struct {
+	Items     []Calendar `json:"items"`
+	PageToken string     `json:"page_token"`
+}
+
+func (r *searchCalendarsResponse) Success() bool {
+	return r.Code == 0
+}
+
+// SearchCalendars searches shared calendars in the tenant by keyword.
+func (c *Client) SearchCalendars(ctx context.Context, token string, tokenType AccessTokenType, query string, pageSize int, pageToken string) ([]Calendar, string, error) {
+	if !c.available() || c.coreConfig == nil {
+		return nil, "", ErrUnavailable
+	}
+	if strings.TrimSpace(query) == "" {
+		return nil, "", errors.New("query is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return nil, "", err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/calendar/v4/calendars/search",
+		HttpMethod:                http.MethodPost,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		Body:                      map[string]any{"query": query},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	if pageSize > 0 {
+		apiReq.QueryParams.Set("page_size", fmt.Sprintf("%d", pageSize))
+	}
+	if pageToken != "" {
+		apiReq.QueryParams.Set("page_token", pageToken)
+	}
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return nil, "", err
+	}
+	if apiResp == nil {
+		return nil, "", errors.New("search calendars failed: empty response")
+	}
+	resp := &searchCalendarsResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return nil, "", err
+	}
+	if !resp.Success() {
+		return nil, "", apiError("search calendars", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil {
+		return nil, "", nil
+	}
+	return resp.Data.Items, resp.Data.PageToken, nil
+}
@@ -0,0 +1,107 @@
+package larksdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+)
+
+// CalendarFreeBusy is a busy interval returned by the freebusy API (RFC3339 bounds).
+type CalendarFreeBusy struct {
+	StartTime  string `json:"start_time"`
+	EndTime    string `json:"end_time"`
+	RsvpStatus string `json:"rsvp_status,omitempty"`
+}
+
+// ListCalendarFreeBusyRequest queries busy intervals for a single user or room.
+type ListCalendarFreeBusyRequest struct {
+	TimeMin                 string
+	TimeMax                 string
+	UserID                  string
+	RoomID                  string
+	UserIDType              string
+	IncludeExternalCalendar *bool
+	OnlyBusy                *bool
+}
+
+type listCalendarFreeBusyResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *listCalendarFreeBusyResponseData `json:"data"`
+}
+
+type listCalendarFreeBusyResponseData struct {
+	FreebusyList []CalendarFreeBusy `json:"freebusy_list"`
+}
+
+func (r *listCalendarFreeBusyResponse) Success() bool {
+	return r.Code == 0
+}
+
+// ListCalendarFreeBusy returns the busy intervals of a user or room between TimeMin and TimeMax.
+func (c *Client) ListCalendarFreeBusy(ctx context.Context, token string, tokenType AccessTokenType, req ListCalendarFreeBusyRequest) ([]CalendarFreeBusy, error) {
+	if !c.available() || c.coreConfig == nil {
+		return nil, ErrUnavailable
+	}
+	if strings.TrimSpace(req.TimeMin) == "" || strings.TrimSpace(req.TimeMax) == "" {
+		return nil, errors.New("time_min and time_max are required")
+	}
+	if req.UserID == "" && req.RoomID == "" {
+		return nil, errors.New("user id or room id is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := map[string]any{
+		"time_min": req.TimeMin,
+		"time_max": req.TimeMax,
+	}
+	if req.UserID != "" {
+		payload["user_id"] = req.UserID
+	}
+	if req.RoomID != "" {
+		payload["room_id"] = req.RoomID
+	}
+	if req.IncludeExternalCalendar != nil {
+		payload["include_external_calendar"] = *req.IncludeExternalCalendar
+	}
+	if req.OnlyBusy != nil {
+		payload["only_busy"] = *req.OnlyBusy
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/calendar/v4/freebusy/list",
+		HttpMethod:                http.MethodPost,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		Body:                      payload,
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	if req.UserIDType != "" {
+		apiReq.QueryParams.Set("user_id_type", req.UserIDType)
+	}
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return nil, err
+	}
+	if apiResp == nil {
+		return nil, errors.New("list freebusy failed: empty response")
+	}
+	resp := &listCalendarFreeBusyResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return nil, err
+	}
+	if !resp.Success() {
+		return nil, apiError("list freebusy", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil {
+		return nil, nil
+	}
+	return resp.Data.FreebusyList, nil
+}
@@ -0,0 +1,96 @@
+package larksdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+)
+
+// ListCalendarEventInstancesRequest expands a recurring event into the
+// occurrences between StartTime and EndTime (unix seconds).
+type ListCalendarEventInstancesRequest struct {
+	CalendarID string
+	EventID    string
+	StartTime  string
+	EndTime    string
+	PageSize   int
+	PageToken  string
+}
+
+type listCalendarEventInstancesResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *listCalendarEventInstancesResponseData `json:"data"`
+}
+
+type listCalendarEventInstancesResponseData struct {
+	Items     []CalendarEvent `json:"items"`
+	PageToken string          `json:"page_token"`
+	HasMore   bool            `json:"has_more"`
+}
+
+func (r *listCalendarEventInstancesResponse) Success() bool {
+	return r.Code == 0
+}
+
+func (c *Client) ListCalendarEventInstances(ctx context.Context, token string, tokenType AccessTokenType, req ListCalendarEventInstancesRequest) (ListCalendarEventsResult, error) {
+	if !c.available() || c.coreConfig == nil {
+		return ListCalendarEventsResult{}, ErrUnavailable
+	}
+	if req.CalendarID == "" {
+		return ListCalendarEventsResult{}, errors.New("calendar id is required")
+	}
+	if req.EventID == "" {
+		return ListCalendarEventsResult{}, errors.New("event id is required")
+	}
+	if req.StartTime == "" || req.EndTime == "" {
+		return ListCalendarEventsResult{}, errors.New("start time and end time are required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return ListCalendarEventsResult{}, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/calendar/v4/calendars/:calendar_id/events/:event_id/instances",
+		HttpMethod:                http.MethodGet,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	apiReq.PathParams.Set("calendar_id", req.CalendarID)
+	apiReq.PathParams.Set("event_id", req.EventID)
+	apiReq.QueryParams.Set("start_time", req.StartTime)
+	apiReq.QueryParams.Set("end_time", req.EndTime)
+	if req.PageSize > 0 {
+		apiReq.QueryParams.Set("page_size", fmt.Sprintf("%d", req.PageSize))
+	}
+	if req.PageToken != "" {
+		apiReq.QueryParams.Set("page_token", req.PageToken)
+	}
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return ListCalendarEventsResult{}, err
+	}
+	if apiResp == nil {
+		return ListCalendarEventsResult{}, errors.New("list calendar event instances failed: empty response")
+	}
+	resp := &listCalendarEventInstancesResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return ListCalendarEventsResult{}, err
+	}
+	if !resp.Success() {
+		return ListCalendarEventsResult{}, apiError("list calendar event instances", resp.Code, resp.Msg)
+	}
+	result := ListCalendarEventsResult{}
+	if resp.Data != nil {
+		result.Items = resp.Data.Items
+		result.PageToken = resp.Data.PageToken
+		result.HasMore = resp.Data.HasMore
+	}
+	return result, nil
+}
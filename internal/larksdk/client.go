@@ -1,8 +1,10 @@
 package larksdk
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"time"
 
 	lark "github.com/larksuite/oapi-sdk-go/v3"
 	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
@@ -18,6 +20,7 @@ type Option func(*options)
 type options struct {
 	httpClient        *http.Client
 	tenantAccessToken string
+	withoutAppSecret  bool
 }
 
 // WithHTTPClient overrides the HTTP client used by the SDK.
@@ -34,6 +37,14 @@ func WithTenantAccessToken(token string) Option {
 	}
 }
 
+// WithoutAppSecret allows a client without an app secret. Such a client can
+// only call APIs with caller-supplied access tokens.
+func WithoutAppSecret() Option {
+	return func(o *options) {
+		o.withoutAppSecret = true
+	}
+}
+
 type Client struct {
 	sdk               *lark.Client
 	coreConfig        *larkcore.Config
@@ -44,13 +55,21 @@ func New(cfg *config.Config, opts ...Option) (*Client, error) {
 	if cfg == nil {
 		return nil, ErrUnavailable
 	}
-	if cfg.AppID == "" || cfg.AppSecret == "" {
-		return nil, ErrUnavailable
-	}
 	settings := options{tenantAccessToken: cfg.TenantAccessToken}
 	for _, opt := range opts {
 		opt(&settings)
 	}
+	if cfg.AppID == "" || (cfg.AppSecret == "" && !settings.withoutAppSecret) {
+		return nil, ErrUnavailable
+	}
+
+	appSecret := cfg.AppSecret
+	if appSecret == "" && settings.withoutAppSecret {
+		// The SDK rejects every request when the app secret is empty, even
+		// with a caller-supplied access token. Token caching is disabled, so
+		// the placeholder is never sent.
+		appSecret = "unused"
+	}
 
 	clientOptions := []lark.ClientOptionFunc{
 		lark.WithEnableTokenCache(false),
@@ -58,9 +77,13 @@ func New(cfg *config.Config, opts ...Option) (*Client, error) {
 	coreConfig := &larkcore.Config{
 		BaseUrl:          lark.FeishuBaseUrl,
 		AppId:            cfg.AppID,
-		AppSecret:        cfg.AppSecret,
+		AppSecret:        appSecret,
 		EnableTokenCache: false,
-		AppType:          larkcore.AppTypeSelfBuilt,
+		// Marketplace apps also use AppTypeSelfBuilt here: the CLI always
+		// passes explicit access tokens, while AppTypeMarketplace would make
+		// the SDK demand a tenant key on every tenant-token request.
+		// Marketplace token exchange lives in marketplace.go.
+		AppType: larkcore.AppTypeSelfBuilt,
 	}
 	if cfg.BaseURL != "" {
 		clientOptions = append(clientOptions, lark.WithOpenBaseUrl(cfg.BaseURL))
@@ -76,7 +99,7 @@ func New(cfg *config.Config, opts ...Option) (*Client, error) {
 	larkcore.NewSerialization(coreConfig)
 	larkcore.NewHttpClient(coreConfig)
 
-	sdk := lark.NewClient(cfg.AppID, cfg.AppSecret, clientOptions...)
+	sdk := lark.NewClient(cfg.AppID, appSecret, clientOptions...)
 	return &Client{sdk: sdk, coreConfig: coreConfig, tenantAccessToken: settings.tenantAccessToken}, nil
 }
 
@@ -93,3 +116,26 @@ func (c *Client) tenantToken(token string) string {
 	}
 	return c.tenantAccessToken
 }
+
+// ServerTime returns the time reported by the OpenAPI host in its Date
+// response header. Used to detect local clock skew, which breaks token expiry
+// checks.
+func (c *Client) ServerTime(ctx context.Context) (time.Time, error) {
+	if !c.available() || c.coreConfig == nil || c.coreConfig.HttpClient == nil {
+		return time.Time{}, ErrUnavailable
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.coreConfig.BaseUrl, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	resp, err := c.coreConfig.HttpClient.Do(req)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer resp.Body.Close()
+	date := resp.Header.Get("Date")
+	if date == "" {
+		return time.Time{}, errors.New("server response has no Date header")
+	}
+	return http.ParseTime(date)
+}
@@ -0,0 +1,154 @@
+package larksdk
+
+import (
+	"context"
+	"errors"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+	contact "github.com/larksuite/oapi-sdk-go/v3/service/contact/v3"
+)
+
+// RootDepartmentID identifies the tenant root in the contact directory.
+const RootDepartmentID = "0"
+
+// Department is a node of the organization directory. ID is the
+// open_department_id; DepartmentID is the tenant's custom ID, if any.
+type Department struct {
+	ID           string `json:"open_department_id"`
+	DepartmentID string `json:"department_id,omitempty"`
+	Name         string `json:"name"`
+	ParentID     string `json:"parent_department_id,omitempty"`
+	LeaderUserID string `json:"leader_user_id,omitempty"`
+	ChatID       string `json:"chat_id,omitempty"`
+	MemberCount  int    `json:"member_count,omitempty"`
+	Order        string `json:"order,omitempty"`
+}
+
+type GetDepartmentRequest struct {
+	DepartmentID     string
+	DepartmentIDType string
+	UserIDType       string
+}
+
+type ListDepartmentChildrenRequest struct {
+	DepartmentID string
+	// FetchChild lists every descendant instead of only direct children.
+	FetchChild       bool
+	PageSize         int
+	PageToken        string
+	DepartmentIDType string
+	UserIDType       string
+}
+
+type ListDepartmentChildrenResult struct {
+	Items     []Department
+	PageToken string
+	HasMore   bool
+}
+
+func (c *Client) GetDepartment(ctx context.Context, token string, req GetDepartmentRequest) (Department, error) {
+	if !c.available() {
+		return Department{}, ErrUnavailable
+	}
+	if req.DepartmentID == "" {
+		return Department{}, errors.New("department id is required")
+	}
+	tenantToken := c.tenantToken(token)
+	if tenantToken == "" {
+		return Department{}, errors.New("tenant access token is required")
+	}
+
+	builder := contact.NewGetDepartmentReqBuilder().DepartmentId(req.DepartmentID)
+	if req.DepartmentIDType != "" {
+		builder.DepartmentIdType(req.DepartmentIDType)
+	}
+	if req.UserIDType != "" {
+		builder.UserIdType(req.UserIDType)
+	}
+	resp, err := c.sdk.Contact.V3.Department.Get(ctx, builder.Build(), larkcore.WithTenantAccessToken(tenantToken))
+	if err != nil {
+		return Department{}, err
+	}
+	if resp == nil {
+		return Department{}, errors.New("get department failed: empty response")
+	}
+	if !resp.Success() {
+		return Department{}, apiError("get department", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil || resp.Data.Department == nil {
+		return Department{}, nil
+	}
+	return mapDepartment(resp.Data.Department), nil
+}
+
+func (c *Client) ListDepartmentChildren(ctx context.Context, token string, req ListDepartmentChildrenRequest) (ListDepartmentChildrenResult, error) {
+	if !c.available() {
+		return ListDepartmentChildrenResult{}, ErrUnavailable
+	}
+	if req.DepartmentID == "" {
+		return ListDepartmentChildrenResult{}, errors.New("department id is required")
+	}
+	tenantToken := c.tenantToken(token)
+	if tenantToken == "" {
+		return ListDepartmentChildrenResult{}, errors.New("tenant access token is required")
+	}
+
+	builder := contact.NewChildrenDepartmentReqBuilder().DepartmentId(req.DepartmentID)
+	if req.FetchChild {
+		builder.FetchChild(true)
+	}
+	if req.PageSize > 0 {
+		builder.PageSize(req.PageSize)
+	}
+	if req.PageToken != "" {
+		builder.PageToken(req.PageToken)
+	}
+	if req.DepartmentIDType != "" {
+		builder.DepartmentIdType(req.DepartmentIDType)
+	}
+	if req.UserIDType != "" {
+		builder.UserIdType(req.UserIDType)
+	}
+	resp, err := c.sdk.Contact.V3.Department.Children(ctx, builder.Build(), larkcore.WithTenantAccessToken(tenantToken))
+	if err != nil {
+		return ListDepartmentChildrenResult{}, err
+	}
+	if resp == nil {
+		return ListDepartmentChildrenResult{}, errors.New("list departments failed: empty response")
+	}
+	if !resp.Success() {
+		return ListDepartmentChildrenResult{}, apiError("list departments", resp.Code, resp.Msg)
+	}
+
+	result := ListDepartmentChildrenResult{}
+	if resp.Data != nil {
+		result.Items = make([]Department, 0, len(resp.Data.Items))
+		for _, department := range resp.Data.Items {
+			result.Items = append(result.Items, mapDepartment(department))
+		}
+		result.PageToken = derefString(resp.Data.PageToken)
+		if resp.Data.HasMore != nil {
+			result.HasMore = *resp.Data.HasMore
+		}
+	}
+	return result, nil
+}
+
+func mapDepartment(department *contact.Department) Department {
+	if department == nil {
+		return Department{}
+	}
+	result := Department{
+		ID:           derefString(department.OpenDepartmentId),
+		DepartmentID: derefString(department.DepartmentId),
+		Name:         derefString(department.Name),
+		ParentID:     derefString(department.ParentDepartmentId),
+		LeaderUserID: derefString(department.LeaderUserId),
+		ChatID:       derefString(department.ChatId),
+		Order:        derefString(department.Order),
+	}
+	if department.MemberCount != nil {
+		result.MemberCount = *department.MemberCount
+	}
+	return result
+}
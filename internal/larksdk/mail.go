@@ -585,7 +585,12 @@ func (c *Client) SendMail(ctx context.Context, token, mailboxID string, req Send
 	attachments := make([]*larkmail.Attachment, 0, len(req.Attachments))
 	for _, att := range req.Attachments {
 		att := att
-		attachments = append(attachments, &larkmail.Attachment{Body: &att.Body, Filename: &att.Filename})
+		attachment := &larkmail.Attachment{Body: &att.Body, Filename: &att.Filename}
+		if att.IsInline {
+			attachment.IsInline = &att.IsInline
+			attachment.Cid = optionalStringPtr(att.CID)
+		}
+		attachments = append(attachments, attachment)
 	}
 
 	builder := larkmail.NewSendUserMailboxMessageReqBodyBuilder()
@@ -0,0 +1,104 @@
+package larksdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+	larkmail "github.com/larksuite/oapi-sdk-go/v3/service/mail/v1"
+)
+
+type MailAttachmentDownloadURL struct {
+	AttachmentID string `json:"attachment_id"`
+	DownloadURL  string `json:"download_url"`
+}
+
+type MailAttachmentDownloadURLs struct {
+	Items     []MailAttachmentDownloadURL `json:"download_urls"`
+	FailedIDs []string                    `json:"failed_ids,omitempty"`
+}
+
+func (c *Client) GetMailAttachmentDownloadURLs(ctx context.Context, token, mailboxID, messageID string, attachmentIDs []string) (MailAttachmentDownloadURLs, error) {
+	if !c.available() {
+		return MailAttachmentDownloadURLs{}, ErrUnavailable
+	}
+	if token == "" {
+		return MailAttachmentDownloadURLs{}, errors.New("user access token is required")
+	}
+	if mailboxID == "" {
+		return MailAttachmentDownloadURLs{}, errors.New("mailbox id is required")
+	}
+	if messageID == "" {
+		return MailAttachmentDownloadURLs{}, errors.New("message id is required")
+	}
+	if len(attachmentIDs) == 0 {
+		return MailAttachmentDownloadURLs{}, errors.New("attachment ids are required")
+	}
+
+	req := larkmail.NewDownloadUrlUserMailboxMessageAttachmentReqBuilder().
+		UserMailboxId(mailboxID).
+		MessageId(messageID).
+		AttachmentIds(attachmentIDs).
+		Build()
+	resp, err := c.sdk.Mail.V1.UserMailboxMessageAttachment.DownloadUrl(ctx, req, larkcore.WithUserAccessToken(token))
+	if err != nil {
+		return MailAttachmentDownloadURLs{}, err
+	}
+	if resp == nil {
+		return MailAttachmentDownloadURLs{}, errors.New("get mail attachment download urls failed: empty response")
+	}
+	if !resp.Success() {
+		return MailAttachmentDownloadURLs{}, apiError("get mail attachment download urls", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil {
+		return MailAttachmentDownloadURLs{}, nil
+	}
+
+	out := MailAttachmentDownloadURLs{FailedIDs: resp.Data.FailedIds}
+	for _, item := range resp.Data.DownloadUrls {
+		if item == nil {
+			continue
+		}
+		out.Items = append(out.Items, MailAttachmentDownloadURL{
+			AttachmentID: derefString(item.AttachmentId),
+			DownloadURL:  derefString(item.DownloadUrl),
+		})
+	}
+	return out, nil
+}
+
+// DownloadMailAttachment fetches a pre-signed attachment download URL. The URL
+// carries its own credentials, so no access token is sent.
+func (c *Client) DownloadMailAttachment(ctx context.Context, downloadURL string) (io.ReadCloser, error) {
+	return c.downloadPresignedURL(ctx, downloadURL, "mail attachment")
+}
+
+func (c *Client) downloadPresignedURL(ctx context.Context, downloadURL, label string) (io.ReadCloser, error) {
+	if c == nil || c.coreConfig == nil {
+		return nil, ErrUnavailable
+	}
+	if downloadURL == "" {
+		return nil, errors.New("download url is required")
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient().Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		data, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil || len(data) == 0 {
+			return nil, fmt.Errorf("%s download failed: %s", label, resp.Status)
+		}
+		return nil, fmt.Errorf("%s download failed: %s: %s", label, resp.Status, string(bytes.TrimSpace(data)))
+	}
+	return resp.Body, nil
+}
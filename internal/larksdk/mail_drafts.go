@@ -0,0 +1,266 @@
+package larksdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+)
+
+type MailDraft struct {
+	DraftID       string        `json:"draft_id"`
+	MessageID     string        `json:"message_id,omitempty"`
+	Subject       string        `json:"subject,omitempty"`
+	To            []MailAddress `json:"to,omitempty"`
+	CC            []MailAddress `json:"cc,omitempty"`
+	BCC           []MailAddress `json:"bcc,omitempty"`
+	BodyPlainText string        `json:"body_plain_text,omitempty"`
+	BodyHTML      string        `json:"body_html,omitempty"`
+	UpdatedAt     string        `json:"updated_at,omitempty"`
+}
+
+type ListMailDraftsRequest struct {
+	MailboxID string
+	PageSize  int
+	PageToken string
+}
+
+type ListMailDraftsResult struct {
+	Items     []MailDraft
+	PageToken string
+	HasMore   bool
+}
+
+type mailDraftResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *mailDraftResponseData `json:"data"`
+}
+
+type mailDraftResponseData struct {
+	Draft     *MailDraft `json:"draft"`
+	DraftID   string     `json:"draft_id"`
+	MessageID string     `json:"message_id"`
+}
+
+func (r *mailDraftResponse) Success() bool {
+	return r.Code == 0
+}
+
+type listMailDraftsResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *listMailDraftsResponseData `json:"data"`
+}
+
+type listMailDraftsResponseData struct {
+	Items     []MailDraft `json:"items"`
+	PageToken string      `json:"page_token"`
+	HasMore   bool        `json:"has_more"`
+}
+
+func (r *listMailDraftsResponse) Success() bool {
+	return r.Code == 0
+}
+
+// mailMessagePayload is the JSON body shared by drafts; it mirrors the send
+// API fields.
+func mailMessagePayload(req SendMailRequest) map[string]any {
+	payload := map[string]any{}
+	if req.Raw != "" {
+		payload["raw"] = req.Raw
+		return payload
+	}
+	if req.Subject != "" {
+		payload["subject"] = req.Subject
+	}
+	if len(req.To) > 0 {
+		payload["to"] = req.To
+	}
+	if len(req.CC) > 0 {
+		payload["cc"] = req.CC
+	}
+	if len(req.BCC) > 0 {
+		payload["bcc"] = req.BCC
+	}
+	if req.HeadFromName != "" {
+		payload["head_from"] = map[string]any{"name": req.HeadFromName}
+	}
+	if req.BodyHTML != "" {
+		payload["body_html"] = req.BodyHTML
+	}
+	if req.BodyPlainText != "" {
+		payload["body_plain_text"] = req.BodyPlainText
+	}
+	if len(req.Attachments) > 0 {
+		payload["attachments"] = req.Attachments
+	}
+	return payload
+}
+
+func (c *Client) CreateMailDraft(ctx context.Context, token, mailboxID string, req SendMailRequest) (MailDraft, error) {
+	if mailboxID == "" {
+		return MailDraft{}, errors.New("mailbox id is required")
+	}
+	resp, err := c.doMailDraft(ctx, token, "create mail draft", http.MethodPost, "/open-apis/mail/v1/user_mailboxes/:user_mailbox_id/drafts", mailboxID, "", mailMessagePayload(req))
+	if err != nil {
+		return MailDraft{}, err
+	}
+	return resp.draft(), nil
+}
+
+func (c *Client) UpdateMailDraft(ctx context.Context, token, mailboxID, draftID string, req SendMailRequest) (MailDraft, error) {
+	if mailboxID == "" {
+		return MailDraft{}, errors.New("mailbox id is required")
+	}
+	if draftID == "" {
+		return MailDraft{}, errors.New("draft id is required")
+	}
+	resp, err := c.doMailDraft(ctx, token, "update mail draft", http.MethodPut, "/open-apis/mail/v1/user_mailboxes/:user_mailbox_id/drafts/:draft_id", mailboxID, draftID, mailMessagePayload(req))
+	if err != nil {
+		return MailDraft{}, err
+	}
+	draft := resp.draft()
+	if draft.DraftID == "" {
+		draft.DraftID = draftID
+	}
+	return draft, nil
+}
+
+// SendMailDraft sends a saved draft and returns the sent message_id.
+func (c *Client) SendMailDraft(ctx context.Context, token, mailboxID, draftID string) (string, error) {
+	if mailboxID == "" {
+		return "", errors.New("mailbox id is required")
+	}
+	if draftID == "" {
+		return "", errors.New("draft id is required")
+	}
+	resp, err := c.doMailDraft(ctx, token, "send mail draft", http.MethodPost, "/open-apis/mail/v1/user_mailboxes/:user_mailbox_id/drafts/:draft_id/send", mailboxID, draftID, map[string]any{})
+	if err != nil {
+		return "", err
+	}
+	if resp.Data == nil {
+		return "", nil
+	}
+	return resp.Data.MessageID, nil
+}
+
+func (c *Client) DeleteMailDraft(ctx context.Context, token, mailboxID, draftID string) error {
+	if mailboxID == "" {
+		return errors.New("mailbox id is required")
+	}
+	if draftID == "" {
+		return errors.New("draft id is required")
+	}
+	_, err := c.doMailDraft(ctx, token, "delete mail draft", http.MethodDelete, "/open-apis/mail/v1/user_mailboxes/:user_mailbox_id/drafts/:draft_id", mailboxID, draftID, nil)
+	return err
+}
+
+func (c *Client) ListMailDrafts(ctx context.Context, token string, req ListMailDraftsRequest) (ListMailDraftsResult, error) {
+	if !c.available() || c.coreConfig == nil {
+		return ListMailDraftsResult{}, ErrUnavailable
+	}
+	if token == "" {
+		return ListMailDraftsResult{}, errors.New("user access token is required")
+	}
+	if req.MailboxID == "" {
+		return ListMailDraftsResult{}, errors.New("mailbox id is required")
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/mail/v1/user_mailboxes/:user_mailbox_id/drafts",
+		HttpMethod:                http.MethodGet,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeUser},
+	}
+	apiReq.PathParams.Set("user_mailbox_id", req.MailboxID)
+	if req.PageSize > 0 {
+		apiReq.QueryParams.Set("page_size", strconv.Itoa(req.PageSize))
+	}
+	if req.PageToken != "" {
+		apiReq.QueryParams.Set("page_token", req.PageToken)
+	}
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, larkcore.WithUserAccessToken(token))
+	if err != nil {
+		return ListMailDraftsResult{}, err
+	}
+	if apiResp == nil {
+		return ListMailDraftsResult{}, errors.New("list mail drafts failed: empty response")
+	}
+	resp := &listMailDraftsResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return ListMailDraftsResult{}, err
+	}
+	if !resp.Success() {
+		return ListMailDraftsResult{}, apiError("list mail drafts", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil {
+		return ListMailDraftsResult{}, nil
+	}
+	return ListMailDraftsResult{
+		Items:     resp.Data.Items,
+		PageToken: resp.Data.PageToken,
+		HasMore:   resp.Data.HasMore,
+	}, nil
+}
+
+func (c *Client) doMailDraft(ctx context.Context, token, op, method, path, mailboxID, draftID string, body any) (*mailDraftResponse, error) {
+	if !c.available() || c.coreConfig == nil {
+		return nil, ErrUnavailable
+	}
+	if token == "" {
+		return nil, errors.New("user access token is required")
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   path,
+		HttpMethod:                method,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeUser},
+	}
+	if body != nil {
+		apiReq.Body = body
+	}
+	apiReq.PathParams.Set("user_mailbox_id", mailboxID)
+	if draftID != "" {
+		apiReq.PathParams.Set("draft_id", draftID)
+	}
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, larkcore.WithUserAccessToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if apiResp == nil {
+		return nil, errors.New(op + " failed: empty response")
+	}
+	resp := &mailDraftResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return nil, err
+	}
+	if !resp.Success() {
+		return nil, apiError(op, resp.Code, resp.Msg)
+	}
+	return resp, nil
+}
+
+func (r *mailDraftResponse) draft() MailDraft {
+	if r == nil || r.Data == nil {
+		return MailDraft{}
+	}
+	draft := MailDraft{}
+	if r.Data.Draft != nil {
+		draft = *r.Data.Draft
+	}
+	if draft.DraftID == "" {
+		draft.DraftID = r.Data.DraftID
+	}
+	if draft.MessageID == "" {
+		draft.MessageID = r.Data.MessageID
+	}
+	return draft
+}
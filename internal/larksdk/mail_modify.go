@@ -0,0 +1,83 @@
+package larksdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+)
+
+// MaxMailModifyBatch is the most message ids one modify call accepts.
+const MaxMailModifyBatch = 100
+
+// ModifyMailMessagesRequest changes the read state and/or folder of messages.
+type ModifyMailMessagesRequest struct {
+	MailboxID  string
+	MessageIDs []string
+	IsRead     *bool
+	FolderID   string
+}
+
+type modifyMailMessagesResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+}
+
+func (r *modifyMailMessagesResponse) Success() bool {
+	return r.Code == 0
+}
+
+func (c *Client) ModifyMailMessages(ctx context.Context, token string, req ModifyMailMessagesRequest) error {
+	if !c.available() || c.coreConfig == nil {
+		return ErrUnavailable
+	}
+	if token == "" {
+		return errors.New("user access token is required")
+	}
+	if req.MailboxID == "" {
+		return errors.New("mailbox id is required")
+	}
+	if len(req.MessageIDs) == 0 {
+		return errors.New("message ids are required")
+	}
+	if len(req.MessageIDs) > MaxMailModifyBatch {
+		return errors.New("too many message ids in one request")
+	}
+	if req.IsRead == nil && req.FolderID == "" {
+		return errors.New("is_read or folder_id is required")
+	}
+
+	body := map[string]any{"message_ids": req.MessageIDs}
+	if req.IsRead != nil {
+		body["is_read"] = *req.IsRead
+	}
+	if req.FolderID != "" {
+		body["folder_id"] = req.FolderID
+	}
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/mail/v1/user_mailboxes/:user_mailbox_id/messages/batch_modify",
+		HttpMethod:                http.MethodPost,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		Body:                      body,
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeUser},
+	}
+	apiReq.PathParams.Set("user_mailbox_id", req.MailboxID)
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, larkcore.WithUserAccessToken(token))
+	if err != nil {
+		return err
+	}
+	if apiResp == nil {
+		return errors.New("modify mail messages failed: empty response")
+	}
+	resp := &modifyMailMessagesResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return err
+	}
+	if !resp.Success() {
+		return apiError("modify mail messages", resp.Code, resp.Msg)
+	}
+	return nil
+}
@@ -0,0 +1,354 @@
+package larksdk
+
+import (
+	"context"
+	"errors"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+	larkmail "github.com/larksuite/oapi-sdk-go/v3/service/mail/v1"
+)
+
+type MailAlias struct {
+	PrimaryEmail string `json:"primary_email,omitempty"`
+	EmailAlias   string `json:"email_alias"`
+}
+
+type PublicMailboxMember struct {
+	MemberID string `json:"member_id,omitempty"`
+	UserID   string `json:"user_id,omitempty"`
+	Type     string `json:"type,omitempty"`
+}
+
+// ListMailboxAliases lists aliases of a user mailbox (tenant token; the
+// mailbox must be an address, not "me").
+func (c *Client) ListMailboxAliases(ctx context.Context, token, mailboxID string) ([]MailAlias, error) {
+	if !c.available() {
+		return nil, ErrUnavailable
+	}
+	tenantToken := c.tenantToken(token)
+	if tenantToken == "" {
+		return nil, errors.New("tenant access token is required")
+	}
+	if mailboxID == "" {
+		return nil, errors.New("mailbox id is required")
+	}
+
+	req := larkmail.NewListUserMailboxAliasReqBuilder().UserMailboxId(mailboxID).Build()
+	resp, err := c.sdk.Mail.V1.UserMailboxAlias.List(ctx, req, larkcore.WithTenantAccessToken(tenantToken))
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errors.New("list mailbox aliases failed: empty response")
+	}
+	if !resp.Success() {
+		return nil, apiError("list mailbox aliases", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil {
+		return nil, nil
+	}
+	return mapMailAliases(resp.Data.Items), nil
+}
+
+func (c *Client) CreateMailboxAlias(ctx context.Context, token, mailboxID, alias string) (MailAlias, error) {
+	if !c.available() {
+		return MailAlias{}, ErrUnavailable
+	}
+	tenantToken := c.tenantToken(token)
+	if tenantToken == "" {
+		return MailAlias{}, errors.New("tenant access token is required")
+	}
+	if mailboxID == "" {
+		return MailAlias{}, errors.New("mailbox id is required")
+	}
+	if alias == "" {
+		return MailAlias{}, errors.New("alias is required")
+	}
+
+	req := larkmail.NewCreateUserMailboxAliasReqBuilder().
+		UserMailboxId(mailboxID).
+		EmailAlias(larkmail.NewEmailAliasBuilder().EmailAlias(alias).Build()).
+		Build()
+	resp, err := c.sdk.Mail.V1.UserMailboxAlias.Create(ctx, req, larkcore.WithTenantAccessToken(tenantToken))
+	if err != nil {
+		return MailAlias{}, err
+	}
+	if resp == nil {
+		return MailAlias{}, errors.New("create mailbox alias failed: empty response")
+	}
+	if !resp.Success() {
+		return MailAlias{}, apiError("create mailbox alias", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil || resp.Data.UserMailboxAlias == nil {
+		return MailAlias{EmailAlias: alias}, nil
+	}
+	return mapMailAlias(resp.Data.UserMailboxAlias), nil
+}
+
+func (c *Client) DeleteMailboxAlias(ctx context.Context, token, mailboxID, alias string) error {
+	if !c.available() {
+		return ErrUnavailable
+	}
+	tenantToken := c.tenantToken(token)
+	if tenantToken == "" {
+		return errors.New("tenant access token is required")
+	}
+	if mailboxID == "" {
+		return errors.New("mailbox id is required")
+	}
+	if alias == "" {
+		return errors.New("alias is required")
+	}
+
+	req := larkmail.NewDeleteUserMailboxAliasReqBuilder().UserMailboxId(mailboxID).AliasId(alias).Build()
+	resp, err := c.sdk.Mail.V1.UserMailboxAlias.Delete(ctx, req, larkcore.WithTenantAccessToken(tenantToken))
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return errors.New("delete mailbox alias failed: empty response")
+	}
+	if !resp.Success() {
+		return apiError("delete mailbox alias", resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+func (c *Client) ListPublicMailboxAliases(ctx context.Context, token, publicMailboxID string) ([]MailAlias, error) {
+	if !c.available() {
+		return nil, ErrUnavailable
+	}
+	tenantToken := c.tenantToken(token)
+	if tenantToken == "" {
+		return nil, errors.New("tenant access token is required")
+	}
+	if publicMailboxID == "" {
+		return nil, errors.New("public mailbox id is required")
+	}
+
+	req := larkmail.NewListPublicMailboxAliasReqBuilder().PublicMailboxId(publicMailboxID).Build()
+	resp, err := c.sdk.Mail.V1.PublicMailboxAlias.List(ctx, req, larkcore.WithTenantAccessToken(tenantToken))
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errors.New("list public mailbox aliases failed: empty response")
+	}
+	if !resp.Success() {
+		return nil, apiError("list public mailbox aliases", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil {
+		return nil, nil
+	}
+	return mapMailAliases(resp.Data.Items), nil
+}
+
+func (c *Client) CreatePublicMailboxAlias(ctx context.Context, token, publicMailboxID, alias string) (MailAlias, error) {
+	if !c.available() {
+		return MailAlias{}, ErrUnavailable
+	}
+	tenantToken := c.tenantToken(token)
+	if tenantToken == "" {
+		return MailAlias{}, errors.New("tenant access token is required")
+	}
+	if publicMailboxID == "" {
+		return MailAlias{}, errors.New("public mailbox id is required")
+	}
+	if alias == "" {
+		return MailAlias{}, errors.New("alias is required")
+	}
+
+	req := larkmail.NewCreatePublicMailboxAliasReqBuilder().
+		PublicMailboxId(publicMailboxID).
+		EmailAlias(larkmail.NewEmailAliasBuilder().EmailAlias(alias).Build()).
+		Build()
+	resp, err := c.sdk.Mail.V1.PublicMailboxAlias.Create(ctx, req, larkcore.WithTenantAccessToken(tenantToken))
+	if err != nil {
+		return MailAlias{}, err
+	}
+	if resp == nil {
+		return MailAlias{}, errors.New("create public mailbox alias failed: empty response")
+	}
+	if !resp.Success() {
+		return MailAlias{}, apiError("create public mailbox alias", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil || resp.Data.PublicMailboxAlias == nil {
+		return MailAlias{EmailAlias: alias}, nil
+	}
+	return mapMailAlias(resp.Data.PublicMailboxAlias), nil
+}
+
+func (c *Client) DeletePublicMailboxAlias(ctx context.Context, token, publicMailboxID, alias string) error {
+	if !c.available() {
+		return ErrUnavailable
+	}
+	tenantToken := c.tenantToken(token)
+	if tenantToken == "" {
+		return errors.New("tenant access token is required")
+	}
+	if publicMailboxID == "" {
+		return errors.New("public mailbox id is required")
+	}
+	if alias == "" {
+		return errors.New("alias is required")
+	}
+
+	req := larkmail.NewDeletePublicMailboxAliasReqBuilder().PublicMailboxId(publicMailboxID).AliasId(alias).Build()
+	resp, err := c.sdk.Mail.V1.PublicMailboxAlias.Delete(ctx, req, larkcore.WithTenantAccessToken(tenantToken))
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return errors.New("delete public mailbox alias failed: empty response")
+	}
+	if !resp.Success() {
+		return apiError("delete public mailbox alias", resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+func (c *Client) ListPublicMailboxMembers(ctx context.Context, token, publicMailboxID, userIDType string) ([]PublicMailboxMember, error) {
+	if !c.available() {
+		return nil, ErrUnavailable
+	}
+	tenantToken := c.tenantToken(token)
+	if tenantToken == "" {
+		return nil, errors.New("tenant access token is required")
+	}
+	if publicMailboxID == "" {
+		return nil, errors.New("public mailbox id is required")
+	}
+
+	members := make([]PublicMailboxMember, 0)
+	pageToken := ""
+	for {
+		builder := larkmail.NewListPublicMailboxMemberReqBuilder().PublicMailboxId(publicMailboxID).PageSize(200)
+		if userIDType != "" {
+			builder.UserIdType(userIDType)
+		}
+		if pageToken != "" {
+			builder.PageToken(pageToken)
+		}
+		resp, err := c.sdk.Mail.V1.PublicMailboxMember.List(ctx, builder.Build(), larkcore.WithTenantAccessToken(tenantToken))
+		if err != nil {
+			return nil, err
+		}
+		if resp == nil {
+			return nil, errors.New("list public mailbox members failed: empty response")
+		}
+		if !resp.Success() {
+			return nil, apiError("list public mailbox members", resp.Code, resp.Msg)
+		}
+		if resp.Data == nil {
+			break
+		}
+		for _, item := range resp.Data.Items {
+			if item == nil {
+				continue
+			}
+			members = append(members, PublicMailboxMember{
+				MemberID: derefString(item.MemberId),
+				UserID:   derefString(item.UserId),
+				Type:     derefString(item.Type),
+			})
+		}
+		pageToken = derefString(resp.Data.PageToken)
+		if resp.Data.HasMore == nil || !*resp.Data.HasMore || pageToken == "" {
+			break
+		}
+	}
+	return members, nil
+}
+
+// AddPublicMailboxMembers adds members in one batch; Type defaults to USER.
+func (c *Client) AddPublicMailboxMembers(ctx context.Context, token, publicMailboxID, userIDType string, members []PublicMailboxMember) error {
+	if !c.available() {
+		return ErrUnavailable
+	}
+	tenantToken := c.tenantToken(token)
+	if tenantToken == "" {
+		return errors.New("tenant access token is required")
+	}
+	if publicMailboxID == "" {
+		return errors.New("public mailbox id is required")
+	}
+	if len(members) == 0 {
+		return errors.New("members are required")
+	}
+
+	items := make([]*larkmail.PublicMailboxMember, 0, len(members))
+	for _, member := range members {
+		memberType := member.Type
+		if memberType == "" {
+			memberType = "USER"
+		}
+		items = append(items, larkmail.NewPublicMailboxMemberBuilder().UserId(member.UserID).Type(memberType).Build())
+	}
+	builder := larkmail.NewBatchCreatePublicMailboxMemberReqBuilder().
+		PublicMailboxId(publicMailboxID).
+		Body(larkmail.NewBatchCreatePublicMailboxMemberReqBodyBuilder().Items(items).Build())
+	if userIDType != "" {
+		builder.UserIdType(userIDType)
+	}
+	resp, err := c.sdk.Mail.V1.PublicMailboxMember.BatchCreate(ctx, builder.Build(), larkcore.WithTenantAccessToken(tenantToken))
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return errors.New("add public mailbox members failed: empty response")
+	}
+	if !resp.Success() {
+		return apiError("add public mailbox members", resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+func (c *Client) RemovePublicMailboxMembers(ctx context.Context, token, publicMailboxID string, memberIDs []string) error {
+	if !c.available() {
+		return ErrUnavailable
+	}
+	tenantToken := c.tenantToken(token)
+	if tenantToken == "" {
+		return errors.New("tenant access token is required")
+	}
+	if publicMailboxID == "" {
+		return errors.New("public mailbox id is required")
+	}
+	if len(memberIDs) == 0 {
+		return errors.New("member ids are required")
+	}
+
+	req := larkmail.NewBatchDeletePublicMailboxMemberReqBuilder().
+		PublicMailboxId(publicMailboxID).
+		Body(larkmail.NewBatchDeletePublicMailboxMemberReqBodyBuilder().MemberIdList(memberIDs).Build()).
+		Build()
+	resp, err := c.sdk.Mail.V1.PublicMailboxMember.BatchDelete(ctx, req, larkcore.WithTenantAccessToken(tenantToken))
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return errors.New("remove public mailbox members failed: empty response")
+	}
+	if !resp.Success() {
+		return apiError("remove public mailbox members", resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+func mapMailAliases(items []*larkmail.EmailAlias) []MailAlias {
+	aliases := make([]MailAlias, 0, len(items))
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		aliases = append(aliases, mapMailAlias(item))
+	}
+	return aliases
+}
+
+func mapMailAlias(alias *larkmail.EmailAlias) MailAlias {
+	return MailAlias{
+		PrimaryEmail: derefString(alias.PrimaryEmail),
+		EmailAlias:   derefString(alias.EmailAlias),
+	}
+}
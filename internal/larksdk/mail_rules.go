@@ -0,0 +1,241 @@
+package larksdk
+
+import (
+	"context"
+	"errors"
+
+	larkmail "github.com/larksuite/oapi-sdk-go/v3/service/mail/v1"
+)
+
+// MailRuleCondition is one inbox rule match clause; Type and Operator use the
+// mail API's numeric enums.
+type MailRuleCondition struct {
+	Type     int    `json:"type"`
+	Operator int    `json:"operator,omitempty"`
+	Input    string `json:"input,omitempty"`
+}
+
+// MailRuleAction is one inbox rule action; Input carries the folder id for
+// move actions.
+type MailRuleAction struct {
+	Type  int    `json:"type"`
+	Input string `json:"input,omitempty"`
+}
+
+type MailRule struct {
+	RuleID         string              `json:"rule_id,omitempty"`
+	Name           string              `json:"name"`
+	Enabled        bool                `json:"is_enable"`
+	StopProcessing bool                `json:"ignore_the_rest_of_rules"`
+	MatchType      int                 `json:"match_type"`
+	Conditions     []MailRuleCondition `json:"conditions"`
+	Actions        []MailRuleAction    `json:"actions"`
+}
+
+func (c *Client) ListMailRules(ctx context.Context, token string, tokenType AccessTokenType, mailboxID string) ([]MailRule, error) {
+	if !c.available() {
+		return nil, ErrUnavailable
+	}
+	if mailboxID == "" {
+		return nil, errors.New("mailbox id is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	req := larkmail.NewListUserMailboxRuleReqBuilder().UserMailboxId(mailboxID).Build()
+	resp, err := c.sdk.Mail.V1.UserMailboxRule.List(ctx, req, option)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errors.New("list mail rules failed: empty response")
+	}
+	if !resp.Success() {
+		return nil, apiError("list mail rules", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil {
+		return nil, nil
+	}
+	rules := make([]MailRule, 0, len(resp.Data.Items))
+	for _, item := range resp.Data.Items {
+		if item == nil {
+			continue
+		}
+		rules = append(rules, mapMailRule(item))
+	}
+	return rules, nil
+}
+
+func (c *Client) CreateMailRule(ctx context.Context, token string, tokenType AccessTokenType, mailboxID string, rule MailRule) (MailRule, error) {
+	if !c.available() {
+		return MailRule{}, ErrUnavailable
+	}
+	if mailboxID == "" {
+		return MailRule{}, errors.New("mailbox id is required")
+	}
+	if rule.Name == "" {
+		return MailRule{}, errors.New("rule name is required")
+	}
+	if len(rule.Conditions) == 0 {
+		return MailRule{}, errors.New("rule conditions are required")
+	}
+	if len(rule.Actions) == 0 {
+		return MailRule{}, errors.New("rule actions are required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return MailRule{}, err
+	}
+
+	req := larkmail.NewCreateUserMailboxRuleReqBuilder().
+		UserMailboxId(mailboxID).
+		Rule(buildMailRule(rule)).
+		Build()
+	resp, err := c.sdk.Mail.V1.UserMailboxRule.Create(ctx, req, option)
+	if err != nil {
+		return MailRule{}, err
+	}
+	if resp == nil {
+		return MailRule{}, errors.New("create mail rule failed: empty response")
+	}
+	if !resp.Success() {
+		return MailRule{}, apiError("create mail rule", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil || resp.Data.Rule == nil {
+		return rule, nil
+	}
+	return mapMailRule(resp.Data.Rule), nil
+}
+
+func (c *Client) DeleteMailRule(ctx context.Context, token string, tokenType AccessTokenType, mailboxID, ruleID string) error {
+	if !c.available() {
+		return ErrUnavailable
+	}
+	if mailboxID == "" {
+		return errors.New("mailbox id is required")
+	}
+	if ruleID == "" {
+		return errors.New("rule id is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return err
+	}
+
+	req := larkmail.NewDeleteUserMailboxRuleReqBuilder().UserMailboxId(mailboxID).RuleId(ruleID).Build()
+	resp, err := c.sdk.Mail.V1.UserMailboxRule.Delete(ctx, req, option)
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return errors.New("delete mail rule failed: empty response")
+	}
+	if !resp.Success() {
+		return apiError("delete mail rule", resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+// ReorderMailRules sets rule evaluation order; ruleIDs must list every rule.
+func (c *Client) ReorderMailRules(ctx context.Context, token string, tokenType AccessTokenType, mailboxID string, ruleIDs []string) error {
+	if !c.available() {
+		return ErrUnavailable
+	}
+	if mailboxID == "" {
+		return errors.New("mailbox id is required")
+	}
+	if len(ruleIDs) == 0 {
+		return errors.New("rule ids are required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return err
+	}
+
+	req := larkmail.NewReorderUserMailboxRuleReqBuilder().
+		UserMailboxId(mailboxID).
+		Body(larkmail.NewReorderUserMailboxRuleReqBodyBuilder().RuleIds(ruleIDs).Build()).
+		Build()
+	resp, err := c.sdk.Mail.V1.UserMailboxRule.Reorder(ctx, req, option)
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return errors.New("reorder mail rules failed: empty response")
+	}
+	if !resp.Success() {
+		return apiError("reorder mail rules", resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+func buildMailRule(rule MailRule) *larkmail.Rule {
+	conditions := make([]*larkmail.RuleConditionItem, 0, len(rule.Conditions))
+	for _, condition := range rule.Conditions {
+		builder := larkmail.NewRuleConditionItemBuilder().Type(condition.Type)
+		if condition.Operator != 0 {
+			builder.Operator(condition.Operator)
+		}
+		if condition.Input != "" {
+			builder.Input(condition.Input)
+		}
+		conditions = append(conditions, builder.Build())
+	}
+	actions := make([]*larkmail.RuleActionItem, 0, len(rule.Actions))
+	for _, action := range rule.Actions {
+		builder := larkmail.NewRuleActionItemBuilder().Type(action.Type)
+		if action.Input != "" {
+			builder.Input(action.Input)
+		}
+		actions = append(actions, builder.Build())
+	}
+	return larkmail.NewRuleBuilder().
+		Name(rule.Name).
+		IsEnable(rule.Enabled).
+		IgnoreTheRestOfRules(rule.StopProcessing).
+		Condition(larkmail.NewRuleConditionBuilder().MatchType(rule.MatchType).Items(conditions).Build()).
+		Action(larkmail.NewRuleActionBuilder().Items(actions).Build()).
+		Build()
+}
+
+func mapMailRule(rule *larkmail.Rule) MailRule {
+	out := MailRule{
+		RuleID:         derefString(rule.Id),
+		Name:           derefString(rule.Name),
+		Enabled:        rule.IsEnable != nil && *rule.IsEnable,
+		StopProcessing: rule.IgnoreTheRestOfRules != nil && *rule.IgnoreTheRestOfRules,
+	}
+	if rule.Condition != nil {
+		if rule.Condition.MatchType != nil {
+			out.MatchType = *rule.Condition.MatchType
+		}
+		for _, item := range rule.Condition.Items {
+			if item == nil {
+				continue
+			}
+			condition := MailRuleCondition{Input: derefString(item.Input)}
+			if item.Type != nil {
+				condition.Type = *item.Type
+			}
+			if item.Operator != nil {
+				condition.Operator = *item.Operator
+			}
+			out.Conditions = append(out.Conditions, condition)
+		}
+	}
+	if rule.Action != nil {
+		for _, item := range rule.Action.Items {
+			if item == nil {
+				continue
+			}
+			action := MailRuleAction{Input: derefString(item.Input)}
+			if item.Type != nil {
+				action.Type = *item.Type
+			}
+			out.Actions = append(out.Actions, action)
+		}
+	}
+	return out
+}
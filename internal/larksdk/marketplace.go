@@ -0,0 +1,91 @@
+package larksdk
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+)
+
+// MarketplaceAppAccessToken exchanges an app_ticket for an app access token.
+// Only marketplace (store/ISV) apps receive app tickets.
+func (c *Client) MarketplaceAppAccessToken(ctx context.Context, appTicket string) (string, int64, error) {
+	if !c.available() {
+		return "", 0, ErrUnavailable
+	}
+	if strings.TrimSpace(appTicket) == "" {
+		return "", 0, errors.New("app ticket is required")
+	}
+	resp, err := c.sdk.GetAppAccessTokenByMarketplaceApp(ctx, &larkcore.MarketplaceAppAccessTokenReq{
+		AppID:     c.coreConfig.AppId,
+		AppSecret: c.coreConfig.AppSecret,
+		AppTicket: appTicket,
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	if resp == nil {
+		return "", 0, errors.New("app access token failed: empty response")
+	}
+	if !resp.Success() {
+		return "", 0, apiError("app access token", resp.Code, resp.Msg)
+	}
+	if resp.AppAccessToken == "" {
+		return "", 0, errors.New("app access token missing from response")
+	}
+	return resp.AppAccessToken, int64(resp.Expire), nil
+}
+
+// MarketplaceTenantAccessToken fetches a tenant access token for the tenant
+// identified by tenantKey that installed the app.
+func (c *Client) MarketplaceTenantAccessToken(ctx context.Context, appAccessToken, tenantKey string) (string, int64, error) {
+	if !c.available() {
+		return "", 0, ErrUnavailable
+	}
+	if appAccessToken == "" {
+		return "", 0, errors.New("app access token is required")
+	}
+	if strings.TrimSpace(tenantKey) == "" {
+		return "", 0, errors.New("tenant key is required")
+	}
+	resp, err := c.sdk.GetTenantAccessTokenByMarketplaceApp(ctx, &larkcore.MarketplaceTenantAccessTokenReq{
+		AppAccessToken: appAccessToken,
+		TenantKey:      tenantKey,
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	if resp == nil {
+		return "", 0, errors.New("tenant access token failed: empty response")
+	}
+	if !resp.Success() {
+		return "", 0, apiError("tenant access token", resp.Code, resp.Msg)
+	}
+	if resp.TenantAccessToken == "" {
+		return "", 0, errors.New("tenant access token missing from response")
+	}
+	return resp.TenantAccessToken, int64(resp.Expire), nil
+}
+
+// ResendAppTicket asks the platform to push a fresh app_ticket event to the
+// app's event callback URL.
+func (c *Client) ResendAppTicket(ctx context.Context) error {
+	if !c.available() {
+		return ErrUnavailable
+	}
+	resp, err := c.sdk.ResendAppTicket(ctx, &larkcore.ResendAppTicketReq{
+		AppID:     c.coreConfig.AppId,
+		AppSecret: c.coreConfig.AppSecret,
+	})
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return errors.New("resend app ticket failed: empty response")
+	}
+	if !resp.Success() {
+		return apiError("resend app ticket", resp.Code, resp.Msg)
+	}
+	return nil
+}
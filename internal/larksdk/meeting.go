@@ -44,6 +44,10 @@ type meetingListInfo struct {
 	Status    *int    `json:"meeting_status,omitempty"`
 	StartTime *string `json:"meeting_start_time"`
 	EndTime   *string `json:"meeting_end_time"`
+	Duration  *string `json:"meeting_duration,omitempty"`
+	Organizer *string `json:"organizer,omitempty"`
+	// Participants is a count; for webinars it counts guests only.
+	Participants *string `json:"number_of_participants,omitempty"`
 }
 
 type listMeetingsByNoResponse struct {
@@ -305,6 +309,9 @@ func (c *Client) ListMeetings(ctx context.Context, token string, req ListMeeting
 				if meeting.EndTime != nil {
 					item.EndTime = *meeting.EndTime
 				}
+				item.Duration = derefString(meeting.Duration)
+				item.Organizer = derefString(meeting.Organizer)
+				item.ParticipantCount = derefString(meeting.Participants)
 				result.Items = append(result.Items, item)
 			}
 		}
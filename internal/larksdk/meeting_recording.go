@@ -0,0 +1,162 @@
+package larksdk
+
+import (
+	"context"
+	"errors"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+	larkvc "github.com/larksuite/oapi-sdk-go/v3/service/vc/v1"
+)
+
+type MeetingRecording struct {
+	ID        string `json:"id,omitempty"`
+	MeetingID string `json:"meeting_id,omitempty"`
+	URL       string `json:"url,omitempty"`
+	// Duration is the total recording length in milliseconds.
+	Duration string `json:"duration,omitempty"`
+}
+
+// RecordingPermissionObject grants access to a recording. Type is 1 (user),
+// 2 (group), 3 (tenant) or 4 (anyone on the internet); Permission 1 is view.
+type RecordingPermissionObject struct {
+	ID         string `json:"id,omitempty"`
+	Type       int    `json:"type"`
+	Permission int    `json:"permission"`
+}
+
+func (c *Client) GetMeetingRecording(ctx context.Context, token string, tokenType AccessTokenType, meetingID string) (MeetingRecording, error) {
+	if !c.available() {
+		return MeetingRecording{}, ErrUnavailable
+	}
+	if meetingID == "" {
+		return MeetingRecording{}, errors.New("meeting id is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return MeetingRecording{}, err
+	}
+
+	req := larkvc.NewGetMeetingRecordingReqBuilder().MeetingId(meetingID).Build()
+	resp, err := c.sdk.Vc.V1.MeetingRecording.Get(ctx, req, option)
+	if err != nil {
+		return MeetingRecording{}, err
+	}
+	if resp == nil {
+		return MeetingRecording{}, errors.New("get meeting recording failed: empty response")
+	}
+	if !resp.Success() {
+		return MeetingRecording{}, apiError("get meeting recording", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil || resp.Data.Recording == nil {
+		return MeetingRecording{}, nil
+	}
+	recording := resp.Data.Recording
+	return MeetingRecording{
+		ID:        derefString(recording.Id),
+		MeetingID: derefString(recording.MeetingId),
+		URL:       derefString(recording.Url),
+		Duration:  derefString(recording.Duration),
+	}, nil
+}
+
+// StartMeetingRecording starts recording an ongoing meeting. timezone is the
+// UTC offset in hours used for the recording file name.
+func (c *Client) StartMeetingRecording(ctx context.Context, token, meetingID string, timezone int) error {
+	if !c.available() {
+		return ErrUnavailable
+	}
+	if token == "" {
+		return errors.New("user access token is required")
+	}
+	if meetingID == "" {
+		return errors.New("meeting id is required")
+	}
+
+	body := larkvc.NewStartMeetingRecordingReqBodyBuilder().Timezone(timezone).Build()
+	req := larkvc.NewStartMeetingRecordingReqBuilder().MeetingId(meetingID).Body(body).Build()
+	resp, err := c.sdk.Vc.V1.MeetingRecording.Start(ctx, req, larkcore.WithUserAccessToken(token))
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return errors.New("start meeting recording failed: empty response")
+	}
+	if !resp.Success() {
+		return apiError("start meeting recording", resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+func (c *Client) StopMeetingRecording(ctx context.Context, token, meetingID string) error {
+	if !c.available() {
+		return ErrUnavailable
+	}
+	if token == "" {
+		return errors.New("user access token is required")
+	}
+	if meetingID == "" {
+		return errors.New("meeting id is required")
+	}
+
+	req := larkvc.NewStopMeetingRecordingReqBuilder().MeetingId(meetingID).Build()
+	resp, err := c.sdk.Vc.V1.MeetingRecording.Stop(ctx, req, larkcore.WithUserAccessToken(token))
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return errors.New("stop meeting recording failed: empty response")
+	}
+	if !resp.Success() {
+		return apiError("stop meeting recording", resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+// SetMeetingRecordingPermission grants (or, with revoke, removes) access to a
+// meeting recording.
+func (c *Client) SetMeetingRecordingPermission(ctx context.Context, token, meetingID string, objects []RecordingPermissionObject, revoke bool, userIDType string) error {
+	if !c.available() {
+		return ErrUnavailable
+	}
+	if token == "" {
+		return errors.New("user access token is required")
+	}
+	if meetingID == "" {
+		return errors.New("meeting id is required")
+	}
+	if len(objects) == 0 {
+		return errors.New("permission objects are required")
+	}
+
+	sdkObjects := make([]*larkvc.RecordingPermissionObject, 0, len(objects))
+	for _, object := range objects {
+		builder := larkvc.NewRecordingPermissionObjectBuilder().Type(object.Type).Permission(object.Permission)
+		if object.ID != "" {
+			builder.Id(object.ID)
+		}
+		sdkObjects = append(sdkObjects, builder.Build())
+	}
+	actionType := 0
+	if revoke {
+		actionType = 1
+	}
+	body := larkvc.NewSetPermissionMeetingRecordingReqBodyBuilder().
+		PermissionObjects(sdkObjects).
+		ActionType(actionType).
+		Build()
+	builder := larkvc.NewSetPermissionMeetingRecordingReqBuilder().MeetingId(meetingID).Body(body)
+	if userIDType != "" {
+		builder.UserIdType(userIDType)
+	}
+	resp, err := c.sdk.Vc.V1.MeetingRecording.SetPermission(ctx, builder.Build(), larkcore.WithUserAccessToken(token))
+	if err != nil {
+		return err
+	}
+	if resp == nil {
+		return errors.New("set meeting recording permission failed: empty response")
+	}
+	if !resp.Success() {
+		return apiError("set meeting recording permission", resp.Code, resp.Msg)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package larksdk
+
+import (
+	"context"
+	"errors"
+
+	larkvc "github.com/larksuite/oapi-sdk-go/v3/service/vc/v1"
+)
+
+// MeetingParticipantRecord is one participant row from the meeting
+// participant list; join and leave times are formatted by the API.
+type MeetingParticipantRecord struct {
+	Name          string `json:"participant_name,omitempty"`
+	Department    string `json:"department,omitempty"`
+	UserID        string `json:"user_id,omitempty"`
+	MeetingRoomID string `json:"meeting_room_id,omitempty"`
+	EmployeeID    string `json:"employee_id,omitempty"`
+	Email         string `json:"email,omitempty"`
+	Device        string `json:"device,omitempty"`
+	JoinTime      string `json:"join_time,omitempty"`
+	LeaveTime     string `json:"leave_time,omitempty"`
+	TimeInMeeting string `json:"time_in_meeting,omitempty"`
+	LeaveReason   string `json:"leave_reason,omitempty"`
+	IsExternal    bool   `json:"is_external,omitempty"`
+}
+
+type ListMeetingParticipantsRequest struct {
+	// StartTime and EndTime bound the meeting start (unix seconds).
+	StartTime  string
+	EndTime    string
+	MeetingNo  string
+	PageSize   int
+	PageToken  string
+	UserIDType string
+}
+
+type ListMeetingParticipantsResult struct {
+	Items     []MeetingParticipantRecord
+	PageToken string
+	HasMore   bool
+}
+
+func (c *Client) ListMeetingParticipants(ctx context.Context, token string, tokenType AccessTokenType, req ListMeetingParticipantsRequest) (ListMeetingParticipantsResult, error) {
+	if !c.available() {
+		return ListMeetingParticipantsResult{}, ErrUnavailable
+	}
+	if req.MeetingNo == "" {
+		return ListMeetingParticipantsResult{}, errors.New("meeting_no is required")
+	}
+	if req.StartTime == "" || req.EndTime == "" {
+		return ListMeetingParticipantsResult{}, errors.New("start and end are required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return ListMeetingParticipantsResult{}, err
+	}
+
+	builder := larkvc.NewGetParticipantListReqBuilder().
+		MeetingStartTime(req.StartTime).
+		MeetingEndTime(req.EndTime).
+		MeetingNo(req.MeetingNo)
+	if req.PageSize > 0 {
+		builder.PageSize(req.PageSize)
+	}
+	if req.PageToken != "" {
+		builder.PageToken(req.PageToken)
+	}
+	if req.UserIDType != "" {
+		builder.UserIdType(req.UserIDType)
+	}
+	resp, err := c.sdk.Vc.V1.ParticipantList.Get(ctx, builder.Build(), option)
+	if err != nil {
+		return ListMeetingParticipantsResult{}, err
+	}
+	if resp == nil {
+		return ListMeetingParticipantsResult{}, errors.New("list meeting participants failed: empty response")
+	}
+	if !resp.Success() {
+		return ListMeetingParticipantsResult{}, apiError("list meeting participants", resp.Code, resp.Msg)
+	}
+
+	result := ListMeetingParticipantsResult{}
+	if resp.Data == nil {
+		return result, nil
+	}
+	for _, participant := range resp.Data.Participants {
+		if participant == nil {
+			continue
+		}
+		result.Items = append(result.Items, MeetingParticipantRecord{
+			Name:          derefString(participant.ParticipantName),
+			Department:    derefString(participant.Department),
+			UserID:        derefString(participant.UserId),
+			MeetingRoomID: derefString(participant.MeetingRoomId),
+			EmployeeID:    derefString(participant.EmployeeId),
+			Email:         derefString(participant.Email),
+			Device:        derefString(participant.Device),
+			JoinTime:      derefString(participant.JoinTime),
+			LeaveTime:     derefString(participant.LeaveTime),
+			TimeInMeeting: derefString(participant.TimeInMeeting),
+			LeaveReason:   derefString(participant.LeaveReason),
+			IsExternal:    participant.IsExternal != nil && *participant.IsExternal,
+		})
+	}
+	result.PageToken = derefString(resp.Data.PageToken)
+	result.HasMore = resp.Data.HasMore != nil && *resp.Data.HasMore
+	return result, nil
+}
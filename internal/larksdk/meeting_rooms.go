@@ -0,0 +1,226 @@
+package larksdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+)
+
+// MeetingRoom is a VC meeting room; Path lists the room level ids
+// (building, floor, ...) from the root down.
+type MeetingRoom struct {
+	RoomID       string              `json:"room_id"`
+	Name         string              `json:"name"`
+	Capacity     int                 `json:"capacity"`
+	Description  string              `json:"description,omitempty"`
+	DisplayID    string              `json:"display_id,omitempty"`
+	CustomRoomID string              `json:"custom_room_id,omitempty"`
+	RoomLevelID  string              `json:"room_level_id,omitempty"`
+	Path         []string            `json:"path,omitempty"`
+	Device       []MeetingRoomDevice `json:"device,omitempty"`
+	RoomStatus   *MeetingRoomStatus  `json:"room_status,omitempty"`
+}
+
+type MeetingRoomDevice struct {
+	Name string `json:"name"`
+}
+
+type MeetingRoomStatus struct {
+	Status           bool   `json:"status"`
+	ScheduleStatus   bool   `json:"schedule_status,omitempty"`
+	DisableStartTime string `json:"disable_start_time,omitempty"`
+	DisableEndTime   string `json:"disable_end_time,omitempty"`
+	DisableReason    string `json:"disable_reason,omitempty"`
+}
+
+// MeetingRoomLevel is a node in the room hierarchy (e.g. a building or floor).
+type MeetingRoomLevel struct {
+	RoomLevelID   string   `json:"room_level_id"`
+	Name          string   `json:"name"`
+	ParentID      string   `json:"parent_id,omitempty"`
+	Path          []string `json:"path,omitempty"`
+	HasChild      bool     `json:"has_child,omitempty"`
+	CustomGroupID string   `json:"custom_group_id,omitempty"`
+}
+
+type ListMeetingRoomsRequest struct {
+	RoomLevelID string
+	PageSize    int
+	PageToken   string
+}
+
+type SearchMeetingRoomsRequest struct {
+	Keyword     string
+	RoomLevelID string
+	PageSize    int
+	PageToken   string
+}
+
+type ListMeetingRoomsResult struct {
+	Items     []MeetingRoom
+	PageToken string
+	HasMore   bool
+}
+
+type listMeetingRoomsResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *listMeetingRoomsResponseData `json:"data"`
+}
+
+type listMeetingRoomsResponseData struct {
+	Rooms     []MeetingRoom `json:"rooms"`
+	PageToken string        `json:"page_token"`
+	HasMore   bool          `json:"has_more"`
+}
+
+func (r *listMeetingRoomsResponse) Success() bool {
+	return r.Code == 0
+}
+
+type meetingRoomLevelsResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *meetingRoomLevelsResponseData `json:"data"`
+}
+
+type meetingRoomLevelsResponseData struct {
+	Items []MeetingRoomLevel `json:"items"`
+}
+
+func (r *meetingRoomLevelsResponse) Success() bool {
+	return r.Code == 0
+}
+
+func (c *Client) ListMeetingRooms(ctx context.Context, token string, tokenType AccessTokenType, req ListMeetingRoomsRequest) (ListMeetingRoomsResult, error) {
+	if !c.available() || c.coreConfig == nil {
+		return ListMeetingRoomsResult{}, ErrUnavailable
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return ListMeetingRoomsResult{}, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/vc/v1/rooms",
+		HttpMethod:                http.MethodGet,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	if req.RoomLevelID != "" {
+		apiReq.QueryParams.Set("room_level_id", req.RoomLevelID)
+	}
+	if req.PageSize > 0 {
+		apiReq.QueryParams.Set("page_size", fmt.Sprintf("%d", req.PageSize))
+	}
+	if req.PageToken != "" {
+		apiReq.QueryParams.Set("page_token", req.PageToken)
+	}
+	return c.doListMeetingRooms(ctx, apiReq, option, "list meeting rooms")
+}
+
+func (c *Client) SearchMeetingRooms(ctx context.Context, token string, tokenType AccessTokenType, req SearchMeetingRoomsRequest) (ListMeetingRoomsResult, error) {
+	if !c.available() || c.coreConfig == nil {
+		return ListMeetingRoomsResult{}, ErrUnavailable
+	}
+	if req.Keyword == "" && req.RoomLevelID == "" {
+		return ListMeetingRoomsResult{}, errors.New("keyword or room level id is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return ListMeetingRoomsResult{}, err
+	}
+
+	payload := map[string]any{}
+	if req.Keyword != "" {
+		payload["keyword"] = req.Keyword
+	}
+	if req.RoomLevelID != "" {
+		payload["room_level_id"] = req.RoomLevelID
+	}
+	if req.PageSize > 0 {
+		payload["page_size"] = req.PageSize
+	}
+	if req.PageToken != "" {
+		payload["page_token"] = req.PageToken
+	}
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/vc/v1/rooms/search",
+		HttpMethod:                http.MethodPost,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		Body:                      payload,
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	return c.doListMeetingRooms(ctx, apiReq, option, "search meeting rooms")
+}
+
+func (c *Client) doListMeetingRooms(ctx context.Context, apiReq *larkcore.ApiReq, option larkcore.RequestOptionFunc, op string) (ListMeetingRoomsResult, error) {
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return ListMeetingRoomsResult{}, err
+	}
+	if apiResp == nil {
+		return ListMeetingRoomsResult{}, errors.New(op + " failed: empty response")
+	}
+	resp := &listMeetingRoomsResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return ListMeetingRoomsResult{}, err
+	}
+	if !resp.Success() {
+		return ListMeetingRoomsResult{}, apiError(op, resp.Code, resp.Msg)
+	}
+	result := ListMeetingRoomsResult{}
+	if resp.Data != nil {
+		result.Items = resp.Data.Rooms
+		result.PageToken = resp.Data.PageToken
+		result.HasMore = resp.Data.HasMore
+	}
+	return result, nil
+}
+
+// GetMeetingRoomLevels batch-fetches room levels by id.
+func (c *Client) GetMeetingRoomLevels(ctx context.Context, token string, tokenType AccessTokenType, levelIDs []string) ([]MeetingRoomLevel, error) {
+	if !c.available() || c.coreConfig == nil {
+		return nil, ErrUnavailable
+	}
+	if len(levelIDs) == 0 {
+		return nil, errors.New("room level ids are required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/vc/v1/room_levels/mget",
+		HttpMethod:                http.MethodPost,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		Body:                      map[string]any{"level_ids": levelIDs},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return nil, err
+	}
+	if apiResp == nil {
+		return nil, errors.New("get meeting room levels failed: empty response")
+	}
+	resp := &meetingRoomLevelsResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return nil, err
+	}
+	if !resp.Success() {
+		return nil, apiError("get meeting room levels", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil {
+		return nil, nil
+	}
+	return resp.Data.Items, nil
+}
@@ -2,7 +2,10 @@ package larksdk
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
+	"strings"
 
 	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
 	larkminutes "github.com/larksuite/oapi-sdk-go/v3/service/minutes/v1"
@@ -69,3 +72,146 @@ func mapMinute(minute *larkminutes.Minute) Minute {
 	}
 	return result
 }
+
+type MinuteStatistics struct {
+	UserViewCount string           `json:"user_view_count,omitempty"`
+	PageViewCount string           `json:"page_view_count,omitempty"`
+	UserViews     []MinuteUserView `json:"user_views,omitempty"`
+}
+
+type MinuteUserView struct {
+	UserID   string `json:"user_id"`
+	ViewTime string `json:"view_time,omitempty"`
+}
+
+type GetMinuteTranscriptRequest struct {
+	MinuteToken   string
+	FileFormat    string
+	NeedSpeaker   bool
+	NeedTimestamp bool
+}
+
+// GetMinuteTranscript returns the transcript file in the requested format
+// (txt or srt).
+func (c *Client) GetMinuteTranscript(ctx context.Context, token string, tokenType AccessTokenType, req GetMinuteTranscriptRequest) ([]byte, error) {
+	if !c.available() {
+		return nil, ErrUnavailable
+	}
+	if req.MinuteToken == "" {
+		return nil, errors.New("minute token is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := larkminutes.NewGetMinuteTranscriptReqBuilder().
+		MinuteToken(req.MinuteToken).
+		NeedSpeaker(req.NeedSpeaker).
+		NeedTimestamp(req.NeedTimestamp)
+	if req.FileFormat != "" {
+		builder.FileFormat(req.FileFormat)
+	}
+	resp, err := c.sdk.Minutes.V1.MinuteTranscript.Get(ctx, builder.Build(), option)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errors.New("get minute transcript failed: empty response")
+	}
+	if resp.ApiResp != nil && strings.Contains(resp.Header.Get("Content-Type"), "application/json") {
+		// Errors can come back as a JSON body with HTTP 200.
+		if err := json.Unmarshal(resp.RawBody, &resp.CodeError); err == nil && resp.Code != 0 {
+			return nil, apiError("get minute transcript", resp.Code, resp.Msg)
+		}
+	}
+	if !resp.Success() {
+		return nil, apiError("get minute transcript", resp.Code, resp.Msg)
+	}
+	if resp.File == nil {
+		return nil, nil
+	}
+	return io.ReadAll(resp.File)
+}
+
+func (c *Client) GetMinuteStatistics(ctx context.Context, token string, tokenType AccessTokenType, minuteToken, userIDType string) (MinuteStatistics, error) {
+	if !c.available() {
+		return MinuteStatistics{}, ErrUnavailable
+	}
+	if minuteToken == "" {
+		return MinuteStatistics{}, errors.New("minute token is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return MinuteStatistics{}, err
+	}
+
+	builder := larkminutes.NewGetMinuteStatisticsReqBuilder().MinuteToken(minuteToken)
+	if userIDType != "" {
+		builder.UserIdType(userIDType)
+	}
+	resp, err := c.sdk.Minutes.V1.MinuteStatistics.Get(ctx, builder.Build(), option)
+	if err != nil {
+		return MinuteStatistics{}, err
+	}
+	if resp == nil {
+		return MinuteStatistics{}, errors.New("get minute statistics failed: empty response")
+	}
+	if !resp.Success() {
+		return MinuteStatistics{}, apiError("get minute statistics", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil || resp.Data.Statistics == nil {
+		return MinuteStatistics{}, nil
+	}
+	stats := resp.Data.Statistics
+	result := MinuteStatistics{
+		UserViewCount: derefString(stats.UserViewCount),
+		PageViewCount: derefString(stats.PageViewCount),
+	}
+	for _, view := range stats.UserViewList {
+		if view == nil {
+			continue
+		}
+		result.UserViews = append(result.UserViews, MinuteUserView{
+			UserID:   derefString(view.UserId),
+			ViewTime: derefString(view.ViewTime),
+		})
+	}
+	return result, nil
+}
+
+// GetMinuteMediaURL returns a short-lived download URL for the recording.
+func (c *Client) GetMinuteMediaURL(ctx context.Context, token string, tokenType AccessTokenType, minuteToken string) (string, error) {
+	if !c.available() {
+		return "", ErrUnavailable
+	}
+	if minuteToken == "" {
+		return "", errors.New("minute token is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return "", err
+	}
+
+	req := larkminutes.NewGetMinuteMediaReqBuilder().MinuteToken(minuteToken).Build()
+	resp, err := c.sdk.Minutes.V1.MinuteMedia.Get(ctx, req, option)
+	if err != nil {
+		return "", err
+	}
+	if resp == nil {
+		return "", errors.New("get minute media failed: empty response")
+	}
+	if !resp.Success() {
+		return "", apiError("get minute media", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil || derefString(resp.Data.DownloadUrl) == "" {
+		return "", errors.New("get minute media failed: empty download url")
+	}
+	return derefString(resp.Data.DownloadUrl), nil
+}
+
+// DownloadMinuteMedia fetches a pre-signed media download URL. The URL
+// carries its own credentials, so no access token is sent.
+func (c *Client) DownloadMinuteMedia(ctx context.Context, downloadURL string) (io.ReadCloser, error) {
+	return c.downloadPresignedURL(ctx, downloadURL, "minute media")
+}
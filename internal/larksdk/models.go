@@ -11,8 +11,16 @@ type TenantInfo struct {
 }
 
 type Calendar struct {
-	CalendarID string `json:"calendar_id"`
-	Summary    string `json:"summary"`
+	CalendarID   string `json:"calendar_id"`
+	Summary      string `json:"summary"`
+	Description  string `json:"description,omitempty"`
+	Permissions  string `json:"permissions,omitempty"`
+	Color        int    `json:"color,omitempty"`
+	Type         string `json:"type,omitempty"`
+	SummaryAlias string `json:"summary_alias,omitempty"`
+	IsDeleted    bool   `json:"is_deleted,omitempty"`
+	IsThirdParty bool   `json:"is_third_party,omitempty"`
+	Role         string `json:"role,omitempty"`
 }
 
 type CalendarEventTime struct {
@@ -237,9 +245,11 @@ type CalendarEventAttendee struct {
 }
 
 type CreateCalendarEventAttendeesRequest struct {
-	CalendarID string
-	EventID    string
-	Attendees  []CalendarEventAttendee
+	CalendarID       string
+	EventID          string
+	UserIDType       string
+	Attendees        []CalendarEventAttendee
+	NeedNotification *bool
 }
 
 type MeetingUser struct {
@@ -307,6 +317,10 @@ type MeetingListItem struct {
 	Status    *int   `json:"status,omitempty"`
 	StartTime string `json:"start_time"`
 	EndTime   string `json:"end_time"`
+
+	Duration         string `json:"duration,omitempty"`
+	Organizer        string `json:"organizer,omitempty"`
+	ParticipantCount string `json:"participant_count,omitempty"`
 }
 
 type MeetingBrief struct {
@@ -399,10 +413,14 @@ type UserInfo struct {
 type User struct {
 	UserID          string           `json:"user_id"`
 	OpenID          string           `json:"open_id"`
+	UnionID         string           `json:"union_id,omitempty"`
 	Name            string           `json:"name"`
 	Email           string           `json:"email"`
 	EnterpriseEmail string           `json:"enterprise_email,omitempty"`
 	Mobile          string           `json:"mobile"`
+	EmployeeNo      string           `json:"employee_no,omitempty"`
+	JobTitle        string           `json:"job_title,omitempty"`
+	LeaderUserID    string           `json:"leader_user_id,omitempty"`
 	DepartmentIDs   []string         `json:"department_ids,omitempty"`
 	Departments     []DepartmentInfo `json:"departments,omitempty"`
 	Avatar          *AvatarInfo      `json:"avatar,omitempty"`
@@ -423,13 +441,16 @@ type GetContactUserRequest struct {
 type BatchGetUserIDRequest struct {
 	Emails  []string
 	Mobiles []string
+	// UserIDType selects the ID returned in User.UserID (default open_id).
+	UserIDType string
 }
 
 type ListUsersByDepartmentRequest struct {
-	DepartmentID string
-	PageSize     int
-	PageToken    string
-	UserIDType   string
+	DepartmentID     string
+	DepartmentIDType string
+	PageSize         int
+	PageToken        string
+	UserIDType       string
 }
 
 type ListUsersByDepartmentResult struct {
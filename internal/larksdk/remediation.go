@@ -1,6 +1,7 @@
 package larksdk
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -19,9 +20,42 @@ func withInsufficientScopeRemediation(err error, serverMsg string) error {
 	if msg == "" {
 		msg = strings.ToLower(err.Error())
 	}
-	// Heuristic: SDK/OpenAPI errors vary by endpoint; keep this conservative.
-	if !(strings.Contains(msg, "insufficient") || strings.Contains(msg, "scope") || strings.Contains(msg, "permission") || strings.Contains(msg, "forbidden")) {
+	if !looksLikeScopeError(msg) {
 		return err
 	}
 	return fmt.Errorf("%w; this may be due to missing permission/scope; try re-authorizing with: `%s`", err, userOAuthReloginCommand)
 }
+
+// looksLikeScopeError reports whether a lower-cased message suggests a missing
+// permission/scope.
+//
+// Heuristic: SDK/OpenAPI errors vary by endpoint; keep this conservative.
+func looksLikeScopeError(msg string) bool {
+	return strings.Contains(msg, "insufficient") || strings.Contains(msg, "scope") || strings.Contains(msg, "permission") || strings.Contains(msg, "forbidden")
+}
+
+// RemediationHint returns a best-effort, one-line fix for common credential
+// failures, or "" when none applies.
+func RemediationHint(err error) string {
+	if err == nil {
+		return ""
+	}
+	if errors.Is(err, ErrUnavailable) {
+		return "set app credentials with `lark auth login --app-id <id> --app-secret <secret>`"
+	}
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "app_ticket") || strings.Contains(msg, "app ticket"):
+		return "run `lark auth app-ticket resend`, then store the ticket with `lark auth app-ticket set`"
+	case strings.Contains(msg, "app_secret") || strings.Contains(msg, "app secret") || strings.Contains(msg, "app_id"):
+		return "check app_id/app_secret in the developer console, then run `lark auth login`"
+	case strings.Contains(msg, "refresh token") || strings.Contains(msg, "refresh_token") || strings.Contains(msg, "invalid_grant"):
+		return fmt.Sprintf("re-authorize with: `%s`", userOAuthReloginCommand)
+	case looksLikeScopeError(msg):
+		return fmt.Sprintf("grant the missing scopes in the developer console or re-authorize with: `%s`", userOAuthReloginCommand)
+	case strings.Contains(msg, "no such host") || strings.Contains(msg, "connection refused") || strings.Contains(msg, "timeout"):
+		return "check network access to the base URL, or select another host with `lark auth platform set feishu|lark`"
+	default:
+		return ""
+	}
+}
@@ -30,3 +30,29 @@ func TestWithInsufficientScopeRemediation_NoChange(t *testing.T) {
 		t.Fatalf("expected unchanged error, got %q", err.Error())
 	}
 }
+
+func TestRemediationHint(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want string
+	}{
+		"unavailable": {ErrUnavailable, "lark auth login"},
+		"app secret":  {apiError("tenant access token", 10014, "app secret invalid"), "app_id/app_secret"},
+		"app ticket":  {errors.New("no app_ticket stored"), "app-ticket resend"},
+		"refresh":     {errors.New("refresh access token failed (code=20064): invalid_grant"), userOAuthReloginCommand},
+		"scope":       {apiError("list chats", 99991672, "Access denied. One of the following scopes is required"), "developer console"},
+		"unknown":     {errors.New("rate limited"), ""},
+	}
+	for name, tc := range cases {
+		got := RemediationHint(tc.err)
+		if tc.want == "" {
+			if got != "" {
+				t.Fatalf("%s: expected no hint, got %q", name, got)
+			}
+			continue
+		}
+		if !strings.Contains(got, tc.want) {
+			t.Fatalf("%s: expected hint containing %q, got %q", name, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package larksdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+)
+
+// SheetConditionFormat is a conditional formatting rule attached to a sheet.
+type SheetConditionFormat struct {
+	CfID     string                     `json:"cf_id,omitempty"`
+	Ranges   []string                   `json:"ranges"`
+	RuleType string                     `json:"rule_type"`
+	Attrs    []SheetConditionFormatAttr `json:"attrs,omitempty"`
+	Style    *SheetConditionFormatStyle `json:"style,omitempty"`
+}
+
+type SheetConditionFormatAttr struct {
+	Operator   string   `json:"operator,omitempty"`
+	TimePeriod string   `json:"time_period,omitempty"`
+	Formula    []string `json:"formula,omitempty"`
+	Text       string   `json:"text,omitempty"`
+}
+
+type SheetConditionFormatStyle struct {
+	Font           *SheetConditionFormatFont `json:"font,omitempty"`
+	TextDecoration int                       `json:"text_decoration,omitempty"`
+	ForeColor      string                    `json:"fore_color,omitempty"`
+	BackColor      string                    `json:"back_color,omitempty"`
+}
+
+type SheetConditionFormatFont struct {
+	Bold   bool `json:"bold,omitempty"`
+	Italic bool `json:"italic,omitempty"`
+}
+
+// SheetConditionFormatEntry pairs a rule with the sheet it belongs to.
+type SheetConditionFormatEntry struct {
+	SheetID         string               `json:"sheet_id"`
+	ConditionFormat SheetConditionFormat `json:"condition_format"`
+}
+
+// SheetConditionFormatResult is the per-rule outcome of a batch create/delete.
+type SheetConditionFormatResult struct {
+	SheetID string `json:"sheet_id"`
+	CfID    string `json:"cf_id"`
+	ResCode int    `json:"res_code"`
+	ResMsg  string `json:"res_msg,omitempty"`
+}
+
+type SheetConditionFormatRef struct {
+	SheetID string `json:"sheet_id"`
+	CfID    string `json:"cf_id"`
+}
+
+type listSheetConditionFormatsResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *listSheetConditionFormatsData `json:"data"`
+}
+
+type listSheetConditionFormatsData struct {
+	SheetConditionFormats []SheetConditionFormatEntry `json:"sheet_condition_formats"`
+}
+
+func (r *listSheetConditionFormatsResponse) Success() bool { return r.Code == 0 }
+
+type batchSheetConditionFormatsResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *batchSheetConditionFormatsData `json:"data"`
+}
+
+type batchSheetConditionFormatsData struct {
+	Responses []SheetConditionFormatResult `json:"responses"`
+}
+
+func (r *batchSheetConditionFormatsResponse) Success() bool { return r.Code == 0 }
+
+// ListSheetConditionFormats returns the conditional formats for the given sheets.
+func (c *Client) ListSheetConditionFormats(ctx context.Context, token string, tokenType AccessTokenType, spreadsheetToken string, sheetIDs []string) ([]SheetConditionFormatEntry, error) {
+	if !c.available() || c.coreConfig == nil {
+		return nil, ErrUnavailable
+	}
+	if spreadsheetToken == "" {
+		return nil, errors.New("spreadsheet token is required")
+	}
+	if len(sheetIDs) == 0 {
+		return nil, errors.New("sheet ids are required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/sheets/v2/spreadsheets/:spreadsheet_token/condition_formats",
+		HttpMethod:                http.MethodGet,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	req.PathParams.Set("spreadsheet_token", spreadsheetToken)
+	req.QueryParams.Set("sheet_ids", strings.Join(sheetIDs, ","))
+
+	apiResp, err := larkcore.Request(ctx, req, c.coreConfig, option)
+	if err != nil {
+		return nil, err
+	}
+	if apiResp == nil {
+		return nil, errors.New("list condition formats failed: empty response")
+	}
+	resp := &listSheetConditionFormatsResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return nil, err
+	}
+	if !resp.Success() {
+		return nil, apiError("list condition formats", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil {
+		return nil, nil
+	}
+	return resp.Data.SheetConditionFormats, nil
+}
+
+// CreateSheetConditionFormats creates conditional formats in batch.
+func (c *Client) CreateSheetConditionFormats(ctx context.Context, token string, tokenType AccessTokenType, spreadsheetToken string, entries []SheetConditionFormatEntry) ([]SheetConditionFormatResult, error) {
+	if !c.available() || c.coreConfig == nil {
+		return nil, ErrUnavailable
+	}
+	if spreadsheetToken == "" {
+		return nil, errors.New("spreadsheet token is required")
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("condition formats are required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/sheets/v2/spreadsheets/:spreadsheet_token/condition_formats/batch_create",
+		HttpMethod:                http.MethodPost,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		Body:                      map[string]any{"sheet_condition_formats": entries},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	req.PathParams.Set("spreadsheet_token", spreadsheetToken)
+
+	return c.doSheetConditionFormatsBatch(ctx, req, option, "create condition formats")
+}
+
+// DeleteSheetConditionFormats deletes conditional formats in batch.
+func (c *Client) DeleteSheetConditionFormats(ctx context.Context, token string, tokenType AccessTokenType, spreadsheetToken string, refs []SheetConditionFormatRef) ([]SheetConditionFormatResult, error) {
+	if !c.available() || c.coreConfig == nil {
+		return nil, ErrUnavailable
+	}
+	if spreadsheetToken == "" {
+		return nil, errors.New("spreadsheet token is required")
+	}
+	if len(refs) == 0 {
+		return nil, errors.New("condition format ids are required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/sheets/v2/spreadsheets/:spreadsheet_token/condition_formats/batch_delete",
+		HttpMethod:                http.MethodDelete,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		Body:                      map[string]any{"sheet_cf_ids": refs},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	req.PathParams.Set("spreadsheet_token", spreadsheetToken)
+
+	return c.doSheetConditionFormatsBatch(ctx, req, option, "delete condition formats")
+}
+
+func (c *Client) doSheetConditionFormatsBatch(ctx context.Context, req *larkcore.ApiReq, option larkcore.RequestOptionFunc, op string) ([]SheetConditionFormatResult, error) {
+	apiResp, err := larkcore.Request(ctx, req, c.coreConfig, option)
+	if err != nil {
+		return nil, err
+	}
+	if apiResp == nil {
+		return nil, errors.New(op + " failed: empty response")
+	}
+	resp := &batchSheetConditionFormatsResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return nil, err
+	}
+	if !resp.Success() {
+		return nil, apiError(op, resp.Code, resp.Msg)
+	}
+	if resp.Data == nil {
+		return nil, nil
+	}
+	return resp.Data.Responses, nil
+}
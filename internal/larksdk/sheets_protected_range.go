@@ -0,0 +1,307 @@
+package larksdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+)
+
+// SheetProtectedDimension identifies a protected row/column span.
+// Indexes follow the v2 API: StartIndex is 1-based and EndIndex is inclusive.
+type SheetProtectedDimension struct {
+	SheetID        string `json:"sheetId"`
+	MajorDimension string `json:"majorDimension"`
+	StartIndex     int    `json:"startIndex"`
+	EndIndex       int    `json:"endIndex"`
+}
+
+type SheetProtectedRangeMember struct {
+	MemberType string `json:"memberType,omitempty"`
+	MemberID   string `json:"memberId,omitempty"`
+}
+
+type SheetProtectedRangeEditors struct {
+	Users []SheetProtectedRangeMember `json:"users,omitempty"`
+}
+
+type SheetProtectedRange struct {
+	ProtectID string                      `json:"protectId"`
+	SheetID   string                      `json:"sheetId,omitempty"`
+	LockInfo  string                      `json:"lockInfo,omitempty"`
+	Dimension *SheetProtectedDimension    `json:"dimension,omitempty"`
+	Editors   *SheetProtectedRangeEditors `json:"editors,omitempty"`
+}
+
+type AddSheetProtectedDimensionRequest struct {
+	Dimension SheetProtectedDimension
+	// Users are editor IDs allowed to modify the range, typed by UserIDType.
+	Users      []string
+	UserIDType string
+	LockInfo   string
+}
+
+type sheetMetainfoResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *sheetMetainfoData `json:"data"`
+}
+
+type sheetMetainfoData struct {
+	Sheets []sheetMetainfoSheet `json:"sheets"`
+}
+
+type sheetMetainfoSheet struct {
+	SheetID        string                `json:"sheetId"`
+	ProtectedRange []SheetProtectedRange `json:"protectedRange"`
+}
+
+func (r *sheetMetainfoResponse) Success() bool { return r.Code == 0 }
+
+type addSheetProtectedDimensionResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *addSheetProtectedDimensionData `json:"data"`
+}
+
+type addSheetProtectedDimensionData struct {
+	AddProtectedDimension []SheetProtectedRange `json:"addProtectedDimension"`
+}
+
+func (r *addSheetProtectedDimensionResponse) Success() bool { return r.Code == 0 }
+
+type getSheetProtectedRangesResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *getSheetProtectedRangesData `json:"data"`
+}
+
+type getSheetProtectedRangesData struct {
+	ProtectedRanges []SheetProtectedRange `json:"protectedRanges"`
+}
+
+func (r *getSheetProtectedRangesResponse) Success() bool { return r.Code == 0 }
+
+type deleteSheetProtectedRangesResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *deleteSheetProtectedRangesData `json:"data"`
+}
+
+type deleteSheetProtectedRangesData struct {
+	DelProtectIDs []string `json:"delProtectIds"`
+}
+
+func (r *deleteSheetProtectedRangesResponse) Success() bool { return r.Code == 0 }
+
+// ListSheetProtectedRanges returns protected ranges declared in the spreadsheet
+// metainfo. Editors are not included; use GetSheetProtectedRanges for those.
+func (c *Client) ListSheetProtectedRanges(ctx context.Context, token string, tokenType AccessTokenType, spreadsheetToken string) ([]SheetProtectedRange, error) {
+	if !c.available() || c.coreConfig == nil {
+		return nil, ErrUnavailable
+	}
+	if spreadsheetToken == "" {
+		return nil, errors.New("spreadsheet token is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/sheets/v2/spreadsheets/:spreadsheet_token/metainfo",
+		HttpMethod:                http.MethodGet,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	req.PathParams.Set("spreadsheet_token", spreadsheetToken)
+
+	apiResp, err := larkcore.Request(ctx, req, c.coreConfig, option)
+	if err != nil {
+		return nil, err
+	}
+	if apiResp == nil {
+		return nil, errors.New("get spreadsheet metainfo failed: empty response")
+	}
+	resp := &sheetMetainfoResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return nil, err
+	}
+	if !resp.Success() {
+		return nil, apiError("get spreadsheet metainfo", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil {
+		return nil, nil
+	}
+	out := []SheetProtectedRange{}
+	for _, sheet := range resp.Data.Sheets {
+		for _, item := range sheet.ProtectedRange {
+			if item.SheetID == "" {
+				item.SheetID = sheet.SheetID
+			}
+			out = append(out, item)
+		}
+	}
+	return out, nil
+}
+
+// GetSheetProtectedRanges returns protected ranges (with editors) by protect ID.
+func (c *Client) GetSheetProtectedRanges(ctx context.Context, token string, tokenType AccessTokenType, spreadsheetToken string, protectIDs []string, memberType string) ([]SheetProtectedRange, error) {
+	if !c.available() || c.coreConfig == nil {
+		return nil, ErrUnavailable
+	}
+	if spreadsheetToken == "" {
+		return nil, errors.New("spreadsheet token is required")
+	}
+	if len(protectIDs) == 0 {
+		return nil, errors.New("protect ids are required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/sheets/v2/spreadsheets/:spreadsheet_token/protected_range_batch_get",
+		HttpMethod:                http.MethodGet,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	req.PathParams.Set("spreadsheet_token", spreadsheetToken)
+	req.QueryParams.Set("protectIds", strings.Join(protectIDs, ","))
+	if memberType != "" {
+		req.QueryParams.Set("memberType", memberType)
+	}
+
+	apiResp, err := larkcore.Request(ctx, req, c.coreConfig, option)
+	if err != nil {
+		return nil, err
+	}
+	if apiResp == nil {
+		return nil, errors.New("get protected ranges failed: empty response")
+	}
+	resp := &getSheetProtectedRangesResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return nil, err
+	}
+	if !resp.Success() {
+		return nil, apiError("get protected ranges", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil {
+		return nil, nil
+	}
+	return resp.Data.ProtectedRanges, nil
+}
+
+// AddSheetProtectedDimension protects a row/column span of a sheet.
+func (c *Client) AddSheetProtectedDimension(ctx context.Context, token string, tokenType AccessTokenType, spreadsheetToken string, req AddSheetProtectedDimensionRequest) (SheetProtectedRange, error) {
+	if !c.available() || c.coreConfig == nil {
+		return SheetProtectedRange{}, ErrUnavailable
+	}
+	if spreadsheetToken == "" {
+		return SheetProtectedRange{}, errors.New("spreadsheet token is required")
+	}
+	if req.Dimension.SheetID == "" {
+		return SheetProtectedRange{}, errors.New("sheet id is required")
+	}
+	if req.Dimension.StartIndex <= 0 || req.Dimension.EndIndex < req.Dimension.StartIndex {
+		return SheetProtectedRange{}, errors.New("invalid protected dimension range")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return SheetProtectedRange{}, err
+	}
+
+	item := map[string]any{"dimension": req.Dimension}
+	if len(req.Users) > 0 {
+		item["users"] = req.Users
+	}
+	if req.LockInfo != "" {
+		item["lockInfo"] = req.LockInfo
+	}
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/sheets/v2/spreadsheets/:spreadsheet_token/protected_dimension",
+		HttpMethod:                http.MethodPost,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		Body:                      map[string]any{"addProtectedDimension": []map[string]any{item}},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	apiReq.PathParams.Set("spreadsheet_token", spreadsheetToken)
+	if req.UserIDType != "" {
+		apiReq.QueryParams.Set("user_id_type", req.UserIDType)
+	}
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return SheetProtectedRange{}, err
+	}
+	if apiResp == nil {
+		return SheetProtectedRange{}, errors.New("add protected dimension failed: empty response")
+	}
+	resp := &addSheetProtectedDimensionResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return SheetProtectedRange{}, err
+	}
+	if !resp.Success() {
+		return SheetProtectedRange{}, apiError("add protected dimension", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil || len(resp.Data.AddProtectedDimension) == 0 {
+		return SheetProtectedRange{}, nil
+	}
+	out := resp.Data.AddProtectedDimension[0]
+	if out.SheetID == "" {
+		out.SheetID = req.Dimension.SheetID
+	}
+	return out, nil
+}
+
+// DeleteSheetProtectedRanges removes protected ranges by protect ID.
+func (c *Client) DeleteSheetProtectedRanges(ctx context.Context, token string, tokenType AccessTokenType, spreadsheetToken string, protectIDs []string) ([]string, error) {
+	if !c.available() || c.coreConfig == nil {
+		return nil, ErrUnavailable
+	}
+	if spreadsheetToken == "" {
+		return nil, errors.New("spreadsheet token is required")
+	}
+	if len(protectIDs) == 0 {
+		return nil, errors.New("protect ids are required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/sheets/v2/spreadsheets/:spreadsheet_token/protected_range_batch_del",
+		HttpMethod:                http.MethodDelete,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		Body:                      map[string]any{"protectIds": protectIDs},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	req.PathParams.Set("spreadsheet_token", spreadsheetToken)
+
+	apiResp, err := larkcore.Request(ctx, req, c.coreConfig, option)
+	if err != nil {
+		return nil, err
+	}
+	if apiResp == nil {
+		return nil, errors.New("delete protected ranges failed: empty response")
+	}
+	resp := &deleteSheetProtectedRangesResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return nil, err
+	}
+	if !resp.Success() {
+		return nil, apiError("delete protected ranges", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil {
+		return nil, nil
+	}
+	return resp.Data.DelProtectIDs, nil
+}
@@ -0,0 +1,287 @@
+package larksdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+)
+
+type TaskComment struct {
+	ID               string      `json:"id,omitempty"`
+	Content          string      `json:"content,omitempty"`
+	Creator          *TaskMember `json:"creator,omitempty"`
+	ReplyToCommentID string      `json:"reply_to_comment_id,omitempty"`
+	ResourceType     string      `json:"resource_type,omitempty"`
+	ResourceID       string      `json:"resource_id,omitempty"`
+	CreatedAt        string      `json:"created_at,omitempty"`
+	UpdatedAt        string      `json:"updated_at,omitempty"`
+}
+
+type CreateTaskCommentRequest struct {
+	TaskGUID         string
+	Content          string
+	ReplyToCommentID string
+	UserIDType       string
+}
+
+type UpdateTaskCommentRequest struct {
+	CommentID  string
+	Content    string
+	UserIDType string
+}
+
+type ListTaskCommentsRequest struct {
+	TaskGUID   string
+	PageSize   int
+	PageToken  string
+	Direction  string
+	UserIDType string
+}
+
+type ListTaskCommentsResult struct {
+	Items     []TaskComment
+	PageToken string
+	HasMore   bool
+}
+
+type taskCommentResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *taskCommentResponseData `json:"data"`
+}
+
+type taskCommentResponseData struct {
+	Comment TaskComment `json:"comment"`
+}
+
+func (r *taskCommentResponse) Success() bool { return r.Code == 0 }
+
+type listTaskCommentsResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *listTaskCommentsResponseData `json:"data"`
+}
+
+type listTaskCommentsResponseData struct {
+	Items     []TaskComment `json:"items"`
+	PageToken *string       `json:"page_token"`
+	HasMore   *bool         `json:"has_more"`
+}
+
+func (r *listTaskCommentsResponse) Success() bool { return r.Code == 0 }
+
+type deleteTaskCommentResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+}
+
+func (r *deleteTaskCommentResponse) Success() bool { return r.Code == 0 }
+
+// CreateTaskComment adds a comment (or a reply to a comment) on a task.
+func (c *Client) CreateTaskComment(ctx context.Context, token string, tokenType AccessTokenType, req CreateTaskCommentRequest) (TaskComment, error) {
+	if !c.available() || c.coreConfig == nil {
+		return TaskComment{}, ErrUnavailable
+	}
+	taskGUID := strings.TrimSpace(req.TaskGUID)
+	if taskGUID == "" {
+		return TaskComment{}, errors.New("task guid is required")
+	}
+	if strings.TrimSpace(req.Content) == "" {
+		return TaskComment{}, errors.New("content is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return TaskComment{}, err
+	}
+
+	payload := map[string]any{
+		"content":       req.Content,
+		"resource_type": "task",
+		"resource_id":   taskGUID,
+	}
+	if strings.TrimSpace(req.ReplyToCommentID) != "" {
+		payload["reply_to_comment_id"] = strings.TrimSpace(req.ReplyToCommentID)
+	}
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/task/v2/comments",
+		HttpMethod:                http.MethodPost,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		Body:                      payload,
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	if strings.TrimSpace(req.UserIDType) != "" {
+		apiReq.QueryParams.Set("user_id_type", req.UserIDType)
+	}
+	return c.doTaskComment(ctx, apiReq, option, "create task comment failed")
+}
+
+// UpdateTaskComment replaces the content of a task comment.
+func (c *Client) UpdateTaskComment(ctx context.Context, token string, tokenType AccessTokenType, req UpdateTaskCommentRequest) (TaskComment, error) {
+	if !c.available() || c.coreConfig == nil {
+		return TaskComment{}, ErrUnavailable
+	}
+	commentID := strings.TrimSpace(req.CommentID)
+	if commentID == "" {
+		return TaskComment{}, errors.New("comment id is required")
+	}
+	if strings.TrimSpace(req.Content) == "" {
+		return TaskComment{}, errors.New("content is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return TaskComment{}, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:     "/open-apis/task/v2/comments/:comment_id",
+		HttpMethod:  http.MethodPatch,
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+		Body: map[string]any{
+			"comment":       map[string]any{"content": req.Content},
+			"update_fields": []string{"content"},
+		},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	apiReq.PathParams.Set("comment_id", commentID)
+	if strings.TrimSpace(req.UserIDType) != "" {
+		apiReq.QueryParams.Set("user_id_type", req.UserIDType)
+	}
+	return c.doTaskComment(ctx, apiReq, option, "update task comment failed")
+}
+
+func (c *Client) doTaskComment(ctx context.Context, apiReq *larkcore.ApiReq, option larkcore.RequestOptionFunc, op string) (TaskComment, error) {
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return TaskComment{}, err
+	}
+	if apiResp == nil {
+		return TaskComment{}, errors.New(op + ": empty response")
+	}
+	resp := &taskCommentResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return TaskComment{}, err
+	}
+	if !resp.Success() {
+		return TaskComment{}, formatCodeError(op, resp.CodeError, resp.ApiResp)
+	}
+	if resp.Data == nil {
+		return TaskComment{}, nil
+	}
+	return resp.Data.Comment, nil
+}
+
+// DeleteTaskComment removes a task comment.
+func (c *Client) DeleteTaskComment(ctx context.Context, token string, tokenType AccessTokenType, commentID string) error {
+	if !c.available() || c.coreConfig == nil {
+		return ErrUnavailable
+	}
+	commentID = strings.TrimSpace(commentID)
+	if commentID == "" {
+		return errors.New("comment id is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/task/v2/comments/:comment_id",
+		HttpMethod:                http.MethodDelete,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	apiReq.PathParams.Set("comment_id", commentID)
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return err
+	}
+	if apiResp == nil {
+		return errors.New("delete task comment failed: empty response")
+	}
+	resp := &deleteTaskCommentResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return err
+	}
+	if !resp.Success() {
+		return formatCodeError("delete task comment failed", resp.CodeError, resp.ApiResp)
+	}
+	return nil
+}
+
+// ListTaskComments lists the comments on a task, oldest first unless Direction is "desc".
+func (c *Client) ListTaskComments(ctx context.Context, token string, tokenType AccessTokenType, req ListTaskCommentsRequest) (ListTaskCommentsResult, error) {
+	if !c.available() || c.coreConfig == nil {
+		return ListTaskCommentsResult{}, ErrUnavailable
+	}
+	taskGUID := strings.TrimSpace(req.TaskGUID)
+	if taskGUID == "" {
+		return ListTaskCommentsResult{}, errors.New("task guid is required")
+	}
+	if req.PageSize < 0 {
+		return ListTaskCommentsResult{}, errors.New("page_size must be greater than or equal to 0")
+	}
+	if req.PageSize > 100 {
+		return ListTaskCommentsResult{}, errors.New("page_size must be less than or equal to 100")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return ListTaskCommentsResult{}, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/task/v2/comments",
+		HttpMethod:                http.MethodGet,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	apiReq.QueryParams.Set("resource_type", "task")
+	apiReq.QueryParams.Set("resource_id", taskGUID)
+	if req.PageSize > 0 {
+		apiReq.QueryParams.Set("page_size", fmt.Sprint(req.PageSize))
+	}
+	if strings.TrimSpace(req.PageToken) != "" {
+		apiReq.QueryParams.Set("page_token", req.PageToken)
+	}
+	if strings.TrimSpace(req.Direction) != "" {
+		apiReq.QueryParams.Set("direction", req.Direction)
+	}
+	if strings.TrimSpace(req.UserIDType) != "" {
+		apiReq.QueryParams.Set("user_id_type", req.UserIDType)
+	}
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return ListTaskCommentsResult{}, err
+	}
+	if apiResp == nil {
+		return ListTaskCommentsResult{}, errors.New("list task comments failed: empty response")
+	}
+	resp := &listTaskCommentsResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return ListTaskCommentsResult{}, err
+	}
+	if !resp.Success() {
+		return ListTaskCommentsResult{}, formatCodeError("list task comments failed", resp.CodeError, resp.ApiResp)
+	}
+
+	result := ListTaskCommentsResult{}
+	if resp.Data != nil {
+		result.Items = resp.Data.Items
+		if resp.Data.PageToken != nil {
+			result.PageToken = *resp.Data.PageToken
+		}
+		if resp.Data.HasMore != nil {
+			result.HasMore = *resp.Data.HasMore
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,141 @@
+package larksdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+)
+
+type TaskCustomFieldOption struct {
+	GUID       string `json:"guid,omitempty"`
+	Name       string `json:"name,omitempty"`
+	ColorIndex int    `json:"color_index,omitempty"`
+	IsHidden   bool   `json:"is_hidden,omitempty"`
+}
+
+type TaskCustomFieldSelectSetting struct {
+	Options []TaskCustomFieldOption `json:"options,omitempty"`
+}
+
+// TaskCustomField is a custom field definition attached to a tasklist.
+type TaskCustomField struct {
+	GUID                string                        `json:"guid,omitempty"`
+	Name                string                        `json:"name,omitempty"`
+	Type                string                        `json:"type,omitempty"`
+	Creator             *TaskMember                   `json:"creator,omitempty"`
+	SingleSelectSetting *TaskCustomFieldSelectSetting `json:"single_select_setting,omitempty"`
+	MultiSelectSetting  *TaskCustomFieldSelectSetting `json:"multi_select_setting,omitempty"`
+	CreatedAt           string                        `json:"created_at,omitempty"`
+	UpdatedAt           string                        `json:"updated_at,omitempty"`
+}
+
+// TaskCustomFieldValue is the value of one custom field on a task. Only the
+// member matching Type is set.
+type TaskCustomFieldValue struct {
+	GUID              string       `json:"guid,omitempty"`
+	Type              string       `json:"type,omitempty"`
+	NumberValue       string       `json:"number_value,omitempty"`
+	DatetimeValue     string       `json:"datetime_value,omitempty"`
+	MemberValue       []TaskMember `json:"member_value,omitempty"`
+	SingleSelectValue string       `json:"single_select_value,omitempty"`
+	MultiSelectValue  []string     `json:"multi_select_value,omitempty"`
+	TextValue         string       `json:"text_value,omitempty"`
+}
+
+type ListTaskCustomFieldsRequest struct {
+	TasklistGUID string
+	PageSize     int
+	PageToken    string
+	UserIDType   string
+}
+
+type ListTaskCustomFieldsResult struct {
+	Items     []TaskCustomField
+	PageToken string
+	HasMore   bool
+}
+
+type listTaskCustomFieldsResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *listTaskCustomFieldsResponseData `json:"data"`
+}
+
+type listTaskCustomFieldsResponseData struct {
+	Items     []TaskCustomField `json:"items"`
+	PageToken *string           `json:"page_token"`
+	HasMore   *bool             `json:"has_more"`
+}
+
+func (r *listTaskCustomFieldsResponse) Success() bool { return r.Code == 0 }
+
+// ListTaskCustomFields lists the custom field definitions of a tasklist.
+func (c *Client) ListTaskCustomFields(ctx context.Context, token string, tokenType AccessTokenType, req ListTaskCustomFieldsRequest) (ListTaskCustomFieldsResult, error) {
+	if !c.available() || c.coreConfig == nil {
+		return ListTaskCustomFieldsResult{}, ErrUnavailable
+	}
+	tasklistGUID := strings.TrimSpace(req.TasklistGUID)
+	if tasklistGUID == "" {
+		return ListTaskCustomFieldsResult{}, errors.New("tasklist guid is required")
+	}
+	if req.PageSize < 0 {
+		return ListTaskCustomFieldsResult{}, errors.New("page_size must be greater than or equal to 0")
+	}
+	if req.PageSize > 100 {
+		return ListTaskCustomFieldsResult{}, errors.New("page_size must be less than or equal to 100")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return ListTaskCustomFieldsResult{}, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/task/v2/custom_fields",
+		HttpMethod:                http.MethodGet,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	apiReq.QueryParams.Set("resource_type", "tasklist")
+	apiReq.QueryParams.Set("resource_id", tasklistGUID)
+	if req.PageSize > 0 {
+		apiReq.QueryParams.Set("page_size", fmt.Sprint(req.PageSize))
+	}
+	if strings.TrimSpace(req.PageToken) != "" {
+		apiReq.QueryParams.Set("page_token", req.PageToken)
+	}
+	if strings.TrimSpace(req.UserIDType) != "" {
+		apiReq.QueryParams.Set("user_id_type", req.UserIDType)
+	}
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return ListTaskCustomFieldsResult{}, err
+	}
+	if apiResp == nil {
+		return ListTaskCustomFieldsResult{}, errors.New("list custom fields failed: empty response")
+	}
+	resp := &listTaskCustomFieldsResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return ListTaskCustomFieldsResult{}, err
+	}
+	if !resp.Success() {
+		return ListTaskCustomFieldsResult{}, formatCodeError("list custom fields failed", resp.CodeError, resp.ApiResp)
+	}
+
+	result := ListTaskCustomFieldsResult{}
+	if resp.Data != nil {
+		result.Items = resp.Data.Items
+		if resp.Data.PageToken != nil {
+			result.PageToken = *resp.Data.PageToken
+		}
+		if resp.Data.HasMore != nil {
+			result.HasMore = *resp.Data.HasMore
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,452 @@
+package larksdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+)
+
+type TaskSection struct {
+	GUID         string      `json:"guid,omitempty"`
+	Name         string      `json:"name,omitempty"`
+	ResourceType string      `json:"resource_type,omitempty"`
+	IsDefault    bool        `json:"is_default,omitempty"`
+	Creator      *TaskMember `json:"creator,omitempty"`
+	Tasklist     *TaskList   `json:"tasklist,omitempty"`
+	CreatedAt    string      `json:"created_at,omitempty"`
+	UpdatedAt    string      `json:"updated_at,omitempty"`
+}
+
+type ListTaskSectionsRequest struct {
+	TasklistGUID string
+	PageSize     int
+	PageToken    string
+}
+
+type ListTaskSectionsResult struct {
+	Items     []TaskSection
+	PageToken string
+	HasMore   bool
+}
+
+type CreateTaskSectionRequest struct {
+	TasklistGUID string
+	Name         string
+	InsertBefore string
+	InsertAfter  string
+}
+
+type UpdateTaskSectionRequest struct {
+	SectionGUID  string
+	Name         string
+	InsertBefore string
+	InsertAfter  string
+}
+
+// ListTasklistTasksRequest lists tasks in a tasklist, or in one of its
+// sections when SectionGUID is set.
+type ListTasklistTasksRequest struct {
+	TasklistGUID string
+	SectionGUID  string
+	PageSize     int
+	PageToken    string
+	Completed    *bool
+	UserIDType   string
+}
+
+type taskSectionResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *taskSectionResponseData `json:"data"`
+}
+
+type taskSectionResponseData struct {
+	Section TaskSection `json:"section"`
+}
+
+func (r *taskSectionResponse) Success() bool { return r.Code == 0 }
+
+type listTaskSectionsResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *listTaskSectionsResponseData `json:"data"`
+}
+
+type listTaskSectionsResponseData struct {
+	Items     []TaskSection `json:"items"`
+	PageToken *string       `json:"page_token"`
+	HasMore   *bool         `json:"has_more"`
+}
+
+func (r *listTaskSectionsResponse) Success() bool { return r.Code == 0 }
+
+type deleteTaskSectionResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+}
+
+func (r *deleteTaskSectionResponse) Success() bool { return r.Code == 0 }
+
+// ListTaskSections lists the sections (Kanban columns) of a tasklist.
+func (c *Client) ListTaskSections(ctx context.Context, token string, tokenType AccessTokenType, req ListTaskSectionsRequest) (ListTaskSectionsResult, error) {
+	if !c.available() || c.coreConfig == nil {
+		return ListTaskSectionsResult{}, ErrUnavailable
+	}
+	tasklistGUID := strings.TrimSpace(req.TasklistGUID)
+	if tasklistGUID == "" {
+		return ListTaskSectionsResult{}, errors.New("tasklist guid is required")
+	}
+	if req.PageSize < 0 {
+		return ListTaskSectionsResult{}, errors.New("page_size must be greater than or equal to 0")
+	}
+	if req.PageSize > 100 {
+		return ListTaskSectionsResult{}, errors.New("page_size must be less than or equal to 100")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return ListTaskSectionsResult{}, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/task/v2/sections",
+		HttpMethod:                http.MethodGet,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	apiReq.QueryParams.Set("resource_type", "tasklist")
+	apiReq.QueryParams.Set("resource_id", tasklistGUID)
+	if req.PageSize > 0 {
+		apiReq.QueryParams.Set("page_size", fmt.Sprint(req.PageSize))
+	}
+	if strings.TrimSpace(req.PageToken) != "" {
+		apiReq.QueryParams.Set("page_token", req.PageToken)
+	}
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return ListTaskSectionsResult{}, err
+	}
+	if apiResp == nil {
+		return ListTaskSectionsResult{}, errors.New("list sections failed: empty response")
+	}
+	resp := &listTaskSectionsResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return ListTaskSectionsResult{}, err
+	}
+	if !resp.Success() {
+		return ListTaskSectionsResult{}, formatCodeError("list sections failed", resp.CodeError, resp.ApiResp)
+	}
+
+	result := ListTaskSectionsResult{}
+	if resp.Data != nil {
+		result.Items = resp.Data.Items
+		if resp.Data.PageToken != nil {
+			result.PageToken = *resp.Data.PageToken
+		}
+		if resp.Data.HasMore != nil {
+			result.HasMore = *resp.Data.HasMore
+		}
+	}
+	return result, nil
+}
+
+// GetTaskSection fetches a section, including the tasklist it belongs to.
+func (c *Client) GetTaskSection(ctx context.Context, token string, tokenType AccessTokenType, sectionGUID string) (TaskSection, error) {
+	if !c.available() || c.coreConfig == nil {
+		return TaskSection{}, ErrUnavailable
+	}
+	sectionGUID = strings.TrimSpace(sectionGUID)
+	if sectionGUID == "" {
+		return TaskSection{}, errors.New("section guid is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return TaskSection{}, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/task/v2/sections/:section_guid",
+		HttpMethod:                http.MethodGet,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	apiReq.PathParams.Set("section_guid", sectionGUID)
+	return c.doTaskSection(ctx, apiReq, option, "get section failed")
+}
+
+// CreateTaskSection adds a section to a tasklist.
+func (c *Client) CreateTaskSection(ctx context.Context, token string, tokenType AccessTokenType, req CreateTaskSectionRequest) (TaskSection, error) {
+	if !c.available() || c.coreConfig == nil {
+		return TaskSection{}, ErrUnavailable
+	}
+	tasklistGUID := strings.TrimSpace(req.TasklistGUID)
+	if tasklistGUID == "" {
+		return TaskSection{}, errors.New("tasklist guid is required")
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return TaskSection{}, errors.New("name is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return TaskSection{}, err
+	}
+
+	payload := map[string]any{
+		"name":          strings.TrimSpace(req.Name),
+		"resource_type": "tasklist",
+		"resource_id":   tasklistGUID,
+	}
+	if strings.TrimSpace(req.InsertBefore) != "" {
+		payload["insert_before"] = strings.TrimSpace(req.InsertBefore)
+	}
+	if strings.TrimSpace(req.InsertAfter) != "" {
+		payload["insert_after"] = strings.TrimSpace(req.InsertAfter)
+	}
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/task/v2/sections",
+		HttpMethod:                http.MethodPost,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		Body:                      payload,
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	return c.doTaskSection(ctx, apiReq, option, "create section failed")
+}
+
+// UpdateTaskSection renames or repositions a section.
+func (c *Client) UpdateTaskSection(ctx context.Context, token string, tokenType AccessTokenType, req UpdateTaskSectionRequest) (TaskSection, error) {
+	if !c.available() || c.coreConfig == nil {
+		return TaskSection{}, ErrUnavailable
+	}
+	sectionGUID := strings.TrimSpace(req.SectionGUID)
+	if sectionGUID == "" {
+		return TaskSection{}, errors.New("section guid is required")
+	}
+	section := map[string]any{}
+	updateFields := make([]string, 0, 3)
+	if strings.TrimSpace(req.Name) != "" {
+		section["name"] = strings.TrimSpace(req.Name)
+		updateFields = append(updateFields, "name")
+	}
+	if strings.TrimSpace(req.InsertBefore) != "" {
+		section["insert_before"] = strings.TrimSpace(req.InsertBefore)
+		updateFields = append(updateFields, "insert_before")
+	}
+	if strings.TrimSpace(req.InsertAfter) != "" {
+		section["insert_after"] = strings.TrimSpace(req.InsertAfter)
+		updateFields = append(updateFields, "insert_after")
+	}
+	if len(updateFields) == 0 {
+		return TaskSection{}, errors.New("update_fields is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return TaskSection{}, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/task/v2/sections/:section_guid",
+		HttpMethod:                http.MethodPatch,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		Body:                      map[string]any{"section": section, "update_fields": updateFields},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	apiReq.PathParams.Set("section_guid", sectionGUID)
+	return c.doTaskSection(ctx, apiReq, option, "update section failed")
+}
+
+func (c *Client) doTaskSection(ctx context.Context, apiReq *larkcore.ApiReq, option larkcore.RequestOptionFunc, op string) (TaskSection, error) {
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return TaskSection{}, err
+	}
+	if apiResp == nil {
+		return TaskSection{}, errors.New(op + ": empty response")
+	}
+	resp := &taskSectionResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return TaskSection{}, err
+	}
+	if !resp.Success() {
+		return TaskSection{}, formatCodeError(op, resp.CodeError, resp.ApiResp)
+	}
+	if resp.Data == nil {
+		return TaskSection{}, nil
+	}
+	return resp.Data.Section, nil
+}
+
+// DeleteTaskSection deletes a section; its tasks move to the default section.
+func (c *Client) DeleteTaskSection(ctx context.Context, token string, tokenType AccessTokenType, sectionGUID string) error {
+	if !c.available() || c.coreConfig == nil {
+		return ErrUnavailable
+	}
+	sectionGUID = strings.TrimSpace(sectionGUID)
+	if sectionGUID == "" {
+		return errors.New("section guid is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/task/v2/sections/:section_guid",
+		HttpMethod:                http.MethodDelete,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	apiReq.PathParams.Set("section_guid", sectionGUID)
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return err
+	}
+	if apiResp == nil {
+		return errors.New("delete section failed: empty response")
+	}
+	resp := &deleteTaskSectionResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return err
+	}
+	if !resp.Success() {
+		return formatCodeError("delete section failed", resp.CodeError, resp.ApiResp)
+	}
+	return nil
+}
+
+// ListTasklistTasks lists the tasks of a tasklist, or of a single section when
+// SectionGUID is set. Unlike ListTasks it is not limited to my_tasks.
+func (c *Client) ListTasklistTasks(ctx context.Context, token string, tokenType AccessTokenType, req ListTasklistTasksRequest) (ListTasksResult, error) {
+	if !c.available() || c.coreConfig == nil {
+		return ListTasksResult{}, ErrUnavailable
+	}
+	tasklistGUID := strings.TrimSpace(req.TasklistGUID)
+	sectionGUID := strings.TrimSpace(req.SectionGUID)
+	if tasklistGUID == "" && sectionGUID == "" {
+		return ListTasksResult{}, errors.New("tasklist guid or section guid is required")
+	}
+	maxPageSize := 100
+	if sectionGUID != "" {
+		maxPageSize = 50
+	}
+	if req.PageSize < 0 {
+		return ListTasksResult{}, errors.New("page_size must be greater than or equal to 0")
+	}
+	if req.PageSize > maxPageSize {
+		return ListTasksResult{}, fmt.Errorf("page_size must be less than or equal to %d", maxPageSize)
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return ListTasksResult{}, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/task/v2/tasklists/:tasklist_guid/tasks",
+		HttpMethod:                http.MethodGet,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	if sectionGUID != "" {
+		apiReq.ApiPath = "/open-apis/task/v2/sections/:section_guid/tasks"
+		apiReq.PathParams.Set("section_guid", sectionGUID)
+	} else {
+		apiReq.PathParams.Set("tasklist_guid", tasklistGUID)
+	}
+	if req.PageSize > 0 {
+		apiReq.QueryParams.Set("page_size", fmt.Sprint(req.PageSize))
+	}
+	if strings.TrimSpace(req.PageToken) != "" {
+		apiReq.QueryParams.Set("page_token", req.PageToken)
+	}
+	if req.Completed != nil {
+		apiReq.QueryParams.Set("completed", fmt.Sprintf("%t", *req.Completed))
+	}
+	if strings.TrimSpace(req.UserIDType) != "" {
+		apiReq.QueryParams.Set("user_id_type", req.UserIDType)
+	}
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return ListTasksResult{}, err
+	}
+	if apiResp == nil {
+		return ListTasksResult{}, errors.New("list tasklist tasks failed: empty response")
+	}
+	resp := &listTasksResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return ListTasksResult{}, err
+	}
+	if !resp.Success() {
+		return ListTasksResult{}, formatCodeError("list tasklist tasks failed", resp.CodeError, resp.ApiResp)
+	}
+
+	result := ListTasksResult{}
+	if resp.Data != nil {
+		result.Items = resp.Data.Items
+		if resp.Data.PageToken != nil {
+			result.PageToken = *resp.Data.PageToken
+		}
+		if resp.Data.HasMore != nil {
+			result.HasMore = *resp.Data.HasMore
+		}
+	}
+	return result, nil
+}
+
+// AddTaskToTasklist adds a task to a tasklist section. When the task is
+// already in the tasklist it is moved to the given section.
+func (c *Client) AddTaskToTasklist(ctx context.Context, token string, tokenType AccessTokenType, taskGUID string, info TaskInTasklistInfo) (Task, error) {
+	if !c.available() || c.coreConfig == nil {
+		return Task{}, ErrUnavailable
+	}
+	taskGUID = strings.TrimSpace(taskGUID)
+	if taskGUID == "" {
+		return Task{}, errors.New("task guid is required")
+	}
+	if strings.TrimSpace(info.TasklistGUID) == "" {
+		return Task{}, errors.New("tasklist guid is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return Task{}, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/task/v2/tasks/:task_guid/add_tasklist",
+		HttpMethod:                http.MethodPost,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		Body:                      info,
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	apiReq.PathParams.Set("task_guid", taskGUID)
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return Task{}, err
+	}
+	if apiResp == nil {
+		return Task{}, errors.New("add task to tasklist failed: empty response")
+	}
+	resp := &getTaskResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return Task{}, err
+	}
+	if !resp.Success() {
+		return Task{}, formatCodeError("add task to tasklist failed", resp.CodeError, resp.ApiResp)
+	}
+	if resp.Data == nil {
+		return Task{}, nil
+	}
+	return resp.Data.Task, nil
+}
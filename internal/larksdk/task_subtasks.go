@@ -0,0 +1,279 @@
+package larksdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
+)
+
+type ListTaskSubtasksRequest struct {
+	TaskGUID   string
+	PageSize   int
+	PageToken  string
+	UserIDType string
+}
+
+type createSubtaskResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *createSubtaskResponseData `json:"data"`
+}
+
+type createSubtaskResponseData struct {
+	Subtask Task `json:"subtask"`
+}
+
+func (r *createSubtaskResponse) Success() bool { return r.Code == 0 }
+
+type taskDependenciesResponse struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *taskDependenciesResponseData `json:"data"`
+}
+
+type taskDependenciesResponseData struct {
+	Dependencies []TaskDependency `json:"dependencies"`
+}
+
+func (r *taskDependenciesResponse) Success() bool { return r.Code == 0 }
+
+// CreateTaskSubtask creates a task nested under parentGUID.
+func (c *Client) CreateTaskSubtask(ctx context.Context, token string, tokenType AccessTokenType, parentGUID string, req CreateTaskRequest) (Task, error) {
+	if !c.available() || c.coreConfig == nil {
+		return Task{}, ErrUnavailable
+	}
+	parentGUID = strings.TrimSpace(parentGUID)
+	if parentGUID == "" {
+		return Task{}, errors.New("parent task guid is required")
+	}
+	if strings.TrimSpace(req.Summary) == "" {
+		return Task{}, errors.New("summary is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return Task{}, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/task/v2/tasks/:task_guid/subtasks",
+		HttpMethod:                http.MethodPost,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		Body:                      createTaskPayload(req),
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	apiReq.PathParams.Set("task_guid", parentGUID)
+	if strings.TrimSpace(req.UserIDType) != "" {
+		apiReq.QueryParams.Set("user_id_type", req.UserIDType)
+	}
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return Task{}, err
+	}
+	if apiResp == nil {
+		return Task{}, errors.New("create subtask failed: empty response")
+	}
+	resp := &createSubtaskResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return Task{}, err
+	}
+	if !resp.Success() {
+		return Task{}, formatCodeError("create subtask failed", resp.CodeError, resp.ApiResp)
+	}
+	if resp.Data == nil {
+		return Task{}, nil
+	}
+	return resp.Data.Subtask, nil
+}
+
+// ListTaskSubtasks lists the direct subtasks of a task.
+func (c *Client) ListTaskSubtasks(ctx context.Context, token string, tokenType AccessTokenType, req ListTaskSubtasksRequest) (ListTasksResult, error) {
+	if !c.available() || c.coreConfig == nil {
+		return ListTasksResult{}, ErrUnavailable
+	}
+	taskGUID := strings.TrimSpace(req.TaskGUID)
+	if taskGUID == "" {
+		return ListTasksResult{}, errors.New("task guid is required")
+	}
+	if req.PageSize < 0 {
+		return ListTasksResult{}, errors.New("page_size must be greater than or equal to 0")
+	}
+	if req.PageSize > 50 {
+		return ListTasksResult{}, errors.New("page_size must be less than or equal to 50")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return ListTasksResult{}, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/task/v2/tasks/:task_guid/subtasks",
+		HttpMethod:                http.MethodGet,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	apiReq.PathParams.Set("task_guid", taskGUID)
+	if req.PageSize > 0 {
+		apiReq.QueryParams.Set("page_size", fmt.Sprint(req.PageSize))
+	}
+	if strings.TrimSpace(req.PageToken) != "" {
+		apiReq.QueryParams.Set("page_token", req.PageToken)
+	}
+	if strings.TrimSpace(req.UserIDType) != "" {
+		apiReq.QueryParams.Set("user_id_type", req.UserIDType)
+	}
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return ListTasksResult{}, err
+	}
+	if apiResp == nil {
+		return ListTasksResult{}, errors.New("list subtasks failed: empty response")
+	}
+	resp := &listTasksResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return ListTasksResult{}, err
+	}
+	if !resp.Success() {
+		return ListTasksResult{}, formatCodeError("list subtasks failed", resp.CodeError, resp.ApiResp)
+	}
+
+	result := ListTasksResult{}
+	if resp.Data != nil {
+		result.Items = resp.Data.Items
+		if resp.Data.PageToken != nil {
+			result.PageToken = *resp.Data.PageToken
+		}
+		if resp.Data.HasMore != nil {
+			result.HasMore = *resp.Data.HasMore
+		}
+	}
+	return result, nil
+}
+
+// AddTaskDependencies adds blocking relations to a task. Type "prev" marks the
+// referenced task as blocking this one; "next" marks it as blocked by this one.
+func (c *Client) AddTaskDependencies(ctx context.Context, token string, tokenType AccessTokenType, taskGUID string, deps []TaskDependency) ([]TaskDependency, error) {
+	return c.changeTaskDependencies(ctx, token, tokenType, taskGUID, deps, "add_dependencies", "add task dependencies failed")
+}
+
+// RemoveTaskDependencies removes blocking relations from a task. Only TaskGUID is read.
+func (c *Client) RemoveTaskDependencies(ctx context.Context, token string, tokenType AccessTokenType, taskGUID string, deps []TaskDependency) ([]TaskDependency, error) {
+	refs := make([]TaskDependency, 0, len(deps))
+	for _, dep := range deps {
+		refs = append(refs, TaskDependency{TaskGUID: dep.TaskGUID})
+	}
+	return c.changeTaskDependencies(ctx, token, tokenType, taskGUID, refs, "remove_dependencies", "remove task dependencies failed")
+}
+
+func (c *Client) changeTaskDependencies(ctx context.Context, token string, tokenType AccessTokenType, taskGUID string, deps []TaskDependency, action, op string) ([]TaskDependency, error) {
+	if !c.available() || c.coreConfig == nil {
+		return nil, ErrUnavailable
+	}
+	taskGUID = strings.TrimSpace(taskGUID)
+	if taskGUID == "" {
+		return nil, errors.New("task guid is required")
+	}
+	if len(deps) == 0 {
+		return nil, errors.New("dependencies are required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/task/v2/tasks/:task_guid/" + action,
+		HttpMethod:                http.MethodPost,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		Body:                      map[string]any{"dependencies": deps},
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	apiReq.PathParams.Set("task_guid", taskGUID)
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return nil, err
+	}
+	if apiResp == nil {
+		return nil, errors.New(op + ": empty response")
+	}
+	resp := &taskDependenciesResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return nil, err
+	}
+	if !resp.Success() {
+		return nil, formatCodeError(op, resp.CodeError, resp.ApiResp)
+	}
+	if resp.Data == nil {
+		return nil, nil
+	}
+	return resp.Data.Dependencies, nil
+}
+
+// AddTaskReminders adds reminders relative to the task due time. A task holds
+// at most one reminder, so existing reminders must be removed first.
+func (c *Client) AddTaskReminders(ctx context.Context, token string, tokenType AccessTokenType, taskGUID string, reminders []TaskReminder) (Task, error) {
+	if len(reminders) == 0 {
+		return Task{}, errors.New("reminders are required")
+	}
+	return c.changeTaskReminders(ctx, token, tokenType, taskGUID, "add_reminders", map[string]any{"reminders": reminders}, "add task reminders failed")
+}
+
+// RemoveTaskReminders removes reminders by ID.
+func (c *Client) RemoveTaskReminders(ctx context.Context, token string, tokenType AccessTokenType, taskGUID string, reminderIDs []string) (Task, error) {
+	if len(reminderIDs) == 0 {
+		return Task{}, errors.New("reminder ids are required")
+	}
+	return c.changeTaskReminders(ctx, token, tokenType, taskGUID, "remove_reminders", map[string]any{"reminder_ids": reminderIDs}, "remove task reminders failed")
+}
+
+func (c *Client) changeTaskReminders(ctx context.Context, token string, tokenType AccessTokenType, taskGUID, action string, body map[string]any, op string) (Task, error) {
+	if !c.available() || c.coreConfig == nil {
+		return Task{}, ErrUnavailable
+	}
+	taskGUID = strings.TrimSpace(taskGUID)
+	if taskGUID == "" {
+		return Task{}, errors.New("task guid is required")
+	}
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return Task{}, err
+	}
+
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/task/v2/tasks/:task_guid/" + action,
+		HttpMethod:                http.MethodPost,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		Body:                      body,
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	apiReq.PathParams.Set("task_guid", taskGUID)
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return Task{}, err
+	}
+	if apiResp == nil {
+		return Task{}, errors.New(op + ": empty response")
+	}
+	resp := &getTaskResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return Task{}, err
+	}
+	if !resp.Success() {
+		return Task{}, formatCodeError(op, resp.CodeError, resp.ApiResp)
+	}
+	if resp.Data == nil {
+		return Task{}, nil
+	}
+	return resp.Data.Task, nil
+}
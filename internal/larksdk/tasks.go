@@ -24,7 +24,12 @@ type TaskMember struct {
 
 type TaskReminder struct {
 	ID                 string `json:"id,omitempty"`
-	RelativeFireMinute int    `json:"relative_fire_minute,omitempty"`
+	RelativeFireMinute int    `json:"relative_fire_minute"`
+}
+
+type TaskDependency struct {
+	Type     string `json:"type,omitempty"`
+	TaskGUID string `json:"task_guid,omitempty"`
 }
 
 type TaskInTasklistInfo struct {
@@ -33,26 +38,30 @@ type TaskInTasklistInfo struct {
 }
 
 type Task struct {
-	GUID           string               `json:"guid,omitempty"`
-	Summary        string               `json:"summary,omitempty"`
-	Description    string               `json:"description,omitempty"`
-	Due            *TaskTime            `json:"due,omitempty"`
-	Start          *TaskTime            `json:"start,omitempty"`
-	CompletedAt    string               `json:"completed_at,omitempty"`
-	Creator        *TaskMember          `json:"creator,omitempty"`
-	Members        []TaskMember         `json:"members,omitempty"`
-	Reminders      []TaskReminder       `json:"reminders,omitempty"`
-	Tasklists      []TaskInTasklistInfo `json:"tasklists,omitempty"`
-	RepeatRule     string               `json:"repeat_rule,omitempty"`
-	Mode           int                  `json:"mode,omitempty"`
-	IsMilestone    *bool                `json:"is_milestone,omitempty"`
-	Status         string               `json:"status,omitempty"`
-	URL            string               `json:"url,omitempty"`
-	TaskID         string               `json:"task_id,omitempty"`
-	ParentTaskGUID string               `json:"parent_task_guid,omitempty"`
-	Extra          string               `json:"extra,omitempty"`
-	CreatedAt      string               `json:"created_at,omitempty"`
-	UpdatedAt      string               `json:"updated_at,omitempty"`
+	GUID           string                 `json:"guid,omitempty"`
+	Summary        string                 `json:"summary,omitempty"`
+	Description    string                 `json:"description,omitempty"`
+	Due            *TaskTime              `json:"due,omitempty"`
+	Start          *TaskTime              `json:"start,omitempty"`
+	CompletedAt    string                 `json:"completed_at,omitempty"`
+	Creator        *TaskMember            `json:"creator,omitempty"`
+	Members        []TaskMember           `json:"members,omitempty"`
+	Reminders      []TaskReminder         `json:"reminders,omitempty"`
+	Tasklists      []TaskInTasklistInfo   `json:"tasklists,omitempty"`
+	RepeatRule     string                 `json:"repeat_rule,omitempty"`
+	Mode           int                    `json:"mode,omitempty"`
+	IsMilestone    *bool                  `json:"is_milestone,omitempty"`
+	Status         string                 `json:"status,omitempty"`
+	URL            string                 `json:"url,omitempty"`
+	TaskID         string                 `json:"task_id,omitempty"`
+	ParentTaskGUID string                 `json:"parent_task_guid,omitempty"`
+	SubtaskCount   int                    `json:"subtask_count,omitempty"`
+	Dependencies   []TaskDependency       `json:"dependencies,omitempty"`
+	Attachments    []TaskAttachment       `json:"attachments,omitempty"`
+	CustomFields   []TaskCustomFieldValue `json:"custom_fields,omitempty"`
+	Extra          string                 `json:"extra,omitempty"`
+	CreatedAt      string                 `json:"created_at,omitempty"`
+	UpdatedAt      string                 `json:"updated_at,omitempty"`
 }
 
 type TaskList struct {
@@ -281,6 +290,39 @@ func (c *Client) createTask(ctx context.Context, req CreateTaskRequest, option l
 		return Task{}, errors.New("summary is required")
 	}
 
+	apiReq := &larkcore.ApiReq{
+		ApiPath:                   "/open-apis/task/v2/tasks",
+		HttpMethod:                http.MethodPost,
+		PathParams:                larkcore.PathParams{},
+		QueryParams:               larkcore.QueryParams{},
+		Body:                      createTaskPayload(req),
+		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
+	}
+	if strings.TrimSpace(req.UserIDType) != "" {
+		apiReq.QueryParams.Set("user_id_type", req.UserIDType)
+	}
+
+	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
+	if err != nil {
+		return Task{}, err
+	}
+	if apiResp == nil {
+		return Task{}, errors.New("create task failed: empty response")
+	}
+	resp := &createTaskResponse{ApiResp: apiResp}
+	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
+		return Task{}, err
+	}
+	if !resp.Success() {
+		return Task{}, formatCodeError("create task failed", resp.CodeError, resp.ApiResp)
+	}
+	if resp.Data == nil {
+		return Task{}, nil
+	}
+	return resp.Data.Task, nil
+}
+
+func createTaskPayload(req CreateTaskRequest) map[string]any {
 	payload := map[string]any{
 		"summary": req.Summary,
 	}
@@ -317,37 +359,7 @@ func (c *Client) createTask(ctx context.Context, req CreateTaskRequest, option l
 	if req.IsMilestone != nil {
 		payload["is_milestone"] = *req.IsMilestone
 	}
-
-	apiReq := &larkcore.ApiReq{
-		ApiPath:                   "/open-apis/task/v2/tasks",
-		HttpMethod:                http.MethodPost,
-		PathParams:                larkcore.PathParams{},
-		QueryParams:               larkcore.QueryParams{},
-		Body:                      payload,
-		SupportedAccessTokenTypes: []larkcore.AccessTokenType{larkcore.AccessTokenTypeTenant, larkcore.AccessTokenTypeUser},
-	}
-	if strings.TrimSpace(req.UserIDType) != "" {
-		apiReq.QueryParams.Set("user_id_type", req.UserIDType)
-	}
-
-	apiResp, err := larkcore.Request(ctx, apiReq, c.coreConfig, option)
-	if err != nil {
-		return Task{}, err
-	}
-	if apiResp == nil {
-		return Task{}, errors.New("create task failed: empty response")
-	}
-	resp := &createTaskResponse{ApiResp: apiResp}
-	if err := apiResp.JSONUnmarshalBody(resp, c.coreConfig); err != nil {
-		return Task{}, err
-	}
-	if !resp.Success() {
-		return Task{}, formatCodeError("create task failed", resp.CodeError, resp.ApiResp)
-	}
-	if resp.Data == nil {
-		return Task{}, nil
-	}
-	return resp.Data.Task, nil
+	return payload
 }
 
 func (c *Client) UpdateTask(ctx context.Context, token string, req UpdateTaskRequest) (Task, error) {
@@ -26,6 +26,9 @@ func (c *Client) BatchGetUserIDs(ctx context.Context, token string, req BatchGet
 		bodyBuilder.Mobiles(req.Mobiles)
 	}
 	builder := contact.NewBatchGetIdUserReqBuilder().Body(bodyBuilder.Build())
+	if req.UserIDType != "" {
+		builder.UserIdType(req.UserIDType)
+	}
 
 	resp, err := c.sdk.Contact.V3.User.BatchGetId(ctx, builder.Build(), larkcore.WithTenantAccessToken(tenantToken))
 	if err != nil {
@@ -70,6 +73,9 @@ func (c *Client) ListUsersByDepartment(ctx context.Context, token string, req Li
 	if req.UserIDType != "" {
 		builder.UserIdType(req.UserIDType)
 	}
+	if req.DepartmentIDType != "" {
+		builder.DepartmentIdType(req.DepartmentIDType)
+	}
 
 	resp, err := c.sdk.Contact.V3.User.FindByDepartment(ctx, builder.Build(), larkcore.WithTenantAccessToken(tenantToken))
 	if err != nil {
@@ -100,23 +106,23 @@ func (c *Client) ListUsersByDepartment(ctx context.Context, token string, req Li
 	return result, nil
 }
 
-func (c *Client) GetContactUser(ctx context.Context, token string, req GetContactUserRequest) (User, error) {
+func (c *Client) GetContactUser(ctx context.Context, token string, tokenType AccessTokenType, req GetContactUserRequest) (User, error) {
 	if !c.available() {
 		return User{}, ErrUnavailable
 	}
 	if req.UserID == "" {
 		return User{}, fmt.Errorf("user id is required")
 	}
-	tenantToken := c.tenantToken(token)
-	if tenantToken == "" {
-		return User{}, errors.New("tenant access token is required")
+	option, _, err := c.accessTokenOption(token, tokenType)
+	if err != nil {
+		return User{}, err
 	}
 
 	builder := contact.NewGetUserReqBuilder().UserId(req.UserID)
 	if req.UserIDType != "" {
 		builder.UserIdType(req.UserIDType)
 	}
-	resp, err := c.sdk.Contact.V3.User.Get(ctx, builder.Build(), larkcore.WithTenantAccessToken(tenantToken))
+	resp, err := c.sdk.Contact.V3.User.Get(ctx, builder.Build(), option)
 	if err != nil {
 		return User{}, err
 	}
@@ -161,6 +167,7 @@ func mapContactUser(user *contact.User) User {
 	if user.OpenId != nil {
 		result.OpenID = *user.OpenId
 	}
+	result.UnionID = derefString(user.UnionId)
 	if user.Name != nil {
 		result.Name = *user.Name
 	}
@@ -173,6 +180,9 @@ func mapContactUser(user *contact.User) User {
 	if user.Mobile != nil {
 		result.Mobile = *user.Mobile
 	}
+	result.EmployeeNo = derefString(user.EmployeeNo)
+	result.JobTitle = derefString(user.JobTitle)
+	result.LeaderUserID = derefString(user.LeaderUserId)
 	if user.DepartmentIds != nil {
 		result.DepartmentIDs = append([]string{}, user.DepartmentIds...)
 	}
@@ -0,0 +1,79 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const defaultKanbanColumnWidth = 28
+
+// KanbanColumn is one column of a board; each card is rendered as a block.
+type KanbanColumn struct {
+	Title string
+	Cards []string
+}
+
+// KanbanText renders columns side by side in bordered boxes. Columns wrap onto
+// a new row once the board would exceed width (0 = never wrap).
+func KanbanText(columns []KanbanColumn, styled bool, width int) string {
+	if len(columns) == 0 {
+		return ""
+	}
+	theme := NewTheme(styled)
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		Padding(0, 1).
+		Width(defaultKanbanColumnWidth)
+	if styled {
+		box = box.BorderForeground(lipgloss.AdaptiveColor{Light: "245", Dark: "240"})
+	}
+	inner := defaultKanbanColumnWidth - 2
+
+	rendered := make([]string, 0, len(columns))
+	for _, column := range columns {
+		title := fmt.Sprintf("%s (%d)", kanbanCell(column.Title), len(column.Cards))
+		lines := []string{theme.RenderSectionTitle(title), theme.RenderSeparator(strings.Repeat("─", inner))}
+		if len(column.Cards) == 0 {
+			lines = append(lines, "(empty)")
+		}
+		for i, card := range column.Cards {
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, kanbanCell(card))
+		}
+		rendered = append(rendered, box.Render(strings.Join(lines, "\n")))
+	}
+
+	perRow := len(rendered)
+	if width > 0 {
+		columnWidth := lipgloss.Width(rendered[0]) + 1
+		perRow = width / columnWidth
+		if perRow < 1 {
+			perRow = 1
+		}
+	}
+	rows := make([]string, 0, (len(rendered)+perRow-1)/perRow)
+	for start := 0; start < len(rendered); start += perRow {
+		end := start + perRow
+		if end > len(rendered) {
+			end = len(rendered)
+		}
+		parts := make([]string, 0, 2*(end-start))
+		for i, column := range rendered[start:end] {
+			if i > 0 {
+				parts = append(parts, " ")
+			}
+			parts = append(parts, column)
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, parts...))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// kanbanCell drops tabs so styled printing does not treat the board as a table.
+func kanbanCell(text string) string {
+	return strings.ReplaceAll(strings.TrimSpace(text), "\t", " ")
+}
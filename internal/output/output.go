@@ -172,6 +172,11 @@ func TableTSVFromLines(headers []string, lines []string) string {
 	return TableTSV(headers, rows)
 }
 
+// TerminalWidth returns the column count of w when it is a terminal, else 0.
+func TerminalWidth(w io.Writer) int {
+	return terminalWidth(w)
+}
+
 func terminalWidth(w io.Writer) int {
 	if w == nil {
 		return 0